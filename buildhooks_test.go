@@ -0,0 +1,99 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// phaseRelayer records the linking steps performed on each path.
+type phaseRelayer struct {
+	ibc.Relayer // Unimplemented methods panic.
+
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *phaseRelayer) record(step, pathName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step+" "+pathName)
+}
+
+func (r *phaseRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	r.record("link", pathName)
+	return nil
+}
+
+func (r *phaseRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) (ibc.CreatedClients, error) {
+	r.record("clients", pathName)
+	return ibc.CreatedClients{}, nil
+}
+
+func (r *phaseRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (ibc.CreatedConnection, error) {
+	r.record("connections", pathName)
+	return ibc.CreatedConnection{}, nil
+}
+
+func (r *phaseRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) (ibc.CreatedChannel, error) {
+	r.record("channel", pathName)
+	return ibc.CreatedChannel{}, nil
+}
+
+func (r *phaseRelayer) takeSteps() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	steps := r.steps
+	r.steps = nil
+	return steps
+}
+
+func TestInterchain_LinkAllPathsHooks(t *testing.T) {
+	ctx := context.Background()
+	a, b, c := &readyChain{name: "a"}, &readyChain{name: "b"}, &readyChain{name: "c"}
+	r := new(phaseRelayer)
+
+	ic := NewInterchain()
+	ic.chains = map[ibc.Chain]string{a: "a", b: "b", c: "c"}
+	ic.relayers = map[ibc.Relayer]string{r: "r"}
+	ic.links = map[relayerPath]interchainLink{
+		{Relayer: r, Path: "a-b"}: {chains: [2]ibc.Chain{a, b}},
+		{Relayer: r, Path: "b-c"}: {chains: [2]ibc.Chain{b, c}},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		require.NoError(t, ic.linkAllPaths(ctx, nil, BuildHooks{}))
+		require.ElementsMatch(t, []string{"link a-b", "link b-c"}, r.takeSteps())
+	})
+
+	t.Run("phases", func(t *testing.T) {
+		var phases [][]string
+		hook := func(ctx context.Context) error {
+			phases = append(phases, r.takeSteps())
+			return nil
+		}
+		require.NoError(t, ic.linkAllPaths(ctx, nil, BuildHooks{ClientsCreated: hook, ConnectionsCreated: hook}))
+		phases = append(phases, r.takeSteps())
+
+		require.Len(t, phases, 3)
+		require.ElementsMatch(t, []string{"clients a-b", "clients b-c"}, phases[0])
+		require.ElementsMatch(t, []string{"connections a-b", "connections b-c"}, phases[1])
+		require.ElementsMatch(t, []string{"channel a-b", "channel b-c"}, phases[2])
+	})
+
+	t.Run("hook error", func(t *testing.T) {
+		errHook := errors.New("unexpected clients")
+		err := ic.linkAllPaths(ctx, nil, BuildHooks{ClientsCreated: func(ctx context.Context) error {
+			return errHook
+		}})
+		require.ErrorIs(t, err, errHook)
+		require.ErrorContains(t, err, "clients created hook")
+
+		// Build stops before creating any connections.
+		require.ElementsMatch(t, []string{"clients a-b", "clients b-c"}, r.takeSteps())
+	})
+}
@@ -0,0 +1,52 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// GrantAuthorization grants grantee, a bech32 address, a generic authorization to execute messages of msgType,
+// e.g. "/cosmos.bank.v1beta1.MsgSend", on behalf of the key granterKey.
+// The authorization never expires if expiration is zero.
+func (tn *ChainNode) GrantAuthorization(ctx context.Context, granterKey, grantee, msgType string, expiration time.Time) error {
+	_, err := tn.ExecTx(ctx, granterKey, authzGrantCommand(grantee, msgType, expiration)...)
+	return err
+}
+
+// authzGrantCommand returns the tx command granting grantee a generic authorization for msgType.
+func authzGrantCommand(grantee, msgType string, expiration time.Time) []string {
+	command := []string{"authz", "grant", grantee, "generic", "--msg-type", msgType}
+	if !expiration.IsZero() {
+		// The expiration is given as a Unix timestamp.
+		command = append(command, "--expiration", fmt.Sprint(expiration.Unix()))
+	}
+	return command
+}
+
+// GrantAuthorization grants grantee, a bech32 address, a generic authorization to execute messages of msgType,
+// e.g. "/cosmos.bank.v1beta1.MsgSend", on behalf of the key granterKey.
+// The authorization never expires if expiration is zero.
+func (c *CosmosChain) GrantAuthorization(ctx context.Context, granterKey, grantee, msgType string, expiration time.Time) error {
+	return c.getFullNode().GrantAuthorization(ctx, granterKey, grantee, msgType, expiration)
+}
+
+// ExecAuthz broadcasts msgs wrapped in a MsgExec signed by the key granteeKey,
+// executing them on behalf of the granters that authorized it, see GrantAuthorization.
+// The signers of msgs are the granters, not the grantee.
+func (c *CosmosChain) ExecAuthz(ctx context.Context, granteeKey string, msgs ...types.Msg) (types.TxResponse, error) {
+	if len(msgs) == 0 {
+		return types.TxResponse{}, fmt.Errorf("no messages to execute")
+	}
+
+	addr, err := c.GetAddress(ctx, granteeKey)
+	if err != nil {
+		return types.TxResponse{}, fmt.Errorf("failed to get address of grantee key %s: %w", granteeKey, err)
+	}
+
+	exec := authz.NewMsgExec(addr, msgs)
+	return c.BroadcastTx(ctx, NewWallet(granteeKey, addr, "", c.cfg).(*CosmosWallet), &exec)
+}
@@ -0,0 +1,23 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthzGrantCommand(t *testing.T) {
+	const msgType = "/cosmos.bank.v1beta1.MsgSend"
+
+	require.Equal(t,
+		[]string{"authz", "grant", "cosmos1grantee", "generic", "--msg-type", msgType},
+		authzGrantCommand("cosmos1grantee", msgType, time.Time{}),
+	)
+
+	exp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t,
+		[]string{"authz", "grant", "cosmos1grantee", "generic", "--msg-type", msgType, "--expiration", "1893456000"},
+		authzGrantCommand("cosmos1grantee", msgType, exp),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"path"
 	"testing"
 
@@ -14,11 +15,34 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 )
 
 type ClientContextOpt func(clientContext client.Context) client.Context
 
+// BroadcastMode is how long broadcasting a transaction waits for it to be processed by the node.
+type BroadcastMode string
+
+const (
+	// BroadcastSync returns once the transaction passed CheckTx and entered the mempool.
+	BroadcastSync BroadcastMode = flags.BroadcastSync
+	// BroadcastAsync returns immediately with the transaction hash, without waiting for CheckTx.
+	BroadcastAsync BroadcastMode = flags.BroadcastAsync
+	// BroadcastBlock waits for the transaction to be committed and returns its result in the block.
+	BroadcastBlock BroadcastMode = flags.BroadcastBlock
+)
+
+// validate returns an error if m is not one of the known broadcast modes.
+func (m BroadcastMode) validate() error {
+	switch m {
+	case BroadcastSync, BroadcastAsync, BroadcastBlock:
+		return nil
+	default:
+		return fmt.Errorf("invalid broadcast mode %q: must be %q, %q, or %q", string(m), BroadcastSync, BroadcastAsync, BroadcastBlock)
+	}
+}
+
 type FactoryOpt func(factory tx.Factory) tx.Factory
 
 type User interface {
@@ -37,7 +61,10 @@ type Broadcaster struct {
 	// chain is a reference to the CosmosChain instance which will be the target of the messages.
 	chain *CosmosChain
 	// t is the testing.T for the current test.
+	// If nil, keyring directories are created with os.MkdirTemp and must be cleaned up with removeTempDirs.
 	t *testing.T
+	// tempDirs contains the directories created when t is nil.
+	tempDirs []string
 
 	// factoryOptions is a slice of broadcast.FactoryOpt which enables arbitrary configuration of the tx.Factory.
 	factoryOptions []FactoryOpt
@@ -103,9 +130,13 @@ func (b *Broadcaster) GetClientContext(ctx context.Context, user User) (client.C
 
 	_, ok := b.keyrings[user]
 	if !ok {
-		localDir := b.t.TempDir()
-		containerKeyringDir := path.Join(cn.HomeDir(), "keyring-test")
-		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerID)
+		localDir, err := b.tempDir()
+		if err != nil {
+			return client.Context{}, err
+		}
+		backend := cn.keyringBackend()
+		containerKeyringDir := path.Join(cn.HomeDir(), "keyring-"+backend)
+		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerID, backend, FileKeyringPassphrase)
 		if err != nil {
 			return client.Context{}, err
 		}
@@ -124,6 +155,28 @@ func (b *Broadcaster) GetClientContext(ctx context.Context, user User) (client.C
 	return clientContext, nil
 }
 
+// tempDir returns a new temporary directory for a local keyring.
+func (b *Broadcaster) tempDir() (string, error) {
+	if b.t != nil {
+		return b.t.TempDir(), nil
+	}
+
+	dir, err := os.MkdirTemp("", "interchaintest-keyring-")
+	if err != nil {
+		return "", fmt.Errorf("creating keyring directory: %w", err)
+	}
+	b.tempDirs = append(b.tempDirs, dir)
+	return dir, nil
+}
+
+// removeTempDirs removes any directories created by tempDir when no testing.T was provided.
+func (b *Broadcaster) removeTempDirs() {
+	for _, dir := range b.tempDirs {
+		_ = os.RemoveAll(dir)
+	}
+	b.tempDirs = nil
+}
+
 // GetTxResponseBytes returns the sdk.TxResponse bytes which returned from broadcast.Tx.
 func (b *Broadcaster) GetTxResponseBytes(ctx context.Context, user User) ([]byte, error) {
 	if b.buf == nil || b.buf.Len() == 0 {
@@ -180,9 +233,38 @@ func (b *Broadcaster) defaultTxFactory(clientCtx client.Context, accountNumber u
 		WithSimulateAndExecute(false)
 }
 
+// WithFees returns a FactoryOpt paying the exact flat fee fees, e.g. "5000uatom",
+// instead of the fee computed from the chain's GasPrices.
+func WithFees(fees string) FactoryOpt {
+	return func(f tx.Factory) tx.Factory {
+		// A factory with both fees and gas prices fails to build the tx.
+		return f.WithGasPrices("").WithFees(fees)
+	}
+}
+
+// WithBroadcastMode returns a ClientContextOpt broadcasting with mode instead of the default BroadcastBlock.
+func WithBroadcastMode(mode BroadcastMode) ClientContextOpt {
+	return func(clientContext client.Context) client.Context {
+		return clientContext.WithBroadcastMode(string(mode))
+	}
+}
+
 // BroadcastTx uses the provided Broadcaster to broadcast all the provided messages which will be signed
 // by the User provided. The sdk.TxResponse and an error are returned.
+// The error wraps ErrInsufficientFee if the transaction was rejected for paying too little,
+// or ErrTxConfirmationTimeout if broadcasting took longer than the chain's tx confirmation timeout.
+//
+// All messages are signed and executed as a single transaction, so the response carries the events of every message.
+// If any message fails, the whole transaction is reverted and the response holds the failing code and log.
+//
+// Transactions are broadcast with BroadcastBlock unless configured otherwise through WithBroadcastMode.
+// With BroadcastSync the response only holds the CheckTx result, and with BroadcastAsync only the hash,
+// so the transaction may still fail once it is included in a block.
 func BroadcastTx(ctx context.Context, broadcaster *Broadcaster, broadcastingUser User, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	if len(msgs) == 0 {
+		return sdk.TxResponse{}, fmt.Errorf("no messages to broadcast")
+	}
+
 	f, err := broadcaster.GetFactory(ctx, broadcastingUser)
 	if err != nil {
 		return sdk.TxResponse{}, err
@@ -193,14 +275,87 @@ func BroadcastTx(ctx context.Context, broadcaster *Broadcaster, broadcastingUser
 		return sdk.TxResponse{}, err
 	}
 
-	if err := tx.BroadcastTx(cc, f, msgs...); err != nil {
+	if err := BroadcastMode(cc.BroadcastMode).validate(); err != nil {
 		return sdk.TxResponse{}, err
 	}
 
+	out, err := broadcastWithTimeout(ctx, broadcaster.chain.Config(), cc, f, msgs...)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	broadcaster.buf.Reset()
+	broadcaster.buf.Write(out)
+
 	txBytes, err := broadcaster.GetTxResponseBytes(ctx, broadcastingUser)
 	if err != nil {
 		return sdk.TxResponse{}, err
 	}
 
-	return broadcaster.UnmarshalTxResponseBytes(ctx, txBytes)
+	resp, err := broadcaster.UnmarshalTxResponseBytes(ctx, txBytes)
+	if err != nil {
+		return resp, err
+	}
+
+	// Other failures are left for the caller to inspect through the response code,
+	// but a rejected fee never reaches a block, so surface it as an error.
+	if isInsufficientFee(resp.Codespace, resp.Code) {
+		return resp, txCodeError(resp.Codespace, resp.Code, resp.RawLog)
+	}
+	return resp, nil
+}
+
+// broadcastWithTimeout broadcasts msgs like tx.BroadcastTx, which cannot be cancelled,
+// but returns once the chain's tx confirmation timeout elapses, with an error naming the transaction hash.
+// A broadcast that has not sent its transaction by then stops before sending it,
+// but a transaction already sent may still be committed after broadcastWithTimeout returned.
+// It returns the output of the broadcast, i.e. the sdk.TxResponse, rather than writing it to the output of cc,
+// as a timed out broadcast keeps running and writes its output whenever it completes.
+func broadcastWithTimeout(ctx context.Context, cfg ibc.ChainConfig, cc client.Context, f tx.Factory, msgs ...sdk.Msg) ([]byte, error) {
+	out := new(bytes.Buffer)
+
+	_, err := withTxConfirmationTimeout(ctx, cfg, func(ctx context.Context) (string, error) {
+		txConfig := &hashRecordingTxConfig{TxConfig: cc.TxConfig, ctx: ctx}
+		cc := cc.WithTxConfig(txConfig).WithOutput(out)
+
+		done := make(chan error, 1)
+		go func() { done <- tx.BroadcastTx(cc, f, msgs...) }()
+		select {
+		case err := <-done:
+			return txConfig.TxHash(), err
+		case <-ctx.Done():
+			return txConfig.TxHash(), ctx.Err()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Only read once the broadcast completed, as it may still be writing to out after a timeout.
+	return out.Bytes(), nil
+}
+
+// SimulateTx uses the provided Broadcaster to simulate the provided messages signed by the User provided,
+// without committing them. It returns the gas used by the simulation, before any gas adjustment,
+// or the reason the transaction would fail.
+func SimulateTx(ctx context.Context, broadcaster *Broadcaster, simulatingUser User, msgs ...sdk.Msg) (uint64, error) {
+	f, err := broadcaster.GetFactory(ctx, simulatingUser)
+	if err != nil {
+		return 0, err
+	}
+
+	cc, err := broadcaster.GetClientContext(ctx, simulatingUser)
+	if err != nil {
+		return 0, err
+	}
+
+	// Fill in the account sequence, and simulate with the user's actual public key.
+	f, err = f.WithSimulateAndExecute(true).Prepare(cc)
+	if err != nil {
+		return 0, err
+	}
+
+	res, _, err := tx.CalculateGas(cc, f, msgs...)
+	if err != nil {
+		return 0, fmt.Errorf("tx simulation failed: %w", err)
+	}
+	return res.GasInfo.GasUsed, nil
 }
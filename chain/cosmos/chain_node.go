@@ -6,11 +6,14 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,24 +23,30 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/tendermint/crypto/bcrypt"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/p2p"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
-	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -60,21 +69,24 @@ type ChainNode struct {
 	containerID string
 
 	// Ports set during StartContainer.
-	hostRPCPort  string
-	hostGRPCPort string
+	hostRPCPort     string
+	hostGRPCPort    string
+	hostMetricsPort string
+	hostP2PPort     string
 }
 
 // ChainNodes is a collection of ChainNode
 type ChainNodes []*ChainNode
 
 const (
-	valKey      = "validator"
-	blockTime   = 2 // seconds
-	p2pPort     = "26656/tcp"
-	rpcPort     = "26657/tcp"
-	grpcPort    = "9090/tcp"
-	apiPort     = "1317/tcp"
-	privValPort = "1234/tcp"
+	valKey           = "validator"
+	defaultBlockTime = 2 * time.Second
+	p2pPort          = "26656/tcp"
+	rpcPort          = "26657/tcp"
+	grpcPort         = "9090/tcp"
+	apiPort          = "1317/tcp"
+	privValPort      = "1234/tcp"
+	metricsPort      = "26660/tcp"
 )
 
 var (
@@ -84,17 +96,17 @@ var (
 		nat.Port(grpcPort):    {},
 		nat.Port(apiPort):     {},
 		nat.Port(privValPort): {},
+		nat.Port(metricsPort): {},
 	}
 )
 
 // NewClient creates and assigns a new Tendermint RPC client to the ChainNode
 func (tn *ChainNode) NewClient(addr string) error {
-	httpClient, err := libclient.DefaultHTTPClient(addr)
+	httpClient, err := tendermint.NewHTTPClient(addr, 10*time.Second, tn.Chain.Config().TLS.ClientConfig())
 	if err != nil {
 		return err
 	}
 
-	httpClient.Timeout = 10 * time.Second
 	rpcClient, err := rpchttp.NewWithClient(addr, "/websocket", httpClient)
 	if err != nil {
 		return err
@@ -127,7 +139,7 @@ func (tn *ChainNode) Name() string {
 	} else {
 		nodeType = "fn"
 	}
-	return fmt.Sprintf("%s-%s-%d-%s", tn.Chain.Config().ChainID, nodeType, tn.Index, dockerutil.SanitizeContainerName(tn.TestName))
+	return fmt.Sprintf("%s-%s-%d-%s", tn.Chain.Config().ChainID, nodeType, tn.Index, dockerutil.ContainerNameSuffix(tn.TestName))
 }
 
 // hostname of the test node container
@@ -196,8 +208,61 @@ func (tn *ChainNode) HomeDir() string {
 	return path.Join("/var/cosmos-chain", tn.Chain.Config().Name)
 }
 
+// applyConfigFileOverrides merges each override into the TOML file at its path relative to the node's home directory,
+// e.g. "config/app.toml".
+func (tn *ChainNode) applyConfigFileOverrides(ctx context.Context, configFileOverrides map[string]any) error {
+	for configFile, modifiedConfig := range configFileOverrides {
+		modifiedToml, ok := testutil.AsToml(modifiedConfig)
+		if !ok {
+			return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (testutil.Toml or map[string]any)", configFile, modifiedConfig)
+		}
+		if err := testutil.ModifyTomlConfigFile(
+			ctx,
+			tn.logger(),
+			tn.DockerClient,
+			tn.TestName,
+			tn.VolumeName,
+			configFile,
+			modifiedToml,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetTestConfig modifies the config to reasonable values for use within interchaintest.
 func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
+	c, a, err := testConfig(tn.Chain.Config())
+	if err != nil {
+		return err
+	}
+
+	if err := testutil.ModifyTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		"config/config.toml",
+		c,
+	); err != nil {
+		return err
+	}
+
+	return testutil.ModifyTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		"config/app.toml",
+		a,
+	)
+}
+
+// testConfig returns the values SetTestConfig sets in config.toml and app.toml for the chain config.
+func testConfig(cfg ibc.ChainConfig) (config, app testutil.Toml, err error) {
 	c := make(testutil.Toml)
 
 	// Set Log Level to info
@@ -213,7 +278,15 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 
 	consensus := make(testutil.Toml)
 
-	blockT := (time.Duration(blockTime) * time.Second).String()
+	blockTime := defaultBlockTime
+	if bt := cfg.BlockTime; bt != "" {
+		var err error
+		blockTime, err = time.ParseDuration(bt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid block time %q: %w", bt, err)
+		}
+	}
+	blockT := blockTime.String()
 	consensus["timeout_commit"] = blockT
 	consensus["timeout_propose"] = blockT
 
@@ -226,29 +299,41 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 
 	c["rpc"] = rpc
 
-	if err := testutil.ModifyTomlConfigFile(
-		ctx,
-		tn.logger(),
-		tn.DockerClient,
-		tn.TestName,
-		tn.VolumeName,
-		"config/config.toml",
-		c,
-	); err != nil {
-		return err
+	switch indexer := cfg.TxIndexer; indexer {
+	case "":
+	case "kv", "null":
+		c["tx_index"] = testutil.Toml{"indexer": indexer}
+	default:
+		return nil, nil, fmt.Errorf("invalid tx indexer %q: must be kv or null", indexer)
+	}
+
+	if cfg.EnableMetrics {
+		instrumentation := make(testutil.Toml)
+
+		// Serve Prometheus metrics on all interfaces so the published port reaches them.
+		instrumentation["prometheus"] = true
+		instrumentation["prometheus_listen_addr"] = "0.0.0.0:26660"
+
+		c["instrumentation"] = instrumentation
 	}
 
 	a := make(testutil.Toml)
-	a["minimum-gas-prices"] = tn.Chain.Config().GasPrices
-	return testutil.ModifyTomlConfigFile(
-		ctx,
-		tn.logger(),
-		tn.DockerClient,
-		tn.TestName,
-		tn.VolumeName,
-		"config/app.toml",
-		a,
-	)
+	minGasPrices := cfg.MinGasPrices
+	if minGasPrices == "" {
+		minGasPrices = cfg.GasPrices
+	}
+	a["minimum-gas-prices"] = minGasPrices
+
+	switch pruning := cfg.Pruning; pruning {
+	case "":
+	case "default", "nothing", "everything":
+		a["pruning"] = pruning
+	default:
+		// A custom strategy also needs its intervals, which can be set through ConfigFileOverrides.
+		return nil, nil, fmt.Errorf("invalid pruning %q: must be default, nothing, or everything", pruning)
+	}
+
+	return c, a, nil
 }
 
 // SetPeers modifies the config persistent_peers for a node
@@ -280,6 +365,19 @@ func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
 	return uint64(height), nil
 }
 
+// Ready returns an error unless the node has committed at least one block and is no longer catching up.
+func (tn *ChainNode) Ready(ctx context.Context) error {
+	stat, err := tn.Client.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc client status: %w", err)
+	}
+	if stat.SyncInfo.LatestBlockHeight == 0 || stat.SyncInfo.CatchingUp {
+		return fmt.Errorf("node %s not ready: height(%d) catching-up(%t)",
+			tn.Name(), stat.SyncInfo.LatestBlockHeight, stat.SyncInfo.CatchingUp)
+	}
+	return nil
+}
+
 // FindTxs implements blockdb.BlockSaver.
 func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
 	h := int64(height)
@@ -381,21 +479,66 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 // with the chain node binary.
 func (tn *ChainNode) TxCommand(keyName string, command ...string) []string {
 	command = append([]string{"tx"}, command...)
+	// The CLI rejects --gas-prices alongside --fees, so an explicit flat fee replaces the chain's gas prices.
+	if !hasFlag(command, "--fees") {
+		command = append(command, "--gas-prices", tn.Chain.Config().GasPrices)
+	}
 	return tn.NodeCommand(append(command,
 		"--from", keyName,
-		"--gas-prices", tn.Chain.Config().GasPrices,
 		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
-		"--keyring-backend", keyring.BackendTest,
+		"--keyring-backend", tn.keyringBackend(),
 		"--output", "json",
 		"-y",
 	)...)
 }
 
+// hasFlag reports whether flag, e.g. "--fees", is set in command, either as a separate argument or as flag=value.
+func hasFlag(command []string, flag string) bool {
+	for _, arg := range command {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 // ExecTx executes a transaction, waits for 2 blocks if successful, then returns the tx hash.
+// If that takes longer than the chain's tx confirmation timeout, the error wraps ErrTxConfirmationTimeout.
 func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...string) (string, error) {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
+	return withTxConfirmationTimeout(ctx, tn.Chain.Config(), func(ctx context.Context) (string, error) {
+		txHash, err := tn.execTx(ctx, keyName, command...)
+		if err != nil {
+			return txHash, err
+		}
+		if err := testutil.WaitForBlocks(ctx, 2, tn); err != nil {
+			return txHash, err
+		}
+		return txHash, nil
+	})
+}
+
+// ExecTxWithMode executes a transaction broadcast with mode and returns the tx hash without waiting for further blocks.
+// With BroadcastBlock the transaction has been committed once it returns, and an error is returned if it failed;
+// with BroadcastSync only CheckTx has passed, and with BroadcastAsync nothing has been checked yet.
+// Broadcasting is bounded by the chain's tx confirmation timeout, like ExecTx.
+func (tn *ChainNode) ExecTxWithMode(ctx context.Context, mode BroadcastMode, keyName string, command ...string) (string, error) {
+	if err := mode.validate(); err != nil {
+		return "", err
+	}
+
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	return withTxConfirmationTimeout(ctx, tn.Chain.Config(), func(ctx context.Context) (string, error) {
+		return tn.execTx(ctx, keyName, withBroadcastModeFlag(command, mode)...)
+	})
+}
+
+// execTx executes a transaction and returns its hash, or an error if the node reports a failure code.
+func (tn *ChainNode) execTx(ctx context.Context, keyName string, command ...string) (string, error) {
 	stdout, _, err := tn.Exec(ctx, tn.TxCommand(keyName, command...), nil)
 	if err != nil {
 		return "", err
@@ -406,23 +549,42 @@ func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...stri
 		return "", err
 	}
 	if output.Code != 0 {
-		return output.TxHash, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
-	}
-	if err := testutil.WaitForBlocks(ctx, 2, tn); err != nil {
-		return "", err
+		return output.TxHash, txCodeError(output.Codespace, uint32(output.Code), output.RawLog)
 	}
 	return output.TxHash, nil
 }
 
+// txResultError returns the error of the committed transaction txHash if it failed when executed,
+// which ExecTx does not report since it only checks the tx passed CheckTx.
+func (tn *ChainNode) txResultError(txHash string) error {
+	res, err := authTx.QueryTx(tn.CliContext(), txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if res.Code != 0 {
+		return txCodeError(res.Codespace, res.Code, res.RawLog)
+	}
+	return nil
+}
+
+// withBroadcastModeFlag returns command broadcasting with mode, unless it already sets --broadcast-mode itself.
+func withBroadcastModeFlag(command []string, mode BroadcastMode) []string {
+	if hasFlag(command, "--broadcast-mode") {
+		return command
+	}
+	return append(command[:len(command):len(command)], "--broadcast-mode", string(mode))
+}
+
 // NodeCommand is a helper to retrieve a full command for a chain node binary.
 // when interactions with the RPC endpoint are necessary.
 // For example, if chain node binary is `gaiad`, and desired command is `gaiad keys show key1`,
 // pass ("keys", "show", "key1") for command to return the full command.
 // Will include additional flags for node URL, home directory, and chain ID.
+// The node is reached over https if TLS is enabled in the chain config, see ibc.TLSConfig.
 func (tn *ChainNode) NodeCommand(command ...string) []string {
 	command = tn.BinCommand(command...)
 	return append(command,
-		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--node", fmt.Sprintf("%s://%s:26657", tn.Chain.Config().TLS.RPCScheme(), tn.HostName()),
 		"--chain-id", tn.Chain.Config().ChainID,
 	)
 }
@@ -500,7 +662,27 @@ func (tn *ChainNode) InitHomeFolder(ctx context.Context) error {
 		"init", CondenseMoniker(tn.Name()),
 		"--chain-id", tn.Chain.Config().ChainID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return tn.initFileKeyring(ctx)
+}
+
+// FileKeyringPassphrase is the passphrase of the keyring of nodes using the "file" keyring backend,
+// see ibc.ChainConfig.KeyringBackend. Commands run within the nodes are given it when it is prompted for.
+const FileKeyringPassphrase = "interchaintest"
+
+// initFileKeyring stores the hash of FileKeyringPassphrase in a "file" keyring before any key is added to it,
+// so that it is then prompted for only once per command, instead of twice to create the keyring.
+func (tn *ChainNode) initFileKeyring(ctx context.Context) error {
+	if tn.keyringBackend() != keyring.BackendFile {
+		return nil
+	}
+	hash, err := bcrypt.GenerateFromPassword(tmcrypto.CRandBytes(16), []byte(FileKeyringPassphrase), 2)
+	if err != nil {
+		return fmt.Errorf("failed to hash keyring passphrase: %w", err)
+	}
+	return tn.WriteFile(ctx, hash, path.Join("keyring-"+keyring.BackendFile, "keyhash"))
 }
 
 // WriteFile accepts file contents in a byte slice and writes the contents to
@@ -511,6 +693,18 @@ func (tn *ChainNode) WriteFile(ctx context.Context, content []byte, relPath stri
 	return fw.WriteFile(ctx, tn.VolumeName, relPath, content)
 }
 
+// ReadFile reads the contents of a single file from the docker filesystem.
+// relPath describes the location of the file in the docker volume relative to
+// the home directory
+func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	content, err := fr.SingleFileContent(ctx, tn.VolumeName, relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
+	}
+	return content, nil
+}
+
 // CopyFile adds a file from the host filesystem to the docker filesystem
 // relPath describes the location of the file in the docker volume relative to
 // the home directory
@@ -522,25 +716,58 @@ func (tn *ChainNode) CopyFile(ctx context.Context, srcPath, dstPath string) erro
 	return tn.WriteFile(ctx, content, dstPath)
 }
 
-// CreateKey creates a key in the keyring backend test for the given node
+// CreateKey creates a key in the node's keyring, see ChainConfig.KeyringBackend
 func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.ExecBin(ctx,
+	command := []string{
 		"keys", "add", name,
 		"--coin-type", tn.Chain.Config().CoinType,
-		"--keyring-backend", keyring.BackendTest,
-	)
+		"--keyring-backend", tn.keyringBackend(),
+	}
+	_, _, err := tn.ExecBin(ctx, append(command, tn.keyAlgoFlags()...)...)
 	return err
 }
 
+// keyringBackend returns the keyring backend holding the node's keys.
+func (tn *ChainNode) keyringBackend() string {
+	if backend := tn.Chain.Config().KeyringBackend; backend != "" {
+		return backend
+	}
+	return keyring.BackendTest
+}
+
+// recoverGenesisKeys recovers the chain's configured genesis keys into the node's keyring.
+func (tn *ChainNode) recoverGenesisKeys(ctx context.Context) error {
+	for _, key := range tn.Chain.Config().GenesisKeys {
+		if err := tn.RecoverKey(ctx, key.Name, key.Mnemonic); err != nil {
+			return fmt.Errorf("failed to recover genesis key %s: %w", key.Name, err)
+		}
+	}
+	return nil
+}
+
+// keyAlgoFlags returns the flags selecting the chain's configured signing algorithm, if any.
+func (tn *ChainNode) keyAlgoFlags() []string {
+	if algo := tn.Chain.Config().SigningAlgorithm; algo != "" {
+		return []string{"--algo", algo}
+	}
+	return nil
+}
+
 // RecoverKey restores a key from a given mnemonic.
 func (tn *ChainNode) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
+	input := fmt.Sprintf(`echo %q`, mnemonic)
+	if tn.keyringBackend() == keyring.BackendFile {
+		// The mnemonic and the keyring passphrase are each read through their own 4096 byte buffer,
+		// so the mnemonic is padded to fill the first, leaving the passphrase to the second.
+		input = fmt.Sprintf(`{ printf '%%-4095s\n' %q; echo "$%s"; }`, mnemonic, keyringPassphraseEnv)
+	}
 	command := []string{
 		"sh",
 		"-c",
-		fmt.Sprintf(`echo %q | %s keys add %s --recover --keyring-backend %s --coin-type %s --home %s --output json`, mnemonic, tn.Chain.Config().Bin, keyName, keyring.BackendTest, tn.Chain.Config().CoinType, tn.HomeDir()),
+		fmt.Sprintf(`%s | %s keys add %s --recover --keyring-backend %s --coin-type %s --home %s --output json %s`, input, tn.Chain.Config().Bin, keyName, tn.keyringBackend(), tn.Chain.Config().CoinType, tn.HomeDir(), strings.Join(tn.keyAlgoFlags(), " ")),
 	}
 
 	tn.lock.Lock()
@@ -579,7 +806,7 @@ func (tn *ChainNode) Gentx(ctx context.Context, name string, genesisSelfDelegati
 
 	_, _, err := tn.ExecBin(ctx,
 		"gentx", valKey, fmt.Sprintf("%d%s", genesisSelfDelegation.Amount.Int64(), genesisSelfDelegation.Denom),
-		"--keyring-backend", keyring.BackendTest,
+		"--keyring-backend", tn.keyringBackend(),
 		"--chain-id", tn.Chain.Config().ChainID,
 	)
 	return err
@@ -599,9 +826,68 @@ func (tn *ChainNode) CollectGentxs(ctx context.Context) error {
 }
 
 type CosmosTx struct {
-	TxHash string `json:"txhash"`
-	Code   int    `json:"code"`
-	RawLog string `json:"raw_log"`
+	TxHash    string `json:"txhash"`
+	Codespace string `json:"codespace"`
+	Code      int    `json:"code"`
+	RawLog    string `json:"raw_log"`
+}
+
+// ErrInsufficientFee is wrapped by the error returned when a transaction is rejected
+// because its fee is below the node's minimum gas prices.
+var ErrInsufficientFee = errors.New("insufficient fee")
+
+// ErrSendDisabled is wrapped by the error returned when a send or IBC transfer is rejected
+// because sending its denom is disabled, by the bank module's send_enabled params
+// or, for IBC transfers, by the transfer module's send_enabled param.
+var ErrSendDisabled = errors.New("send disabled")
+
+// ErrBlockedAddress is wrapped by the error returned when a send is rejected
+// because its recipient is blocked by the bank module, such as a module account.
+var ErrBlockedAddress = errors.New("blocked address")
+
+// ErrInstantiateUnauthorized is wrapped by the error returned when a wasm instantiate is rejected
+// because the code's instantiate permission, see InstantiatePermission, does not allow the sender.
+var ErrInstantiateUnauthorized = errors.New("instantiate unauthorized")
+
+// txCodeError returns the error for a transaction that failed with the given code.
+func txCodeError(codespace string, code uint32, rawLog string) error {
+	var sentinel error
+	switch {
+	case isInsufficientFee(codespace, code):
+		sentinel = ErrInsufficientFee
+	case isSendDisabled(codespace, code):
+		sentinel = ErrSendDisabled
+	case isBlockedAddress(codespace, code, rawLog):
+		sentinel = ErrBlockedAddress
+	case isInstantiateUnauthorized(codespace, code, rawLog):
+		sentinel = ErrInstantiateUnauthorized
+	default:
+		return fmt.Errorf("transaction failed with code %d: %s", code, rawLog)
+	}
+	return fmt.Errorf("%w: transaction failed with code %d: %s", sentinel, code, rawLog)
+}
+
+func isInsufficientFee(codespace string, code uint32) bool {
+	return codespace == sdkerrors.RootCodespace && code == sdkerrors.ErrInsufficientFee.ABCICode()
+}
+
+func isSendDisabled(codespace string, code uint32) bool {
+	return (codespace == banktypes.ErrSendDisabled.Codespace() && code == banktypes.ErrSendDisabled.ABCICode()) ||
+		(codespace == transfertypes.ErrSendDisabled.Codespace() && code == transfertypes.ErrSendDisabled.ABCICode())
+}
+
+// isBlockedAddress reports whether the bank module rejected the recipient.
+// The bank module reports blocked recipients with the generic unauthorized code, so its message is matched too.
+func isBlockedAddress(codespace string, code uint32, rawLog string) bool {
+	return codespace == sdkerrors.RootCodespace && code == sdkerrors.ErrUnauthorized.ABCICode() &&
+		strings.Contains(rawLog, "is not allowed to receive funds")
+}
+
+// isInstantiateUnauthorized reports whether the wasm module rejected the sender of an instantiate.
+// As for blocked addresses, the generic unauthorized code is used, so the message is matched too.
+func isInstantiateUnauthorized(codespace string, code uint32, rawLog string) bool {
+	return codespace == sdkerrors.RootCodespace && code == sdkerrors.ErrUnauthorized.ABCICode() &&
+		strings.Contains(rawLog, "can not instantiate")
 }
 
 func (tn *ChainNode) SendIBCTransfer(
@@ -615,25 +901,34 @@ func (tn *ChainNode) SendIBCTransfer(
 		"ibc-transfer", "transfer", "transfer", channelID,
 		amount.Address, fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
 	}
-	if options.Timeout != nil {
-		if options.Timeout.NanoSeconds > 0 {
-			command = append(command, "--packet-timeout-timestamp", fmt.Sprint(options.Timeout.NanoSeconds))
-		} else if options.Timeout.Height > 0 {
-			command = append(command, "--packet-timeout-height", fmt.Sprintf("0-%d", options.Timeout.Height))
-		}
+	if t := options.Timeout; t != nil && (t.NanoSeconds > 0 || t.Height > 0) {
+		// Both flags are always set together, so that a zero value disables that timeout
+		// instead of falling back to the CLI's default.
+		command = append(command,
+			"--packet-timeout-timestamp", fmt.Sprint(t.NanoSeconds),
+			"--packet-timeout-height", fmt.Sprintf("0-%d", t.Height),
+		)
 	}
 	if options.Memo != "" {
 		command = append(command, "--memo", options.Memo)
 	}
+	if options.Fees != "" {
+		command = append(command, "--fees", options.Fees)
+	}
 	return tn.ExecTx(ctx, keyName, command...)
 }
 
 func (tn *ChainNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	_, err := tn.ExecTx(ctx,
+	_, err := tn.BankSend(ctx, keyName, amount)
+	return err
+}
+
+// BankSend sends amount from keyName to amount.Address and returns the transaction hash.
+func (tn *ChainNode) BankSend(ctx context.Context, keyName string, amount ibc.WalletAmount) (string, error) {
+	return tn.ExecTx(ctx,
 		keyName, "bank", "send", keyName,
 		amount.Address, fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
 	)
-	return err
 }
 
 type InstantiateContractAttribute struct {
@@ -663,15 +958,62 @@ type CodeInfosResponse struct {
 	CodeInfos []CodeInfo `json:"code_infos"`
 }
 
+// Access types for an InstantiatePermission.
+const (
+	AccessEverybody      = "Everybody"
+	AccessNobody         = "Nobody"
+	AccessAnyOfAddresses = "AnyOfAddresses"
+)
+
+// InstantiatePermission restricts who may instantiate a stored wasm code.
+type InstantiatePermission struct {
+	// Access is one of AccessEverybody, AccessNobody, or AccessAnyOfAddresses.
+	Access string
+	// Addresses may instantiate the code when Access is AccessAnyOfAddresses.
+	Addresses []string
+}
+
+// flags returns the wasm store flags setting the permission.
+func (p InstantiatePermission) flags() ([]string, error) {
+	switch p.Access {
+	case AccessEverybody:
+		return []string{"--instantiate-everybody", "true"}, nil
+	case AccessNobody:
+		return []string{"--instantiate-nobody", "true"}, nil
+	case AccessAnyOfAddresses:
+		if len(p.Addresses) == 0 {
+			return nil, fmt.Errorf("instantiate permission %s requires at least one address", p.Access)
+		}
+		return []string{"--instantiate-anyof-addresses", strings.Join(p.Addresses, ",")}, nil
+	default:
+		return nil, fmt.Errorf("unknown instantiate permission access type %q", p.Access)
+	}
+}
+
 // StoreContract takes a file path to smart contract and stores it on-chain. Returns the contracts code id.
-func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName string) (string, error) {
+// If a permission is given, only the accounts it allows may instantiate the code;
+// otherwise the chain's default instantiate permission applies.
+func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName string, permission ...InstantiatePermission) (string, error) {
+	var permissionFlags []string
+	switch len(permission) {
+	case 0:
+	case 1:
+		var err error
+		if permissionFlags, err = permission[0].flags(); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("at most one instantiate permission may be given, got %d", len(permission))
+	}
+
 	_, file := filepath.Split(fileName)
 	err := tn.CopyFile(ctx, fileName, file)
 	if err != nil {
 		return "", fmt.Errorf("writing contract file to docker volume: %w", err)
 	}
 
-	if _, err := tn.ExecTx(ctx, keyName, "wasm", "store", path.Join(tn.HomeDir(), file)); err != nil {
+	command := append([]string{"wasm", "store", path.Join(tn.HomeDir(), file)}, permissionFlags...)
+	if _, err := tn.ExecTx(ctx, keyName, command...); err != nil {
 		return "", err
 	}
 
@@ -694,15 +1036,20 @@ func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName
 }
 
 // InstantiateContract takes a code id for a smart contract and initialization message and returns the instantiated contract address.
+// If the code's instantiate permission does not allow keyName, the error wraps ErrInstantiateUnauthorized.
 func (tn *ChainNode) InstantiateContract(ctx context.Context, keyName string, codeID string, initMessage string, needsNoAdminFlag bool) (string, error) {
 	command := []string{"wasm", "instantiate", codeID, initMessage, "--label", "wasm-contract"}
 	if needsNoAdminFlag {
 		command = append(command, "--no-admin")
 	}
-	_, err := tn.ExecTx(ctx, keyName, command...)
+	txHash, err := tn.ExecTx(ctx, keyName, command...)
 	if err != nil {
 		return "", err
 	}
+	// The instantiate permission is only checked once the tx is executed.
+	if err := tn.txResultError(txHash); err != nil {
+		return "", fmt.Errorf("instantiate code %s: %w", codeID, err)
+	}
 
 	stdout, _, err := tn.ExecQuery(ctx, "wasm", "list-contract-by-code", codeID)
 	if err != nil {
@@ -713,11 +1060,40 @@ func (tn *ChainNode) InstantiateContract(ctx context.Context, keyName string, co
 	if err := json.Unmarshal([]byte(stdout), &contactsRes); err != nil {
 		return "", err
 	}
+	if len(contactsRes.Contracts) == 0 {
+		return "", fmt.Errorf("no contract instantiated from code %s", codeID)
+	}
 
 	contractAddress := contactsRes.Contracts[len(contactsRes.Contracts)-1]
 	return contractAddress, nil
 }
 
+// PinCodesProposal submits a governance proposal to pin the given wasm codes in the chain's in-memory cache.
+func (tn *ChainNode) PinCodesProposal(ctx context.Context, keyName string, prop PinCodesProposal) (string, error) {
+	command := append([]string{"gov", "submit-proposal", "pin-codes"}, prop.CodeIDs...)
+	command = append(command,
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	)
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// QueryPinnedCodes returns the IDs of the wasm codes pinned on the chain.
+func (tn *ChainNode) QueryPinnedCodes(ctx context.Context) ([]string, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "wasm", "pinned")
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		CodeIDs []string `json:"code_ids"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return nil, err
+	}
+	return res.CodeIDs, nil
+}
+
 // ExecuteContract executes a contract transaction with a message using it's address.
 func (tn *ChainNode) ExecuteContract(ctx context.Context, keyName string, contractAddress string, message string) error {
 	_, err := tn.ExecTx(ctx, keyName,
@@ -794,6 +1170,19 @@ func (tn *ChainNode) QueryProposal(ctx context.Context, proposalID string) (*Pro
 	return &proposal, nil
 }
 
+// QueryProposalTally returns the current tally of votes on a governance proposal.
+func (tn *ChainNode) QueryProposalTally(ctx context.Context, proposalID string) (ProposalFinalTallyResult, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "gov", "tally", proposalID)
+	if err != nil {
+		return ProposalFinalTallyResult{}, err
+	}
+	var tally ProposalFinalTallyResult
+	if err := json.Unmarshal(stdout, &tally); err != nil {
+		return ProposalFinalTallyResult{}, err
+	}
+	return tally, nil
+}
+
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
 	command := []string{
@@ -869,15 +1258,43 @@ func (tn *ChainNode) DumpContractState(ctx context.Context, contractAddress stri
 	return res, nil
 }
 
+// ExportState runs the chain binary's export command against the node's data, returning the app state at height
+// as genesis JSON, e.g. at the halt height of an upgrade.
+// The export cannot open the node's database while the node runs,
+// so a running node is stopped for the export and started again afterwards.
 func (tn *ChainNode) ExportState(ctx context.Context, height int64) (string, error) {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, stderr, err := tn.ExecBin(ctx, "export", "--height", fmt.Sprint(height))
+	running := false
+	if tn.containerID != "" {
+		c, err := tn.DockerClient.ContainerInspect(ctx, tn.containerID)
+		if err != nil {
+			return "", fmt.Errorf("inspect container %s: %w", tn.Name(), err)
+		}
+		running = c.State.Running
+	}
+	if running {
+		if err := tn.StopContainer(ctx); err != nil {
+			return "", fmt.Errorf("stop node %s before export: %w", tn.Name(), err)
+		}
+	}
+
+	stdout, stderr, err := tn.ExecBin(ctx, "export", "--height", fmt.Sprint(height))
+
+	if running {
+		if startErr := tn.StartContainer(ctx); startErr != nil {
+			return "", fmt.Errorf("restart node %s after export: %w", tn.Name(), startErr)
+		}
+	}
 	if err != nil {
 		return "", err
 	}
-	// output comes to stderr for some reason
+
+	// Depending on the SDK version, the exported state is written to stdout or stderr.
+	if len(bytes.TrimSpace(stdout)) > 0 {
+		return string(stdout), nil
+	}
 	return string(stderr), nil
 }
 
@@ -889,20 +1306,87 @@ func (tn *ChainNode) UnsafeResetAll(ctx context.Context) error {
 	return err
 }
 
-func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
+// hostPortBindings returns port bindings reusing the host ports of this node's previous container, if any,
+// so that the host RPC and gRPC addresses remain valid when the node is stopped and started again.
+// Ports not yet assigned are left to PublishAllPorts.
+func (tn *ChainNode) hostPortBindings() nat.PortMap {
+	bindings := make(nat.PortMap)
+	for port, hostAddr := range map[string]string{
+		rpcPort:     tn.hostRPCPort,
+		grpcPort:    tn.hostGRPCPort,
+		metricsPort: tn.hostMetricsPort,
+		p2pPort:     tn.hostP2PPort,
+	} {
+		if hostAddr == "" {
+			continue
+		}
+		_, hostPort, err := net.SplitHostPort(hostAddr)
+		if err != nil {
+			continue
+		}
+		bindings[nat.Port(port)] = []nat.PortBinding{{HostPort: hostPort}}
+	}
+	return bindings
+}
+
+// startCmd returns the command run by the node container,
+// i.e. the chain's StartCmd or the default start command, followed by its AdditionalStartArgs.
+func (tn *ChainNode) startCmd() []string {
 	chainCfg := tn.Chain.Config()
-	cmd := []string{chainCfg.Bin, "start", "--home", tn.HomeDir(), "--x-crisis-skip-assert-invariants"}
+	home := tn.HomeDir()
+	if chainCfg.NoHostMount {
+		home += "_nomnt"
+	}
+
+	cmd := append([]string(nil), chainCfg.StartCmd...)
+	if len(cmd) == 0 {
+		cmd = []string{chainCfg.Bin, "start", "--home", home, "--x-crisis-skip-assert-invariants"}
+	}
+	cmd = append(cmd, chainCfg.AdditionalStartArgs...)
+
 	if chainCfg.NoHostMount {
-		cmd = []string{"sh", "-c", fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt --x-crisis-skip-assert-invariants", tn.HomeDir(), tn.HomeDir(), chainCfg.Bin, tn.HomeDir())}
+		// The command is passed as positional arguments so that the shell does not split or expand them.
+		return append([]string{"sh", "-c", `cp -r "$0" "$0"_nomnt && "$@"`, tn.HomeDir()}, cmd...)
 	}
+	return cmd
+}
+
+// startEnv returns the chain's Env as the variables of the node container, sorted by name.
+func (tn *ChainNode) startEnv() []string {
+	chainEnv := tn.Chain.Config().Env
+	env := make([]string, 0, len(chainEnv))
+	for k, v := range chainEnv {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// reproduceCmd returns the shell command equivalent to what the node container runs, including its environment.
+func reproduceCmd(env, cmd []string) string {
+	if len(env) == 0 {
+		return strings.Join(cmd, " ")
+	}
+	return "env " + strings.Join(env, " ") + " " + strings.Join(cmd, " ")
+}
+
+func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
+	chainCfg := tn.Chain.Config()
+	cmd := tn.startCmd()
+	env := tn.startEnv()
 	imageRef := tn.Image.Ref()
 	tn.logger().
 		Info("Running command",
-			zap.String("command", strings.Join(cmd, " ")),
+			zap.String("command", reproduceCmd(env, cmd)),
 			zap.String("container", tn.Name()),
 			zap.String("image", imageRef),
 		)
 
+	resources, err := dockerutil.ContainerResources(chainCfg.CPUs, chainCfg.Memory)
+	if err != nil {
+		return err
+	}
+
 	cc, err := tn.DockerClient.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -910,18 +1394,25 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 
 			Entrypoint: []string{},
 			Cmd:        cmd,
+			Env:        env,
 
 			Hostname: tn.HostName(),
 
-			Labels: map[string]string{dockerutil.CleanupLabel: tn.TestName},
+			Labels: dockerutil.CleanupLabels(tn.TestName, map[string]string{
+				dockerutil.ChainIDLabel:  chainCfg.ChainID,
+				dockerutil.HomeDirLabel:  tn.HomeDir(),
+				dockerutil.StartCmdLabel: reproduceCmd(env, cmd),
+			}),
 
 			ExposedPorts: sentryPorts,
 		},
 		&container.HostConfig{
 			Binds:           tn.Bind(),
+			PortBindings:    tn.hostPortBindings(),
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -951,10 +1442,12 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	// Set the host ports once since they will not change after the container has started.
 	tn.hostRPCPort = dockerutil.GetHostPort(c, rpcPort)
 	tn.hostGRPCPort = dockerutil.GetHostPort(c, grpcPort)
+	tn.hostMetricsPort = dockerutil.GetHostPort(c, metricsPort)
+	tn.hostP2PPort = dockerutil.GetHostPort(c, p2pPort)
 
 	tn.logger().Info("Cosmos chain node started", zap.String("container", tn.Name()), zap.String("rpc_port", tn.hostRPCPort))
 
-	err = tn.NewClient("tcp://" + tn.hostRPCPort)
+	err = tn.NewClient(tn.Chain.Config().TLS.RPCScheme() + "://" + tn.hostRPCPort)
 	if err != nil {
 		return err
 	}
@@ -1015,7 +1508,29 @@ func (tn *ChainNode) InitFullNodeFiles(ctx context.Context) error {
 		return err
 	}
 
-	return tn.SetTestConfig(ctx)
+	// Templates are the base the harness settings are applied on.
+	if err := tn.applyTemplates(ctx); err != nil {
+		return err
+	}
+
+	if err := tn.SetTestConfig(ctx); err != nil {
+		return err
+	}
+
+	return tn.copyHostFiles(ctx)
+}
+
+// copyHostFiles copies every file or directory in the chain config's HostFiles into the node's home directory.
+func (tn *ChainNode) copyHostFiles(ctx context.Context) error {
+	files := tn.Chain.Config().HostFiles
+	if len(files) == 0 {
+		return nil
+	}
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteHostFiles(ctx, tn.VolumeName, files); err != nil {
+		return fmt.Errorf("copying host files: %w", err)
+	}
+	return nil
 }
 
 // NodeID returns the persistent ID of a given node.
@@ -1043,7 +1558,7 @@ func (tn *ChainNode) NodeID(ctx context.Context) (string, error) {
 func (tn *ChainNode) KeyBech32(ctx context.Context, name string, bech string) (string, error) {
 	command := []string{tn.Chain.Config().Bin, "keys", "show", "--address", name,
 		"--home", tn.HomeDir(),
-		"--keyring-backend", keyring.BackendTest,
+		"--keyring-backend", tn.keyringBackend(),
 	}
 
 	if bech != "" {
@@ -1063,6 +1578,31 @@ func (tn *ChainNode) AccountKeyBech32(ctx context.Context, name string) (string,
 	return tn.KeyBech32(ctx, name, "")
 }
 
+// ValidatorOperatorAddress returns the operator address of the node's validator key, e.g. cosmosvaloper1...,
+// formatted with the chain's validator bech32 prefix rather than the one compiled into the binary.
+func (tn *ChainNode) ValidatorOperatorAddress(ctx context.Context) (string, error) {
+	acc, err := tn.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return "", err
+	}
+	cfg := tn.Chain.Config()
+	bz, err := types.GetFromBech32(acc, cfg.Bech32Prefix)
+	if err != nil {
+		return "", fmt.Errorf("validator key address %s: %w", acc, err)
+	}
+	return types.Bech32ifyAddressBytes(cfg.ValidatorBech32Prefix(), bz)
+}
+
+// ValidatorConsensusAddress returns the consensus address of the node, e.g. cosmosvalcons1...,
+// formatted with the chain's consensus bech32 prefix.
+func (tn *ChainNode) ValidatorConsensusAddress(ctx context.Context) (string, error) {
+	stat, err := tn.Client.Status(ctx)
+	if err != nil {
+		return "", fmt.Errorf("tendermint rpc client status: %w", err)
+	}
+	return types.Bech32ifyAddressBytes(tn.Chain.Config().ConsensusBech32Prefix(), stat.ValidatorInfo.Address)
+}
+
 // PeerString returns the string for connecting the nodes passed in
 func (nodes ChainNodes) PeerString(ctx context.Context) string {
 	addrs := make([]string, len(nodes))
@@ -1108,13 +1648,41 @@ func (nodes ChainNodes) logger() *zap.Logger {
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
 	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
-		Env:   env,
+		Env:   append(tn.execEnv(), env...),
 		Binds: tn.Bind(),
 	}
-	res := job.Run(ctx, cmd, opts)
+	res := job.Run(ctx, tn.withKeyringPassphrase(cmd), opts)
 	return res.Stdout, res.Stderr, res.Err
 }
 
+// keyringPassphraseEnv is the environment variable holding FileKeyringPassphrase in the commands run within the node.
+const keyringPassphraseEnv = "KEYRING_PASSPHRASE"
+
+// execEnv returns the environment of the commands run within the node, before the caller's own.
+// Those dialing the node's RPC server over TLS trust the chain config's TLS CACertFile, if set,
+// and those using a "file" keyring find its passphrase in keyringPassphraseEnv.
+func (tn *ChainNode) execEnv() []string {
+	var env []string
+	if tlsCfg := tn.Chain.Config().TLS; tlsCfg.Enabled && tlsCfg.CACertFile != "" {
+		env = append(env, "SSL_CERT_FILE="+path.Join(tn.HomeDir(), tlsCfg.CACertFile))
+	}
+	if tn.keyringBackend() == keyring.BackendFile {
+		env = append(env, keyringPassphraseEnv+"="+FileKeyringPassphrase)
+	}
+	return env
+}
+
+// withKeyringPassphrase returns cmd with the keyring passphrase on its standard input,
+// if it uses a "file" keyring, i.e. cmd sets --keyring-backend file, which prompts for it.
+func (tn *ChainNode) withKeyringPassphrase(cmd []string) []string {
+	for i, arg := range cmd {
+		if arg == "--keyring-backend" && i+1 < len(cmd) && cmd[i+1] == keyring.BackendFile {
+			return append([]string{"sh", "-c", fmt.Sprintf(`echo "$%s" | "$@"`, keyringPassphraseEnv), "sh"}, cmd...)
+		}
+	}
+	return cmd
+}
+
 func (tn *ChainNode) logger() *zap.Logger {
 	return tn.log.With(
 		zap.String("chain_id", tn.Chain.Config().ChainID),
@@ -1167,8 +1735,14 @@ func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, from
 		return err
 	}
 
-	_, err = tn.ExecTx(ctx, fromAddr,
-		"intertx", "submit", string(msg),
+	return tn.SendICATx(ctx, fromAddr, connectionID, string(msg))
+}
+
+// SendICATx submits a single JSON-encoded sdk.Msg, including its "@type" field,
+// to be executed by the interchain account owned by keyName on the counterparty chain.
+func (tn *ChainNode) SendICATx(ctx context.Context, keyName, connectionID, msg string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"intertx", "submit", msg,
 		"--connection-id", connectionID,
 	)
 	return err
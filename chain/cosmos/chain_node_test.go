@@ -0,0 +1,382 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+)
+
+func TestTxCodeError(t *testing.T) {
+	err := txCodeError(sdkerrors.RootCodespace, sdkerrors.ErrInsufficientFee.ABCICode(), "insufficient fees; got: 10uatom required: 100uatom")
+	require.True(t, errors.Is(err, ErrInsufficientFee))
+	require.ErrorContains(t, err, "required: 100uatom")
+
+	err = txCodeError(sdkerrors.RootCodespace, sdkerrors.ErrInsufficientFunds.ABCICode(), "insufficient funds")
+	require.False(t, errors.Is(err, ErrInsufficientFee))
+
+	// Codes are only unique within a codespace.
+	err = txCodeError("wasm", sdkerrors.ErrInsufficientFee.ABCICode(), "some wasm error")
+	require.False(t, errors.Is(err, ErrInsufficientFee))
+
+	err = txCodeError(banktypes.ModuleName, banktypes.ErrSendDisabled.ABCICode(), "uatom transfers are currently disabled: send transactions are disabled")
+	require.True(t, errors.Is(err, ErrSendDisabled))
+
+	err = txCodeError(transfertypes.ModuleName, transfertypes.ErrSendDisabled.ABCICode(), "fungible token transfers from this chain are disabled")
+	require.True(t, errors.Is(err, ErrSendDisabled))
+
+	err = txCodeError(sdkerrors.RootCodespace, sdkerrors.ErrUnauthorized.ABCICode(), "cosmos1abc is not allowed to receive funds: unauthorized")
+	require.True(t, errors.Is(err, ErrBlockedAddress))
+
+	err = txCodeError(sdkerrors.RootCodespace, sdkerrors.ErrUnauthorized.ABCICode(), "signature verification failed")
+	require.False(t, errors.Is(err, ErrBlockedAddress))
+	require.False(t, errors.Is(err, ErrInstantiateUnauthorized))
+
+	// An instantiate by an address the code's InstantiatePermission does not allow.
+	err = txCodeError(sdkerrors.RootCodespace, sdkerrors.ErrUnauthorized.ABCICode(), "failed to execute message; message index: 0: can not instantiate: unauthorized")
+	require.True(t, errors.Is(err, ErrInstantiateUnauthorized))
+	require.False(t, errors.Is(err, ErrBlockedAddress))
+}
+
+func TestInstantiatePermissionFlags(t *testing.T) {
+	flags, err := InstantiatePermission{Access: AccessNobody}.flags()
+	require.NoError(t, err)
+	require.Equal(t, []string{"--instantiate-nobody", "true"}, flags)
+
+	flags, err = InstantiatePermission{Access: AccessAnyOfAddresses, Addresses: []string{"wasm1a", "wasm1b"}}.flags()
+	require.NoError(t, err)
+	require.Equal(t, []string{"--instantiate-anyof-addresses", "wasm1a,wasm1b"}, flags)
+
+	_, err = InstantiatePermission{Access: AccessAnyOfAddresses}.flags()
+	require.ErrorContains(t, err, "at least one address")
+
+	_, err = InstantiatePermission{Access: "Somebody"}.flags()
+	require.ErrorContains(t, err, "unknown instantiate permission")
+}
+
+func TestKeyringBackend(t *testing.T) {
+	tn := &ChainNode{Chain: &CosmosChain{}}
+	require.Equal(t, "test", tn.keyringBackend())
+
+	tn.Chain = &CosmosChain{cfg: ibc.ChainConfig{KeyringBackend: "os"}}
+	require.Equal(t, "os", tn.keyringBackend())
+
+	cmd := tn.TxCommand("alice", "bank", "send")
+	for i, arg := range cmd {
+		if arg == "--keyring-backend" {
+			require.Equal(t, "os", cmd[i+1])
+			return
+		}
+	}
+	t.Fatalf("no --keyring-backend flag in %v", cmd)
+}
+
+func TestFileKeyringPassphrase(t *testing.T) {
+	tn := &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{Bin: "gaiad"}}}
+	cmd := tn.TxCommand("alice", "bank", "send")
+	require.Equal(t, cmd, tn.withKeyringPassphrase(cmd))
+	require.Empty(t, tn.execEnv())
+
+	tn.Chain = &CosmosChain{cfg: ibc.ChainConfig{Bin: "gaiad", KeyringBackend: "file"}}
+	require.Equal(t, []string{"KEYRING_PASSPHRASE=" + FileKeyringPassphrase}, tn.execEnv())
+
+	// Only commands using the keyring are given the passphrase.
+	cmd = tn.TxCommand("alice", "bank", "send")
+	require.Equal(t, append([]string{"sh", "-c", `echo "$KEYRING_PASSPHRASE" | "$@"`, "sh"}, cmd...), tn.withKeyringPassphrase(cmd))
+	cmd = tn.BinCommand("status")
+	require.Equal(t, cmd, tn.withKeyringPassphrase(cmd))
+}
+
+func TestNodeCommandTLS(t *testing.T) {
+	tn := &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{Bin: "gaiad", ChainID: "gaia-1"}}, TestName: "TestFoo"}
+	cmd := tn.NodeCommand("status")
+	require.Contains(t, cmd, "http://"+tn.HostName()+":26657")
+	require.Empty(t, tn.execEnv())
+
+	tn.Chain = &CosmosChain{cfg: ibc.ChainConfig{Bin: "gaiad", ChainID: "gaia-1", TLS: ibc.TLSConfig{Enabled: true}}}
+	cmd = tn.NodeCommand("status")
+	require.Contains(t, cmd, "https://"+tn.HostName()+":26657")
+	require.Empty(t, tn.execEnv(), "the image's trust store is used by default")
+
+	tn.Chain = &CosmosChain{cfg: ibc.ChainConfig{Bin: "gaiad", ChainID: "gaia-1", TLS: ibc.TLSConfig{Enabled: true, CACertFile: "config/ca.pem"}}}
+	require.Equal(t, []string{"SSL_CERT_FILE=" + tn.HomeDir() + "/config/ca.pem"}, tn.execEnv())
+}
+
+func TestTxCommandFees(t *testing.T) {
+	tn := &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{GasPrices: "0.01uatom"}}}
+
+	cmd := tn.TxCommand("alice", "bank", "send")
+	require.True(t, hasFlag(cmd, "--gas-prices"))
+	require.False(t, hasFlag(cmd, "--fees"))
+
+	for _, fees := range [][]string{{"--fees", "5000uatom"}, {"--fees=5000uatom"}} {
+		cmd = tn.TxCommand("alice", append([]string{"bank", "send"}, fees...)...)
+		require.True(t, hasFlag(cmd, "--fees"))
+		require.False(t, hasFlag(cmd, "--gas-prices"), "fees and gas prices are mutually exclusive")
+	}
+}
+
+func TestWithBroadcastModeFlag(t *testing.T) {
+	cmd := withBroadcastModeFlag([]string{"bank", "send"}, BroadcastAsync)
+	require.Equal(t, []string{"bank", "send", "--broadcast-mode", "async"}, cmd)
+
+	cmd = withBroadcastModeFlag([]string{"bank", "send", "--broadcast-mode=sync"}, BroadcastBlock)
+	require.Equal(t, []string{"bank", "send", "--broadcast-mode=sync"}, cmd, "an explicit flag takes precedence")
+
+	require.NoError(t, BroadcastBlock.validate())
+	require.Error(t, BroadcastMode("commit").validate())
+}
+
+func TestChainNodeStartEnv(t *testing.T) {
+	tn := &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{}}}
+	require.Empty(t, tn.startEnv())
+	require.Equal(t, "gaiad start", reproduceCmd(tn.startEnv(), []string{"gaiad", "start"}))
+
+	tn.Chain = &CosmosChain{cfg: ibc.ChainConfig{Env: map[string]string{"B_FLAG": "2", "A_FLAG": "1"}}}
+	env := tn.startEnv()
+	require.Equal(t, []string{"A_FLAG=1", "B_FLAG=2"}, env)
+	require.Equal(t, "env A_FLAG=1 B_FLAG=2 gaiad start --trace", reproduceCmd(env, []string{"gaiad", "start", "--trace"}))
+}
+
+func TestCosmosChainLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	c := NewCosmosChain("TestFoo", ibc.ChainConfig{ChainID: "foo-1", CoinType: "118"}, 1, 0, zap.New(core))
+
+	c.Logger().Info("hello")
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	require.Equal(t, map[string]interface{}{"chain_id": "foo-1", "test": "TestFoo"}, entries[0].ContextMap())
+}
+
+func TestNewCosmosChainDefaultUidGid(t *testing.T) {
+	images := []ibc.DockerImage{{Repository: "a"}, {Repository: "b", UidGid: "1000:1000"}}
+	c := NewCosmosChain("TestFoo", ibc.ChainConfig{ChainID: "foo-1", CoinType: "118", Images: images}, 1, 0, zap.NewNop())
+
+	require.Equal(t, "1025:1025", c.Config().Images[0].UidGid)
+	require.Equal(t, "1000:1000", c.Config().Images[1].UidGid)
+	require.Empty(t, images[0].UidGid, "the caller's images must not be modified")
+}
+
+func TestChainNodeStartCmd(t *testing.T) {
+	node := func(cfg ibc.ChainConfig) *ChainNode {
+		cfg.Name, cfg.Bin = "gaia", "gaiad"
+		return &ChainNode{Chain: &CosmosChain{cfg: cfg}}
+	}
+
+	require.Equal(t,
+		[]string{"gaiad", "start", "--home", "/var/cosmos-chain/gaia", "--x-crisis-skip-assert-invariants"},
+		node(ibc.ChainConfig{}).startCmd(),
+	)
+
+	require.Equal(t,
+		[]string{"gaiad", "start", "--home", "/var/cosmos-chain/gaia", "--x-crisis-skip-assert-invariants", "--log_level", "debug"},
+		node(ibc.ChainConfig{AdditionalStartArgs: []string{"--log_level", "debug"}}).startCmd(),
+	)
+
+	require.Equal(t,
+		[]string{"/wrapper.sh", "gaiad", "--trace"},
+		node(ibc.ChainConfig{StartCmd: []string{"/wrapper.sh", "gaiad"}, AdditionalStartArgs: []string{"--trace"}}).startCmd(),
+	)
+
+	require.Equal(t,
+		[]string{
+			"sh", "-c", `cp -r "$0" "$0"_nomnt && "$@"`, "/var/cosmos-chain/gaia",
+			"gaiad", "start", "--home", "/var/cosmos-chain/gaia_nomnt", "--x-crisis-skip-assert-invariants", "--pruning", "nothing",
+		},
+		node(ibc.ChainConfig{NoHostMount: true, AdditionalStartArgs: []string{"--pruning", "nothing"}}).startCmd(),
+	)
+}
+
+func TestTestConfig_History(t *testing.T) {
+	c, a, err := testConfig(ibc.ChainConfig{GasPrices: "0.01uatom"})
+	require.NoError(t, err)
+	require.NotContains(t, c, "tx_index")
+	require.NotContains(t, a, "pruning")
+	require.Equal(t, "0.01uatom", a["minimum-gas-prices"])
+
+	c, a, err = testConfig(ibc.ChainConfig{Pruning: "nothing", TxIndexer: "kv"})
+	require.NoError(t, err)
+	require.Equal(t, testutil.Toml{"indexer": "kv"}, c["tx_index"])
+	require.Equal(t, "nothing", a["pruning"])
+
+	_, _, err = testConfig(ibc.ChainConfig{Pruning: "custom"})
+	require.ErrorContains(t, err, `invalid pruning "custom"`)
+
+	_, _, err = testConfig(ibc.ChainConfig{TxIndexer: "psql"})
+	require.ErrorContains(t, err, `invalid tx indexer "psql"`)
+}
+
+func TestCosmosChain_SendPacketUnsupported(t *testing.T) {
+	c := &CosmosChain{cfg: ibc.ChainConfig{}}
+	_, err := c.SendPacket(context.Background(), "user", "channel-0", "myapp", []byte("data"), ibc.IBCTimeout{})
+	require.ErrorIs(t, err, ErrSendPacketUnsupported)
+}
+
+func TestSentPacketTx(t *testing.T) {
+	sendPacket := func(port, channel string) abcitypes.Event {
+		attr := func(k, v string) abcitypes.EventAttribute {
+			return abcitypes.EventAttribute{Key: []byte(k), Value: []byte(v)}
+		}
+		return abcitypes.Event{Type: "send_packet", Attributes: []abcitypes.EventAttribute{
+			attr("packet_sequence", "5"),
+			attr("packet_src_port", port),
+			attr("packet_src_channel", channel),
+			attr("packet_dst_port", "counterparty"),
+			attr("packet_dst_channel", "channel-9"),
+			attr("packet_timeout_height", "0-20"),
+			attr("packet_timeout_timestamp", "0"),
+			attr("packet_data", "data"),
+		}}
+	}
+	txResp := func(code uint32, events ...abcitypes.Event) *types.TxResponse {
+		return &types.TxResponse{
+			Height: 12, TxHash: "ABC", GasWanted: 200, GasUsed: 100,
+			Codespace: sdkerrors.RootCodespace, Code: code, Events: events,
+		}
+	}
+
+	t.Run("packet", func(t *testing.T) {
+		tx, err := sentPacketTx(txResp(0, abcitypes.Event{Type: "message"}, sendPacket("myapp", "channel-0")), "myapp", "channel-0")
+		require.NoError(t, err)
+		require.Equal(t, ibc.Tx{
+			Height:   12,
+			TxHash:   "ABC",
+			GasSpent: 200,
+			GasUsed:  100,
+			Packet: ibc.Packet{
+				Sequence:      5,
+				SourcePort:    "myapp",
+				SourceChannel: "channel-0",
+				DestPort:      "counterparty",
+				DestChannel:   "channel-9",
+				Data:          []byte("data"),
+				TimeoutHeight: "0-20",
+			},
+		}, tx)
+	})
+
+	t.Run("other channel", func(t *testing.T) {
+		_, err := sentPacketTx(txResp(0, sendPacket("myapp", "channel-1")), "myapp", "channel-0")
+		require.ErrorContains(t, err, "sent a packet on myapp/channel-1 instead of myapp/channel-0")
+	})
+
+	t.Run("other port", func(t *testing.T) {
+		_, err := sentPacketTx(txResp(0, sendPacket("transfer", "channel-0")), "myapp", "channel-0")
+		require.ErrorContains(t, err, "sent a packet on transfer/channel-0 instead of myapp/channel-0")
+	})
+
+	t.Run("no packet", func(t *testing.T) {
+		_, err := sentPacketTx(txResp(0, abcitypes.Event{Type: "message"}), "myapp", "channel-0")
+		require.ErrorContains(t, err, "transaction ABC did not send a packet")
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		tx, err := sentPacketTx(txResp(sdkerrors.ErrInsufficientFee.ABCICode()), "myapp", "channel-0")
+		require.ErrorIs(t, err, ErrInsufficientFee)
+		require.Equal(t, "ABC", tx.TxHash)
+	})
+}
+
+func TestCosmosChain_GetTransferEscrowAddress(t *testing.T) {
+	c := &CosmosChain{cfg: ibc.ChainConfig{Bech32Prefix: "cosmos"}}
+	require.Equal(t, "cosmos1a53udazy8ayufvy0s434pfwjcedzqv34kvz9tw", c.GetTransferEscrowAddress("transfer", "channel-0"))
+}
+
+// fakeBankQueryClient serves canned supply responses; its other methods are unimplemented.
+type fakeBankQueryClient struct {
+	banktypes.QueryClient
+
+	pages    []*banktypes.QueryTotalSupplyResponse
+	supplyOf map[string]types.Coin
+}
+
+func (f *fakeBankQueryClient) TotalSupply(_ context.Context, req *banktypes.QueryTotalSupplyRequest, _ ...grpc.CallOption) (*banktypes.QueryTotalSupplyResponse, error) {
+	// Page i is requested with key "i"; the first page with no key.
+	i := 0
+	if len(req.Pagination.Key) > 0 {
+		i = int(req.Pagination.Key[0] - '0')
+	}
+	return f.pages[i], nil
+}
+
+func (f *fakeBankQueryClient) SupplyOf(_ context.Context, req *banktypes.QuerySupplyOfRequest, _ ...grpc.CallOption) (*banktypes.QuerySupplyOfResponse, error) {
+	coin, ok := f.supplyOf[req.Denom]
+	if !ok {
+		coin = types.NewInt64Coin(req.Denom, 0)
+	}
+	return &banktypes.QuerySupplyOfResponse{Amount: coin}, nil
+}
+
+func TestTotalSupply(t *testing.T) {
+	ctx := context.Background()
+
+	qc := &fakeBankQueryClient{pages: []*banktypes.QueryTotalSupplyResponse{
+		{
+			Supply:     types.NewCoins(types.NewInt64Coin("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", 100), types.NewInt64Coin("uatom", 5000)),
+			Pagination: &query.PageResponse{NextKey: []byte("1")},
+		},
+		{
+			Supply: types.NewCoins(types.NewInt64Coin("ustake", 7)),
+		},
+	}}
+	supply, err := totalSupply(ctx, qc)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.WalletAmount{
+		{Denom: "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", Amount: 100},
+		{Denom: "uatom", Amount: 5000},
+		{Denom: "ustake", Amount: 7},
+	}, supply)
+
+	huge, ok := types.NewIntFromString("9223372036854775808") // math.MaxInt64 + 1.
+	require.True(t, ok)
+	qc = &fakeBankQueryClient{pages: []*banktypes.QueryTotalSupplyResponse{
+		{Supply: types.NewCoins(types.NewCoin("uhuge", huge))},
+	}}
+	_, err = totalSupply(ctx, qc)
+	require.ErrorContains(t, err, "supply of uhuge overflows int64")
+}
+
+func TestSupplyOf(t *testing.T) {
+	ctx := context.Background()
+	qc := &fakeBankQueryClient{supplyOf: map[string]types.Coin{"uatom": types.NewInt64Coin("uatom", 5000)}}
+
+	amount, err := supplyOf(ctx, qc, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), amount)
+
+	amount, err = supplyOf(ctx, qc, "unone")
+	require.NoError(t, err)
+	require.Zero(t, amount)
+}
+
+func TestHeightQueryError(t *testing.T) {
+	err := heightQueryError(5, errors.New("rpc error: code = InvalidArgument desc = failed to load state at height 5; version does not exist (latest height: 120): invalid request"))
+	require.True(t, errors.Is(err, ErrHeightPruned), "unexpected error: %v", err)
+	require.ErrorContains(t, err, "height 5")
+
+	err = heightQueryError(130, errors.New("rpc error: code = InvalidArgument desc = failed to load state at height 130; version does not exist (latest height: 120): invalid request"))
+	require.True(t, errors.Is(err, ErrHeightNotYetProduced), "unexpected error: %v", err)
+	require.False(t, errors.Is(err, ErrHeightPruned))
+
+	err = heightQueryError(500, errors.New("rpc error: code = InvalidArgument desc = cannot query with height in the future; please provide a valid height: invalid height"))
+	require.True(t, errors.Is(err, ErrHeightNotYetProduced), "unexpected error: %v", err)
+	require.ErrorContains(t, err, "height 500")
+
+	err = heightQueryError(5, errors.New("connection refused"))
+	require.False(t, errors.Is(err, ErrHeightPruned))
+	require.False(t, errors.Is(err, ErrHeightNotYetProduced))
+	require.ErrorContains(t, err, "failed to query at height 5")
+}
@@ -13,6 +13,8 @@ import (
 	ibctypes "github.com/cosmos/ibc-go/v6/modules/core/types"
 )
 
+// DefaultEncoding returns an encoding config with the interfaces of the simapp modules, bank, and IBC registered.
+// This is used for any cosmos chain whose ChainConfig.EncodingConfig is nil.
 func DefaultEncoding() simappparams.EncodingConfig {
 	// core modules
 	cfg := simappparams.MakeTestEncodingConfig()
@@ -29,6 +31,22 @@ func DefaultEncoding() simappparams.EncodingConfig {
 	return cfg
 }
 
+// DefaultEncodingWith returns DefaultEncoding after additionally calling each register function
+// on its interface registry. It is intended for use as a ChainConfig's EncodingConfig
+// when a chain has custom modules, so that their messages can be used with the native SDK client helpers.
+//
+//	cfg := ibc.ChainConfig{
+//		// ...
+//		EncodingConfig: cosmos.DefaultEncodingWith(wasmtypes.RegisterInterfaces),
+//	}
+func DefaultEncodingWith(register ...func(codectypes.InterfaceRegistry)) *simappparams.EncodingConfig {
+	cfg := DefaultEncoding()
+	for _, fn := range register {
+		fn(cfg.InterfaceRegistry)
+	}
+	return &cfg
+}
+
 func decodeTX(interfaceRegistry codectypes.InterfaceRegistry, txbz []byte) (sdk.Tx, error) {
 	cdc := codec.NewProtoCodec(interfaceRegistry)
 	return authTx.DefaultTxDecoder(cdc)(txbz)
@@ -3,9 +3,10 @@ package cosmos
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,11 +19,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
+	"github.com/cosmos/go-bip39"
+	transferTypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
 	chanTypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
-	dockertypes "github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/internal/tendermint"
@@ -30,10 +34,11 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v6/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 // CosmosChain is a local docker testnet for a Cosmos SDK chain.
@@ -84,6 +89,30 @@ func NewCosmosChain(testName string, chainConfig ibc.ChainConfig, numValidators
 		chainConfig.EncodingConfig = &cfg
 	}
 
+	// Key derivation on the host and via the chain's CLI must agree on the coin type,
+	// so resolve the default once rather than leaving it to each caller.
+	if chainConfig.CoinType == "" {
+		coinType, err := chainConfig.VerifyCoinType()
+		if err != nil {
+			panic(err)
+		}
+		chainConfig.CoinType = coinType
+	}
+	if err := validateSigningAlgorithm(chainConfig.SigningAlgorithm); err != nil {
+		panic(err)
+	}
+
+	// Node home directories are owned by UidGid, and heighliner images run as the heighliner user,
+	// so leaving it unset would make the volumes unwritable by the chain binary.
+	images := make([]ibc.DockerImage, len(chainConfig.Images))
+	copy(images, chainConfig.Images)
+	for i := range images {
+		if images[i].UidGid == "" {
+			images[i].UidGid = dockerutil.GetHeighlinerUserString()
+		}
+	}
+	chainConfig.Images = images
+
 	registry := codectypes.NewInterfaceRegistry()
 	cryptocodec.RegisterInterfaces(registry)
 	cdc := codec.NewProtoCodec(registry)
@@ -104,6 +133,18 @@ func (c *CosmosChain) Nodes() ChainNodes {
 	return append(c.Validators, c.FullNodes...)
 }
 
+// Ready returns an error unless every node of the chain is producing blocks and has caught up.
+func (c *CosmosChain) Ready(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return n.Ready(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
 	// Get peer string for existing nodes
@@ -135,22 +176,8 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 			if err := fn.overwriteGenesisFile(ctx, genbz); err != nil {
 				return err
 			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					fn.logger(),
-					fn.DockerClient,
-					fn.TestName,
-					fn.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
+			if err := fn.applyConfigFileOverrides(ctx, configFileOverrides); err != nil {
+				return err
 			}
 			if err := fn.CreateNodeContainer(ctx); err != nil {
 				return err
@@ -166,6 +193,15 @@ func (c *CosmosChain) Config() ibc.ChainConfig {
 	return c.cfg
 }
 
+// Logger returns the logger the chain was created with, annotated with the chain ID and test name,
+// so that test helpers can log alongside the chain's own container lifecycle messages.
+func (c *CosmosChain) Logger() *zap.Logger {
+	return c.log.With(
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("test", c.testName),
+	)
+}
+
 // Implements Chain interface
 func (c *CosmosChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
 	return c.initializeChainNodes(ctx, testName, cli, networkID)
@@ -189,7 +225,7 @@ func (c *CosmosChain) Exec(ctx context.Context, cmd []string, env []string) (std
 
 // Implements Chain interface
 func (c *CosmosChain) GetRPCAddress() string {
-	return fmt.Sprintf("http://%s:26657", c.getFullNode().HostName())
+	return fmt.Sprintf("%s://%s:26657", c.cfg.TLS.RPCScheme(), c.getFullNode().HostName())
 }
 
 // Implements Chain interface
@@ -199,16 +235,23 @@ func (c *CosmosChain) GetGRPCAddress() string {
 
 // GetHostRPCAddress returns the address of the RPC server accessible by the host.
 // This will not return a valid address until the chain has been started.
+// The address stays the same when nodes are restarted, e.g. through StopAllNodes and StartAllNodes.
 func (c *CosmosChain) GetHostRPCAddress() string {
-	return "http://" + c.getFullNode().hostRPCPort
+	return c.cfg.TLS.RPCScheme() + "://" + c.getFullNode().hostRPCPort
 }
 
 // GetHostGRPCAddress returns the address of the gRPC server accessible by the host.
 // This will not return a valid address until the chain has been started.
+// The server is dialed over TLS if enabled in the chain's TLS config, see ibc.TLSConfig.GRPCCredentials.
 func (c *CosmosChain) GetHostGRPCAddress() string {
 	return c.getFullNode().hostGRPCPort
 }
 
+// dialGRPC dials the gRPC server at addr, over TLS if enabled in the chain config.
+func (c *CosmosChain) dialGRPC(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr, grpc.WithTransportCredentials(c.cfg.TLS.GRPCCredentials()))
+}
+
 // HomeDir implements ibc.Chain.
 func (c *CosmosChain) HomeDir() string {
 	return c.getFullNode().HomeDir()
@@ -236,18 +279,26 @@ func (c *CosmosChain) GetAddress(ctx context.Context, keyName string) ([]byte, e
 
 // BuildWallet will return a Cosmos wallet
 // If mnemonic != "", it will restore using that mnemonic
-// If mnemonic == "", it will create a new key
+// If mnemonic == "", it will create a new key from a freshly generated mnemonic,
+// which is available through the returned wallet's Mnemonic method.
 func (c *CosmosChain) BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error) {
-	if mnemonic != "" {
-		if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
-			return nil, fmt.Errorf("failed to recover key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+	if mnemonic == "" {
+		// Generate the mnemonic here rather than letting the node create the key,
+		// so that the returned wallet can be reconstructed outside the container.
+		entropy, err := bip39.NewEntropy(256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate entropy for key %q on chain %s: %w", keyName, c.cfg.Name, err)
 		}
-	} else {
-		if err := c.CreateKey(ctx, keyName); err != nil {
-			return nil, fmt.Errorf("failed to create key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+		mnemonic, err = bip39.NewMnemonic(entropy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mnemonic for key %q on chain %s: %w", keyName, c.cfg.Name, err)
 		}
 	}
 
+	if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
+		return nil, fmt.Errorf("failed to recover key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+	}
+
 	addrBytes, err := c.GetAddress(ctx, keyName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account address for key %q on chain %s: %w", keyName, c.cfg.Name, err)
@@ -281,12 +332,38 @@ func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (i
 		return nil, fmt.Errorf("failed to get address: %w", err)
 	}
 
+	if c.cfg.SigningAlgorithm == EthSecp256k1 {
+		// The in-memory keyring only knows secp256k1 addresses;
+		// the private key is the same, but Ethermint accounts use the Ethereum address.
+		wallet := NewWallet(keyName, nil, mnemonic, c.cfg).(*CosmosWallet)
+		privKey, err := wallet.PrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		addrBytes = ethAddress(privKey)
+	}
+
 	return NewWallet(keyName, addrBytes, mnemonic, c.cfg), nil
 }
 
+// SendFunds performs a bank send from keyName to amount.Address on this chain.
+// The broadcast only reports CheckTx failures, so the committed result is also checked,
+// e.g. to surface an insufficient-funds error from DeliverTx.
 // Implements Chain interface
 func (c *CosmosChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	return c.getFullNode().SendFunds(ctx, keyName, amount)
+	txHash, err := c.getFullNode().BankSend(ctx, keyName, amount)
+	if err != nil {
+		return fmt.Errorf("failed to send %d%s from %s to %s: %w", amount.Amount, amount.Denom, keyName, amount.Address, err)
+	}
+
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if txResp.Code != 0 {
+		return fmt.Errorf("bank send %s: %w", txHash, txCodeError(txResp.Codespace, txResp.Code, txResp.RawLog))
+	}
+	return nil
 }
 
 // Implements Chain interface
@@ -306,53 +383,125 @@ func (c *CosmosChain) SendIBCTransfer(
 		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
 	}
 	if txResp.Code != 0 {
-		return tx, fmt.Errorf("error in transaction (code: %d): %s", txResp.Code, txResp.RawLog)
+		return tx, fmt.Errorf("ibc transfer %s: %w", txHash, txCodeError(txResp.Codespace, txResp.Code, txResp.RawLog))
 	}
-	tx.Height = uint64(txResp.Height)
-	tx.TxHash = txHash
-	// In cosmos, user is charged for entire gas requested, not the actual gas used.
-	tx.GasSpent = txResp.GasWanted
+	tx = packetTx(txResp)
 
-	const evType = "send_packet"
-	events := txResp.Events
+	packet, ok, err := SendPacket(txResp.Events)
+	if err != nil {
+		return tx, err
+	}
+	if !ok {
+		return tx, fmt.Errorf("transaction %s did not send a packet", txHash)
+	}
+	tx.Packet = packet
 
-	var (
-		seq, _           = tendermint.AttributeValue(events, evType, "packet_sequence")
-		srcPort, _       = tendermint.AttributeValue(events, evType, "packet_src_port")
-		srcChan, _       = tendermint.AttributeValue(events, evType, "packet_src_channel")
-		dstPort, _       = tendermint.AttributeValue(events, evType, "packet_dst_port")
-		dstChan, _       = tendermint.AttributeValue(events, evType, "packet_dst_channel")
-		timeoutHeight, _ = tendermint.AttributeValue(events, evType, "packet_timeout_height")
-		timeoutTs, _     = tendermint.AttributeValue(events, evType, "packet_timeout_timestamp")
-		data, _          = tendermint.AttributeValue(events, evType, "packet_data")
-	)
-	tx.Packet.SourcePort = srcPort
-	tx.Packet.SourceChannel = srcChan
-	tx.Packet.DestPort = dstPort
-	tx.Packet.DestChannel = dstChan
-	tx.Packet.TimeoutHeight = timeoutHeight
-	tx.Packet.Data = []byte(data)
+	return tx, nil
+}
+
+// ErrSendPacketUnsupported is returned by SendPacket for chains without a ChainConfig.SendPacketCmd.
+var ErrSendPacketUnsupported = errors.New("chain config has no SendPacketCmd")
 
-	seqNum, err := strconv.Atoi(seq)
+// SendPacket sends a packet with arbitrary data on the given port and channel from the key fromKey,
+// through the command returned by the chain config's SendPacketCmd, since IBC has no generic message sending packets.
+// The returned Tx carries the sent packet, e.g. for testutil.WaitForAck or PollForAck.
+func (c *CosmosChain) SendPacket(
+	ctx context.Context,
+	fromKey, channelID, portID string,
+	data []byte,
+	timeout ibc.IBCTimeout,
+) (tx ibc.Tx, _ error) {
+	if c.cfg.SendPacketCmd == nil {
+		return tx, fmt.Errorf("send packet on %s/%s: %w", portID, channelID, ErrSendPacketUnsupported)
+	}
+
+	txHash, err := c.getFullNode().ExecTx(ctx, fromKey, c.cfg.SendPacketCmd(portID, channelID, data, timeout)...)
 	if err != nil {
-		return tx, fmt.Errorf("invalid packet sequence from events %s: %w", seq, err)
+		return tx, fmt.Errorf("send packet on %s/%s: %w", portID, channelID, err)
+	}
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	return sentPacketTx(txResp, portID, channelID)
+}
+
+// sentPacketTx returns the ibc.Tx of a committed transaction, with the packet it sent on the given port and channel.
+func sentPacketTx(txResp *types.TxResponse, portID, channelID string) (ibc.Tx, error) {
+	tx := packetTx(txResp)
+	if txResp.Code != 0 {
+		return tx, fmt.Errorf("send packet %s: %w", txResp.TxHash, txCodeError(txResp.Codespace, txResp.Code, txResp.RawLog))
 	}
-	tx.Packet.Sequence = uint64(seqNum)
 
-	timeoutNano, err := strconv.ParseUint(timeoutTs, 10, 64)
+	packet, ok, err := SendPacket(txResp.Events)
 	if err != nil {
-		return tx, fmt.Errorf("invalid packet timestamp timeout %s: %w", timeoutTs, err)
+		return tx, err
+	}
+	if !ok {
+		return tx, fmt.Errorf("transaction %s did not send a packet", txResp.TxHash)
+	}
+	if packet.SourcePort != portID || packet.SourceChannel != channelID {
+		return tx, fmt.Errorf("transaction %s sent a packet on %s/%s instead of %s/%s",
+			txResp.TxHash, packet.SourcePort, packet.SourceChannel, portID, channelID)
 	}
-	tx.Packet.TimeoutTimestamp = ibc.Nanoseconds(timeoutNano)
+	tx.Packet = packet
 
 	return tx, nil
 }
 
+// packetTx returns the ibc.Tx of a committed transaction, without its packet.
+func packetTx(txResp *types.TxResponse) ibc.Tx {
+	return ibc.Tx{
+		Height: uint64(txResp.Height),
+		TxHash: txResp.TxHash,
+		// In cosmos, user is charged for entire gas requested, not the actual gas used.
+		GasSpent: txResp.GasWanted,
+		GasUsed:  txResp.GasUsed,
+	}
+}
+
+// BroadcastTx signs the given messages with the user's key from the test keyring
+// and broadcasts them in a single transaction, using the gas prices and adjustment from the chain config.
+// Unlike the CLI helpers, any sdk.Msg may be sent, whether or not the chain binary has a subcommand for it.
+// The transaction is broadcast with BroadcastBlock, so the response holds its committed result, including its events.
+//
+// Like the package-level BroadcastTx, it takes a User rather than an ibc.Wallet, as the address is needed
+// with the chain's prefix; the wallets returned by interchaintest.GetAndFundTestUsers are Users.
+func (c *CosmosChain) BroadcastTx(ctx context.Context, user User, msgs ...types.Msg) (types.TxResponse, error) {
+	return c.BroadcastTxWithMode(ctx, BroadcastBlock, user, msgs...)
+}
+
+// BroadcastTxWithMode is like BroadcastTx, but broadcasts with the given mode,
+// e.g. BroadcastAsync to submit many transactions without waiting for each to be committed.
+func (c *CosmosChain) BroadcastTxWithMode(ctx context.Context, mode BroadcastMode, user User, msgs ...types.Msg) (types.TxResponse, error) {
+	b := NewBroadcaster(nil, c)
+	defer b.removeTempDirs()
+	b.ConfigureClientContextOptions(WithBroadcastMode(mode))
+
+	return BroadcastTx(ctx, b, user, msgs...)
+}
+
+// SimulateTx simulates the messages signed by user without committing them,
+// returning the gas used so that it can be set on a subsequent broadcast,
+// or the reason the transaction would fail.
+func (c *CosmosChain) SimulateTx(ctx context.Context, user User, msgs ...types.Msg) (uint64, error) {
+	b := NewBroadcaster(nil, c)
+	defer b.removeTempDirs()
+
+	return SimulateTx(ctx, b, user, msgs...)
+}
+
 // QueryProposal returns the state and details of a governance proposal.
 func (c *CosmosChain) QueryProposal(ctx context.Context, proposalID string) (*ProposalResponse, error) {
 	return c.getFullNode().QueryProposal(ctx, proposalID)
 }
 
+// QueryProposalTally returns the current tally of votes on a governance proposal.
+// Unlike the proposal's FinalTallyResult, it is populated while the proposal is still in its voting period.
+func (c *CosmosChain) QueryProposalTally(ctx context.Context, proposalID string) (ProposalFinalTallyResult, error) {
+	return c.getFullNode().QueryProposalTally(ctx, proposalID)
+}
+
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (c *CosmosChain) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (tx TxProposal, _ error) {
 	txHash, err := c.getFullNode().UpgradeProposal(ctx, keyName, prop)
@@ -402,20 +551,68 @@ func (c *CosmosChain) txProposal(txHash string) (tx TxProposal, _ error) {
 }
 
 // StoreContract takes a file path to smart contract and stores it on-chain. Returns the contracts code id.
-func (c *CosmosChain) StoreContract(ctx context.Context, keyName string, fileName string) (string, error) {
-	return c.getFullNode().StoreContract(ctx, keyName, fileName)
+// If a permission is given, only the accounts it allows may instantiate the code.
+func (c *CosmosChain) StoreContract(ctx context.Context, keyName string, fileName string, permission ...InstantiatePermission) (string, error) {
+	return c.getFullNode().StoreContract(ctx, keyName, fileName, permission...)
 }
 
 // InstantiateContract takes a code id for a smart contract and initialization message and returns the instantiated contract address.
+// If the code's instantiate permission does not allow keyName, the error wraps ErrInstantiateUnauthorized.
 func (c *CosmosChain) InstantiateContract(ctx context.Context, keyName string, codeID string, initMessage string, needsNoAdminFlag bool) (string, error) {
 	return c.getFullNode().InstantiateContract(ctx, keyName, codeID, initMessage, needsNoAdminFlag)
 }
 
+// PinCodesProposal submits a governance proposal to pin wasm codes, signed by keyName.
+func (c *CosmosChain) PinCodesProposal(ctx context.Context, keyName string, prop PinCodesProposal) (tx TxProposal, _ error) {
+	txHash, err := c.getFullNode().PinCodesProposal(ctx, keyName, prop)
+	if err != nil {
+		return tx, fmt.Errorf("failed to submit pin codes proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// QueryPinnedCodes returns the IDs of the wasm codes pinned on the chain.
+func (c *CosmosChain) QueryPinnedCodes(ctx context.Context) ([]string, error) {
+	return c.getFullNode().QueryPinnedCodes(ctx)
+}
+
 // ExecuteContract executes a contract transaction with a message using it's address.
 func (c *CosmosChain) ExecuteContract(ctx context.Context, keyName string, contractAddress string, message string) error {
 	return c.getFullNode().ExecuteContract(ctx, keyName, contractAddress, message)
 }
 
+// ExecuteContractWithResult executes a contract transaction like ExecuteContract,
+// and returns the committed transaction, with the packet it sent over IBC, if any.
+// Its Packet is left empty when the contract did not send a packet.
+func (c *CosmosChain) ExecuteContractWithResult(ctx context.Context, keyName string, contractAddress string, message string) (tx ibc.Tx, _ error) {
+	return c.executeContract(ctx, keyName, contractAddress, message)
+}
+
+// executeContract executes a contract transaction with additional flags, such as --amount,
+// and returns the committed transaction with the packet it sent, if any.
+func (c *CosmosChain) executeContract(ctx context.Context, keyName string, contractAddress string, message string, flags ...string) (tx ibc.Tx, _ error) {
+	command := append([]string{"wasm", "execute", contractAddress, message}, flags...)
+	txHash, err := c.getFullNode().ExecTx(ctx, keyName, command...)
+	if err != nil {
+		return tx, fmt.Errorf("execute contract: %w", err)
+	}
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if txResp.Code != 0 {
+		return tx, fmt.Errorf("error in transaction (code: %d): %s", txResp.Code, txResp.RawLog)
+	}
+	tx = packetTx(txResp)
+
+	packet, _, err := SendPacket(txResp.Events)
+	if err != nil {
+		return tx, err
+	}
+	tx.Packet = packet
+	return tx, nil
+}
+
 // QueryContract performs a smart query, taking in a query struct and returning a error with the response struct populated.
 func (c *CosmosChain) QueryContract(ctx context.Context, contractAddress string, query any, response any) error {
 	return c.getFullNode().QueryContract(ctx, contractAddress, query, response)
@@ -436,7 +633,8 @@ func (c *CosmosChain) QueryClientContractCode(ctx context.Context, codeHash stri
 	return c.getFullNode().QueryClientContractCode(ctx, codeHash, response)
 }
 
-// ExportState exports the chain state at specific height.
+// ExportState exports the chain state at specific height as genesis JSON,
+// briefly stopping the full node if it is running.
 // Implements Chain interface
 func (c *CosmosChain) ExportState(ctx context.Context, height int64) (string, error) {
 	return c.getFullNode().ExportState(ctx, height)
@@ -446,8 +644,8 @@ func (c *CosmosChain) ExportState(ctx context.Context, height int64) (string, er
 // Implements Chain interface
 func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
 	params := &bankTypes.QueryBalanceRequest{Address: address, Denom: denom}
-	grpcAddress := c.getFullNode().hostGRPCPort
-	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	grpcAddress := c.GetHostGRPCAddress()
+	conn, err := c.dialGRPC(grpcAddress)
 	if err != nil {
 		return 0, err
 	}
@@ -463,11 +661,167 @@ func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom stri
 	return res.Balance.Amount.Int64(), nil
 }
 
+// ErrHeightPruned is wrapped by the error returned when querying state at a height the node no longer retains.
+// Set the chain's Pruning to "nothing" to keep the state of every height.
+var ErrHeightPruned = errors.New("state at height pruned")
+
+// ErrHeightNotYetProduced is wrapped by the error returned when querying state at a height the chain has not reached yet.
+var ErrHeightNotYetProduced = errors.New("height not yet produced")
+
+// GetBalanceAtHeight fetches the balance of an account address and denom as of the end of block height,
+// e.g. to find which block of a relayed packet changed it.
+// The error wraps ErrHeightPruned if the full node no longer has the state at height,
+// or ErrHeightNotYetProduced if the chain has not reached height yet.
+func (c *CosmosChain) GetBalanceAtHeight(ctx context.Context, address string, denom string, height int64) (int64, error) {
+	if height <= 0 {
+		return 0, fmt.Errorf("invalid height %d: must be positive", height)
+	}
+
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+	res, err := bankTypes.NewQueryClient(conn).Balance(ctx, &bankTypes.QueryBalanceRequest{Address: address, Denom: denom})
+	if err != nil {
+		return 0, heightQueryError(height, err)
+	}
+	return res.Balance.Amount.Int64(), nil
+}
+
+// heightQueryError returns the error for a query at height that failed with err.
+func heightQueryError(height int64, err error) error {
+	msg := err.Error()
+	if strings.Contains(msg, "cannot query with height in the future") {
+		return fmt.Errorf("%w: height %d: %v", ErrHeightNotYetProduced, height, err)
+	}
+	// The SDK reports a height missing from the store, whether pruned or not yet committed, with this message,
+	// followed by the latest height of the store.
+	if strings.Contains(msg, "failed to load state at height") {
+		if m := latestHeightPattern.FindStringSubmatch(msg); m != nil {
+			if latest, perr := strconv.ParseInt(m[1], 10, 64); perr == nil && height > latest {
+				return fmt.Errorf("%w: height %d: %v", ErrHeightNotYetProduced, height, err)
+			}
+		}
+		return fmt.Errorf("%w: height %d: %v", ErrHeightPruned, height, err)
+	}
+	return fmt.Errorf("failed to query at height %d: %w", height, err)
+}
+
+// latestHeightPattern matches the latest height of the store in an SDK error, e.g. "(latest height: 120)".
+var latestHeightPattern = regexp.MustCompile(`latest height: (\d+)`)
+
+// GetTransferEscrowAddress returns the address of the account escrowing the native tokens
+// transferred out over the given port and channel.
+func (c *CosmosChain) GetTransferEscrowAddress(portID, channelID string) string {
+	return types.MustBech32ifyAddressBytes(c.cfg.Bech32Prefix, transferTypes.GetEscrowAddress(portID, channelID))
+}
+
+// GetTransferEscrowBalance returns the amount of denom escrowed for the given port and channel,
+// i.e. sent out over the channel and neither transferred back nor refunded yet.
+func (c *CosmosChain) GetTransferEscrowBalance(ctx context.Context, portID, channelID, denom string) (int64, error) {
+	return c.GetBalance(ctx, c.GetTransferEscrowAddress(portID, channelID), denom)
+}
+
+// TotalSupply queries the bank module for the total supply of every denom on the chain,
+// e.g. to check that IBC vouchers are minted and burned as tokens are transferred in and out.
+func (c *CosmosChain) TotalSupply(ctx context.Context) ([]ibc.WalletAmount, error) {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return totalSupply(ctx, bankTypes.NewQueryClient(conn))
+}
+
+// totalSupply pages through the total supply reported by queryClient.
+func totalSupply(ctx context.Context, queryClient bankTypes.QueryClient) ([]ibc.WalletAmount, error) {
+	var (
+		supply  []ibc.WalletAmount
+		nextKey []byte
+	)
+	for {
+		res, err := queryClient.TotalSupply(ctx, &bankTypes.QueryTotalSupplyRequest{
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query total supply: %w", err)
+		}
+		for _, coin := range res.Supply {
+			amount, err := supplyAmount(coin)
+			if err != nil {
+				return nil, err
+			}
+			supply = append(supply, ibc.WalletAmount{Denom: coin.Denom, Amount: amount})
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return supply, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// SupplyOf queries the bank module for the total supply of denom on the chain, or 0 if there is none.
+func (c *CosmosChain) SupplyOf(ctx context.Context, denom string) (int64, error) {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	return supplyOf(ctx, bankTypes.NewQueryClient(conn), denom)
+}
+
+// supplyOf queries queryClient for the total supply of denom.
+func supplyOf(ctx context.Context, queryClient bankTypes.QueryClient, denom string) (int64, error) {
+	res, err := queryClient.SupplyOf(ctx, &bankTypes.QuerySupplyOfRequest{Denom: denom})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query supply of %s: %w", denom, err)
+	}
+	return supplyAmount(res.Amount)
+}
+
+// supplyAmount returns the amount of coin, or an error if it does not fit in an int64.
+func supplyAmount(coin types.Coin) (int64, error) {
+	if !coin.Amount.IsInt64() {
+		return 0, fmt.Errorf("supply of %s overflows int64: %s", coin.Denom, coin.Amount)
+	}
+	return coin.Amount.Int64(), nil
+}
+
+// DenomTrace queries the transfer module for the path and base denom of an IBC voucher.
+// The hash may be given with or without the "ibc/" prefix.
+func (c *CosmosChain) DenomTrace(ctx context.Context, hash string) (ibc.DenomTrace, error) {
+	grpcAddress := c.GetHostGRPCAddress()
+	conn, err := c.dialGRPC(grpcAddress)
+	if err != nil {
+		return ibc.DenomTrace{}, err
+	}
+	defer conn.Close()
+
+	queryClient := transferTypes.NewQueryClient(conn)
+	res, err := queryClient.DenomTrace(ctx, &transferTypes.QueryDenomTraceRequest{
+		Hash: strings.TrimPrefix(hash, "ibc/"),
+	})
+	if err != nil {
+		return ibc.DenomTrace{}, fmt.Errorf("failed to query denom trace %s: %w", hash, err)
+	}
+
+	return ibc.DenomTrace{
+		Path:      res.DenomTrace.Path,
+		BaseDenom: res.DenomTrace.BaseDenom,
+	}, nil
+}
+
 // AllBalances fetches an account address's balance for all denoms it holds
 func (c *CosmosChain) AllBalances(ctx context.Context, address string) (types.Coins, error) {
 	params := bankTypes.QueryAllBalancesRequest{Address: address}
-	grpcAddress := c.getFullNode().hostGRPCPort
-	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	grpcAddress := c.GetHostGRPCAddress()
+	conn, err := c.dialGRPC(grpcAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -483,7 +837,11 @@ func (c *CosmosChain) AllBalances(ctx context.Context, address string) (types.Co
 	return res.GetBalances(), nil
 }
 
-func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
+// GetTransaction fetches the committed transaction with the given hash from the full node.
+// The returned response includes the parsed logs and the ABCI events emitted by the transaction.
+// Because the transaction may not be committed yet when queried right after broadcast,
+// the query is retried for a few seconds.
+func (c *CosmosChain) GetTransaction(ctx context.Context, txHash string) (*types.TxResponse, error) {
 	// Retry because sometimes the tx is not committed to state yet.
 	var txResp *types.TxResponse
 	err := retry.Do(func() error {
@@ -491,6 +849,7 @@ func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
 		txResp, err = authTx.QueryTx(c.getFullNode().CliContext(), txHash)
 		return err
 	},
+		retry.Context(ctx),
 		// retry for total of 3 seconds
 		retry.Attempts(15),
 		retry.Delay(200*time.Millisecond),
@@ -500,6 +859,36 @@ func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
 	return txResp, err
 }
 
+// txSearchPageSize is the number of transactions requested per page by TxsByEvent.
+const txSearchPageSize = 100
+
+// TxsByEvent returns every committed transaction matching the Tendermint event query,
+// e.g. "send_packet.packet_src_channel='channel-0'", from oldest to newest.
+// Conditions may be combined with AND, e.g. to restrict the search to a range with "tx.height>=10 AND tx.height<=20".
+// All pages of results are fetched from the full node.
+func (c *CosmosChain) TxsByEvent(ctx context.Context, query string) ([]*types.TxResponse, error) {
+	clientCtx := c.getFullNode().CliContext()
+
+	var txs []*types.TxResponse
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		res, err := authTx.QueryTxsByEvents(clientCtx, []string{query}, page, txSearchPageSize, "asc")
+		if err != nil {
+			return nil, fmt.Errorf("failed to search txs matching %q: %w", query, err)
+		}
+		txs = append(txs, res.Txs...)
+		if uint64(page) >= res.PageTotal {
+			return txs, nil
+		}
+	}
+}
+
+func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
+	return c.GetTransaction(context.Background(), txHash)
+}
+
 func (c *CosmosChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
 	gasPrice, _ := strconv.ParseFloat(strings.Replace(c.cfg.GasPrices, c.cfg.Denom, "", 1), 64)
 	fees := float64(gasPaid) * gasPrice
@@ -519,20 +908,12 @@ func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, ve
 
 func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
 	for _, image := range c.Config().Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, c.log, cli, image); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 }
@@ -561,11 +942,9 @@ func (c *CosmosChain) NewChainNode(
 	}
 
 	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
-
+		Labels: dockerutil.CleanupLabels(testName, map[string]string{
 			dockerutil.NodeOwnerLabel: tn.Name(),
-		},
+		}),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume for chain node: %w", err)
@@ -584,6 +963,10 @@ func (c *CosmosChain) NewChainNode(
 	}); err != nil {
 		return nil, fmt.Errorf("set volume owner: %w", err)
 	}
+	// Nodes run as the image's default user, so fail here rather than on the first write to the home directory.
+	if err := dockerutil.CheckVolumeWritable(ctx, cli, image.Ref(), image.UidGid); err != nil {
+		return nil, fmt.Errorf("chain node %s: %w", tn.Name(), err)
+	}
 	return tn, nil
 }
 
@@ -658,6 +1041,48 @@ type ValidatorWithIntPower struct {
 	PubKeyBase64 string
 }
 
+// validatorGenesisCoins returns the genesis account balance and self-delegation for validator i,
+// honoring cfg.ValidatorStakes.
+// The account keeps the same unbonded remainder as with the defaults,
+// so a larger stake also raises the validator's genesis balance.
+func validatorGenesisCoins(cfg ibc.ChainConfig, i int, defaultAmount, defaultSelfDelegation types.Coin) ([]types.Coin, types.Coin, error) {
+	if i >= len(cfg.ValidatorStakes) || cfg.ValidatorStakes[i] == 0 {
+		return []types.Coin{defaultAmount}, defaultSelfDelegation, nil
+	}
+
+	stake := cfg.ValidatorStakes[i]
+	if stake < 0 {
+		return nil, types.Coin{}, fmt.Errorf("validator %d stake must not be negative: %d", i, stake)
+	}
+
+	selfDelegation := types.NewCoin(defaultSelfDelegation.Denom, types.NewInt(stake))
+	unbonded := defaultAmount.Sub(defaultSelfDelegation)
+	return []types.Coin{selfDelegation.Add(unbonded)}, selfDelegation, nil
+}
+
+// genesisAccount is an account added at genesis with all of its coins.
+type genesisAccount struct {
+	address string
+	coins   []types.Coin
+}
+
+// genesisAccounts groups the wallets by address, in order of first appearance,
+// since add-genesis-account fails for an address that was already added, e.g. a faucet holding several denoms.
+func genesisAccounts(wallets []ibc.WalletAmount) []genesisAccount {
+	var accs []genesisAccount
+	index := make(map[string]int, len(wallets))
+	for _, w := range wallets {
+		coin := types.Coin{Denom: w.Denom, Amount: types.NewInt(w.Amount)}
+		if i, ok := index[w.Address]; ok {
+			accs[i].coins = append(accs[i].coins, coin)
+			continue
+		}
+		index[w.Address] = len(accs)
+		accs = append(accs, genesisAccount{address: w.Address, coins: []types.Coin{coin}})
+	}
+	return accs
+}
+
 // Bootstraps the chain and starts it from genesis
 func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
 	chainCfg := c.Config()
@@ -674,35 +1099,32 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 
 	genesisAmounts := []types.Coin{genesisAmount}
 
+	if len(chainCfg.ValidatorStakes) > len(c.Validators) {
+		return fmt.Errorf("%d validator stakes configured for %d validators", len(chainCfg.ValidatorStakes), len(c.Validators))
+	}
+
 	configFileOverrides := chainCfg.ConfigFileOverrides
 
 	eg := new(errgroup.Group)
 	// Initialize config and sign gentx for each validator.
-	for _, v := range c.Validators {
+	for i, v := range c.Validators {
 		v := v
 		v.Validator = true
+		valAmounts, valSelfDelegation, err := validatorGenesisCoins(chainCfg, i, genesisAmount, genesisSelfDelegation)
+		if err != nil {
+			return err
+		}
 		eg.Go(func() error {
 			if err := v.InitFullNodeFiles(ctx); err != nil {
 				return err
 			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					v.logger(),
-					v.DockerClient,
-					v.TestName,
-					v.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
+			if err := v.applyConfigFileOverrides(ctx, configFileOverrides); err != nil {
+				return err
+			}
+			if err := v.recoverGenesisKeys(ctx); err != nil {
+				return err
 			}
-			return v.InitValidatorGenTx(ctx, &chainCfg, genesisAmounts, genesisSelfDelegation)
+			return v.InitValidatorGenTx(ctx, &chainCfg, valAmounts, valSelfDelegation)
 		})
 	}
 
@@ -714,24 +1136,10 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 			if err := n.InitFullNodeFiles(ctx); err != nil {
 				return err
 			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					n.logger(),
-					n.DockerClient,
-					n.TestName,
-					n.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
+			if err := n.applyConfigFileOverrides(ctx, configFileOverrides); err != nil {
+				return err
 			}
-			return nil
+			return n.recoverGenesisKeys(ctx)
 		})
 	}
 
@@ -760,12 +1168,22 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		}
 	}
 
-	for _, wallet := range additionalGenesisWallets {
-		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []types.Coin{{Denom: wallet.Denom, Amount: types.NewInt(wallet.Amount)}}); err != nil {
+	for _, acc := range genesisAccounts(additionalGenesisWallets) {
+		if err := validator0.AddGenesisAccount(ctx, acc.address, acc.coins); err != nil {
 			return err
 		}
 	}
 
+	if err := c.addGenesisKeyAccounts(ctx, validator0); err != nil {
+		return err
+	}
+
+	if c.cfg.PreGenesis != nil {
+		if err := c.cfg.PreGenesis(ctx, c); err != nil {
+			return fmt.Errorf("pre-genesis hook: %w", err)
+		}
+	}
+
 	if err := validator0.CollectGentxs(ctx); err != nil {
 		return err
 	}
@@ -777,7 +1195,33 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 
 	genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
 
+	return c.startWithGenesis(ctx, genbz)
+}
+
+// addGenesisKeyAccounts adds the funded accounts of the chain's genesis keys to the genesis file of node,
+// whose keyring must already hold the keys.
+func (c *CosmosChain) addGenesisKeyAccounts(ctx context.Context, node *ChainNode) error {
+	for _, key := range c.cfg.GenesisKeys {
+		if key.Amount == 0 {
+			continue
+		}
+		bech32, err := node.AccountKeyBech32(ctx, key.Name)
+		if err != nil {
+			return err
+		}
+		if err := node.AddGenesisAccount(ctx, bech32, []types.Coin{{Denom: c.cfg.Denom, Amount: types.NewInt(key.Amount)}}); err != nil {
+			return fmt.Errorf("failed to fund genesis key %s: %w", key.Name, err)
+		}
+	}
+	return nil
+}
+
+// startWithGenesis applies ModifyGenesis to the genesis file content, writes it to every node,
+// and then starts the node containers.
+func (c *CosmosChain) startWithGenesis(ctx context.Context, genbz []byte) error {
+	chainCfg := c.Config()
 	if c.cfg.ModifyGenesis != nil {
+		var err error
 		genbz, err = c.cfg.ModifyGenesis(chainCfg, genbz)
 		if err != nil {
 			return err
@@ -836,7 +1280,16 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	}
 
 	// Wait for 5 blocks before considering the chains "started"
-	return testutil.WaitForBlocks(ctx, 5, c.getFullNode())
+	if err := testutil.WaitForBlocks(ctx, 5, c.getFullNode()); err != nil {
+		return err
+	}
+
+	if c.cfg.PostStart != nil {
+		if err := c.cfg.PostStart(ctx, c); err != nil {
+			return fmt.Errorf("post-start hook: %w", err)
+		}
+	}
+	return nil
 }
 
 // Height implements ibc.Chain
@@ -877,6 +1330,32 @@ func (c *CosmosChain) Acknowledgements(ctx context.Context, height uint64) ([]ib
 	return ibcAcks, nil
 }
 
+// ReceivedPackets returns the packets received by the chain in the block at height,
+// as delivered by the relayer's MsgRecvPacket, e.g. to check the memo of a transfer with ibc.Packet.TransferData.
+// Every MsgRecvPacket in the block is included, even one in a failed transaction, such as a redundant delivery.
+func (c *CosmosChain) ReceivedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+	var packets []ibc.Packet
+	err := rangeBlockMessages(ctx, c.cfg.EncodingConfig.InterfaceRegistry, c.getFullNode().Client, height, func(msg types.Msg) bool {
+		if found, ok := msg.(*chanTypes.MsgRecvPacket); ok {
+			packets = append(packets, ibc.Packet{
+				Sequence:         found.Packet.Sequence,
+				SourcePort:       found.Packet.SourcePort,
+				SourceChannel:    found.Packet.SourceChannel,
+				DestPort:         found.Packet.DestinationPort,
+				DestChannel:      found.Packet.DestinationChannel,
+				Data:             found.Packet.Data,
+				TimeoutHeight:    found.Packet.TimeoutHeight.String(),
+				TimeoutTimestamp: ibc.Nanoseconds(found.Packet.TimeoutTimestamp),
+			})
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find received packets at height %d: %w", height, err)
+	}
+	return packets, nil
+}
+
 // Timeouts implements ibc.Chain, returning all timeouts in block at height
 func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
 	var timeouts []*chanTypes.MsgTimeout
@@ -951,6 +1430,62 @@ func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// Snapshot stops every node, copies each node's home volume into a snapshot volume
+// identified by name, and then starts the nodes again.
+// The chain resumes from the snapshotted state when Restore is called with the same name.
+func (c *CosmosChain) Snapshot(ctx context.Context, name string) error {
+	return c.withAllNodesStopped(ctx, func(n *ChainNode) error {
+		return dockerutil.SnapshotVolume(ctx, dockerutil.VolumeSnapshotOptions{
+			Log:          c.log,
+			Client:       n.DockerClient,
+			VolumeName:   n.VolumeName,
+			SnapshotName: name,
+			TestName:     n.TestName,
+		})
+	})
+}
+
+// Restore stops every node, replaces each node's home volume with the snapshot
+// previously created by Snapshot with the same name, and then starts the nodes again.
+func (c *CosmosChain) Restore(ctx context.Context, name string) error {
+	return c.withAllNodesStopped(ctx, func(n *ChainNode) error {
+		return dockerutil.RestoreVolume(ctx, dockerutil.VolumeSnapshotOptions{
+			Log:          c.log,
+			Client:       n.DockerClient,
+			VolumeName:   n.VolumeName,
+			SnapshotName: name,
+			TestName:     n.TestName,
+		})
+	})
+}
+
+// withAllNodesStopped stops all nodes, calls fn for every node concurrently,
+// and starts all nodes again, regardless of whether fn succeeded.
+func (c *CosmosChain) withAllNodesStopped(ctx context.Context, fn func(n *ChainNode) error) error {
+	if err := c.StopAllNodes(ctx); err != nil {
+		return fmt.Errorf("stopping nodes: %w", err)
+	}
+
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return fn(n)
+		})
+	}
+	fnErr := eg.Wait()
+
+	if err := c.StartAllNodes(ctx); err != nil {
+		return multierr.Append(fnErr, fmt.Errorf("starting nodes: %w", err))
+	}
+	if fnErr != nil {
+		return fnErr
+	}
+
+	// Give the nodes a chance to reconnect to each other before handing control back.
+	return testutil.WaitForBlocks(ctx, 2, c.getFullNode())
+}
+
 func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error {
 	var eg errgroup.Group
 	for _, n := range c.Nodes() {
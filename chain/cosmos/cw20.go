@@ -0,0 +1,153 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// CW20TokenInfo is the token_info query response of a cw20 contract.
+type CW20TokenInfo struct {
+	Name        string    `json:"name"`
+	Symbol      string    `json:"symbol"`
+	Decimals    uint8     `json:"decimals"`
+	TotalSupply types.Int `json:"total_supply"`
+}
+
+// cw20BalanceQuery is the balance query of a cw20 contract.
+type cw20BalanceQuery struct {
+	Balance struct {
+		Address string `json:"address"`
+	} `json:"balance"`
+}
+
+// cw20TokenInfoQuery is the token_info query of a cw20 contract.
+type cw20TokenInfoQuery struct {
+	TokenInfo struct{} `json:"token_info"`
+}
+
+// cw20TransferMsg is the execute message moving cw20 tokens to an account.
+type cw20TransferMsg struct {
+	Transfer struct {
+		Recipient string    `json:"recipient"`
+		Amount    types.Int `json:"amount"`
+	} `json:"transfer"`
+}
+
+// cw20SendMsg is the execute message moving cw20 tokens to a contract,
+// which is called with msg, e.g. to convert or transfer the tokens over IBC.
+type cw20SendMsg struct {
+	Send struct {
+		Contract string    `json:"contract"`
+		Amount   types.Int `json:"amount"`
+		// Msg is the JSON message for the receiving contract, base64 encoded by the JSON marshaler.
+		Msg []byte `json:"msg"`
+	} `json:"send"`
+}
+
+// CW20ICS20TransferMsg is the message for a cw20-ics20 contract, sent along with cw20 tokens by CW20Send,
+// to transfer them over an IBC channel bound to the contract's port; see ibc.WasmPortID.
+type CW20ICS20TransferMsg struct {
+	Transfer struct {
+		Channel       string `json:"channel"`
+		RemoteAddress string `json:"remote_address"`
+		// Timeout in seconds, or the contract's default timeout if zero.
+		Timeout uint64 `json:"timeout,omitempty"`
+	} `json:"transfer"`
+}
+
+// NewCW20ICS20TransferMsg returns the message transferring cw20 tokens over channelID to remoteAddress.
+func NewCW20ICS20TransferMsg(channelID, remoteAddress string, timeoutSeconds uint64) CW20ICS20TransferMsg {
+	var msg CW20ICS20TransferMsg
+	msg.Transfer.Channel = channelID
+	msg.Transfer.RemoteAddress = remoteAddress
+	msg.Transfer.Timeout = timeoutSeconds
+	return msg
+}
+
+// newCW20SendMsg returns the execute message sending amount to contract, which is called with msg.
+func newCW20SendMsg(contract string, amount types.Int, msg any) (string, error) {
+	inner, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal message for contract %s: %w", contract, err)
+	}
+	var send cw20SendMsg
+	send.Send.Contract = contract
+	send.Send.Amount = amount
+	send.Send.Msg = inner
+	bz, err := json.Marshal(send)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// CW20Balance queries the cw20 token contract for the balance of address.
+func (c *CosmosChain) CW20Balance(ctx context.Context, contractAddress, address string) (types.Int, error) {
+	var query cw20BalanceQuery
+	query.Balance.Address = address
+
+	var res struct {
+		Data struct {
+			Balance types.Int `json:"balance"`
+		} `json:"data"`
+	}
+	if err := c.QueryContract(ctx, contractAddress, query, &res); err != nil {
+		return types.Int{}, fmt.Errorf("failed to query cw20 balance of %s: %w", address, err)
+	}
+	if res.Data.Balance.IsNil() {
+		return types.ZeroInt(), nil
+	}
+	return res.Data.Balance, nil
+}
+
+// CW20TokenInfo queries the name, symbol, decimals and total supply of the cw20 token contract.
+func (c *CosmosChain) CW20TokenInfo(ctx context.Context, contractAddress string) (CW20TokenInfo, error) {
+	var res struct {
+		Data CW20TokenInfo `json:"data"`
+	}
+	if err := c.QueryContract(ctx, contractAddress, cw20TokenInfoQuery{}, &res); err != nil {
+		return CW20TokenInfo{}, fmt.Errorf("failed to query cw20 token info: %w", err)
+	}
+	return res.Data, nil
+}
+
+// CW20Transfer transfers amount of the cw20 token contract from the wallet with keyName to recipient.
+func (c *CosmosChain) CW20Transfer(ctx context.Context, keyName, contractAddress, recipient string, amount types.Int) (ibc.Tx, error) {
+	var msg cw20TransferMsg
+	msg.Transfer.Recipient = recipient
+	msg.Transfer.Amount = amount
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	return c.executeContract(ctx, keyName, contractAddress, string(bz))
+}
+
+// CW20Send sends amount of the cw20 token contract from the wallet with keyName to the contract recipient,
+// which is called with msg, e.g. a CW20ICS20TransferMsg for a cw20-ics20 contract,
+// or whatever message a converter contract expects to release native tokens for cw20 ones.
+// The returned transaction holds the IBC packet sent by the recipient, if any.
+func (c *CosmosChain) CW20Send(ctx context.Context, keyName, contractAddress, recipient string, amount types.Int, msg any) (ibc.Tx, error) {
+	send, err := newCW20SendMsg(recipient, amount, msg)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	return c.executeContract(ctx, keyName, contractAddress, send)
+}
+
+// ConvertToCW20 executes the converter contract with msg, paying it amount of a bank denom
+// from the wallet with keyName, so it can mint or release the matching cw20 tokens.
+// Converting back is done by sending the cw20 tokens to the converter with CW20Send.
+func (c *CosmosChain) ConvertToCW20(ctx context.Context, keyName, converterAddress string, amount ibc.WalletAmount, msg any) (ibc.Tx, error) {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	return c.executeContract(ctx, keyName, converterAddress, string(bz),
+		"--amount", fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
+	)
+}
@@ -0,0 +1,42 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCW20SendMsg(t *testing.T) {
+	t.Parallel()
+
+	msg, err := newCW20SendMsg("wasm1ics20", types.NewInt(1_000), NewCW20ICS20TransferMsg("channel-0", "cosmos1remote", 0))
+	require.NoError(t, err)
+
+	var got struct {
+		Send struct {
+			Contract string `json:"contract"`
+			Amount   string `json:"amount"`
+			Msg      []byte `json:"msg"`
+		} `json:"send"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(msg), &got))
+	require.Equal(t, "wasm1ics20", got.Send.Contract)
+	require.Equal(t, "1000", got.Send.Amount)
+	require.JSONEq(t, `{"transfer":{"channel":"channel-0","remote_address":"cosmos1remote"}}`, string(got.Send.Msg))
+}
+
+func TestCW20TokenInfo_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	var res struct {
+		Data CW20TokenInfo `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"data":{"name":"Test","symbol":"TST","decimals":6,"total_supply":"123456789012345678901"}}`), &res))
+	require.Equal(t, "TST", res.Data.Symbol)
+	require.Equal(t, uint8(6), res.Data.Decimals)
+	want, ok := types.NewIntFromString("123456789012345678901")
+	require.True(t, ok)
+	require.True(t, want.Equal(res.Data.TotalSupply))
+}
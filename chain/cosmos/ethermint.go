@@ -0,0 +1,59 @@
+package cosmos
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+	"golang.org/x/crypto/sha3"
+)
+
+// EthSecp256k1 is the SigningAlgorithm of Ethermint-based chains such as Evmos.
+// Keys are derived the same way as secp256k1 keys, typically with coin type 60,
+// but the account address is the Ethereum address of the public key.
+const EthSecp256k1 = "eth_secp256k1"
+
+// ethAddress returns the 20-byte Ethereum address of the secp256k1 private key privKey.
+func ethAddress(privKey []byte) []byte {
+	_, pub := secp256k1.PrivKeyFromBytes(privKey)
+	// Drop the 0x04 prefix of the uncompressed encoding.
+	return keccak256(pub.SerializeUncompressed()[1:])[12:]
+}
+
+// checksumHex formats addr as a 0x-prefixed hex string with the EIP-55 mixed-case checksum.
+func checksumHex(addr []byte) string {
+	lower := hex.EncodeToString(addr)
+	hash := keccak256([]byte(lower))
+
+	var sb strings.Builder
+	sb.WriteString("0x")
+	for i, c := range lower {
+		// Each hex character is checked against the corresponding nibble of the hash.
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0xf >= 8 {
+			c -= 'a' - 'A'
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+func keccak256(b []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+// validateSigningAlgorithm returns an error for algorithms the host-side key derivation does not support.
+func validateSigningAlgorithm(algo string) error {
+	switch algo {
+	case "", "secp256k1", EthSecp256k1:
+		return nil
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", algo)
+	}
+}
@@ -0,0 +1,28 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthAddress(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	cfg := ibc.ChainConfig{Bech32Prefix: "evmos", CoinType: "60", SigningAlgorithm: EthSecp256k1}
+
+	privKey, err := NewWallet("user", nil, mnemonic, cfg).(*CosmosWallet).PrivateKey()
+	require.NoError(t, err)
+
+	// Matches the first account MetaMask derives for this mnemonic.
+	w := NewWallet("user", ethAddress(privKey), mnemonic, cfg).(*CosmosWallet)
+	require.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", w.HexAddress())
+	require.Equal(t, "evmos1npvwllfr9dqr8erajqqr6s0vxnk2ak55t3r99j", w.FormattedAddress())
+}
+
+func TestValidateSigningAlgorithm(t *testing.T) {
+	for _, algo := range []string{"", "secp256k1", EthSecp256k1} {
+		require.NoError(t, validateSigningAlgorithm(algo))
+	}
+	require.Error(t, validateSigningAlgorithm("ed25519"))
+}
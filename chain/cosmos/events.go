@@ -0,0 +1,201 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/internal/tendermint"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.uber.org/zap"
+)
+
+// subscriptionBufferSize is the capacity of the channels used for event subscriptions.
+// Tendermint drops a subscription whose buffer is full, so this is generous.
+const subscriptionBufferSize = 100
+
+// unsubscribeTimeout bounds how long a subscription waits for the node to drop it once ctx is done.
+const unsubscribeTimeout = 5 * time.Second
+
+// SubscribeEvents subscribes to the full node's Tendermint WebSocket using the given query,
+// e.g. "tm.event='Tx' AND send_packet.packet_src_channel='channel-0'",
+// and returns a channel of the ABCI events contained in each matching tx or block.
+// The WebSocket is dialed over TLS, i.e. wss, if enabled in the chain config.
+//
+// The subscription is removed and the returned channel is closed once ctx is cancelled,
+// or if the connection to the node is lost and cannot be reestablished.
+func (c *CosmosChain) SubscribeEvents(ctx context.Context, query string) (<-chan abcitypes.Event, error) {
+	addr := c.getFullNode().hostRPCPort
+
+	var ws *jsonrpcclient.WSClient
+	// The node forgets the subscription when the connection drops, so it is renewed on reconnect.
+	resubscribe := jsonrpcclient.OnReconnect(func() {
+		if err := ws.Subscribe(context.Background(), query); err != nil {
+			c.log.Info("Failed to resubscribe to events", zap.String("query", query), zap.Error(err))
+		}
+	})
+	ws, err := tendermint.NewWSClient(addr, c.cfg.TLS.ClientConfig(), resubscribe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create websocket client for %s: %w", addr, err)
+	}
+	if err := ws.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start websocket client: %w", err)
+	}
+
+	if err := ws.Subscribe(ctx, query); err != nil {
+		_ = ws.Stop()
+		return nil, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+	}
+
+	out := make(chan abcitypes.Event, subscriptionBufferSize)
+	go func() {
+		defer close(out)
+		defer func() {
+			if !ws.IsRunning() {
+				return
+			}
+			// The ctx is likely done, so use a fresh context to let the node drop the subscription,
+			// bounded so that an unresponsive node cannot hold up the caller, e.g. Close.
+			unsubCtx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+			defer cancel()
+			if err := ws.UnsubscribeAll(unsubCtx); err != nil {
+				c.log.Info("Failed to unsubscribe from events", zap.String("query", query), zap.Error(err))
+			}
+			_ = ws.Stop()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case resp, ok := <-ws.ResponsesCh:
+				if !ok {
+					return
+				}
+				if resp.Error != nil {
+					c.log.Info("Event subscription error", zap.String("query", query), zap.Error(resp.Error))
+					continue
+				}
+
+				// Other responses, such as the empty result of the subscribe call, have no query.
+				var res coretypes.ResultEvent
+				if err := tmjson.Unmarshal(resp.Result, &res); err != nil || res.Query != query {
+					continue
+				}
+				for _, ev := range resultEvents(res) {
+					select {
+					case <-ctx.Done():
+						return
+					case out <- ev:
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// GetBlockResults returns the results of the block at the given height from the full node's RPC.
+// Unlike tx results, these include the events emitted in BeginBlock and EndBlock,
+// such as epoch or slashing events.
+func (c *CosmosChain) GetBlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
+	res, err := c.getFullNode().Client.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block results at height %d: %w", height, err)
+	}
+	return res, nil
+}
+
+// resultEvents returns the ABCI events carried by a subscription result.
+func resultEvents(res coretypes.ResultEvent) []abcitypes.Event {
+	switch data := res.Data.(type) {
+	case tmtypes.EventDataTx:
+		return data.Result.Events
+	case tmtypes.EventDataNewBlock:
+		events := append([]abcitypes.Event{}, data.ResultBeginBlock.Events...)
+		return append(events, data.ResultEndBlock.Events...)
+	case tmtypes.EventDataNewBlockHeader:
+		events := append([]abcitypes.Event{}, data.ResultBeginBlock.Events...)
+		return append(events, data.ResultEndBlock.Events...)
+	default:
+		return nil
+	}
+}
+
+// AttributeValue returns the value of the first attribute with key attrKey
+// in the first event of type eventType, e.g. the packet_sequence of a send_packet event.
+func AttributeValue(events []abcitypes.Event, eventType, attrKey string) (string, bool) {
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == attrKey {
+				return string(attr.Value), true
+			}
+		}
+	}
+	return "", false
+}
+
+// SendPacket returns the packet of the first send_packet event in events,
+// e.g. those of a tx that sent an IBC transfer or executed a contract sending a packet.
+// It returns false if no packet was sent, and an error if the event is malformed.
+//
+// The packet data is read from packet_data_hex when present, so binary data is kept intact.
+func SendPacket(events []abcitypes.Event) (ibc.Packet, bool, error) {
+	const evType = "send_packet"
+
+	seq, ok := AttributeValue(events, evType, "packet_sequence")
+	if !ok {
+		return ibc.Packet{}, false, nil
+	}
+
+	var (
+		srcPort, _       = AttributeValue(events, evType, "packet_src_port")
+		srcChan, _       = AttributeValue(events, evType, "packet_src_channel")
+		dstPort, _       = AttributeValue(events, evType, "packet_dst_port")
+		dstChan, _       = AttributeValue(events, evType, "packet_dst_channel")
+		timeoutHeight, _ = AttributeValue(events, evType, "packet_timeout_height")
+		timeoutTs, _     = AttributeValue(events, evType, "packet_timeout_timestamp")
+	)
+	packet := ibc.Packet{
+		SourcePort:    srcPort,
+		SourceChannel: srcChan,
+		DestPort:      dstPort,
+		DestChannel:   dstChan,
+		TimeoutHeight: timeoutHeight,
+	}
+
+	seqNum, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return packet, true, fmt.Errorf("invalid packet sequence from events %s: %w", seq, err)
+	}
+	packet.Sequence = seqNum
+
+	timeoutNano, err := strconv.ParseUint(timeoutTs, 10, 64)
+	if err != nil {
+		return packet, true, fmt.Errorf("invalid packet timestamp timeout %s: %w", timeoutTs, err)
+	}
+	packet.TimeoutTimestamp = ibc.Nanoseconds(timeoutNano)
+
+	if dataHex, ok := AttributeValue(events, evType, "packet_data_hex"); ok {
+		packet.Data, err = hex.DecodeString(dataHex)
+		if err != nil {
+			return packet, true, fmt.Errorf("invalid packet data hex from events: %w", err)
+		}
+	} else {
+		data, _ := AttributeValue(events, evType, "packet_data")
+		packet.Data = []byte(data)
+	}
+
+	return packet, true, nil
+}
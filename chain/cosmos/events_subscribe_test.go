@@ -0,0 +1,133 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.uber.org/zap"
+)
+
+// fakeEventNode is the WebSocket of a Tendermint RPC server, answering every subscription with a single tx result.
+type fakeEventNode struct {
+	events []abcitypes.Event
+
+	unsubscribeOnce sync.Once
+	unsubscribed    chan struct{}
+}
+
+func newFakeEventNode(events ...abcitypes.Event) *fakeEventNode {
+	return &fakeEventNode{events: events, unsubscribed: make(chan struct{})}
+}
+
+func (n *fakeEventNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		var req rpctypes.RPCRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		var result any = struct{}{}
+		switch req.Method {
+		case "subscribe":
+			var params struct {
+				Query string `json:"query"`
+			}
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return
+			}
+			// The response to the subscribe call is followed by the subscription's first result.
+			if err := conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, result)); err != nil {
+				return
+			}
+			result = &coretypes.ResultEvent{
+				Query: params.Query,
+				Data: tmtypes.EventDataTx{TxResult: abcitypes.TxResult{
+					Height: 1,
+					Result: abcitypes.ResponseDeliverTx{Events: n.events},
+				}},
+			}
+		case "unsubscribe_all":
+			n.unsubscribeOnce.Do(func() { close(n.unsubscribed) })
+		}
+		if err := conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, result)); err != nil {
+			return
+		}
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	transfer := abcitypes.Event{Type: "transfer", Attributes: []abcitypes.EventAttribute{
+		{Key: []byte("amount"), Value: []byte("10uatom")},
+	}}
+
+	for _, tc := range []struct {
+		name      string
+		newServer func(http.Handler) *httptest.Server
+		tls       ibc.TLSConfig
+	}{
+		{name: "ws", newServer: httptest.NewServer},
+		// The self-signed certificate of the test server is only accepted when skipping verification.
+		{name: "wss", newServer: httptest.NewTLSServer, tls: ibc.TLSConfig{Enabled: true, InsecureSkipVerify: true}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			node := newFakeEventNode(transfer)
+			srv := tc.newServer(node)
+			defer srv.Close()
+
+			c := NewCosmosChain("TestSubscribeEvents", ibc.ChainConfig{ChainID: "foo-1", CoinType: "118", TLS: tc.tls}, 1, 0, zap.NewNop())
+			c.FullNodes = ChainNodes{{hostRPCPort: srv.Listener.Addr().String()}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := c.SubscribeEvents(ctx, "tm.event='Tx'")
+			require.NoError(t, err)
+
+			select {
+			case ev := <-events:
+				require.Equal(t, transfer, ev)
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for the subscribed event")
+			}
+
+			// Cancelling ctx removes the subscription and closes the channel.
+			cancel()
+			select {
+			case <-node.unsubscribed:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for the subscription to be removed")
+			}
+			for range events {
+			}
+		})
+	}
+
+	t.Run("wss certificate verified", func(t *testing.T) {
+		srv := httptest.NewTLSServer(newFakeEventNode(transfer))
+		defer srv.Close()
+
+		c := NewCosmosChain("TestSubscribeEvents", ibc.ChainConfig{ChainID: "foo-1", CoinType: "118", TLS: ibc.TLSConfig{Enabled: true}}, 1, 0, zap.NewNop())
+		c.FullNodes = ChainNodes{{hostRPCPort: srv.Listener.Addr().String()}}
+
+		_, err := c.SubscribeEvents(context.Background(), "tm.event='Tx'")
+		require.ErrorContains(t, err, "certificate")
+	})
+}
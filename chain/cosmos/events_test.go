@@ -0,0 +1,93 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestAttributeValue(t *testing.T) {
+	events := []abcitypes.Event{
+		{Type: "message", Attributes: []abcitypes.EventAttribute{
+			{Key: []byte("packet_sequence"), Value: []byte("wrong")},
+		}},
+		{Type: "send_packet", Attributes: []abcitypes.EventAttribute{
+			{Key: []byte("packet_src_channel"), Value: []byte("channel-0")},
+			{Key: []byte("packet_sequence"), Value: []byte("7")},
+		}},
+	}
+
+	v, ok := cosmos.AttributeValue(events, "send_packet", "packet_sequence")
+	require.True(t, ok)
+	require.Equal(t, "7", v)
+
+	_, ok = cosmos.AttributeValue(events, "send_packet", "packet_data")
+	require.False(t, ok)
+
+	_, ok = cosmos.AttributeValue(events, "recv_packet", "packet_sequence")
+	require.False(t, ok)
+}
+
+func TestSendPacket(t *testing.T) {
+	attrs := func(kv ...string) []abcitypes.EventAttribute {
+		var out []abcitypes.EventAttribute
+		for i := 0; i < len(kv); i += 2 {
+			out = append(out, abcitypes.EventAttribute{Key: []byte(kv[i]), Value: []byte(kv[i+1])})
+		}
+		return out
+	}
+	sendPacket := attrs(
+		"packet_sequence", "3",
+		"packet_src_port", "transfer",
+		"packet_src_channel", "channel-0",
+		"packet_dst_port", "transfer",
+		"packet_dst_channel", "channel-1",
+		"packet_timeout_height", "0-100",
+		"packet_timeout_timestamp", "1000",
+		"packet_data", `{"amount":"1"}`,
+	)
+
+	t.Run("packet", func(t *testing.T) {
+		events := []abcitypes.Event{
+			{Type: "message", Attributes: attrs("action", "transfer")},
+			{Type: "send_packet", Attributes: sendPacket},
+		}
+		packet, ok, err := cosmos.SendPacket(events)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, ibc.Packet{
+			Sequence:         3,
+			SourcePort:       "transfer",
+			SourceChannel:    "channel-0",
+			DestPort:         "transfer",
+			DestChannel:      "channel-1",
+			Data:             []byte(`{"amount":"1"}`),
+			TimeoutHeight:    "0-100",
+			TimeoutTimestamp: 1000,
+		}, packet)
+	})
+
+	t.Run("hex data", func(t *testing.T) {
+		events := []abcitypes.Event{
+			{Type: "send_packet", Attributes: append(attrs("packet_data_hex", "00ff"), sendPacket...)},
+		}
+		packet, _, err := cosmos.SendPacket(events)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x00, 0xff}, packet.Data)
+	})
+
+	t.Run("no packet", func(t *testing.T) {
+		_, ok, err := cosmos.SendPacket([]abcitypes.Event{{Type: "message", Attributes: attrs("action", "execute")}})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, ok, err := cosmos.SendPacket([]abcitypes.Event{{Type: "send_packet", Attributes: attrs("packet_sequence", "x")}})
+		require.True(t, ok)
+		require.ErrorContains(t, err, "invalid packet sequence")
+	})
+}
@@ -0,0 +1,70 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// RegisterCounterpartyPayee registers the address on the counterparty chain
+// that receives the ICS-29 receive fees for packets relayed by relayerAddr over the given fee-enabled channel.
+// The transaction must be signed by the relayer, so keyName must be the relayer's key.
+func (tn *ChainNode) RegisterCounterpartyPayee(ctx context.Context, keyName, portID, channelID, relayerAddr, counterpartyPayee string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"ibc-fee", "register-counterparty-payee", portID, channelID, relayerAddr, counterpartyPayee,
+	)
+	return err
+}
+
+// PayPacketFee escrows ICS-29 fees for an already sent packet, identified by its source port, channel, and sequence.
+// Each fee is a coin string, e.g. "1000uatom"; an empty fee is omitted.
+func (tn *ChainNode) PayPacketFee(ctx context.Context, keyName, portID, channelID string, sequence uint64, recvFee, ackFee, timeoutFee string) error {
+	cmd := []string{
+		"ibc-fee", "pay-packet-fee", portID, channelID, strconv.FormatUint(sequence, 10),
+	}
+	if recvFee != "" {
+		cmd = append(cmd, "--recv-fee", recvFee)
+	}
+	if ackFee != "" {
+		cmd = append(cmd, "--ack-fee", ackFee)
+	}
+	if timeoutFee != "" {
+		cmd = append(cmd, "--timeout-fee", timeoutFee)
+	}
+	_, err := tn.ExecTx(ctx, keyName, cmd...)
+	return err
+}
+
+// QueryCounterpartyPayee returns the counterparty payee registered for relayerAddr on the given channel.
+func (tn *ChainNode) QueryCounterpartyPayee(ctx context.Context, channelID, relayerAddr string) (string, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "ibc-fee", "counterparty-payee", channelID, relayerAddr)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		CounterpartyPayee string `json:"counterparty_payee"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return "", fmt.Errorf("failed to unmarshal counterparty payee: %w", err)
+	}
+	return res.CounterpartyPayee, nil
+}
+
+// RegisterCounterpartyPayee registers the counterparty payee of a relayer on a fee-enabled channel.
+// See (*ChainNode).RegisterCounterpartyPayee.
+func (c *CosmosChain) RegisterCounterpartyPayee(ctx context.Context, keyName, portID, channelID, relayerAddr, counterpartyPayee string) error {
+	return c.getFullNode().RegisterCounterpartyPayee(ctx, keyName, portID, channelID, relayerAddr, counterpartyPayee)
+}
+
+// PayPacketFee escrows ICS-29 fees for an already sent packet.
+// See (*ChainNode).PayPacketFee.
+func (c *CosmosChain) PayPacketFee(ctx context.Context, keyName, portID, channelID string, sequence uint64, recvFee, ackFee, timeoutFee string) error {
+	return c.getFullNode().PayPacketFee(ctx, keyName, portID, channelID, sequence, recvFee, ackFee, timeoutFee)
+}
+
+// QueryCounterpartyPayee returns the counterparty payee registered for relayerAddr on the given channel.
+func (c *CosmosChain) QueryCounterpartyPayee(ctx context.Context, channelID, relayerAddr string) (string, error) {
+	return c.getFullNode().QueryCounterpartyPayee(ctx, channelID, relayerAddr)
+}
@@ -0,0 +1,84 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// GrantFeeAllowance grants grantee, a bech32 address, an allowance to pay transaction fees
+// from the balance of the key granterKey.
+func (tn *ChainNode) GrantFeeAllowance(ctx context.Context, granterKey, grantee string, allowance ibc.FeeAllowance) error {
+	flags, err := feeAllowanceFlags(allowance)
+	if err != nil {
+		return err
+	}
+
+	command := append([]string{"feegrant", "grant", granterKey, grantee}, flags...)
+	_, err = tn.ExecTx(ctx, granterKey, command...)
+	return err
+}
+
+// feeAllowanceFlags returns the flags of the feegrant grant command for allowance.
+func feeAllowanceFlags(allowance ibc.FeeAllowance) ([]string, error) {
+	var flags []string
+	if allowance.SpendLimit != "" {
+		flags = append(flags, "--spend-limit", allowance.SpendLimit)
+	}
+	if !allowance.Expiration.IsZero() {
+		flags = append(flags, "--expiration", allowance.Expiration.UTC().Format(time.RFC3339))
+	}
+
+	if (allowance.Period == 0) != (allowance.PeriodLimit == "") {
+		return nil, fmt.Errorf("fee allowance period and period limit must be set together")
+	}
+	if allowance.Period != 0 {
+		// The period is given in whole seconds.
+		if allowance.Period%time.Second != 0 || allowance.Period < 0 {
+			return nil, fmt.Errorf("fee allowance period %s must be a positive number of seconds", allowance.Period)
+		}
+		flags = append(flags,
+			"--period", fmt.Sprint(int64(allowance.Period/time.Second)),
+			"--period-limit", allowance.PeriodLimit,
+		)
+	}
+
+	if len(allowance.AllowedMessages) > 0 {
+		flags = append(flags, "--allowed-messages", strings.Join(allowance.AllowedMessages, ","))
+	}
+	return flags, nil
+}
+
+// WithFeeGranter returns a FactoryOpt paying the transaction's fees from the allowance granted by granter,
+// see GrantFeeAllowance.
+func WithFeeGranter(granter types.AccAddress) FactoryOpt {
+	return func(f tx.Factory) tx.Factory {
+		return f.WithFeeGranter(granter)
+	}
+}
+
+// GrantFeeAllowance grants grantee, a bech32 address, an allowance to pay transaction fees
+// from the balance of the key granterKey.
+func (c *CosmosChain) GrantFeeAllowance(ctx context.Context, granterKey, grantee string, allowance ibc.FeeAllowance) error {
+	return c.getFullNode().GrantFeeAllowance(ctx, granterKey, grantee, allowance)
+}
+
+// BroadcastTxWithFeeGranter is like BroadcastTx, but pays the fees from the allowance granted to user by granter,
+// a bech32 address, so that user's balance is left untouched.
+func (c *CosmosChain) BroadcastTxWithFeeGranter(ctx context.Context, granter string, user User, msgs ...types.Msg) (types.TxResponse, error) {
+	granterAddr, err := types.GetFromBech32(granter, c.cfg.Bech32Prefix)
+	if err != nil {
+		return types.TxResponse{}, fmt.Errorf("invalid fee granter address %s: %w", granter, err)
+	}
+
+	b := NewBroadcaster(nil, c)
+	defer b.removeTempDirs()
+	b.ConfigureFactoryOptions(WithFeeGranter(granterAddr))
+
+	return BroadcastTx(ctx, b, user, msgs...)
+}
@@ -0,0 +1,37 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeAllowanceFlags(t *testing.T) {
+	flags, err := feeAllowanceFlags(ibc.FeeAllowance{})
+	require.NoError(t, err)
+	require.Empty(t, flags)
+
+	flags, err = feeAllowanceFlags(ibc.FeeAllowance{
+		SpendLimit:      "1000uatom",
+		Expiration:      time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Period:          time.Hour,
+		PeriodLimit:     "10uatom",
+		AllowedMessages: []string{"/cosmos.bank.v1beta1.MsgSend", "/cosmos.gov.v1beta1.MsgVote"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"--spend-limit", "1000uatom",
+		"--expiration", "2030-01-02T03:04:05Z",
+		"--period", "3600",
+		"--period-limit", "10uatom",
+		"--allowed-messages", "/cosmos.bank.v1beta1.MsgSend,/cosmos.gov.v1beta1.MsgVote",
+	}, flags)
+
+	_, err = feeAllowanceFlags(ibc.FeeAllowance{Period: time.Hour})
+	require.Error(t, err)
+
+	_, err = feeAllowanceFlags(ibc.FeeAllowance{Period: 1500 * time.Millisecond, PeriodLimit: "10uatom"})
+	require.Error(t, err)
+}
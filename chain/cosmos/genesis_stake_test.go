@@ -0,0 +1,43 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorGenesisCoins(t *testing.T) {
+	defaultAmount := types.NewInt64Coin("stake", 10_000)
+	defaultSelfDelegation := types.NewInt64Coin("stake", 5_000)
+	cfg := ibc.ChainConfig{ValidatorStakes: []int64{90_000, 0, -1}}
+
+	amounts, selfDelegation, err := validatorGenesisCoins(cfg, 0, defaultAmount, defaultSelfDelegation)
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("stake", 90_000), selfDelegation)
+	require.Equal(t, []types.Coin{types.NewInt64Coin("stake", 95_000)}, amounts)
+
+	// Zero entries and validators past the end of the slice use the defaults.
+	for _, i := range []int{1, 3} {
+		amounts, selfDelegation, err = validatorGenesisCoins(cfg, i, defaultAmount, defaultSelfDelegation)
+		require.NoError(t, err)
+		require.Equal(t, defaultSelfDelegation, selfDelegation)
+		require.Equal(t, []types.Coin{defaultAmount}, amounts)
+	}
+
+	_, _, err = validatorGenesisCoins(cfg, 2, defaultAmount, defaultSelfDelegation)
+	require.Error(t, err)
+}
+
+func TestGenesisAccounts(t *testing.T) {
+	accs := genesisAccounts([]ibc.WalletAmount{
+		{Address: "faucet", Denom: "stake", Amount: 100},
+		{Address: "user", Denom: "stake", Amount: 10},
+		{Address: "faucet", Denom: "ufee", Amount: 50},
+	})
+	require.Equal(t, []genesisAccount{
+		{address: "faucet", coins: []types.Coin{types.NewInt64Coin("stake", 100), types.NewInt64Coin("ufee", 50)}},
+		{address: "user", coins: []types.Coin{types.NewInt64Coin("stake", 10)}},
+	}, accs)
+}
@@ -0,0 +1,89 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	icacontrollertypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/controller/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+var _ testutil.InterchainAccountController = (*CosmosChain)(nil)
+
+// icaRegistrationBlocks is the number of blocks RegisterInterchainAccount waits
+// for the interchain account channel handshake to complete.
+const icaRegistrationBlocks = 20
+
+// RegisterInterchainAccount registers an interchain account owned by keyName on the counterparty chain of connectionID,
+// and returns the address of the account on the host chain.
+//
+// Registration opens a new channel, so a relayer must be relaying the connection's path for this to succeed.
+func (c *CosmosChain) RegisterInterchainAccount(ctx context.Context, keyName, connectionID string) (string, error) {
+	tn := c.getFullNode()
+
+	owner, err := tn.AccountKeyBech32(ctx, keyName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get address of key %s: %w", keyName, err)
+	}
+
+	if _, err := tn.RegisterICA(ctx, keyName, connectionID); err != nil {
+		return "", fmt.Errorf("failed to register interchain account: %w", err)
+	}
+
+	var queryErr error
+	for i := 0; i < icaRegistrationBlocks; i++ {
+		addr, err := tn.QueryICA(ctx, connectionID, owner)
+		if err == nil && addr != "" {
+			return addr, nil
+		}
+		queryErr = err
+
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("interchain account for %s on %s not found after %d blocks: %v", owner, connectionID, icaRegistrationBlocks, queryErr)
+}
+
+// QueryICA returns the host chain address of the interchain account owned by address over connectionID.
+func (c *CosmosChain) QueryICA(ctx context.Context, connectionID, address string) (string, error) {
+	return c.getFullNode().QueryICA(ctx, connectionID, address)
+}
+
+// GetInterchainAccountAddress queries the ICA controller module for the host chain address
+// of the interchain account owned by ownerAddress over connectionID.
+// Unlike QueryICA, this does not depend on any chain-specific module such as intertx.
+// An error is returned if the account has not been registered yet.
+func (c *CosmosChain) GetInterchainAccountAddress(ctx context.Context, connectionID, ownerAddress string) (string, error) {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	queryClient := icacontrollertypes.NewQueryClient(conn)
+	res, err := queryClient.InterchainAccount(ctx, &icacontrollertypes.QueryInterchainAccountRequest{
+		Owner:        ownerAddress,
+		ConnectionId: connectionID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to query interchain account of %s on %s (it may not be registered yet): %w", ownerAddress, connectionID, err)
+	}
+	if res.Address == "" {
+		return "", fmt.Errorf("no interchain account registered for %s on %s", ownerAddress, connectionID)
+	}
+
+	return res.Address, nil
+}
+
+// SendICATx submits a JSON-encoded sdk.Msg to be executed by the interchain account owned by keyName.
+// See (*ChainNode).SendICATx.
+func (c *CosmosChain) SendICATx(ctx context.Context, keyName, connectionID, msg string) error {
+	return c.getFullNode().SendICATx(ctx, keyName, connectionID, msg)
+}
+
+// SendICABankTransfer sends a MsgSend from the interchain account owned by fromAddr.
+func (c *CosmosChain) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
+	return c.getFullNode().SendICABankTransfer(ctx, connectionID, fromAddr, amount)
+}
@@ -0,0 +1,289 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// ConsumerPortID and ProviderPortID are the ports of the CCV channel between a consumer and its provider.
+	ConsumerPortID = "consumer"
+	ProviderPortID = "provider"
+
+	// ConsumerClientID is the client tracking the provider, created by a consumer chain at genesis.
+	ConsumerClientID = "07-tendermint-0"
+
+	// consumerProposalBlocks is how many provider blocks StartConsumer waits for the consumer-addition proposal to pass.
+	consumerProposalBlocks = 30
+)
+
+// CCVChannelOptions returns the options for opening the CCV channel from a consumer chain to its provider.
+func CCVChannelOptions() ibc.CreateChannelOptions {
+	return ibc.CreateChannelOptions{
+		SourcePortName: ConsumerPortID,
+		DestPortName:   ProviderPortID,
+		Order:          ibc.Ordered,
+		Version:        "1",
+	}
+}
+
+// consumerAdditionProposal is the JSON file accepted by the provider's consumer-addition proposal command.
+type consumerAdditionProposal struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	ChainID       string                `json:"chain_id"`
+	InitialHeight consumerInitialHeight `json:"initial_height"`
+	GenesisHash   []byte                `json:"genesis_hash"`
+	BinaryHash    []byte                `json:"binary_hash"`
+	SpawnTime     time.Time             `json:"spawn_time"`
+
+	ConsumerRedistributionFraction    string        `json:"consumer_redistribution_fraction"`
+	BlocksPerDistributionTransmission int64         `json:"blocks_per_distribution_transmission"`
+	HistoricalEntries                 int64         `json:"historical_entries"`
+	CCVTimeoutPeriod                  time.Duration `json:"ccv_timeout_period"`
+	TransferTimeoutPeriod             time.Duration `json:"transfer_timeout_period"`
+	UnbondingPeriod                   time.Duration `json:"unbonding_period"`
+
+	Deposit string `json:"deposit"`
+}
+
+type consumerInitialHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// newConsumerAdditionProposal returns a proposal spawning chainID as soon as it passes.
+// The genesis and binary hashes are not verified by the provider, so placeholders are used.
+func newConsumerAdditionProposal(chainID, deposit string, spawnTime time.Time) consumerAdditionProposal {
+	return consumerAdditionProposal{
+		Title:       "Add consumer chain " + chainID,
+		Description: "Spawn consumer chain " + chainID,
+
+		ChainID: chainID,
+		InitialHeight: consumerInitialHeight{
+			RevisionNumber: clienttypes.ParseChainID(chainID),
+			RevisionHeight: 1,
+		},
+		GenesisHash: []byte("gen_hash"),
+		BinaryHash:  []byte("bin_hash"),
+		SpawnTime:   spawnTime,
+
+		ConsumerRedistributionFraction:    "0.75",
+		BlocksPerDistributionTransmission: 1000,
+		HistoricalEntries:                 10000,
+		CCVTimeoutPeriod:                  28 * 24 * time.Hour,
+		TransferTimeoutPeriod:             time.Hour,
+		UnbondingPeriod:                   20 * 24 * time.Hour,
+
+		Deposit: deposit,
+	}
+}
+
+// ConsumerAdditionProposal submits a proposal to the provider chain to spawn the consumer chain with the given chain ID.
+func (tn *ChainNode) ConsumerAdditionProposal(ctx context.Context, keyName, consumerChainID, deposit string) (string, error) {
+	content, err := json.Marshal(newConsumerAdditionProposal(consumerChainID, deposit, time.Now().UTC()))
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+	proposalFilename := fmt.Sprintf("%x.json", hash)
+	if err := tn.WriteFile(ctx, content, proposalFilename); err != nil {
+		return "", fmt.Errorf("writing consumer addition proposal: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"gov", "submit-proposal",
+		"consumer-addition",
+		filepath.Join(tn.HomeDir(), proposalFilename),
+	)
+}
+
+// ConsumerGenesis queries the provider chain for the CCV consumer genesis state of a spawned consumer chain.
+func (tn *ChainNode) ConsumerGenesis(ctx context.Context, consumerChainID string) (json.RawMessage, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "provider", "consumer-genesis", consumerChainID)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(stdout) {
+		return nil, fmt.Errorf("consumer genesis for %s is not valid JSON: %s", consumerChainID, stdout)
+	}
+	return stdout, nil
+}
+
+// StartConsumer starts the chain as an Interchain Security consumer of provider,
+// instead of from its own staking genesis as Start does.
+//
+// StartConsumer proposes the consumer chain on the provider, votes for it with every provider validator,
+// and waits for the proposal to pass, so the provider's voting period must elapse within a few blocks,
+// e.g. by shortening it through the provider's ModifyGenesis.
+// The consumer is then started from the CCV genesis state generated by the provider.
+// Each consumer validator reuses the consensus key of the provider validator with the same index,
+// so the chain must have as many validators as the provider.
+//
+// The CCV channel is not opened by StartConsumer; see CCVChannelOptions.
+func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, provider ibc.Chain, additionalGenesisWallets ...ibc.WalletAmount) error {
+	p, ok := provider.(*CosmosChain)
+	if !ok {
+		return fmt.Errorf("provider chain %s must be a cosmos chain", provider.Config().ChainID)
+	}
+	if len(c.Validators) != len(p.Validators) {
+		return fmt.Errorf("consumer chain %s has %d validators but its provider %s has %d",
+			c.cfg.ChainID, len(c.Validators), p.cfg.ChainID, len(p.Validators))
+	}
+
+	if err := p.proposeConsumer(ctx, c.cfg.ChainID); err != nil {
+		return fmt.Errorf("failed to add consumer chain %s to provider %s: %w", c.cfg.ChainID, p.cfg.ChainID, err)
+	}
+
+	ccvGenesis, err := p.getFullNode().ConsumerGenesis(ctx, c.cfg.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to query consumer genesis: %w", err)
+	}
+
+	chainCfg := c.Config()
+	genesisAmounts := []types.Coin{{Amount: types.NewInt(10_000_000_000_000), Denom: chainCfg.Denom}}
+	configFileOverrides := chainCfg.ConfigFileOverrides
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, v := range c.Validators {
+		v := v
+		v.Validator = true
+		providerVal := p.Validators[i]
+		eg.Go(func() error {
+			if err := v.InitFullNodeFiles(egCtx); err != nil {
+				return err
+			}
+			if err := v.applyConfigFileOverrides(egCtx, configFileOverrides); err != nil {
+				return err
+			}
+			key, err := providerVal.ReadFile(egCtx, "config/priv_validator_key.json")
+			if err != nil {
+				return err
+			}
+			if err := v.WriteFile(egCtx, key, "config/priv_validator_key.json"); err != nil {
+				return err
+			}
+			if err := v.recoverGenesisKeys(egCtx); err != nil {
+				return err
+			}
+			return v.CreateKey(egCtx, valKey)
+		})
+	}
+	for _, n := range c.FullNodes {
+		n := n
+		n.Validator = false
+		eg.Go(func() error {
+			if err := n.InitFullNodeFiles(egCtx); err != nil {
+				return err
+			}
+			if err := n.applyConfigFileOverrides(egCtx, configFileOverrides); err != nil {
+				return err
+			}
+			return n.recoverGenesisKeys(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	validator0 := c.Validators[0]
+	// Only the first validator's genesis file is used, so every account is added there.
+	for _, v := range c.Validators {
+		bech32, err := v.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return err
+		}
+		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
+			return err
+		}
+	}
+
+	for _, acc := range genesisAccounts(additionalGenesisWallets) {
+		if err := validator0.AddGenesisAccount(ctx, acc.address, acc.coins); err != nil {
+			return err
+		}
+	}
+
+	if err := c.addGenesisKeyAccounts(ctx, validator0); err != nil {
+		return err
+	}
+
+	if c.cfg.PreGenesis != nil {
+		if err := c.cfg.PreGenesis(ctx, c); err != nil {
+			return fmt.Errorf("pre-genesis hook: %w", err)
+		}
+	}
+
+	genbz, err := validator0.genesisFileContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	genbz, err = withConsumerGenesis(genbz, ccvGenesis)
+	if err != nil {
+		return err
+	}
+
+	return c.startWithGenesis(ctx, genbz)
+}
+
+// proposeConsumer submits a consumer-addition proposal for consumerChainID,
+// votes for it with every validator, and waits for it to pass.
+func (c *CosmosChain) proposeConsumer(ctx context.Context, consumerChainID string) error {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return err
+	}
+
+	deposit := fmt.Sprintf("%d%s", 10_000_000, c.cfg.Denom)
+	txHash, err := c.getFullNode().ConsumerAdditionProposal(ctx, valKey, consumerChainID, deposit)
+	if err != nil {
+		return fmt.Errorf("failed to submit consumer addition proposal: %w", err)
+	}
+	prop, err := c.txProposal(txHash)
+	if err != nil {
+		return err
+	}
+
+	if err := c.VoteOnProposalAllValidators(ctx, prop.ProposalID, ProposalVoteYes); err != nil {
+		return fmt.Errorf("failed to vote on consumer addition proposal: %w", err)
+	}
+
+	if _, err := PollForProposalStatus(ctx, c, height, height+consumerProposalBlocks, prop.ProposalID, ProposalStatusPassed); err != nil {
+		return fmt.Errorf("consumer addition proposal %s did not pass: %w", prop.ProposalID, err)
+	}
+	return nil
+}
+
+// withConsumerGenesis sets the CCV consumer module genesis state in the genesis file content.
+func withConsumerGenesis(genbz []byte, ccvGenesis json.RawMessage) ([]byte, error) {
+	var g map[string]json.RawMessage
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	var appState map[string]json.RawMessage
+	if err := json.Unmarshal(g["app_state"], &appState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis app state: %w", err)
+	}
+	appState["ccvconsumer"] = ccvGenesis
+
+	bz, err := json.Marshal(appState)
+	if err != nil {
+		return nil, err
+	}
+	g["app_state"] = bz
+
+	return json.MarshalIndent(g, "", "  ")
+}
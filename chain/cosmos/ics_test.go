@@ -0,0 +1,40 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithConsumerGenesis(t *testing.T) {
+	genbz := []byte(`{"chain_id":"consumer-1","app_state":{"bank":{"balances":[]}}}`)
+	ccv := json.RawMessage(`{"params":{"enabled":true},"new_chain":true}`)
+
+	got, err := withConsumerGenesis(genbz, ccv)
+	require.NoError(t, err)
+
+	var g struct {
+		ChainID  string                     `json:"chain_id"`
+		AppState map[string]json.RawMessage `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(got, &g))
+	require.Equal(t, "consumer-1", g.ChainID)
+	require.JSONEq(t, `{"balances":[]}`, string(g.AppState["bank"]))
+	require.JSONEq(t, string(ccv), string(g.AppState["ccvconsumer"]))
+}
+
+func TestNewConsumerAdditionProposal(t *testing.T) {
+	spawn := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	bz, err := json.Marshal(newConsumerAdditionProposal("consumer-2", "10000000uatom", spawn))
+	require.NoError(t, err)
+
+	var prop map[string]any
+	require.NoError(t, json.Unmarshal(bz, &prop))
+	require.Equal(t, "consumer-2", prop["chain_id"])
+	require.Equal(t, map[string]any{"revision_number": float64(2), "revision_height": float64(1)}, prop["initial_height"])
+	require.Equal(t, "2022-10-01T00:00:00Z", prop["spawn_time"])
+	require.Equal(t, float64(time.Hour), prop["transfer_timeout_period"])
+	require.Equal(t, "10000000uatom", prop["deposit"])
+}
@@ -0,0 +1,67 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const (
+	// maxUnconfirmedTxs is the most transactions a tendermint node returns from unconfirmed_txs.
+	maxUnconfirmedTxs = 100
+
+	// methodNotFoundCode is the JSON-RPC error code of a call to an unknown method.
+	methodNotFoundCode = -32601
+)
+
+// ErrMempoolRPCUnavailable is returned when the node does not serve the mempool RPC endpoints,
+// e.g. because they are disabled in its configuration.
+var ErrMempoolRPCUnavailable = errors.New("mempool rpc endpoint unavailable")
+
+// UnconfirmedTxCount returns the number of transactions in the node's mempool.
+func (tn *ChainNode) UnconfirmedTxCount(ctx context.Context) (int, error) {
+	res, err := tn.Client.NumUnconfirmedTxs(ctx)
+	if err != nil {
+		return 0, mempoolRPCError("num_unconfirmed_txs", err)
+	}
+	return res.Total, nil
+}
+
+// MempoolTxs returns the raw bytes of up to 100 transactions in the node's mempool, oldest first.
+func (tn *ChainNode) MempoolTxs(ctx context.Context) ([][]byte, error) {
+	limit := maxUnconfirmedTxs
+	res, err := tn.Client.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, mempoolRPCError("unconfirmed_txs", err)
+	}
+	txs := make([][]byte, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+// mempoolRPCError wraps the error of a call to the mempool RPC endpoint method,
+// identifying a node that does not serve it with ErrMempoolRPCUnavailable.
+func mempoolRPCError(method string, err error) error {
+	var rpcErr *rpctypes.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == methodNotFoundCode {
+		return fmt.Errorf("%w: tendermint rpc %s: %v", ErrMempoolRPCUnavailable, method, err)
+	}
+	return fmt.Errorf("tendermint rpc %s: %w", method, err)
+}
+
+// GetUnconfirmedTxCount returns the number of transactions in the mempool of the chain's full node,
+// e.g. to assert on the backlog of a load test.
+// The error wraps ErrMempoolRPCUnavailable if the node does not serve the endpoint.
+func (c *CosmosChain) GetUnconfirmedTxCount(ctx context.Context) (int, error) {
+	return c.getFullNode().UnconfirmedTxCount(ctx)
+}
+
+// GetMempoolTxs returns the raw bytes of up to 100 transactions in the mempool of the chain's full node, oldest first.
+// The error wraps ErrMempoolRPCUnavailable if the node does not serve the endpoint.
+func (c *CosmosChain) GetMempoolTxs(ctx context.Context) ([][]byte, error) {
+	return c.getFullNode().MempoolTxs(ctx)
+}
@@ -0,0 +1,22 @@
+package cosmos
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestMempoolRPCError(t *testing.T) {
+	notFound := fmt.Errorf("post failed: %w", &rpctypes.RPCError{Code: -32601, Message: "Method not found"})
+	err := mempoolRPCError("num_unconfirmed_txs", notFound)
+	require.ErrorIs(t, err, ErrMempoolRPCUnavailable)
+	require.Contains(t, err.Error(), "num_unconfirmed_txs")
+
+	boom := errors.New("connection refused")
+	err = mempoolRPCError("unconfirmed_txs", boom)
+	require.ErrorIs(t, err, boom)
+	require.NotErrorIs(t, err, ErrMempoolRPCUnavailable)
+}
@@ -0,0 +1,79 @@
+package cosmos
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetMetrics scrapes the Prometheus metrics of the chain's full node.
+// The chain must have been configured with EnableMetrics.
+//
+// The returned map is keyed by series, i.e. the metric name followed by its labels exactly as exposed,
+// e.g. `tendermint_mempool_size{chain_id="gaia-1"}`.
+func (c *CosmosChain) GetMetrics(ctx context.Context) (map[string]float64, error) {
+	return c.getFullNode().GetMetrics(ctx)
+}
+
+// GetMetrics scrapes the node's Prometheus metrics endpoint.
+// See (*CosmosChain).GetMetrics for the format of the returned map.
+func (tn *ChainNode) GetMetrics(ctx context.Context) (map[string]float64, error) {
+	if !tn.Chain.Config().EnableMetrics {
+		return nil, fmt.Errorf("metrics are not enabled for chain %s", tn.Chain.Config().ChainID)
+	}
+	if tn.hostMetricsPort == "" {
+		return nil, fmt.Errorf("node %s has not been started", tn.Name())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+tn.hostMetricsPort+"/metrics", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape metrics from %s: %w", tn.Name(), err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scraping metrics from %s: unexpected status %s", tn.Name(), res.Status)
+	}
+	return parseMetrics(res.Body)
+}
+
+// parseMetrics parses samples in the Prometheus text exposition format.
+func parseMetrics(r io.Reader) (map[string]float64, error) {
+	metrics := make(map[string]float64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Label values may contain spaces, so split after the closing brace if there is one.
+		seriesEnd := strings.LastIndex(line, "}") + 1
+		if seriesEnd == 0 {
+			seriesEnd = strings.IndexAny(line, " \t")
+		}
+		if seriesEnd <= 0 {
+			return nil, fmt.Errorf("malformed metric line %q", line)
+		}
+
+		// The value may be followed by an optional timestamp.
+		fields := strings.Fields(line[seriesEnd:])
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("metric line %q has no value", line)
+		}
+		v, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("metric line %q: %w", line, err)
+		}
+		metrics[line[:seriesEnd]] = v
+	}
+	return metrics, scanner.Err()
+}
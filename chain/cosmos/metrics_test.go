@@ -0,0 +1,32 @@
+package cosmos
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetrics(t *testing.T) {
+	const exposition = `# HELP tendermint_mempool_size Size of the mempool.
+# TYPE tendermint_mempool_size gauge
+tendermint_mempool_size{chain_id="gaia-1"} 12
+tendermint_p2p_peers{chain_id="gaia-1"} 3 1668000000000
+tendermint_consensus_block_interval_seconds_sum{chain_id="gaia-1",note="a b"} 4.5
+go_goroutines 42
+process_max_fds +Inf
+`
+	metrics, err := parseMetrics(strings.NewReader(exposition))
+	require.NoError(t, err)
+
+	require.Len(t, metrics, 5)
+	require.Equal(t, 12.0, metrics[`tendermint_mempool_size{chain_id="gaia-1"}`])
+	require.Equal(t, 3.0, metrics[`tendermint_p2p_peers{chain_id="gaia-1"}`])
+	require.Equal(t, 4.5, metrics[`tendermint_consensus_block_interval_seconds_sum{chain_id="gaia-1",note="a b"}`])
+	require.Equal(t, 42.0, metrics["go_goroutines"])
+	require.True(t, math.IsInf(metrics["process_max_fds"], 1))
+
+	_, err = parseMetrics(strings.NewReader("go_goroutines lots\n"))
+	require.Error(t, err)
+}
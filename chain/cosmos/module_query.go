@@ -0,0 +1,81 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	paramsproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+)
+
+// QueryJSON runs the query command, e.g. ("epochs", "epoch-infos") for `<bin> query epochs epoch-infos --output json`,
+// and unmarshals its output into result, giving access to any module without a dedicated helper.
+//
+// Protobuf messages, e.g. the module's generated response types, are unmarshaled with the chain's codec,
+// and anything else with encoding/json.
+// The error of a failed query includes the command's output.
+func (tn *ChainNode) QueryJSON(ctx context.Context, result any, query ...string) error {
+	stdout, _, err := tn.ExecQuery(ctx, query...)
+	if err != nil {
+		return fmt.Errorf("query %s: %w", strings.Join(query, " "), err)
+	}
+	if err := tn.unmarshalQueryJSON(stdout, result); err != nil {
+		return fmt.Errorf("failed to unmarshal output of query %s: %w", strings.Join(query, " "), err)
+	}
+	return nil
+}
+
+func (tn *ChainNode) unmarshalQueryJSON(bz []byte, result any) error {
+	if msg, ok := result.(codec.ProtoMarshaler); ok {
+		if encoding := tn.Chain.Config().EncodingConfig; encoding != nil {
+			return encoding.Codec.UnmarshalJSON(bz, msg)
+		}
+	}
+	return json.Unmarshal(bz, result)
+}
+
+// QueryJSON runs the query command against the full node and unmarshals its output into result;
+// see ChainNode.QueryJSON.
+func (c *CosmosChain) QueryJSON(ctx context.Context, result any, query ...string) error {
+	return c.getFullNode().QueryJSON(ctx, result, query...)
+}
+
+// QueryGRPC invokes the gRPC query method, given by its full path such as "/osmosis.epochs.v1beta1.Query/EpochInfos",
+// on the full node, and stores the response in resp.
+// Unlike QueryJSON, it does not depend on the binary having a CLI command for the query.
+func (c *CosmosChain) QueryGRPC(ctx context.Context, method string, req, resp codec.ProtoMarshaler) error {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.Invoke(ctx, method, req, resp); err != nil {
+		return fmt.Errorf("grpc query %s: %w", method, err)
+	}
+	return nil
+}
+
+// QueryParams returns the current value of the x/params parameter key in subspace,
+// e.g. ("interchainquery", "AllowQueries"), such as to check that a ModifyGenesis override took effect.
+func (c *CosmosChain) QueryParams(ctx context.Context, subspace, key string) (json.RawMessage, error) {
+	var res paramsproposal.QueryParamsResponse
+	if err := c.QueryGRPC(ctx, "/cosmos.params.v1beta1.Query/Params", &paramsproposal.QueryParamsRequest{Subspace: subspace, Key: key}, &res); err != nil {
+		return nil, err
+	}
+	return paramValue(res.Param)
+}
+
+// paramValue returns the JSON encoded value of param.
+func paramValue(param paramsproposal.ParamChange) (json.RawMessage, error) {
+	// An unknown key has an empty value rather than an error.
+	if param.Value == "" {
+		return nil, fmt.Errorf("param %s/%s not found", param.Subspace, param.Key)
+	}
+	if !json.Valid([]byte(param.Value)) {
+		return nil, fmt.Errorf("param %s/%s is not valid JSON: %s", param.Subspace, param.Key, param.Value)
+	}
+	return json.RawMessage(param.Value), nil
+}
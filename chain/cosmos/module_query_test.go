@@ -0,0 +1,45 @@
+package cosmos
+
+import (
+	"testing"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	paramsproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalQueryJSON(t *testing.T) {
+	encoding := DefaultEncoding()
+	tn := &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{EncodingConfig: &encoding}}}
+
+	t.Run("proto", func(t *testing.T) {
+		var res banktypes.QueryBalanceResponse
+		require.NoError(t, tn.unmarshalQueryJSON([]byte(`{"balance":{"denom":"uatom","amount":"42"}}`), &res))
+		require.Equal(t, int64(42), res.Balance.Amount.Int64())
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		var res struct {
+			Epochs []struct {
+				Identifier string `json:"identifier"`
+			} `json:"epochs"`
+		}
+		require.NoError(t, tn.unmarshalQueryJSON([]byte(`{"epochs":[{"identifier":"day"}]}`), &res))
+		require.Equal(t, "day", res.Epochs[0].Identifier)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var res map[string]any
+		require.Error(t, tn.unmarshalQueryJSON([]byte(`Error: unknown command "epochs"`), &res))
+	})
+}
+
+func TestParamValue(t *testing.T) {
+	v, err := paramValue(paramsproposal.ParamChange{Subspace: "interchainquery", Key: "AllowQueries", Value: `["/cosmos.bank.v1beta1.Query/AllBalances"]`})
+	require.NoError(t, err)
+	require.JSONEq(t, `["/cosmos.bank.v1beta1.Query/AllBalances"]`, string(v))
+
+	_, err = paramValue(paramsproposal.ParamChange{Subspace: "interchainquery", Key: "Unknown"})
+	require.EqualError(t, err, "param interchainquery/Unknown not found")
+}
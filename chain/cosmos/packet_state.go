@@ -0,0 +1,62 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	chanTypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPacketCommitment queries the IBC core module for the commitment of the packet sent with sequence
+// over the given port and channel, i.e. the hash stored when the packet was sent.
+// It returns nil without error if no commitment is stored, either because no such packet was sent,
+// or because it was acknowledged or timed out, which deletes the commitment.
+// Together with GetPacketReceipt on the counterparty, this tells the stage of a packet independently of the relayer.
+func (c *CosmosChain) GetPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chanTypes.NewQueryClient(conn).PacketCommitment(ctx, &chanTypes.QueryPacketCommitmentRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query packet commitment %d on %s/%s: %w", sequence, portID, channelID, err)
+	}
+	return res.Commitment, nil
+}
+
+// GetPacketReceipt queries the IBC core module for whether the packet with sequence was received
+// over the given port and channel, i.e. the channel end on the packet's destination chain.
+// Receipts are only stored for unordered channels; ordered channels track the next sequence to receive instead.
+func (c *CosmosChain) GetPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	res, err := chanTypes.NewQueryClient(conn).PacketReceipt(ctx, &chanTypes.QueryPacketReceiptRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to query packet receipt %d on %s/%s: %w", sequence, portID, channelID, err)
+	}
+	return res.Received, nil
+}
+
+// isNotFound reports whether a gRPC query failed because the requested state does not exist.
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
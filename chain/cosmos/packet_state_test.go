@@ -0,0 +1,18 @@
+package cosmos
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsNotFound(t *testing.T) {
+	require.True(t, isNotFound(status.Error(codes.NotFound, "packet commitment hash not found")))
+	require.False(t, isNotFound(status.Error(codes.InvalidArgument, "identifier cannot be blank")))
+	require.False(t, isNotFound(errors.New("connection refused")))
+	require.False(t, isNotFound(fmt.Errorf("wrapped: %w", errors.New("not found"))))
+}
@@ -0,0 +1,142 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+)
+
+// NodePeerInfo describes the P2P identity and wiring of a single chain node.
+type NodePeerInfo struct {
+	// Name is the node's container name.
+	Name string
+	// NodeID is the Tendermint node ID derived from the node key.
+	NodeID string
+	// P2PAddress is the node's address for peers inside the Docker network, in the id@host:port format.
+	// The port is the one the node listens on, per p2p.laddr in its config.toml.
+	P2PAddress string
+	// HostP2PAddress is the host:port through which the node's P2P port is reachable from the host.
+	HostP2PAddress string
+	// PersistentPeers are the peer addresses configured in the node's config.toml.
+	PersistentPeers []string
+	// ConnectedPeers are the node IDs of the peers the node is currently connected to.
+	ConnectedPeers []string
+}
+
+// GetNodeID returns the node ID of the node at index in Nodes, i.e. validators first, then full nodes.
+func (c *CosmosChain) GetNodeID(ctx context.Context, index int) (string, error) {
+	nodes := c.Nodes()
+	if index < 0 || index >= len(nodes) {
+		return "", fmt.Errorf("node index %d out of range for chain %s with %d nodes", index, c.cfg.ChainID, len(nodes))
+	}
+	return nodes[index].NodeID(ctx)
+}
+
+// NodePeerInfo returns the P2P identity and wiring of every node, in the same order as Nodes.
+// The chain must have been started.
+func (c *CosmosChain) NodePeerInfo(ctx context.Context) ([]NodePeerInfo, error) {
+	nodes := c.Nodes()
+	infos := make([]NodePeerInfo, len(nodes))
+	for i, n := range nodes {
+		info, err := n.PeerInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		infos[i] = info
+	}
+	return infos, nil
+}
+
+// PeerInfo returns the node's P2P identity, its configured persistent peers, and its connected peers.
+func (tn *ChainNode) PeerInfo(ctx context.Context) (NodePeerInfo, error) {
+	id, err := tn.NodeID(ctx)
+	if err != nil {
+		return NodePeerInfo{}, err
+	}
+
+	p2p, err := tn.p2pConfig(ctx)
+	if err != nil {
+		return NodePeerInfo{}, err
+	}
+	port, err := p2p.listenPort()
+	if err != nil {
+		return NodePeerInfo{}, err
+	}
+
+	netInfo, err := tn.Client.NetInfo(ctx)
+	if err != nil {
+		return NodePeerInfo{}, fmt.Errorf("tendermint rpc client net info: %w", err)
+	}
+	connected := make([]string, len(netInfo.Peers))
+	for i, p := range netInfo.Peers {
+		connected[i] = string(p.NodeInfo.ID())
+	}
+
+	return NodePeerInfo{
+		Name:            tn.Name(),
+		NodeID:          id,
+		P2PAddress:      fmt.Sprintf("%s@%s", id, net.JoinHostPort(tn.HostName(), port)),
+		HostP2PAddress:  tn.hostP2PPort,
+		PersistentPeers: splitPeers(p2p.PersistentPeers),
+		ConnectedPeers:  connected,
+	}, nil
+}
+
+// p2pConfig is the p2p section of a node's config.toml.
+type p2pConfig struct {
+	ListenAddress   string `toml:"laddr"`
+	PersistentPeers string `toml:"persistent_peers"`
+}
+
+// p2pConfig reads the p2p section of the node's config.toml.
+func (tn *ChainNode) p2pConfig(ctx context.Context) (p2pConfig, error) {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	bz, err := fr.SingleFileContent(ctx, tn.VolumeName, "config/config.toml")
+	if err != nil {
+		return p2pConfig{}, fmt.Errorf("getting config.toml content: %w", err)
+	}
+	return parseP2PConfig(bz)
+}
+
+func parseP2PConfig(bz []byte) (p2pConfig, error) {
+	var cfg struct {
+		P2P p2pConfig `toml:"p2p"`
+	}
+	if err := toml.Unmarshal(bz, &cfg); err != nil {
+		return p2pConfig{}, fmt.Errorf("unmarshaling config.toml: %w", err)
+	}
+	return cfg.P2P, nil
+}
+
+// listenPort returns the port of the P2P listen address, e.g. 26656 for tcp://0.0.0.0:26656.
+// Without a listen address, Tendermint's default port, which is the one exposed by the node's container, is returned.
+func (cfg p2pConfig) listenPort() (string, error) {
+	if cfg.ListenAddress == "" {
+		return nat.Port(p2pPort).Port(), nil
+	}
+	addr := cfg.ListenAddress
+	if _, rest, ok := strings.Cut(addr, "://"); ok {
+		addr = rest
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid p2p laddr %q: %w", cfg.ListenAddress, err)
+	}
+	return port, nil
+}
+
+// splitPeers splits a comma-separated peer list, dropping empty entries.
+func splitPeers(peers string) []string {
+	var out []string
+	for _, p := range strings.Split(peers, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPeers(t *testing.T) {
+	require.Empty(t, splitPeers(""))
+	require.Equal(t,
+		[]string{"abc@val-0:26656", "def@val-1:26656"},
+		splitPeers("abc@val-0:26656, def@val-1:26656,"),
+	)
+}
+
+func TestParseP2PConfig(t *testing.T) {
+	cfg, err := parseP2PConfig([]byte(`
+[rpc]
+laddr = "tcp://0.0.0.0:26657"
+
+[p2p]
+laddr = "tcp://0.0.0.0:36656"
+persistent_peers = "abc@val-0:36656,def@val-1:36656"
+`))
+	require.NoError(t, err)
+	require.Equal(t, p2pConfig{
+		ListenAddress:   "tcp://0.0.0.0:36656",
+		PersistentPeers: "abc@val-0:36656,def@val-1:36656",
+	}, cfg)
+
+	_, err = parseP2PConfig([]byte(`[p2p`))
+	require.ErrorContains(t, err, "unmarshaling config.toml")
+}
+
+func TestP2PConfig_ListenPort(t *testing.T) {
+	for _, tt := range []struct {
+		laddr string
+		want  string
+	}{
+		{laddr: "tcp://0.0.0.0:26656", want: "26656"},
+		{laddr: "tcp://0.0.0.0:36656", want: "36656"},
+		{laddr: "0.0.0.0:36656", want: "36656"},
+		{laddr: "", want: "26656"},
+	} {
+		port, err := p2pConfig{ListenAddress: tt.laddr}.listenPort()
+		require.NoError(t, err, tt.laddr)
+		require.Equal(t, tt.want, port, tt.laddr)
+	}
+
+	_, err := p2pConfig{ListenAddress: "tcp://0.0.0.0"}.listenPort()
+	require.ErrorContains(t, err, `invalid p2p laddr "tcp://0.0.0.0"`)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
@@ -11,6 +12,8 @@ import (
 )
 
 // PollForProposalStatus attempts to find a proposal with matching ID and status.
+// If the proposal never reaches the status by maxHeight,
+// the error reports the last observed status, tally, and deposit.
 func PollForProposalStatus(ctx context.Context, chain *CosmosChain, startHeight, maxHeight uint64, proposalID string, status string) (ProposalResponse, error) {
 	var zero ProposalResponse
 	doPoll := func(ctx context.Context, height uint64) (ProposalResponse, error) {
@@ -19,7 +22,14 @@ func PollForProposalStatus(ctx context.Context, chain *CosmosChain, startHeight,
 			return zero, err
 		}
 		if p.Status != status {
-			return zero, fmt.Errorf("proposal status (%s) does not match expected: (%s)", p.Status, status)
+			tally := p.FinalTallyResult
+			// The final tally is only recorded once voting ends, so report the running tally instead.
+			if p.Status == ProposalStatusVotingPeriod {
+				if t, err := chain.QueryProposalTally(ctx, proposalID); err == nil {
+					tally = t
+				}
+			}
+			return zero, proposalStatusError(*p, tally, status)
 		}
 		return *p, nil
 	}
@@ -27,6 +37,19 @@ func PollForProposalStatus(ctx context.Context, chain *CosmosChain, startHeight,
 	return bp.DoPoll(ctx, startHeight, maxHeight)
 }
 
+func proposalStatusError(p ProposalResponse, tally ProposalFinalTallyResult, want string) error {
+	deposit := make([]string, len(p.TotalDeposit))
+	for i, d := range p.TotalDeposit {
+		deposit[i] = d.Amount + d.Denom
+	}
+	return fmt.Errorf(
+		"proposal %s status (%s) does not match expected: (%s); tally yes=%s no=%s abstain=%s no_with_veto=%s; deposit [%s]",
+		p.ProposalID, p.Status, want,
+		tally.Yes, tally.No, tally.Abstain, tally.NoWithVeto,
+		strings.Join(deposit, ","),
+	)
+}
+
 // PollForMessage searches every transaction for a message. Must pass a coded registry capable of decoding the cosmos transaction.
 // fn is optional. Return true from the fn to stop polling and return the found message. If fn is nil, returns the first message to match type T.
 func PollForMessage[T any](ctx context.Context, chain *CosmosChain, registry codectypes.InterfaceRegistry, startHeight, maxHeight uint64, fn func(found T) bool) (T, error) {
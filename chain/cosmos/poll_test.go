@@ -0,0 +1,22 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalStatusError(t *testing.T) {
+	p := ProposalResponse{
+		ProposalID:   "3",
+		Status:       ProposalStatusVotingPeriod,
+		TotalDeposit: []ProposalDeposit{{Denom: "uatom", Amount: "500"}},
+	}
+	tally := ProposalFinalTallyResult{Yes: "10", No: "2", Abstain: "0", NoWithVeto: "1"}
+
+	err := proposalStatusError(p, tally, ProposalStatusPassed)
+	require.EqualError(t, err,
+		"proposal 3 status (PROPOSAL_STATUS_VOTING_PERIOD) does not match expected: (PROPOSAL_STATUS_PASSED); "+
+			"tally yes=10 no=2 abstain=0 no_with_veto=1; deposit [500uatom]",
+	)
+}
@@ -0,0 +1,88 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// JailValidator stops the container of the validator at index i, then waits up to maxBlocks blocks
+// for the slashing module to jail it for downtime. It returns the validator as last seen in the jailed state.
+//
+// For this to finish quickly, configure a small signed blocks window, e.g. with ChainSpec.SignedBlocksWindow.
+// The chain keeps producing blocks only if the remaining bonded validators hold more than two thirds of the voting power,
+// which JailValidator checks before stopping the validator; e.g. with equal stakes, at least four validators are needed.
+// The validator's container is left stopped; restart it with StartContainer.
+func (c *CosmosChain) JailValidator(ctx context.Context, i int, maxBlocks uint64) (ibc.Validator, error) {
+	var zero ibc.Validator
+	if i < 0 || i >= len(c.Validators) {
+		return zero, fmt.Errorf("validator index %d out of range for %d validators", i, len(c.Validators))
+	}
+	val := c.Validators[i]
+	if val == c.getFullNode() {
+		return zero, fmt.Errorf("validator %d serves the chain's queries; add a full node to jail it", i)
+	}
+
+	operator, err := val.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	validators, err := c.QueryValidators(ctx)
+	if err != nil {
+		return zero, err
+	}
+	if err := checkLivenessWithout(validators, operator); err != nil {
+		return zero, err
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return zero, fmt.Errorf("failed to stop validator %d: %w", i, err)
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return zero, err
+	}
+	poll := func(ctx context.Context, _ uint64) (ibc.Validator, error) {
+		validators, err := c.QueryValidators(ctx)
+		if err != nil {
+			return zero, err
+		}
+		for _, v := range validators {
+			if v.OperatorAddress != operator {
+				continue
+			}
+			if !v.Jailed {
+				return zero, fmt.Errorf("validator %s is not jailed (status %s)", operator, v.Status)
+			}
+			return v, nil
+		}
+		return zero, fmt.Errorf("validator %s not found", operator)
+	}
+	bp := testutil.BlockPoller[ibc.Validator]{CurrentHeight: c.Height, PollFunc: poll}
+	return bp.DoPoll(ctx, height, height+maxBlocks)
+}
+
+// checkLivenessWithout returns an error if the bonded validators other than operator
+// hold at most two thirds of the bonded tokens, in which case the chain halts once operator stops signing.
+func checkLivenessWithout(validators []ibc.Validator, operator string) error {
+	total, remaining := sdk.ZeroInt(), sdk.ZeroInt()
+	for _, v := range validators {
+		if v.Status != stakingtypes.Bonded.String() {
+			continue
+		}
+		total = total.Add(v.Tokens)
+		if v.OperatorAddress != operator {
+			remaining = remaining.Add(v.Tokens)
+		}
+	}
+	if remaining.MulRaw(3).LTE(total.MulRaw(2)) {
+		return fmt.Errorf("remaining validators hold %s of %s bonded tokens, so the chain would halt without %s", remaining, total, operator)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckLivenessWithout(t *testing.T) {
+	bonded := func(operator string, tokens int64) ibc.Validator {
+		return ibc.Validator{OperatorAddress: operator, Status: "BOND_STATUS_BONDED", Tokens: sdk.NewInt(tokens)}
+	}
+
+	// Two equal validators cannot lose one.
+	require.ErrorContains(t, checkLivenessWithout([]ibc.Validator{bonded("a", 10), bonded("b", 10)}, "a"), "would halt")
+
+	// Exactly two thirds remaining is not enough either.
+	require.Error(t, checkLivenessWithout([]ibc.Validator{bonded("a", 10), bonded("b", 10), bonded("c", 10)}, "a"))
+
+	require.NoError(t, checkLivenessWithout([]ibc.Validator{bonded("a", 10), bonded("b", 10), bonded("c", 10), bonded("d", 10)}, "a"))
+
+	// Unbonded validators hold no voting power.
+	unbonded := ibc.Validator{OperatorAddress: "e", Status: "BOND_STATUS_UNBONDED", Tokens: sdk.NewInt(1000)}
+	require.NoError(t, checkLivenessWithout([]ibc.Validator{bonded("a", 1), bonded("b", 10), unbonded}, "a"))
+}
+
+func TestJailValidator_Rejected(t *testing.T) {
+	c := NewCosmosChain("TestJailValidator", ibc.ChainConfig{ChainID: "foo-1", CoinType: "118"}, 2, 0, zap.NewNop())
+	c.Validators = ChainNodes{{Index: 0}, {Index: 1}}
+
+	_, err := c.JailValidator(context.Background(), 2, 10)
+	require.EqualError(t, err, "validator index 2 out of range for 2 validators")
+
+	// Without full nodes, the first validator serves the queries that watch for the jailing.
+	_, err = c.JailValidator(context.Background(), 0, 10)
+	require.ErrorContains(t, err, "serves the chain's queries")
+}
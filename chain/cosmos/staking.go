@@ -0,0 +1,116 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// QueryValidators returns every validator known to the staking module, regardless of bond status.
+func (c *CosmosChain) QueryValidators(ctx context.Context) ([]ibc.Validator, error) {
+	var out []ibc.Validator
+	err := c.stakingQuery(func(qc stakingtypes.QueryClient, page *query.PageRequest) (*query.PageResponse, error) {
+		res, err := qc.Validators(ctx, &stakingtypes.QueryValidatorsRequest{Pagination: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query validators: %w", err)
+		}
+		for _, v := range res.Validators {
+			out = append(out, ibc.Validator{
+				OperatorAddress: v.OperatorAddress,
+				Moniker:         v.Description.Moniker,
+				Status:          v.Status.String(),
+				Jailed:          v.Jailed,
+				Tokens:          v.Tokens,
+				DelegatorShares: v.DelegatorShares,
+			})
+		}
+		return res.Pagination, nil
+	})
+	return out, err
+}
+
+// QueryDelegations returns the delegations of the delegator with each validator.
+func (c *CosmosChain) QueryDelegations(ctx context.Context, delegator string) ([]ibc.Delegation, error) {
+	var out []ibc.Delegation
+	err := c.stakingQuery(func(qc stakingtypes.QueryClient, page *query.PageRequest) (*query.PageResponse, error) {
+		res, err := qc.DelegatorDelegations(ctx, &stakingtypes.QueryDelegatorDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    page,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query delegations of %s: %w", delegator, err)
+		}
+		for _, d := range res.DelegationResponses {
+			out = append(out, ibc.Delegation{
+				DelegatorAddress: d.Delegation.DelegatorAddress,
+				ValidatorAddress: d.Delegation.ValidatorAddress,
+				Shares:           d.Delegation.Shares,
+				Balance:          d.Balance,
+			})
+		}
+		return res.Pagination, nil
+	})
+	return out, err
+}
+
+// QueryUnbonding returns the delegator's unbonding delegations that have not completed yet.
+func (c *CosmosChain) QueryUnbonding(ctx context.Context, delegator string) ([]ibc.UnbondingDelegation, error) {
+	var out []ibc.UnbondingDelegation
+	err := c.stakingQuery(func(qc stakingtypes.QueryClient, page *query.PageRequest) (*query.PageResponse, error) {
+		res, err := qc.DelegatorUnbondingDelegations(ctx, &stakingtypes.QueryDelegatorUnbondingDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    page,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query unbonding delegations of %s: %w", delegator, err)
+		}
+		for _, u := range res.UnbondingResponses {
+			out = append(out, unbondingDelegation(u))
+		}
+		return res.Pagination, nil
+	})
+	return out, err
+}
+
+func unbondingDelegation(u stakingtypes.UnbondingDelegation) ibc.UnbondingDelegation {
+	out := ibc.UnbondingDelegation{
+		DelegatorAddress: u.DelegatorAddress,
+		ValidatorAddress: u.ValidatorAddress,
+		Entries:          make([]ibc.UnbondingDelegationEntry, len(u.Entries)),
+	}
+	for i, e := range u.Entries {
+		out.Entries[i] = ibc.UnbondingDelegationEntry{
+			CreationHeight: e.CreationHeight,
+			CompletionTime: e.CompletionTime,
+			InitialBalance: e.InitialBalance,
+			Balance:        e.Balance,
+		}
+	}
+	return out
+}
+
+// stakingQuery dials the chain's gRPC endpoint and calls f once per page of results,
+// until f returns a response without a next key.
+func (c *CosmosChain) stakingQuery(f func(stakingtypes.QueryClient, *query.PageRequest) (*query.PageResponse, error)) error {
+	conn, err := c.dialGRPC(c.GetHostGRPCAddress())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+	page := &query.PageRequest{}
+	for {
+		res, err := f(queryClient, page)
+		if err != nil {
+			return err
+		}
+		if res == nil || len(res.NextKey) == 0 {
+			return nil
+		}
+		page = &query.PageRequest{Key: res.NextKey}
+	}
+}
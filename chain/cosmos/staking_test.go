@@ -0,0 +1,30 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbondingDelegation(t *testing.T) {
+	completion := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	got := unbondingDelegation(stakingtypes.UnbondingDelegation{
+		DelegatorAddress: "cosmos1delegator",
+		ValidatorAddress: "cosmosvaloper1validator",
+		Entries: []stakingtypes.UnbondingDelegationEntry{
+			{CreationHeight: 10, CompletionTime: completion, InitialBalance: sdk.NewInt(100), Balance: sdk.NewInt(95)},
+		},
+	})
+
+	require.Equal(t, ibc.UnbondingDelegation{
+		DelegatorAddress: "cosmos1delegator",
+		ValidatorAddress: "cosmosvaloper1validator",
+		Entries: []ibc.UnbondingDelegationEntry{
+			{CreationHeight: 10, CompletionTime: completion, InitialBalance: sdk.NewInt(100), Balance: sdk.NewInt(95)},
+		},
+	}, got)
+}
@@ -0,0 +1,102 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// applyTemplates merges the chain config's templates over the genesis and config files generated by init,
+// before the harness applies its own settings to them.
+func (tn *ChainNode) applyTemplates(ctx context.Context) error {
+	cfg := tn.Chain.Config()
+	templates := cfg.Templates
+
+	for _, f := range []struct{ template, relPath string }{
+		{templates.AppToml, "config/app.toml"},
+		{templates.ConfigToml, "config/config.toml"},
+	} {
+		if f.template == "" {
+			continue
+		}
+		bz, err := os.ReadFile(f.template)
+		if err != nil {
+			return fmt.Errorf("failed to read template of %s: %w", f.relPath, err)
+		}
+		var t testutil.Toml
+		if err := toml.Unmarshal(bz, &t); err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", f.template, err)
+		}
+		if err := testutil.ModifyTomlConfigFile(ctx, tn.logger(), tn.DockerClient, tn.TestName, tn.VolumeName, f.relPath, t); err != nil {
+			return err
+		}
+	}
+
+	if templates.Genesis == "" {
+		return nil
+	}
+	template, err := os.ReadFile(templates.Genesis)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis template: %w", err)
+	}
+	genbz, err := tn.genesisFileContent(ctx)
+	if err != nil {
+		return err
+	}
+	genbz, err = mergeGenesisTemplate(genbz, template, cfg.ChainID)
+	if err != nil {
+		return fmt.Errorf("genesis template %s: %w", templates.Genesis, err)
+	}
+	return tn.overwriteGenesisFile(ctx, genbz)
+}
+
+// mergeGenesisTemplate returns the genesis file genbz with template deep-merged over it,
+// keeping the chain ID of the chain being started.
+// Objects are merged member by member, while any other value of template, including arrays, replaces that of genbz.
+func mergeGenesisTemplate(genbz, template []byte, chainID string) ([]byte, error) {
+	g, err := decodeGenesisJSON(genbz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	t, err := decodeGenesisJSON(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template: %w", err)
+	}
+
+	mergeJSONObject(g, t)
+	g["chain_id"] = chainID
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// decodeGenesisJSON decodes the JSON object bz keeping numbers as json.Number, so large amounts are not rounded.
+func decodeGenesisJSON(bz []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var g map[string]interface{}
+	if err := dec.Decode(&g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// mergeJSONObject merges src into dst, recursing into the objects present in both.
+func mergeJSONObject(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcObj, ok := v.(map[string]interface{})
+		if dstObj, dstOK := dst[k].(map[string]interface{}); ok && dstOK {
+			mergeJSONObject(dstObj, srcObj)
+			continue
+		}
+		dst[k] = v
+	}
+}
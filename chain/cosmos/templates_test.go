@@ -0,0 +1,52 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeGenesisTemplate(t *testing.T) {
+	const genesis = `{
+		"chain_id": "gaia-1",
+		"genesis_time": "2022-10-01T00:00:00Z",
+		"app_state": {
+			"bank": {"balances": [], "supply": []},
+			"gov": {"voting_params": {"voting_period": "172800s"}, "deposit_params": {"max_deposit_period": "172800s"}},
+			"staking": {"params": {"bond_denom": "stake"}}
+		}
+	}`
+	const template = `{
+		"chain_id": "cosmoshub-4",
+		"app_state": {
+			"bank": {"balances": [{"address": "cosmos1abc", "coins": [{"denom": "uatom", "amount": "100000000000000000000"}]}]},
+			"gov": {"voting_params": {"voting_period": "10s"}},
+			"mint": {"params": {"mint_denom": "uatom"}}
+		}
+	}`
+
+	out, err := mergeGenesisTemplate([]byte(genesis), []byte(template), "gaia-1")
+	require.NoError(t, err)
+
+	require.JSONEq(t, `{
+		"chain_id": "gaia-1",
+		"genesis_time": "2022-10-01T00:00:00Z",
+		"app_state": {
+			"bank": {"balances": [{"address": "cosmos1abc", "coins": [{"denom": "uatom", "amount": "100000000000000000000"}]}], "supply": []},
+			"gov": {"voting_params": {"voting_period": "10s"}, "deposit_params": {"max_deposit_period": "172800s"}},
+			"staking": {"params": {"bond_denom": "stake"}},
+			"mint": {"params": {"mint_denom": "uatom"}}
+		}
+	}`, string(out))
+
+	// Large numbers are kept as they are.
+	out, err = mergeGenesisTemplate([]byte(`{"initial_height": 1}`), []byte(`{"max": 123456789012345678901234567890}`), "gaia-1")
+	require.NoError(t, err)
+	var g map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(out, &g))
+	require.Equal(t, "123456789012345678901234567890", string(g["max"]))
+
+	_, err = mergeGenesisTemplate([]byte(genesis), []byte(`[]`), "gaia-1")
+	require.ErrorContains(t, err, "failed to unmarshal template")
+}
@@ -0,0 +1,101 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// DefaultTxConfirmationTimeout is how long a transaction helper waits for its transaction to be committed
+// when the chain config has no TxConfirmationTimeout.
+const DefaultTxConfirmationTimeout = 2 * time.Minute
+
+// ErrTxConfirmationTimeout is wrapped by the error returned when a transaction is not committed
+// within the chain's tx confirmation timeout. The error names the hash of the transaction, if it was broadcast,
+// as such a transaction may still be committed later.
+var ErrTxConfirmationTimeout = errors.New("tx confirmation timeout")
+
+// txConfirmationTimeout returns the TxConfirmationTimeout of the chain config, or the default if it is empty.
+func txConfirmationTimeout(cfg ibc.ChainConfig) (time.Duration, error) {
+	s := cfg.TxConfirmationTimeout
+	if s == "" {
+		return DefaultTxConfirmationTimeout, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tx confirmation timeout %q: %w", s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid tx confirmation timeout %q: must be positive", s)
+	}
+	return d, nil
+}
+
+// withTxConfirmationTimeout calls fn, which broadcasts a transaction and waits for it to be committed,
+// with a context that is done after the chain's tx confirmation timeout.
+// If the timeout elapses first, the error of fn is wrapped with ErrTxConfirmationTimeout and the hash returned by fn.
+func withTxConfirmationTimeout(ctx context.Context, cfg ibc.ChainConfig, fn func(ctx context.Context) (string, error)) (string, error) {
+	timeout, err := txConfirmationTimeout(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	txHash, err := fn(tctx)
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return txHash, txConfirmationTimeoutError(txHash, timeout, err)
+	}
+	return txHash, err
+}
+
+// txConfirmationTimeoutError returns the error for a transaction not committed within timeout.
+func txConfirmationTimeoutError(txHash string, timeout time.Duration, err error) error {
+	if txHash == "" {
+		return fmt.Errorf("%w: tx not broadcast within %s: %v", ErrTxConfirmationTimeout, timeout, err)
+	}
+	return fmt.Errorf("%w: tx %s not committed within %s: %v", ErrTxConfirmationTimeout, txHash, timeout, err)
+}
+
+// hashRecordingTxConfig records the hash of the last transaction it encodes,
+// i.e. the one broadcast by tx.BroadcastTx, so a timed out broadcast can name its transaction.
+// Once ctx is done it refuses to encode, so that a timed out tx.BroadcastTx does not go on to send its transaction.
+type hashRecordingTxConfig struct {
+	client.TxConfig
+
+	ctx context.Context
+
+	mu   sync.Mutex
+	hash string
+}
+
+func (c *hashRecordingTxConfig) TxEncoder() sdk.TxEncoder {
+	encode := c.TxConfig.TxEncoder()
+	return func(tx sdk.Tx) ([]byte, error) {
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+		bz, err := encode(tx)
+		if err == nil {
+			c.mu.Lock()
+			c.hash = fmt.Sprintf("%X", tmtypes.Tx(bz).Hash())
+			c.mu.Unlock()
+		}
+		return bz, err
+	}
+}
+
+// TxHash returns the hash of the last encoded transaction, or an empty string if none was encoded yet.
+func (c *hashRecordingTxConfig) TxHash() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hash
+}
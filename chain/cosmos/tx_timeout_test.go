@@ -0,0 +1,226 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestTxConfirmationTimeout(t *testing.T) {
+	t.Parallel()
+
+	d, err := txConfirmationTimeout(ibc.ChainConfig{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultTxConfirmationTimeout, d)
+
+	d, err = txConfirmationTimeout(ibc.ChainConfig{TxConfirmationTimeout: "30s"})
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, d)
+
+	_, err = txConfirmationTimeout(ibc.ChainConfig{TxConfirmationTimeout: "soon"})
+	require.ErrorContains(t, err, "invalid tx confirmation timeout")
+
+	_, err = txConfirmationTimeout(ibc.ChainConfig{TxConfirmationTimeout: "-1s"})
+	require.ErrorContains(t, err, "must be positive")
+}
+
+func TestWithTxConfirmationTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := ibc.ChainConfig{TxConfirmationTimeout: "10ms"}
+
+	t.Run("timeout", func(t *testing.T) {
+		txHash, err := withTxConfirmationTimeout(context.Background(), cfg, func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "ABCD", ctx.Err()
+		})
+		require.Equal(t, "ABCD", txHash)
+		require.True(t, errors.Is(err, ErrTxConfirmationTimeout), "unexpected error: %v", err)
+		require.ErrorContains(t, err, "tx ABCD not committed within 10ms")
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		_, err := withTxConfirmationTimeout(context.Background(), cfg, func(ctx context.Context) (string, error) {
+			return "", errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+
+	t.Run("caller cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := withTxConfirmationTimeout(ctx, cfg, func(ctx context.Context) (string, error) {
+			return "", ctx.Err()
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.False(t, errors.Is(err, ErrTxConfirmationTimeout))
+	})
+}
+
+func TestHashRecordingTxConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	txConfig := &hashRecordingTxConfig{TxConfig: DefaultEncoding().TxConfig, ctx: ctx}
+	require.Empty(t, txConfig.TxHash())
+
+	b := txConfig.NewTxBuilder()
+	require.NoError(t, b.SetMsgs(&banktypes.MsgSend{FromAddress: "a", ToAddress: "b"}))
+	bz, err := txConfig.TxEncoder()(b.GetTx())
+	require.NoError(t, err)
+	hash := fmt.Sprintf("%X", tmtypes.Tx(bz).Hash())
+	require.Equal(t, hash, txConfig.TxHash())
+
+	// Nothing is encoded, hence broadcast, once the context is done.
+	cancel()
+	b.SetMemo("late")
+	_, err = txConfig.TxEncoder()(b.GetTx())
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, hash, txConfig.TxHash())
+}
+
+// blockingBroadcastClient is a node whose first BroadcastTxSync blocks until release is closed;
+// its other methods are unimplemented.
+type blockingBroadcastClient struct {
+	rpcclient.Client
+
+	release       chan struct{}
+	calls         int32
+	firstReturned chan struct{}
+}
+
+func (c *blockingBroadcastClient) BroadcastTxSync(_ context.Context, tx tmtypes.Tx) (*coretypes.ResultBroadcastTx, error) {
+	if atomic.AddInt32(&c.calls, 1) == 1 {
+		<-c.release
+		defer close(c.firstReturned)
+	}
+	return &coretypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func TestBroadcastWithTimeout_AfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	enc := DefaultEncoding()
+	kr := keyring.NewInMemory(enc.Codec)
+	record, _, err := kr.NewMnemonic("alice", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	addr, err := record.GetAddress()
+	require.NoError(t, err)
+
+	node := &blockingBroadcastClient{release: make(chan struct{}), firstReturned: make(chan struct{})}
+	// Every broadcast shares the output of the client context, like those of a Broadcaster.
+	var shared bytes.Buffer
+	cc := client.Context{}.
+		WithCodec(enc.Codec).
+		WithTxConfig(enc.TxConfig).
+		WithClient(node).
+		WithChainID("foo-1").
+		WithKeyring(kr).
+		WithFromName("alice").
+		WithFromAddress(addr).
+		WithSkipConfirmation(true).
+		WithBroadcastMode(string(BroadcastSync)).
+		WithOutput(&shared)
+	f := tx.Factory{}.
+		WithTxConfig(enc.TxConfig).
+		WithKeybase(kr).
+		WithAccountRetriever(client.TestAccountRetriever{Accounts: map[string]client.TestAccount{
+			addr.String(): {Address: addr, Num: 1, Seq: 1},
+		}}).
+		WithChainID("foo-1").
+		WithAccountNumber(1).
+		WithSequence(1).
+		WithGas(200_000).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+	msg := banktypes.NewMsgSend(addr, addr, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)))
+
+	_, err = broadcastWithTimeout(context.Background(), ibc.ChainConfig{TxConfirmationTimeout: "10ms"}, cc, f, msg)
+	require.True(t, errors.Is(err, ErrTxConfirmationTimeout), "unexpected error: %v", err)
+
+	// The timed out broadcast completes and writes its response while the next one runs.
+	close(node.release)
+	<-node.firstReturned
+	out, err := broadcastWithTimeout(context.Background(), ibc.ChainConfig{}, cc, f.WithSequence(2), msg)
+	require.NoError(t, err)
+
+	var resp sdk.TxResponse
+	require.NoError(t, enc.Codec.UnmarshalJSON(out, &resp))
+	require.NotEmpty(t, resp.TxHash)
+	require.Zero(t, shared.Len(), "broadcasts must not write to the output of the client context")
+}
+
+// blockingSignKeyring is a keyring whose Sign blocks until release is closed.
+type blockingSignKeyring struct {
+	keyring.Keyring
+
+	release chan struct{}
+}
+
+func (k *blockingSignKeyring) Sign(uid string, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	<-k.release
+	return k.Keyring.Sign(uid, msg)
+}
+
+func TestBroadcastWithTimeout_NotSentAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	enc := DefaultEncoding()
+	kr := keyring.NewInMemory(enc.Codec)
+	record, _, err := kr.NewMnemonic("alice", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	addr, err := record.GetAddress()
+	require.NoError(t, err)
+
+	node := &blockingBroadcastClient{release: make(chan struct{}), firstReturned: make(chan struct{})}
+	close(node.release)
+	blocking := &blockingSignKeyring{Keyring: kr, release: make(chan struct{})}
+	cc := client.Context{}.
+		WithCodec(enc.Codec).
+		WithTxConfig(enc.TxConfig).
+		WithClient(node).
+		WithChainID("foo-1").
+		WithKeyring(blocking).
+		WithFromName("alice").
+		WithFromAddress(addr).
+		WithSkipConfirmation(true).
+		WithBroadcastMode(string(BroadcastSync))
+	f := tx.Factory{}.
+		WithTxConfig(enc.TxConfig).
+		WithKeybase(blocking).
+		WithAccountRetriever(client.TestAccountRetriever{Accounts: map[string]client.TestAccount{
+			addr.String(): {Address: addr, Num: 1, Seq: 1},
+		}}).
+		WithChainID("foo-1").
+		WithAccountNumber(1).
+		WithSequence(1).
+		WithGas(200_000).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+	msg := banktypes.NewMsgSend(addr, addr, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)))
+
+	// Signing outlasts the timeout, so the transaction is never sent.
+	_, err = broadcastWithTimeout(context.Background(), ibc.ChainConfig{TxConfirmationTimeout: "10ms"}, cc, f, msg)
+	require.True(t, errors.Is(err, ErrTxConfirmationTimeout), "unexpected error: %v", err)
+
+	close(blocking.release)
+	require.Never(t, func() bool {
+		return atomic.LoadInt32(&node.calls) > 0
+	}, 200*time.Millisecond, 10*time.Millisecond, "timed out transaction was broadcast")
+}
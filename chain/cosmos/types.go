@@ -39,6 +39,14 @@ type TextProposal struct {
 	Expedited   bool
 }
 
+// PinCodesProposal is a governance proposal to pin wasm codes in the chain's in-memory cache.
+type PinCodesProposal struct {
+	Deposit     string
+	Title       string
+	Description string
+	CodeIDs     []string
+}
+
 // SoftwareUpgradeProposal defines the required and optional parameters for submitting a software-upgrade proposal.
 type SoftwareUpgradeProposal struct {
 	Deposit     string
@@ -0,0 +1,87 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+)
+
+// validatorJoinBlocks is the number of blocks JoinValidatorSet waits for the new validator to gain voting power.
+const validatorJoinBlocks = 10
+
+// CreateValidator submits a create-validator transaction for the node's consensus key,
+// self-delegating selfDelegation from the node's validator key.
+func (tn *ChainNode) CreateValidator(ctx context.Context, selfDelegation types.Coin) error {
+	pubKey, _, err := tn.ExecBin(ctx, "tendermint", "show-validator")
+	if err != nil {
+		return fmt.Errorf("failed to get consensus pubkey: %w", err)
+	}
+
+	_, err = tn.ExecTx(ctx, valKey,
+		"staking", "create-validator",
+		"--amount", selfDelegation.String(),
+		"--pubkey", strings.TrimSpace(string(pubKey)),
+		"--moniker", tn.Name(),
+		"--commission-rate", "0.1",
+		"--commission-max-rate", "0.2",
+		"--commission-max-change-rate", "0.01",
+		"--min-self-delegation", "1",
+	)
+	return err
+}
+
+// JoinValidatorSet turns a running full node of the chain into a validator after genesis.
+// It funds a new validator key on fullNode from funderKeyName with selfDelegation plus feeBuffer,
+// submits a create-validator transaction, and returns once the node reports non-zero voting power,
+// i.e. it is bonded and expected to sign blocks.
+// The node stays in FullNodes, since its container name and hostname are derived from its node type.
+func (c *CosmosChain) JoinValidatorSet(ctx context.Context, fullNode *ChainNode, funderKeyName string, selfDelegation, feeBuffer int64) error {
+	found := false
+	for _, n := range c.FullNodes {
+		if n == fullNode {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("node %s is not a full node of chain %s", fullNode.Name(), c.cfg.ChainID)
+	}
+
+	if err := fullNode.CreateKey(ctx, valKey); err != nil {
+		return fmt.Errorf("failed to create validator key: %w", err)
+	}
+	valAddr, err := fullNode.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return fmt.Errorf("failed to get validator key address: %w", err)
+	}
+
+	if err := c.SendFunds(ctx, funderKeyName, ibc.WalletAmount{
+		Address: valAddr,
+		Denom:   c.cfg.Denom,
+		Amount:  selfDelegation + feeBuffer,
+	}); err != nil {
+		return fmt.Errorf("failed to fund validator key: %w", err)
+	}
+
+	if err := fullNode.CreateValidator(ctx, types.NewInt64Coin(c.cfg.Denom, selfDelegation)); err != nil {
+		return fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	for i := 0; i < validatorJoinBlocks; i++ {
+		stat, err := fullNode.Client.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("tendermint rpc client status: %w", err)
+		}
+		if stat.ValidatorInfo.VotingPower > 0 {
+			return nil
+		}
+
+		if err := testutil.WaitForBlocks(ctx, 1, fullNode); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("node %s has no voting power %d blocks after creating its validator", fullNode.Name(), validatorJoinBlocks)
+}
@@ -1,6 +1,10 @@
 package cosmos
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 )
@@ -32,7 +36,13 @@ func (w *CosmosWallet) FormattedAddress() string {
 	return types.MustBech32ifyAddressBytes(w.chainCfg.Bech32Prefix, w.address)
 }
 
-// Get mnemonic, only used for relayer wallets
+// FormattedValidatorAddress returns the address of the validator operated by the wallet,
+// using the chain's validator bech32 prefix.
+func (w *CosmosWallet) FormattedValidatorAddress() string {
+	return types.MustBech32ifyAddressBytes(w.chainCfg.ValidatorBech32Prefix(), w.address)
+}
+
+// Get mnemonic the key was derived from
 func (w *CosmosWallet) Mnemonic() string {
 	return w.mnemonic
 }
@@ -42,6 +52,27 @@ func (w *CosmosWallet) Address() []byte {
 	return w.address
 }
 
+// HexAddress returns the EIP-55 checksummed, 0x-prefixed hex encoding of the address,
+// as used by the EVM of Ethermint-based chains.
+func (w *CosmosWallet) HexAddress() string {
+	return checksumHex(w.address)
+}
+
 func (w *CosmosWallet) FormattedAddressWithPrefix(prefix string) string {
 	return types.MustBech32ifyAddressBytes(prefix, w.address)
 }
+
+// PrivateKey derives the secp256k1 private key bytes from the wallet's mnemonic.
+// Test wallets only; never use this with keys holding real funds.
+func (w *CosmosWallet) PrivateKey() ([]byte, error) {
+	if w.mnemonic == "" {
+		return nil, fmt.Errorf("wallet %q has no mnemonic", w.keyName)
+	}
+
+	coinType, err := strconv.ParseUint(w.chainCfg.CoinType, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type: %w", err)
+	}
+
+	return hd.Secp256k1.Derive()(w.mnemonic, "", hd.CreateHDPath(uint32(coinType), 0, 0).String())
+}
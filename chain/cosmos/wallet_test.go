@@ -0,0 +1,62 @@
+package cosmos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosWallet_PrivateKey(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	cfg := ibc.ChainConfig{Bech32Prefix: "cosmos", CoinType: "118"}
+
+	// Known address for the mnemonic above at m/44'/118'/0'/0/0.
+	const wantAddr = "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4"
+
+	probe := cosmos.NewWallet("user", nil, mnemonic, cfg).(*cosmos.CosmosWallet)
+	priv, err := probe.PrivateKey()
+	require.NoError(t, err)
+
+	pub := (&secp256k1.PrivKey{Key: priv}).PubKey()
+	w := cosmos.NewWallet("user", pub.Address(), mnemonic, cfg)
+	require.Equal(t, wantAddr, w.FormattedAddress())
+
+	_, err = cosmos.NewWallet("empty", nil, "", cfg).(*cosmos.CosmosWallet).PrivateKey()
+	require.Error(t, err)
+}
+
+func TestCosmosWallet_FormattedValidatorAddress(t *testing.T) {
+	addr := make([]byte, 20)
+
+	w := cosmos.NewWallet("val", addr, "", ibc.ChainConfig{Bech32Prefix: "cosmos"}).(*cosmos.CosmosWallet)
+	require.Equal(t, "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw", w.FormattedValidatorAddress())
+
+	w = cosmos.NewWallet("val", addr, "", ibc.ChainConfig{Bech32Prefix: "cosmos", Bech32ValidatorPrefix: "cosmosop"}).(*cosmos.CosmosWallet)
+	require.True(t, strings.HasPrefix(w.FormattedValidatorAddress(), "cosmosop1"))
+
+	require.Equal(t, "cosmosvalcons", ibc.ChainConfig{Bech32Prefix: "cosmos"}.ConsensusBech32Prefix())
+	require.Equal(t, "cvc", ibc.ChainConfig{Bech32Prefix: "cosmos", Bech32ConsensusPrefix: "cvc"}.ConsensusBech32Prefix())
+}
+
+func TestCosmosWallet_PrivateKeyCoinType(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	w118 := cosmos.NewWallet("user", nil, mnemonic, ibc.ChainConfig{CoinType: "118"}).(*cosmos.CosmosWallet)
+	w60 := cosmos.NewWallet("user", nil, mnemonic, ibc.ChainConfig{CoinType: "60"}).(*cosmos.CosmosWallet)
+
+	k118, err := w118.PrivateKey()
+	require.NoError(t, err)
+	k60, err := w60.PrivateKey()
+	require.NoError(t, err)
+
+	require.NotEqual(t, k118, k60, "coin type must be part of the derivation path")
+}
+
+func TestNewCosmosChain_DefaultCoinType(t *testing.T) {
+	c := cosmos.NewCosmosChain("test", ibc.ChainConfig{}, 1, 0, nil)
+	require.Equal(t, "118", c.Config().CoinType)
+}
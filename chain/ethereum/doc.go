@@ -0,0 +1,3 @@
+// Package ethereum provides an implementation of ibc.Chain for a development Ethereum node,
+// run with anvil from the foundry image, to test IBC bridges to EVM chains.
+package ethereum
@@ -0,0 +1,548 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"go.uber.org/zap"
+)
+
+const (
+	rpcPort = "8545/tcp"
+
+	// defaultChainID is the chain ID of anvil, used when the configured chain ID is not numeric.
+	defaultChainID = 31337
+
+	// defaultBlockTime is the interval between blocks mined by anvil when the chain config has no BlockTime.
+	defaultBlockTime = time.Second
+)
+
+// weiPerGwei converts amounts of the chain's denom, gwei, to the wei used by the JSON-RPC API.
+// Balances in gwei fit an int64, unlike balances in wei.
+var weiPerGwei = big.NewInt(1_000_000_000)
+
+var exposedPorts = nat.PortSet{
+	nat.Port(rpcPort): {},
+}
+
+var _ ibc.Chain = &EthereumChain{}
+
+// EthereumChain is a single anvil development node.
+// Amounts of the chain's denom are gwei.
+//
+// Keys are kept in memory rather than on the node.
+// Funds are sent from any key without signing, by impersonating its address on the node for each send.
+type EthereumChain struct {
+	log      *zap.Logger
+	testName string
+	cfg      ibc.ChainConfig
+
+	DockerClient *client.Client
+	NetworkID    string
+	VolumeName   string
+
+	containerID string
+
+	// Set during Start.
+	hostRPCPort string
+	rpc         *rpcClient
+
+	// mu serializes sent transactions, so the impersonated sender is not stopped by a concurrent send.
+	mu sync.Mutex
+
+	keysMu sync.Mutex
+	keys   map[string][]byte // Addresses by key name.
+}
+
+func NewEthereumChain(log *zap.Logger, testName string, chainConfig ibc.ChainConfig) *EthereumChain {
+	return &EthereumChain{
+		log:      log,
+		testName: testName,
+		cfg:      chainConfig,
+		keys:     make(map[string][]byte),
+	}
+}
+
+// Implements Chain interface
+func (c *EthereumChain) Config() ibc.ChainConfig {
+	return c.cfg
+}
+
+// Logger returns the logger the chain was created with, annotated with the chain ID and test name.
+func (c *EthereumChain) Logger() *zap.Logger {
+	return c.log.With(
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("test", c.testName),
+	)
+}
+
+// Name of the anvil container
+func (c *EthereumChain) Name() string {
+	return fmt.Sprintf("%s-anvil-%s", c.cfg.ChainID, dockerutil.ContainerNameSuffix(c.testName))
+}
+
+// HostName of the anvil container
+func (c *EthereumChain) HostName() string {
+	return dockerutil.CondenseHostName(c.Name())
+}
+
+// Bind returns the home folder bind point for running the node
+func (c *EthereumChain) Bind() []string {
+	return []string{fmt.Sprintf("%s:%s", c.VolumeName, c.HomeDir())}
+}
+
+// Implements Chain interface
+func (c *EthereumChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+	for _, image := range c.cfg.Images {
+		if err := dockerutil.PullImage(ctx, c.log, cli, image); err != nil {
+			c.log.Error("Failed to pull image",
+				zap.Error(err),
+				zap.String("repository", image.Repository),
+				zap.String("tag", image.Version),
+			)
+		}
+	}
+
+	c.DockerClient = cli
+	c.NetworkID = networkID
+
+	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Labels: dockerutil.CleanupLabels(testName, map[string]string{
+			dockerutil.NodeOwnerLabel: c.Name(),
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("creating anvil volume: %w", err)
+	}
+	c.VolumeName = v.Name
+
+	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
+		Log: c.log,
+
+		Client: cli,
+
+		VolumeName: c.VolumeName,
+		ImageRef:   c.cfg.Images[0].Ref(),
+		TestName:   testName,
+		UidGid:     c.cfg.Images[0].UidGid,
+	}); err != nil {
+		return fmt.Errorf("set anvil volume owner: %w", err)
+	}
+	return nil
+}
+
+// startCmd returns the anvil command for the chain config.
+func (c *EthereumChain) startCmd() ([]string, error) {
+	chainID := uint64(defaultChainID)
+	if id, err := strconv.ParseUint(c.cfg.ChainID, 10, 64); err == nil {
+		chainID = id
+	}
+
+	blockTime := defaultBlockTime
+	if c.cfg.BlockTime != "" {
+		d, err := time.ParseDuration(c.cfg.BlockTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block time %q: %w", c.cfg.BlockTime, err)
+		}
+		blockTime = d
+	}
+	// Anvil mines blocks every whole number of seconds.
+	blockSecs := int64((blockTime + time.Second - 1) / time.Second)
+	if blockSecs < 1 {
+		blockSecs = 1
+	}
+
+	bin := c.cfg.Bin
+	if bin == "" {
+		bin = "anvil"
+	}
+	return []string{
+		bin,
+		"--host", "0.0.0.0",
+		"--port", strings.TrimSuffix(rpcPort, "/tcp"),
+		"--chain-id", strconv.FormatUint(chainID, 10),
+		"--mnemonic", devMnemonic,
+		"--block-time", strconv.FormatInt(blockSecs, 10),
+		// Transactions are free, so balances only change by the amounts sent.
+		"--base-fee", "0",
+		"--gas-price", "0",
+	}, nil
+}
+
+// Start runs the anvil node and funds the additional genesis wallets.
+// Implements Chain interface
+func (c *EthereumChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
+	cmd, err := c.startCmd()
+	if err != nil {
+		return err
+	}
+	c.log.Info("Running command", zap.String("command", strings.Join(cmd, " ")), zap.String("container", c.Name()))
+
+	image := c.cfg.Images[0]
+	resources, err := dockerutil.ContainerResources(c.cfg.CPUs, c.cfg.Memory)
+	if err != nil {
+		return err
+	}
+
+	cc, err := c.DockerClient.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: image.Ref(),
+
+			Entrypoint: []string{},
+			Cmd:        cmd,
+
+			Hostname: c.HostName(),
+			User:     image.UidGid,
+
+			Labels: dockerutil.CleanupLabels(c.testName),
+
+			ExposedPorts: exposedPorts,
+		},
+		&container.HostConfig{
+			Binds:           c.Bind(),
+			PublishAllPorts: true,
+			AutoRemove:      false,
+			DNS:             []string{},
+			Resources:       resources,
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				c.NetworkID: {},
+			},
+		},
+		nil,
+		c.Name(),
+	)
+	if err != nil {
+		return fmt.Errorf("creating anvil container: %w", err)
+	}
+	c.containerID = cc.ID
+
+	if err := dockerutil.StartContainer(ctx, c.DockerClient, c.containerID); err != nil {
+		return fmt.Errorf("starting anvil container: %w", err)
+	}
+
+	inspect, err := c.DockerClient.ContainerInspect(ctx, c.containerID)
+	if err != nil {
+		return err
+	}
+	c.hostRPCPort = dockerutil.GetHostPort(inspect, rpcPort)
+	c.rpc = &rpcClient{url: c.GetHostRPCAddress()}
+
+	c.log.Info("Waiting for RPC endpoint to be available", zap.String("container", c.Name()))
+	if err := retry.Do(func() error {
+		var chainID string
+		return c.rpc.call(ctx, &chainID, "eth_chainId")
+	}, retry.Context(ctx), retry.Attempts(10), retry.Delay(time.Second), retry.LastErrorOnly(true)); err != nil {
+		return fmt.Errorf("anvil RPC endpoint never became available: %w", err)
+	}
+
+	for _, wallet := range additionalGenesisWallets {
+		if err := c.setBalance(ctx, wallet); err != nil {
+			return err
+		}
+	}
+
+	// Wait for 2 blocks before considering the chain "started"
+	if err := testutil.WaitForBlocks(ctx, 2, c); err != nil {
+		return err
+	}
+
+	if c.cfg.PostStart != nil {
+		if err := c.cfg.PostStart(ctx, c); err != nil {
+			return fmt.Errorf("post-start hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// setBalance sets the balance of the wallet's address, as genesis does for other chains.
+func (c *EthereumChain) setBalance(ctx context.Context, wallet ibc.WalletAmount) error {
+	if wallet.Denom != c.cfg.Denom {
+		return fmt.Errorf("cannot fund %s with %s: only the native denom %s is supported", wallet.Address, wallet.Denom, c.cfg.Denom)
+	}
+	wei := new(big.Int).Mul(big.NewInt(wallet.Amount), weiPerGwei)
+	if err := c.rpc.call(ctx, nil, "anvil_setBalance", wallet.Address, formatQuantity(wei)); err != nil {
+		return fmt.Errorf("failed to fund %s: %w", wallet.Address, err)
+	}
+	return nil
+}
+
+// Exec runs a container for a specific job and blocks until the container exits.
+// Implements Chain interface
+func (c *EthereumChain) Exec(ctx context.Context, cmd []string, env []string) (stdout, stderr []byte, err error) {
+	image := c.cfg.Images[0]
+	job := dockerutil.NewImage(c.log, c.DockerClient, c.NetworkID, c.testName, image.Repository, image.Version)
+	opts := dockerutil.ContainerOptions{
+		Binds: c.Bind(),
+		Env:   env,
+		User:  image.UidGid,
+	}
+	res := job.Run(ctx, cmd, opts)
+	return res.Stdout, res.Stderr, res.Err
+}
+
+// Implements Chain interface
+func (c *EthereumChain) ExportState(ctx context.Context, height int64) (string, error) {
+	return "", errors.New("exporting state is not supported on ethereum chains")
+}
+
+// Implements Chain interface
+func (c *EthereumChain) GetRPCAddress() string {
+	return fmt.Sprintf("http://%s:%s", c.HostName(), strings.TrimSuffix(rpcPort, "/tcp"))
+}
+
+// GetGRPCAddress returns an empty string, as Ethereum nodes have no gRPC server.
+// Implements Chain interface
+func (c *EthereumChain) GetGRPCAddress() string {
+	return ""
+}
+
+// GetHostRPCAddress returns the address of the JSON-RPC server accessible by the host.
+// This will not return a valid address until the chain has been started.
+func (c *EthereumChain) GetHostRPCAddress() string {
+	return "http://" + c.hostRPCPort
+}
+
+// GetHostGRPCAddress returns an empty string, as Ethereum nodes have no gRPC server.
+func (c *EthereumChain) GetHostGRPCAddress() string {
+	return ""
+}
+
+// HomeDir is where the chain's volume is mounted for Exec.
+// Implements Chain interface
+func (c *EthereumChain) HomeDir() string {
+	return path.Join("/var/ethereum-chain", c.cfg.Name)
+}
+
+func (c *EthereumChain) coinType() (uint32, error) {
+	if c.cfg.CoinType == "" {
+		return defaultCoinType, nil
+	}
+	coinType, err := strconv.ParseUint(c.cfg.CoinType, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coin type: %w", err)
+	}
+	return uint32(coinType), nil
+}
+
+// Implements Chain interface
+func (c *EthereumChain) CreateKey(ctx context.Context, keyName string) error {
+	_, err := c.createKey(keyName)
+	return err
+}
+
+// createKey stores a key with a new mnemonic and returns the mnemonic.
+func (c *EthereumChain) createKey(keyName string) (string, error) {
+	mnemonic, err := newMnemonic()
+	if err != nil {
+		return "", fmt.Errorf("failed to create mnemonic: %w", err)
+	}
+	if err := c.RecoverKey(context.Background(), keyName, mnemonic); err != nil {
+		return "", err
+	}
+	return mnemonic, nil
+}
+
+// RecoverKey restores a key from a given mnemonic.
+// Implements Chain interface
+func (c *EthereumChain) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
+	coinType, err := c.coinType()
+	if err != nil {
+		return err
+	}
+	addr, err := deriveAddress(mnemonic, coinType)
+	if err != nil {
+		return err
+	}
+
+	c.keysMu.Lock()
+	defer c.keysMu.Unlock()
+	if _, ok := c.keys[keyName]; ok {
+		return fmt.Errorf("key %q already exists", keyName)
+	}
+	c.keys[keyName] = addr
+	return nil
+}
+
+// Implements Chain interface
+func (c *EthereumChain) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
+	c.keysMu.Lock()
+	defer c.keysMu.Unlock()
+	addr, ok := c.keys[keyName]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found", keyName)
+	}
+	return addr, nil
+}
+
+// BuildWallet will return an Ethereum wallet
+// If mnemonic != "", it will restore using that mnemonic
+// If mnemonic == "", it will create a new key
+func (c *EthereumChain) BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error) {
+	if mnemonic != "" {
+		if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
+			return nil, fmt.Errorf("failed to recover key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+		}
+	} else {
+		if err := c.CreateKey(ctx, keyName); err != nil {
+			return nil, fmt.Errorf("failed to create key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+		}
+	}
+
+	addr, err := c.GetAddress(ctx, keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account address for key %q on chain %s: %w", keyName, c.cfg.Name, err)
+	}
+	return NewWallet(keyName, addr, mnemonic), nil
+}
+
+// BuildRelayerWallet will return an Ethereum wallet populated with the mnemonic so that the wallet can
+// be restored in the relayer node using the mnemonic. After it is built, that address is funded
+// when the chain starts.
+func (c *EthereumChain) BuildRelayerWallet(ctx context.Context, keyName string) (ibc.Wallet, error) {
+	mnemonic, err := c.createKey(keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+	}
+
+	addr, err := c.GetAddress(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+	return NewWallet(keyName, addr, mnemonic), nil
+}
+
+// SendFunds sends amount from the address of keyName, impersonating it on the node.
+// Implements Chain interface
+func (c *EthereumChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	if amount.Denom != c.cfg.Denom {
+		return fmt.Errorf("cannot send %s: only the native denom %s is supported", amount.Denom, c.cfg.Denom)
+	}
+	from, err := c.GetAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	if _, err := parseAddress(amount.Address); err != nil {
+		return err
+	}
+
+	sender := checksumAddress(from)
+	wei := new(big.Int).Mul(big.NewInt(amount.Amount), weiPerGwei)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.rpc.call(ctx, nil, "anvil_impersonateAccount", sender); err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.rpc.call(ctx, nil, "anvil_stopImpersonatingAccount", sender); err != nil {
+			c.log.Info("Failed to stop impersonating account", zap.String("address", sender), zap.Error(err))
+		}
+	}()
+
+	var txHash string
+	if err := c.rpc.call(ctx, &txHash, "eth_sendTransaction", map[string]string{
+		"from":  sender,
+		"to":    amount.Address,
+		"value": formatQuantity(wei),
+	}); err != nil {
+		return fmt.Errorf("failed to send %d%s from %s to %s: %w", amount.Amount, amount.Denom, sender, amount.Address, err)
+	}
+
+	return c.waitForReceipt(ctx, txHash)
+}
+
+// waitForReceipt waits for the transaction to be mined and returns an error if it failed.
+func (c *EthereumChain) waitForReceipt(ctx context.Context, txHash string) error {
+	return retry.Do(func() error {
+		var receipt *struct {
+			Status string `json:"status"`
+		}
+		if err := c.rpc.call(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+			return retry.Unrecoverable(err)
+		}
+		if receipt == nil {
+			return fmt.Errorf("transaction %s not yet mined", txHash)
+		}
+		if receipt.Status != "0x1" {
+			return retry.Unrecoverable(fmt.Errorf("transaction %s failed with status %s", txHash, receipt.Status))
+		}
+		return nil
+	}, retry.Context(ctx), retry.Attempts(30), retry.Delay(500*time.Millisecond), retry.DelayType(retry.FixedDelay), retry.LastErrorOnly(true))
+}
+
+// Implements Chain interface
+func (c *EthereumChain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, options ibc.TransferOptions) (ibc.Tx, error) {
+	return ibc.Tx{}, errors.New("ibc transfers are not supported on ethereum chains; call the bridge contract instead")
+}
+
+// Height returns the latest block number.
+// Implements Chain interface
+func (c *EthereumChain) Height(ctx context.Context) (uint64, error) {
+	var res string
+	if err := c.rpc.call(ctx, &res, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	n, err := parseQuantity(res)
+	if err != nil {
+		return 0, err
+	}
+	return n.Uint64(), nil
+}
+
+// GetBalance returns the balance of address in gwei, rounded down.
+// Implements Chain interface
+func (c *EthereumChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
+	if denom != c.cfg.Denom {
+		return 0, fmt.Errorf("cannot query %s balance: only the native denom %s is supported", denom, c.cfg.Denom)
+	}
+	var res string
+	if err := c.rpc.call(ctx, &res, "eth_getBalance", address, "latest"); err != nil {
+		return 0, err
+	}
+	wei, err := parseQuantity(res)
+	if err != nil {
+		return 0, err
+	}
+	gwei := new(big.Int).Quo(wei, weiPerGwei)
+	if !gwei.IsInt64() {
+		return 0, fmt.Errorf("balance of %s overflows int64: %s%s", address, gwei, denom)
+	}
+	return gwei.Int64(), nil
+}
+
+// Implements Chain interface
+func (c *EthereumChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
+	gasPrice, _ := strconv.ParseFloat(strings.Replace(c.cfg.GasPrices, c.cfg.Denom, "", 1), 64)
+	fees := float64(gasPaid) * gasPrice
+	return int64(fees)
+}
+
+// Implements Chain interface
+func (c *EthereumChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
+	return nil, errors.New("acknowledgements are not supported on ethereum chains")
+}
+
+// Implements Chain interface
+func (c *EthereumChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
+	return nil, errors.New("timeouts are not supported on ethereum chains")
+}
@@ -0,0 +1,69 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/go-bip39"
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// defaultCoinType is the BIP-44 coin type of Ethereum keys, used when the chain config has none.
+	defaultCoinType = 60
+
+	// devMnemonic is the mnemonic anvil derives its prefunded dev accounts from.
+	devMnemonic = "test test test test test test test test test test test junk"
+)
+
+// newMnemonic returns a new random 24 word mnemonic.
+func newMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// deriveAddress returns the Ethereum address of the first account derived from mnemonic with coinType,
+// the same account as the one shown by anvil or MetaMask for the mnemonic.
+func deriveAddress(mnemonic string, coinType uint32) ([]byte, error) {
+	priv, err := hd.Secp256k1.Derive()(mnemonic, "", hd.CreateHDPath(coinType, 0, 0).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	_, pub := secp256k1.PrivKeyFromBytes(priv)
+
+	// The address is the last 20 bytes of the hash of the public key, without its 0x04 prefix.
+	return keccak256(pub.SerializeUncompressed()[1:])[12:], nil
+}
+
+// checksumAddress formats addr as a 0x-prefixed hex string with the mixed-case checksum of EIP-55.
+func checksumAddress(addr []byte) string {
+	lower := hex.EncodeToString(addr)
+	hash := keccak256([]byte(lower))
+
+	var b strings.Builder
+	b.WriteString("0x")
+	for i, c := range lower {
+		// Letters are uppercased where the matching nibble of the hash is at least 8.
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0xf
+		}
+		if c >= 'a' && nibble >= 8 {
+			c -= 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
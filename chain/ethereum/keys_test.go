@@ -0,0 +1,31 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveAddress(t *testing.T) {
+	// The first account anvil funds for its default mnemonic.
+	addr, err := deriveAddress(devMnemonic, defaultCoinType)
+	require.NoError(t, err)
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", checksumAddress(addr))
+
+	_, err = deriveAddress("not a mnemonic", defaultCoinType)
+	require.Error(t, err)
+}
+
+func TestChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55.
+	for _, want := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		addr, err := parseAddress(want)
+		require.NoError(t, err)
+		require.Equal(t, want, checksumAddress(addr))
+	}
+}
@@ -0,0 +1,105 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// rpcClient calls the JSON-RPC API of an Ethereum node over HTTP.
+type rpcClient struct {
+	url    string
+	nextID uint64
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// call invokes method with params and unmarshals its result into result, unless result is nil.
+func (c *rpcClient) call(ctx context.Context, result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: atomic.AddUint64(&c.nextID, 1), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	var out rpcResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return fmt.Errorf("%s: failed to decode response with status %s: %w", method, res.Status, err)
+	}
+	if out.Error != nil {
+		return fmt.Errorf("%s: %w", method, out.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(out.Result, result); err != nil {
+		return fmt.Errorf("%s: failed to unmarshal result %s: %w", method, out.Result, err)
+	}
+	return nil
+}
+
+// parseQuantity parses a 0x-prefixed hex quantity of the JSON-RPC API.
+func parseQuantity(s string) (*big.Int, error) {
+	digits := strings.TrimPrefix(s, "0x")
+	if digits == s || digits == "" {
+		return nil, fmt.Errorf("invalid hex quantity %q", s)
+	}
+	n, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity %q", s)
+	}
+	return n, nil
+}
+
+// formatQuantity formats n as a 0x-prefixed hex quantity of the JSON-RPC API.
+func formatQuantity(n *big.Int) string {
+	return "0x" + n.Text(16)
+}
+
+// parseAddress parses a 0x-prefixed hex address, in any case.
+func parseAddress(s string) ([]byte, error) {
+	addr, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(addr) != 20 || !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	return addr, nil
+}
@@ -0,0 +1,52 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCClientCall(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "2.0", req.JSONRPC)
+
+		switch req.Method {
+		case "eth_getBalance":
+			require.Equal(t, []any{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "latest"}, req.Params)
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x3b9aca00"}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := &rpcClient{url: srv.URL}
+	ctx := context.Background()
+
+	var res string
+	require.NoError(t, c.call(ctx, &res, "eth_getBalance", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "latest"))
+	require.Equal(t, "0x3b9aca00", res)
+
+	err := c.call(ctx, nil, "eth_unknown")
+	require.ErrorContains(t, err, "eth_unknown: rpc error -32601: Method not found")
+}
+
+func TestParseQuantity(t *testing.T) {
+	n, err := parseQuantity("0x3b9aca00")
+	require.NoError(t, err)
+	require.Equal(t, weiPerGwei, n)
+	require.Equal(t, "0x3b9aca00", formatQuantity(n))
+	require.Equal(t, "0x0", formatQuantity(new(big.Int)))
+
+	for _, s := range []string{"", "0x", "3b9aca00", "0xzz"} {
+		_, err := parseQuantity(s)
+		require.Error(t, err, s)
+	}
+}
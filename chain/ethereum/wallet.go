@@ -0,0 +1,46 @@
+package ethereum
+
+import (
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+var _ ibc.Wallet = &EthereumWallet{}
+
+type EthereumWallet struct {
+	mnemonic string
+	address  []byte
+	keyName  string
+}
+
+func NewWallet(keyname string, address []byte, mnemonic string) *EthereumWallet {
+	return &EthereumWallet{
+		mnemonic: mnemonic,
+		address:  address,
+		keyName:  keyname,
+	}
+}
+
+func (w *EthereumWallet) KeyName() string {
+	return w.keyName
+}
+
+// FormattedAddress returns the EIP-55 checksummed hex address.
+func (w *EthereumWallet) FormattedAddress() string {
+	return checksumAddress(w.address)
+}
+
+// Get mnemonic, only used for relayer wallets
+func (w *EthereumWallet) Mnemonic() string {
+	return w.mnemonic
+}
+
+// Get Address
+func (w *EthereumWallet) Address() []byte {
+	return w.address
+}
+
+// FormattedAddressWithPrefix returns the same hex address as FormattedAddress,
+// as Ethereum addresses have no bech32 prefix.
+func (w *EthereumWallet) FormattedAddressWithPrefix(prefix string) string {
+	return w.FormattedAddress()
+}
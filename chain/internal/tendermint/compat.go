@@ -0,0 +1,145 @@
+package tendermint
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+// NewHTTPClient returns the HTTP client for an RPC client of the node at addr,
+// e.g. for rpchttp.NewWithClient, which understands the event encodings of both tendermint and CometBFT.
+//
+// Tendermint v0.34 encodes the key and value of event attributes as base64,
+// which is what the imported RPC types decode, while CometBFT v0.37 and later send them as plain strings.
+// Responses in the CometBFT encoding are detected and converted to the base64 encoding,
+// so things like block results, transactions, and AttributeValue work against chains running either.
+// Subscriptions over the WebSocket do not go through this client, so they still require the legacy encoding.
+//
+// If tlsConfig is not nil, it is used for an addr with the https scheme.
+func NewHTTPClient(addr string, timeout time.Duration, tlsConfig *tls.Config) (*http.Client, error) {
+	httpClient, err := libclient.DefaultHTTPClient(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		httpClient.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+	}
+	httpClient.Timeout = timeout
+	httpClient.Transport = &eventCompatTransport{next: httpClient.Transport}
+	return httpClient, nil
+}
+
+// eventCompatTransport converts the event attributes of CometBFT responses to the tendermint v0.34 encoding.
+type eventCompatTransport struct {
+	next http.RoundTripper
+}
+
+func (t *eventCompatTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = NormalizeEventAttributes(body)
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	res.Header.Del("Content-Length")
+	return res, nil
+}
+
+// legacyAttributeKey matches the decoded keys of tendermint v0.34 event attributes, e.g. "packet_src_channel".
+var legacyAttributeKey = regexp.MustCompile(`^[A-Za-z0-9_.\-/]+$`)
+
+// NormalizeEventAttributes returns the JSON document bz, such as an RPC response,
+// with the key and value of every event attribute base64 encoded as in tendermint v0.34,
+// if they are plain strings as in CometBFT v0.37 and later.
+//
+// The encoding is detected for the document as a whole: it is left untouched if every attribute key
+// is valid base64 of an event key, and bz is returned unchanged if it is not a JSON document.
+func NormalizeEventAttributes(bz []byte) []byte {
+	// Quickly skip responses without events, such as status or ABCI queries.
+	if !bytes.Contains(bz, []byte(`"attributes"`)) {
+		return bz
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber() // Keep heights and amounts exact.
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return bz
+	}
+
+	var attrs []map[string]interface{}
+	collectAttributes(doc, &attrs)
+	if len(attrs) == 0 || legacyEncoded(attrs) {
+		return bz
+	}
+
+	for _, attr := range attrs {
+		for _, field := range []string{"key", "value"} {
+			if s, ok := attr[field].(string); ok {
+				attr[field] = base64.StdEncoding.EncodeToString([]byte(s))
+			}
+		}
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return bz
+	}
+	return out
+}
+
+// collectAttributes appends every object of an "attributes" array within v to attrs.
+func collectAttributes(v interface{}, attrs *[]map[string]interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if list, ok := child.([]interface{}); ok && k == "attributes" {
+				for _, a := range list {
+					if attr, ok := a.(map[string]interface{}); ok {
+						if _, hasKey := attr["key"]; hasKey {
+							*attrs = append(*attrs, attr)
+						}
+					}
+				}
+				continue
+			}
+			collectAttributes(child, attrs)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectAttributes(child, attrs)
+		}
+	}
+}
+
+// legacyEncoded reports whether every attribute key is base64 of an event key.
+// A plain key is almost never also base64 of one, e.g. "code" decodes to binary data.
+func legacyEncoded(attrs []map[string]interface{}) bool {
+	for _, attr := range attrs {
+		key, _ := attr["key"].(string)
+		if key == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || !legacyAttributeKey.Match(decoded) {
+			return false
+		}
+	}
+	return true
+}
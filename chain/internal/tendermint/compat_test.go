@@ -0,0 +1,101 @@
+package tendermint
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func TestNormalizeEventAttributes(t *testing.T) {
+	t.Run("CometBFT", func(t *testing.T) {
+		in := `{"events":[{"type":"send_packet","attributes":[{"key":"packet_sequence","value":"12","index":true},{"key":"code","value":null}]}],"height":"18446744073709551615"}`
+		out := NormalizeEventAttributes([]byte(in))
+		require.JSONEq(t, `{"events":[{"type":"send_packet","attributes":[{"key":"cGFja2V0X3NlcXVlbmNl","value":"MTI=","index":true},{"key":"Y29kZQ==","value":null}]}],"height":"18446744073709551615"}`, string(out))
+	})
+
+	t.Run("tendermint", func(t *testing.T) {
+		in := `{"events":[{"type":"send_packet","attributes":[{"key":"cGFja2V0X3NlcXVlbmNl","value":"MTI=","index":true}]}]}`
+		require.Equal(t, in, string(NormalizeEventAttributes([]byte(in))))
+	})
+
+	t.Run("not JSON", func(t *testing.T) {
+		in := `"attributes": not json`
+		require.Equal(t, in, string(NormalizeEventAttributes([]byte(in))))
+	})
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	// Block results as returned by a CometBFT v0.37 node.
+	const result = `{"height":"5","txs_results":[{"code":0,"data":null,"log":"","info":"","gas_wanted":"100","gas_used":"50",
+"events":[{"type":"send_packet","attributes":[{"key":"packet_sequence","value":"1","index":true}]}],"codespace":""}],
+"begin_block_events":null,"end_block_events":null,"validator_updates":null,"consensus_param_updates":null}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, result)
+	}))
+	defer srv.Close()
+
+	httpClient, err := NewHTTPClient(srv.URL, 10*time.Second, nil)
+	require.NoError(t, err)
+	client, err := rpchttp.NewWithClient(srv.URL, "/websocket", httpClient)
+	require.NoError(t, err)
+
+	height := int64(5)
+	res, err := client.BlockResults(context.Background(), &height)
+	require.NoError(t, err)
+
+	seq, ok := AttributeValue(res.TxsResults[0].Events, "send_packet", "packet_sequence")
+	require.True(t, ok)
+	require.Equal(t, "1", seq)
+}
+
+func TestNewHTTPClient_TLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{}}`, req.ID)
+	}))
+	// Silence the handshake error of the client rejecting the certificate.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("verified", func(t *testing.T) {
+		httpClient, err := NewHTTPClient(srv.URL, 10*time.Second, &tls.Config{MinVersion: tls.VersionTLS12})
+		require.NoError(t, err)
+		client, err := rpchttp.NewWithClient(srv.URL, "/websocket", httpClient)
+		require.NoError(t, err)
+
+		// The test server's certificate is self-signed.
+		_, err = client.Health(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		httpClient, err := NewHTTPClient(srv.URL, 10*time.Second, &tls.Config{InsecureSkipVerify: true})
+		require.NoError(t, err)
+		client, err := rpchttp.NewWithClient(srv.URL, "/websocket", httpClient)
+		require.NoError(t, err)
+
+		_, err = client.Health(ctx)
+		require.NoError(t, err)
+	})
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
-	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 	"go.uber.org/zap"
 )
 
@@ -67,12 +66,11 @@ var (
 
 // NewClient creates and assigns a new Tendermint RPC client to the TendermintNode
 func (tn *TendermintNode) NewClient(addr string) error {
-	httpClient, err := libclient.DefaultHTTPClient(addr)
+	httpClient, err := NewHTTPClient(addr, 10*time.Second, nil)
 	if err != nil {
 		return err
 	}
 
-	httpClient.Timeout = 10 * time.Second
 	rpcClient, err := rpchttp.NewWithClient(addr, "/websocket", httpClient)
 	if err != nil {
 		return err
@@ -84,7 +82,7 @@ func (tn *TendermintNode) NewClient(addr string) error {
 
 // Name is the hostname of the test node container
 func (tn *TendermintNode) Name() string {
-	return fmt.Sprintf("node-%d-%s-%s", tn.Index, tn.Chain.Config().ChainID, dockerutil.SanitizeContainerName(tn.TestName))
+	return fmt.Sprintf("node-%d-%s-%s", tn.Index, tn.Chain.Config().ChainID, dockerutil.ContainerNameSuffix(tn.TestName))
 }
 
 func (tn *TendermintNode) HostName() string {
@@ -220,6 +218,11 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 	cmd = append(cmd, additionalFlags...)
 	fmt.Printf("{%s} -> '%s'\n", tn.Name(), strings.Join(cmd, " "))
 
+	resources, err := dockerutil.ContainerResources(chainCfg.CPUs, chainCfg.Memory)
+	if err != nil {
+		return err
+	}
+
 	cc, err := tn.DockerClient.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -230,7 +233,7 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 
 			Hostname: tn.HostName(),
 
-			Labels: map[string]string{dockerutil.CleanupLabel: tn.TestName},
+			Labels: dockerutil.CleanupLabels(tn.TestName),
 
 			ExposedPorts: sentryPorts,
 		},
@@ -239,6 +242,7 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -0,0 +1,27 @@
+package tendermint
+
+import (
+	"crypto/tls"
+	"net"
+
+	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+// NewWSClient returns a WebSocket client of the RPC server at hostPort, e.g. "127.0.0.1:26657",
+// which is dialed over TLS if tlsConfig is not nil. The client must be started before use.
+//
+// The WebSocket client of tendermint v0.34 fails to dial wss addresses,
+// so the TLS connection is established by the client's dialer instead.
+func NewWSClient(hostPort string, tlsConfig *tls.Config, options ...func(*libclient.WSClient)) (*libclient.WSClient, error) {
+	ws, err := libclient.NewWS("tcp://"+hostPort, "/websocket", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		ws.Dialer = func(string, string) (net.Conn, error) {
+			return tls.Dial("tcp", hostPort, tlsConfig)
+		}
+	}
+	return ws, nil
+}
@@ -49,7 +49,7 @@ var exposedPorts = nat.PortSet{
 
 // Name of the test node container
 func (p *PenumbraAppNode) Name() string {
-	return fmt.Sprintf("pd-%d-%s-%s", p.Index, p.Chain.Config().ChainID, p.TestName)
+	return fmt.Sprintf("pd-%d-%s-%s", p.Index, p.Chain.Config().ChainID, dockerutil.ContainerNameSuffix(p.TestName))
 }
 
 // the hostname of the test node container
@@ -216,6 +216,12 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 	cmd := []string{"pd", "start", "--host", "0.0.0.0", "--home", p.HomeDir()}
 	fmt.Printf("{%s} -> '%s'\n", p.Name(), strings.Join(cmd, " "))
 
+	chainCfg := p.Chain.Config()
+	resources, err := dockerutil.ContainerResources(chainCfg.CPUs, chainCfg.Memory)
+	if err != nil {
+		return err
+	}
+
 	cc, err := p.DockerClient.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -227,7 +233,7 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 			Hostname: p.HostName(),
 			User:     p.Image.UidGid,
 
-			Labels: map[string]string{dockerutil.CleanupLabel: p.TestName},
+			Labels: dockerutil.CleanupLabels(p.TestName),
 
 			ExposedPorts: exposedPorts,
 		},
@@ -236,6 +242,7 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -3,9 +3,7 @@ package penumbra
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 
@@ -14,7 +12,6 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
-	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/pelletier/go-toml/v2"
@@ -100,6 +97,14 @@ func (c *PenumbraChain) Config() ibc.ChainConfig {
 	return c.cfg
 }
 
+// Logger returns the logger the chain was created with, annotated with the chain ID and test name.
+func (c *PenumbraChain) Logger() *zap.Logger {
+	return c.log.With(
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("test", c.testName),
+	)
+}
+
 // Implements Chain interface
 func (c *PenumbraChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
 	return c.initializeChainNodes(ctx, testName, cli, networkID)
@@ -141,8 +146,11 @@ func (c *PenumbraChain) GetHostGRPCAddress() string {
 	return c.getRelayerNode().PenumbraAppNode.hostGRPCPort
 }
 
+// HomeDir returns the home directory of the pd app node, where Exec runs commands.
+// The tendermint process keeps its own home; see tendermint.TendermintNode.HomeDir.
+// Implements Chain interface
 func (c *PenumbraChain) HomeDir() string {
-	panic(errors.New("HomeDir not implemented yet"))
+	return c.getRelayerNode().PenumbraAppNode.HomeDir()
 }
 
 // Implements Chain interface
@@ -257,20 +265,12 @@ func (c *PenumbraChain) initializeChainNodes(
 	count := c.numValidators + c.numFullNodes
 	chainCfg := c.Config()
 	for _, image := range chainCfg.Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			types.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, c.log, cli, image); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 	for i := 0; i < count; i++ {
@@ -278,11 +278,9 @@ func (c *PenumbraChain) initializeChainNodes(
 			DockerClient: cli, NetworkID: networkID, TestName: testName, Image: chainCfg.Images[0]}
 
 		tv, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-			Labels: map[string]string{
-				dockerutil.CleanupLabel: testName,
-
+			Labels: dockerutil.CleanupLabels(testName, map[string]string{
 				dockerutil.NodeOwnerLabel: tn.Name(),
-			},
+			}),
 		})
 		if err != nil {
 			return fmt.Errorf("creating tendermint volume: %w", err)
@@ -304,11 +302,9 @@ func (c *PenumbraChain) initializeChainNodes(
 		pn := &PenumbraAppNode{log: c.log, Index: i, Chain: c,
 			DockerClient: cli, NetworkID: networkID, TestName: testName, Image: chainCfg.Images[1]}
 		pv, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-			Labels: map[string]string{
-				dockerutil.CleanupLabel: testName,
-
+			Labels: dockerutil.CleanupLabels(testName, map[string]string{
 				dockerutil.NodeOwnerLabel: pn.Name(),
-			},
+			}),
 		})
 		if err != nil {
 			return fmt.Errorf("creating penumbra volume: %w", err)
@@ -50,7 +50,7 @@ type ParachainNodes []*ParachainNode
 
 // Name returns the name of the test node container.
 func (pn *ParachainNode) Name() string {
-	return fmt.Sprintf("%s-%d-%s-%s", pn.Bin, pn.Index, pn.ChainID, dockerutil.SanitizeContainerName(pn.TestName))
+	return fmt.Sprintf("%s-%d-%s-%s", pn.Bin, pn.Index, pn.ChainID, dockerutil.ContainerNameSuffix(pn.TestName))
 }
 
 // HostName returns the docker hostname of the test container.
@@ -252,6 +252,12 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 			zap.String("container", pn.Name()),
 		)
 
+	chainCfg := pn.Chain.Config()
+	resources, err := dockerutil.ContainerResources(chainCfg.CPUs, chainCfg.Memory)
+	if err != nil {
+		return err
+	}
+
 	cc, err := pn.DockerClient.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -263,7 +269,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 			Hostname: pn.HostName(),
 			User:     pn.Image.UidGid,
 
-			Labels: map[string]string{dockerutil.CleanupLabel: pn.TestName},
+			Labels: dockerutil.CleanupLabels(pn.TestName),
 
 			ExposedPorts: exposedPorts,
 		},
@@ -272,6 +278,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -5,7 +5,6 @@ import (
 	crand "crypto/rand"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math/rand"
 	"strings"
 
@@ -14,7 +13,6 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/cosmos/go-bip39"
-	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/icza/dyno"
@@ -99,20 +97,12 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 		images = append(images, parachain.Image)
 	}
 	for _, image := range images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			types.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, c.log, cli, image); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 	for i := 0; i < c.numRelayChainNodes; i++ {
@@ -164,11 +154,9 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 		}
 
 		v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-			Labels: map[string]string{
-				dockerutil.CleanupLabel: testName,
-
+			Labels: dockerutil.CleanupLabels(testName, map[string]string{
 				dockerutil.NodeOwnerLabel: pn.Name(),
-			},
+			}),
 		})
 		if err != nil {
 			return fmt.Errorf("creating volume for chain node: %w", err)
@@ -211,11 +199,9 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 				RelayChainFlags: parachainConfig.RelayChainFlags,
 			}
 			v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-				Labels: map[string]string{
-					dockerutil.CleanupLabel: testName,
-
+				Labels: dockerutil.CleanupLabels(testName, map[string]string{
 					dockerutil.NodeOwnerLabel: pn.Name(),
-				},
+				}),
 			})
 			if err != nil {
 				return fmt.Errorf("creating volume for chain node: %w", err)
@@ -360,7 +346,8 @@ func (c *PolkadotChain) modifyRelayChainGenesis(ctx context.Context, chainSpec i
 	return nil
 }
 
-func (c *PolkadotChain) logger() *zap.Logger {
+// Logger returns the logger the chain was created with, annotated with the chain ID and test name.
+func (c *PolkadotChain) Logger() *zap.Logger {
 	return c.log.With(
 		zap.String("chain_id", c.cfg.ChainID),
 		zap.String("test", c.testName),
@@ -381,7 +368,7 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 		for _, n := range parachainNodes {
 			n := n
 			eg.Go(func() error {
-				c.logger().Info("Copying parachain chain spec", zap.String("container", n.Name()))
+				c.Logger().Info("Copying parachain chain spec", zap.String("container", n.Name()))
 				fw := dockerutil.NewFileWriter(n.logger(), n.DockerClient, n.TestName)
 				return fw.WriteFile(ctx, n.VolumeName, n.ParachainChainSpecFileName(), parachainChainSpec)
 			})
@@ -396,8 +383,8 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 	if err := firstNode.GenerateChainSpec(ctx); err != nil {
 		return fmt.Errorf("error generating chain spec: %w", err)
 	}
-	fr := dockerutil.NewFileRetriever(c.logger(), firstNode.DockerClient, c.testName)
-	fw := dockerutil.NewFileWriter(c.logger(), firstNode.DockerClient, c.testName)
+	fr := dockerutil.NewFileRetriever(c.Logger(), firstNode.DockerClient, c.testName)
+	fw := dockerutil.NewFileWriter(c.Logger(), firstNode.DockerClient, c.testName)
 
 	chainSpecBytes, err := fr.SingleFileContent(ctx, firstNode.VolumeName, firstNode.ChainSpecFilePathContainer())
 	if err != nil {
@@ -422,7 +409,7 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 		return fmt.Errorf("error writing modified chain spec: %w", err)
 	}
 
-	c.logger().Info("Generating raw chain spec", zap.String("container", firstNode.Name()))
+	c.Logger().Info("Generating raw chain spec", zap.String("container", firstNode.Name()))
 
 	if err := firstNode.GenerateChainSpecRaw(ctx); err != nil {
 		return err
@@ -438,16 +425,16 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 		i := i
 		eg.Go(func() error {
 			if i != 0 {
-				c.logger().Info("Copying raw chain spec", zap.String("container", n.Name()))
+				c.Logger().Info("Copying raw chain spec", zap.String("container", n.Name()))
 				if err := fw.WriteFile(ctx, n.VolumeName, n.RawChainSpecFilePathRelative(), rawChainSpecBytes); err != nil {
 					return fmt.Errorf("error writing raw chain spec: %w", err)
 				}
 			}
-			c.logger().Info("Creating container", zap.String("name", n.Name()))
+			c.Logger().Info("Creating container", zap.String("name", n.Name()))
 			if err := n.CreateNodeContainer(ctx); err != nil {
 				return err
 			}
-			c.logger().Info("Starting container", zap.String("name", n.Name()))
+			c.Logger().Info("Starting container", zap.String("name", n.Name()))
 			return n.StartContainer(ctx)
 		})
 	}
@@ -459,16 +446,16 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 		for _, n := range nodes {
 			n := n
 			eg.Go(func() error {
-				c.logger().Info("Copying raw chain spec", zap.String("container", n.Name()))
+				c.Logger().Info("Copying raw chain spec", zap.String("container", n.Name()))
 				if err := fw.WriteFile(ctx, n.VolumeName, n.RawRelayChainSpecFilePathRelative(), rawChainSpecBytes); err != nil {
 					return fmt.Errorf("error writing raw chain spec: %w", err)
 				}
 				//fmt.Print(string(rawChainSpecBytes))
-				c.logger().Info("Creating container", zap.String("name", n.Name()))
+				c.Logger().Info("Creating container", zap.String("name", n.Name()))
 				if err := n.CreateNodeContainer(ctx); err != nil {
 					return err
 				}
-				c.logger().Info("Starting container", zap.String("name", n.Name()))
+				c.Logger().Info("Starting container", zap.String("name", n.Name()))
 				return n.StartContainer(ctx)
 			})
 		}
@@ -73,7 +73,7 @@ var exposedPorts = map[nat.Port]struct{}{
 
 // Name returns the name of the test node.
 func (p *RelayChainNode) Name() string {
-	return fmt.Sprintf("relaychain-%d-%s-%s", p.Index, p.Chain.Config().ChainID, dockerutil.SanitizeContainerName(p.TestName))
+	return fmt.Sprintf("relaychain-%d-%s-%s", p.Index, p.Chain.Config().ChainID, dockerutil.ContainerNameSuffix(p.TestName))
 }
 
 // HostName returns the docker hostname of the test container.
@@ -228,6 +228,11 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 			zap.String("container", p.Name()),
 		)
 
+	resources, err := dockerutil.ContainerResources(chainCfg.CPUs, chainCfg.Memory)
+	if err != nil {
+		return err
+	}
+
 	cc, err := p.DockerClient.ContainerCreate(
 		ctx,
 		&container.Config{
@@ -239,7 +244,7 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 			Hostname: p.HostName(),
 			User:     p.Image.UidGid,
 
-			Labels: map[string]string{dockerutil.CleanupLabel: p.TestName},
+			Labels: dockerutil.CleanupLabels(p.TestName),
 
 			ExposedPorts: exposedPorts,
 		},
@@ -248,6 +253,7 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 			PublishAllPorts: true,
 			AutoRemove:      false,
 			DNS:             []string{},
+			Resources:       resources,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
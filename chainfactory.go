@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/penumbra"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
@@ -98,8 +99,16 @@ func (f *BuiltinChainFactory) Count() int {
 
 func (f *BuiltinChainFactory) Chains(testName string) ([]ibc.Chain, error) {
 	chains := make([]ibc.Chain, len(f.specs))
+	specIndexByChainID := make(map[string]int, len(f.specs))
 	for i, s := range f.specs {
-		cfg, err := s.Config(f.log)
+		suffix := s.suffix
+		if s.UniqueChainID {
+			// Derived for every call rather than kept in the spec, so that a spec reused by several tests
+			// gets the suffix of each of them.
+			i := i
+			suffix = func() string { return testSuffix(testName, i) }
+		}
+		cfg, err := s.config(f.log, suffix)
 		if err != nil {
 			// Prefer to wrap the error with the chain name if possible.
 			if s.Name != "" {
@@ -109,6 +118,19 @@ func (f *BuiltinChainFactory) Chains(testName string) ([]ibc.Chain, error) {
 			return nil, fmt.Errorf("failed to build chain config at index %d: %w", i, err)
 		}
 
+		// Duplicate chain IDs are rejected once the chains are added to an Interchain,
+		// but warn here too, as the chains' containers and logs would be indistinguishable.
+		if j, ok := specIndexByChainID[cfg.ChainID]; ok {
+			f.log.Warn(
+				"Duplicate chain ID in chain specs",
+				zap.String("chain_id", cfg.ChainID),
+				zap.Int("first_index", j),
+				zap.Int("index", i),
+			)
+		} else {
+			specIndexByChainID[cfg.ChainID] = i
+		}
+
 		chain, err := buildChain(f.log, testName, *cfg, s.NumValidators, s.NumFullNodes)
 		if err != nil {
 			return nil, err
@@ -139,6 +161,8 @@ func buildChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValida
 		return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
 	case "penumbra":
 		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
+	case "ethereum":
+		return ethereum.NewEthereumChain(log, testName, cfg), nil
 	case "polkadot":
 		// TODO Clean this up. RelayChain config should only reference cfg.Images[0] and parachains should iterate through the remaining
 		// Maybe just pass everything in like NewCosmosChain and NewPenumbraChain, let NewPolkadotChain figure it out
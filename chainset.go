@@ -49,12 +49,12 @@ func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
 // Each chain may run a docker pull command,
 // so with a cold image cache, running concurrently may save some time.
 func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
-	var eg errgroup.Group
+	eg, egCtx := errgroup.WithContext(ctx)
 
 	for c := range cs.chains {
 		c := c
 		eg.Go(func() error {
-			if err := c.Initialize(ctx, testName, cli, networkID); err != nil {
+			if err := c.Initialize(egCtx, testName, cli, networkID); err != nil {
 				return fmt.Errorf("failed to initialize chain %s: %w", c.Config().Name, err)
 			}
 
@@ -101,11 +101,24 @@ func (cs *chainSet) CreateCommonAccount(ctx context.Context, keyName string) (fa
 }
 
 // Start concurrently calls Start against each chain in the set.
-func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount) error {
+//
+// consumers maps each Interchain Security consumer chain to its provider.
+// Consumers are started after every other chain, as they are spawned by their already running provider.
+func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount, consumers map[ibc.Chain]ibc.Chain) error {
+	// Every consumer is checked before any chain is started.
+	for c := range consumers {
+		if _, ok := c.(consumerChain); !ok {
+			return fmt.Errorf("chain %s cannot be started as a consumer chain", c.Config().Name)
+		}
+	}
+
 	eg, egCtx := errgroup.WithContext(ctx)
 
 	for c := range cs.chains {
 		c := c
+		if _, ok := consumers[c]; ok {
+			continue
+		}
 		eg.Go(func() error {
 			if err := c.Start(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
 				return fmt.Errorf("failed to start chain %s: %w", c.Config().Name, err)
@@ -115,6 +128,74 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 		})
 	}
 
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for c, provider := range consumers {
+		c, provider := c, provider
+		cc := c.(consumerChain)
+		eg.Go(func() error {
+			if err := cc.StartConsumer(testName, egCtx, provider, additionalGenesisWallets[c]...); err != nil {
+				return fmt.Errorf("failed to start consumer chain %s: %w", c.Config().Name, err)
+			}
+
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
+// consumerChain is implemented by chains that can be started as an Interchain Security consumer of a provider chain.
+type consumerChain interface {
+	StartConsumer(testName string, ctx context.Context, provider ibc.Chain, additionalGenesisWallets ...ibc.WalletAmount) error
+}
+
+// readyChecker is implemented by chains that can report whether all of their nodes are ready for use.
+type readyChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// WaitForReady blocks until every chain implementing readyChecker reports ready,
+// or until timeout elapses.
+func (cs *chainSet) WaitForReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for c := range cs.chains {
+		c := c
+		rc, ok := c.(readyChecker)
+		if !ok {
+			continue
+		}
+		eg.Go(func() error {
+			ticker := time.NewTicker(250 * time.Millisecond)
+			defer ticker.Stop()
+
+			// lastErr is why the chain was last not ready.
+			// A check cut short by the timeout only reports the timeout, so it does not replace an earlier error.
+			var lastErr error
+			for {
+				err := rc.Ready(egCtx)
+				if err == nil {
+					return nil
+				}
+				if lastErr == nil || egCtx.Err() == nil {
+					lastErr = err
+				}
+
+				select {
+				case <-egCtx.Done():
+					return fmt.Errorf("chain %s not ready after %s: %w", c.Config().Name, timeout, lastErr)
+				case <-ticker.C:
+				}
+			}
+		})
+	}
+
 	return eg.Wait()
 }
 
@@ -0,0 +1,121 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type readyChain struct {
+	ibc.Chain // Unimplemented methods panic.
+
+	name       string
+	readyAfter int
+	calls      int
+
+	// If set, checks after the first block until ctx is done.
+	blockAfterFirst bool
+}
+
+func (c *readyChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{Name: c.name}
+}
+
+func (c *readyChain) Ready(ctx context.Context) error {
+	c.calls++
+	if c.blockAfterFirst && c.calls > 1 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if c.calls <= c.readyAfter {
+		return errors.New("height(0) catching-up(true)")
+	}
+	return nil
+}
+
+func TestChainSet_WaitForReady(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ready", func(t *testing.T) {
+		c := &readyChain{name: "a", readyAfter: 2}
+		cs := newChainSet(zap.NewNop(), []ibc.Chain{c})
+
+		require.NoError(t, cs.WaitForReady(ctx, 10*time.Second))
+		require.Equal(t, 3, c.calls)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := &readyChain{name: "a", readyAfter: 1_000_000}
+		cs := newChainSet(zap.NewNop(), []ibc.Chain{c})
+
+		err := cs.WaitForReady(ctx, 300*time.Millisecond)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "chain a not ready")
+		require.Contains(t, err.Error(), "catching-up(true)")
+	})
+
+	t.Run("timeout during check", func(t *testing.T) {
+		c := &readyChain{name: "a", readyAfter: 1_000_000, blockAfterFirst: true}
+		cs := newChainSet(zap.NewNop(), []ibc.Chain{c})
+
+		// The check cut short by the timeout does not hide why the chain was not ready.
+		err := cs.WaitForReady(ctx, 500*time.Millisecond)
+		require.ErrorContains(t, err, "chain a not ready")
+		require.ErrorContains(t, err, "catching-up(true)")
+		require.NotErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+type startChain struct {
+	ibc.Chain // Unimplemented methods panic.
+
+	name    string
+	started *[]string
+
+	// Set when the chain was started as a consumer.
+	provider ibc.Chain
+}
+
+func (c *startChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{Name: c.name}
+}
+
+func (c *startChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
+	*c.started = append(*c.started, c.name)
+	return nil
+}
+
+func (c *startChain) StartConsumer(testName string, ctx context.Context, provider ibc.Chain, additionalGenesisWallets ...ibc.WalletAmount) error {
+	c.provider = provider
+	return c.Start(testName, ctx, additionalGenesisWallets...)
+}
+
+func TestChainSet_StartConsumersLast(t *testing.T) {
+	var started []string
+	provider := &startChain{name: "provider", started: &started}
+	consumer := &startChain{name: "consumer", started: &started}
+
+	cs := newChainSet(zap.NewNop(), []ibc.Chain{consumer, provider})
+	require.NoError(t, cs.Start(context.Background(), t.Name(), nil, map[ibc.Chain]ibc.Chain{consumer: provider}))
+
+	require.Equal(t, []string{"provider", "consumer"}, started)
+	require.Equal(t, provider, consumer.provider)
+	require.Nil(t, provider.provider)
+}
+
+func TestChainSet_StartRejectsConsumersBeforeStarting(t *testing.T) {
+	var started []string
+	provider := &startChain{name: "provider", started: &started}
+	consumer := &readyChain{name: "consumer"}
+
+	cs := newChainSet(zap.NewNop(), []ibc.Chain{consumer, provider})
+	err := cs.Start(context.Background(), t.Name(), nil, map[ibc.Chain]ibc.Chain{consumer: provider})
+	require.EqualError(t, err, "chain consumer cannot be started as a consumer chain")
+
+	require.Empty(t, started)
+}
@@ -1,14 +1,17 @@
 package interchaintest
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v6/label"
 	"go.uber.org/zap"
 )
@@ -41,6 +44,46 @@ type ChainSpec struct {
 	// If unspecified, NumValidators defaults to 2 and NumFullNodes defaults to 1.
 	NumValidators, NumFullNodes *int
 
+	// Governance overrides written into the gov module genesis, to speed up governance tests.
+	// VotingPeriod and MaxDepositPeriod are left at the chain's defaults when zero,
+	// and MinDeposit, an amount of the chain's Denom, when nil.
+	// They are applied before ModifyGenesis, which may still change them.
+	VotingPeriod, MaxDepositPeriod time.Duration
+	MinDeposit                     *int64
+
+	// Slashing overrides written into the slashing module genesis, e.g. so that a stopped validator
+	// is jailed for downtime within a few blocks; see cosmos.CosmosChain.JailValidator.
+	// A validator is jailed once it misses more than (1 - MinSignedPerWindow) of the last SignedBlocksWindow blocks,
+	// with MinSignedPerWindow a decimal fraction such as "0.5".
+	// Each is left at the chain's default when zero or empty, and applied before ModifyGenesis.
+	SignedBlocksWindow   int64
+	MinSignedPerWindow   string
+	DowntimeJailDuration time.Duration
+
+	// GenesisTime and InitialHeight override the genesis_time and initial_height of the genesis file,
+	// e.g. to test light client time windows or to continue from a snapshot height.
+	// The overridden genesis file is shared by every node, so all validators agree on them.
+	// Nodes wait until GenesisTime before producing the first block, so a time far in the future stalls the chain.
+	// Each is left at the chain's default when zero, and applied before ModifyGenesis.
+	GenesisTime   time.Time
+	InitialHeight int64
+
+	// Bank send restrictions written into the bank module genesis, e.g. to check that a denom cannot leave the chain over IBC.
+	// SendEnabled sets whether each of its denoms can be sent, and DefaultSendEnabled, when not nil,
+	// whether the other denoms can. Sends and IBC transfers they reject fail with cosmos.ErrSendDisabled.
+	// Both are applied before ModifyGenesis; nothing is restricted by default.
+	//
+	// Blocked recipients, such as module accounts, are configured by the chain's app rather than its genesis,
+	// and sends to them fail with cosmos.ErrBlockedAddress.
+	SendEnabled        map[string]bool
+	DefaultSendEnabled *bool
+
+	// UniqueChainID derives the chain ID and chain name generated for a spec leaving them empty
+	// from the name of the test building the chain, as in TestChainID, rather than from a counter of the process.
+	// This keeps the chains of tests running in parallel apart in logs and on a shared Docker network.
+	// A ChainID or ChainName that is set is always used as is.
+	UniqueChainID bool
+
 	// Generate the automatic suffix on demand when needed.
 	autoSuffixOnce sync.Once
 	autoSuffix     string
@@ -49,6 +92,11 @@ type ChainSpec struct {
 // Config returns the underlying ChainConfig,
 // with any overrides applied.
 func (s *ChainSpec) Config(log *zap.Logger) (*ibc.ChainConfig, error) {
+	return s.config(log, s.suffix)
+}
+
+// config is Config, with suffix returning the suffix of a generated chain name or chain ID.
+func (s *ChainSpec) config(log *zap.Logger, suffix func() string) (*ibc.ChainConfig, error) {
 	if s.Version == "" {
 		// Version must be set at top-level if not set in inlined config.
 		if len(s.ChainConfig.Images) == 0 || s.ChainConfig.Images[0].Version == "" {
@@ -73,7 +121,7 @@ func (s *ChainSpec) Config(log *zap.Logger) (*ibc.ChainConfig, error) {
 			return nil, errors.New("ChainSpec.Name required when not all config fields are set")
 		}
 
-		return s.applyConfigOverrides(s.ChainConfig)
+		return s.applyConfigOverrides(s.ChainConfig, suffix)
 	}
 
 	builtinChainConfigs, err := initBuiltinChainConfig(log)
@@ -113,14 +161,14 @@ func (s *ChainSpec) Config(log *zap.Logger) (*ibc.ChainConfig, error) {
 	cfg.CoinType = coinType
 
 	// Apply remaining top-level overrides.
-	return s.applyConfigOverrides(cfg)
+	return s.applyConfigOverrides(cfg, suffix)
 }
 
-func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig, error) {
+func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig, suffix func() string) (*ibc.ChainConfig, error) {
 	// If no ChainName provided, generate one based on the spec name.
 	cfg.Name = s.ChainName
 	if cfg.Name == "" {
-		cfg.Name = s.Name + s.suffix()
+		cfg.Name = s.Name + suffix()
 	}
 
 	// If no ChainID provided, generate one -- prefer chain name but fall back to spec name.
@@ -129,7 +177,7 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 		if prefix == "" {
 			prefix = s.Name
 		}
-		cfg.ChainID = prefix + s.suffix()
+		cfg.ChainID = prefix + suffix()
 	}
 
 	if s.GasAdjustment != nil {
@@ -141,10 +189,39 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 	if s.ModifyGenesis != nil {
 		cfg.ModifyGenesis = s.ModifyGenesis
 	}
+	if s.PreGenesis != nil {
+		cfg.PreGenesis = s.PreGenesis
+	}
+	if s.PostStart != nil {
+		cfg.PostStart = s.PostStart
+	}
+
+	// The genesis overrides of the spec are applied before any ModifyGenesis of the config, which gets the final say.
+	var modifyGenesis []func(ibc.ChainConfig, []byte) ([]byte, error)
+	if s.VotingPeriod != 0 || s.MaxDepositPeriod != 0 || s.MinDeposit != nil {
+		modifyGenesis = append(modifyGenesis, modifyGenesisGov(s.VotingPeriod, s.MaxDepositPeriod, s.MinDeposit))
+	}
+	if s.SignedBlocksWindow != 0 || s.MinSignedPerWindow != "" || s.DowntimeJailDuration != 0 {
+		modifyGenesis = append(modifyGenesis, modifyGenesisSlashing(s.SignedBlocksWindow, s.MinSignedPerWindow, s.DowntimeJailDuration))
+	}
+	if !s.GenesisTime.IsZero() || s.InitialHeight != 0 {
+		modifyGenesis = append(modifyGenesis, modifyGenesisStart(s.GenesisTime, s.InitialHeight))
+	}
+	if len(s.SendEnabled) > 0 || s.DefaultSendEnabled != nil {
+		modifyGenesis = append(modifyGenesis, modifyGenesisBank(s.SendEnabled, s.DefaultSendEnabled))
+	}
+	if len(modifyGenesis) > 0 {
+		cfg.ModifyGenesis = chainModifyGenesis(append(modifyGenesis, cfg.ModifyGenesis)...)
+	}
+
+	// Fail before any container is created on invalid resource limits.
+	if _, err := dockerutil.ContainerResources(cfg.CPUs, cfg.Memory); err != nil {
+		return nil, err
+	}
 
 	// Set the version depending on the chain type.
 	switch cfg.Type {
-	case "cosmos":
+	case "cosmos", "ethereum":
 		if s.Version != "" && len(cfg.Images) > 0 {
 			cfg.Images[0].Version = s.Version
 		}
@@ -208,5 +285,18 @@ func (s *ChainSpec) suffix() string {
 	return s.autoSuffix
 }
 
+// TestChainID returns a chain ID for the chain named name, at the given index among the chains of the test named testName,
+// e.g. "gaia-1c8f2e0a-1" for the first chain.
+// The ID differs between tests, yet is the same on every run of a test, as it is derived from a hash of testName.
+func TestChainID(testName, name string, index int) string {
+	return name + testSuffix(testName, index)
+}
+
+// testSuffix returns the suffix of TestChainID.
+func testSuffix(testName string, index int) string {
+	sum := sha256.Sum256([]byte(testName))
+	return fmt.Sprintf("-%x-%d", sum[:4], index+1)
+}
+
 // suffixCounter is a package-level counter for safely generating unique suffixes per execution environment.
 var suffixCounter int32
@@ -1,14 +1,18 @@
 package interchaintest_test
 
 import (
+	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestChainSpec_Config(t *testing.T) {
@@ -93,18 +97,76 @@ func TestChainSpec_Config(t *testing.T) {
 			require.Equal(t, "mychain", cfg.Name)
 			require.Regexp(t, regexp.MustCompile(`^mychain-\d+$`), cfg.ChainID)
 		})
+
+		t.Run("derived from test name when UniqueChainID set", func(t *testing.T) {
+			chains, err := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+				{Name: "gaia", Version: "v7.0.1", UniqueChainID: true},
+				{Name: "gaia", Version: "v7.0.1", UniqueChainID: true},
+				{Name: "gaia", ChainName: "receiver", Version: "v7.0.1", UniqueChainID: true},
+				{Name: "gaia", Version: "v7.0.1", UniqueChainID: true, ChainConfig: ibc.ChainConfig{ChainID: "fixed-1"}},
+			}).Chains("TestParallel")
+			require.NoError(t, err)
+
+			ids := make([]string, len(chains))
+			for i, c := range chains {
+				ids[i] = c.Config().ChainID
+			}
+			require.Equal(t, []string{
+				interchaintest.TestChainID("TestParallel", "gaia", 0),
+				interchaintest.TestChainID("TestParallel", "gaia", 1),
+				interchaintest.TestChainID("TestParallel", "receiver", 2),
+				"fixed-1",
+			}, ids)
+			require.Equal(t, ids[0], chains[0].Config().Name)
+			require.Equal(t, "receiver", chains[2].Config().Name)
+
+			require.Regexp(t, regexp.MustCompile(`^gaia-[0-9a-f]{8}-1$`), ids[0])
+			require.Equal(t, ids[0], interchaintest.TestChainID("TestParallel", "gaia", 0), "IDs must be reproducible")
+			require.NotEqual(t, ids[0], interchaintest.TestChainID("TestOther", "gaia", 0))
+		})
+
+		t.Run("derived from each test reusing a UniqueChainID spec", func(t *testing.T) {
+			specs := []*interchaintest.ChainSpec{{Name: "gaia", Version: "v7.0.1", UniqueChainID: true}}
+			f := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), specs)
+
+			for _, testName := range []string{"TestFirst", "TestSecond"} {
+				chains, err := f.Chains(testName)
+				require.NoError(t, err)
+				require.Equal(t, interchaintest.TestChainID(testName, "gaia", 0), chains[0].Config().ChainID)
+			}
+		})
+	})
+
+	t.Run("ethereum", func(t *testing.T) {
+		s := interchaintest.ChainSpec{
+			Name: "anvil",
+
+			Version: "nightly",
+		}
+
+		cfg, err := s.Config(zaptest.NewLogger(t))
+		require.NoError(t, err)
+
+		require.Equal(t, "ethereum", cfg.Type)
+		require.Equal(t, "60", cfg.CoinType)
+		require.Equal(t, "nightly", cfg.Images[0].Version)
 	})
 
 	t.Run("overrides", func(t *testing.T) {
-		baseSpec := &interchaintest.ChainSpec{
-			Name:    "gaia",
-			Version: "v7.0.1",
+		// A subtest whose overrides must not leak into later subtests overrides its own copy of the base spec.
+		// ChainSpec must not be copied once used, so the copies are made by newBaseSpec.
+		newBaseSpec := func() *interchaintest.ChainSpec {
+			return &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
 
-			ChainName: "g",
-			ChainConfig: ibc.ChainConfig{
-				ChainID: "g-0000",
-			},
+				ChainName: "g",
+				ChainConfig: ibc.ChainConfig{
+					ChainID: "g-0000",
+				},
+			}
 		}
+		baseSpec := newBaseSpec()
 		baseCfg, err := baseSpec.Config(zaptest.NewLogger(t))
 		require.NoError(t, err)
 
@@ -133,6 +195,87 @@ func TestChainSpec_Config(t *testing.T) {
 
 			require.Equal(t, m, cfg.NoHostMount)
 		})
+
+		t.Run("lifecycle hooks", func(t *testing.T) {
+			require.Nil(t, baseCfg.PreGenesis)
+			require.Nil(t, baseCfg.PostStart)
+
+			var called []string
+			s := baseSpec
+			s.PreGenesis = func(context.Context, ibc.Chain) error {
+				called = append(called, "pre-genesis")
+				return nil
+			}
+			s.PostStart = func(context.Context, ibc.Chain) error {
+				called = append(called, "post-start")
+				return nil
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.NoError(t, cfg.PreGenesis(context.Background(), nil))
+			require.NoError(t, cfg.PostStart(context.Background(), nil))
+			require.Equal(t, []string{"pre-genesis", "post-start"}, called)
+		})
+
+		t.Run("governance genesis", func(t *testing.T) {
+			require.Nil(t, baseCfg.ModifyGenesis)
+
+			s := newBaseSpec()
+			s.VotingPeriod = 10 * time.Second
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			out, err := cfg.ModifyGenesis(*cfg, []byte(`{"app_state":{"gov":{"voting_params":{"voting_period":"172800s"},"deposit_params":{}}}}`))
+			require.NoError(t, err)
+			require.Contains(t, string(out), `"voting_period":"10s"`)
+		})
+
+		t.Run("slashing genesis", func(t *testing.T) {
+			s := newBaseSpec()
+			s.SignedBlocksWindow = 10
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			out, err := cfg.ModifyGenesis(*cfg, []byte(`{"app_state":{"slashing":{"params":{"signed_blocks_window":"100"}}}}`))
+			require.NoError(t, err)
+			require.Contains(t, string(out), `"signed_blocks_window":"10"`)
+		})
+
+		t.Run("bank genesis", func(t *testing.T) {
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				SendEnabled: map[string]bool{"uatom": false},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			out, err := cfg.ModifyGenesis(*cfg, []byte(`{"app_state":{"bank":{"params":{"send_enabled":[],"default_send_enabled":true}}}}`))
+			require.NoError(t, err)
+			require.Contains(t, string(out), `"send_enabled":[{"denom":"uatom","enabled":false}]`)
+		})
+
+		t.Run("genesis start", func(t *testing.T) {
+			s := &interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				InitialHeight: 50,
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			out, err := cfg.ModifyGenesis(*cfg, []byte(`{"initial_height":"1"}`))
+			require.NoError(t, err)
+			require.Contains(t, string(out), `"initial_height":"50"`)
+		})
 	})
 
 	t.Run("error cases", func(t *testing.T) {
@@ -163,5 +306,37 @@ func TestChainSpec_Config(t *testing.T) {
 			_, err := s.Config(zaptest.NewLogger(t))
 			require.ErrorContains(t, err, "no chain configuration for invalid_chain (available chains are:")
 		})
+
+		t.Run("invalid memory limit", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{Memory: "lots"},
+			}
+
+			_, err := s.Config(zaptest.NewLogger(t))
+			require.ErrorContains(t, err, `invalid memory limit "lots"`)
+		})
 	})
 }
+
+func TestBuiltinChainFactory_DuplicateChainID(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	cf := interchaintest.NewBuiltinChainFactory(zap.New(core), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+		{Name: "gaia", ChainName: "g3", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+	})
+
+	_, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	warnings := logs.FilterMessage("Duplicate chain ID in chain specs").All()
+	require.Len(t, warnings, 1)
+	require.Equal(t, map[string]any{
+		"chain_id":    "cosmoshub-0",
+		"first_index": int64(0),
+		"index":       int64(2),
+	}, warnings[0].ContextMap())
+}
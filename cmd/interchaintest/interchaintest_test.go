@@ -172,7 +172,7 @@ func configureTestReporter() error {
 func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerFactory, error) {
 	switch name {
 	case "rly", "cosmos/relayer":
-		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.StartupFlags("-b", "100")), nil
+		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.BlockHistory(100)), nil
 	case "hermes":
 		return interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
 	default:
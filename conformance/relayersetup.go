@@ -79,7 +79,18 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		rep.TrackTest(t)
 		req := require.New(rep.TestifyT(t))
 
-		req.NoError(r.CreateClients(ctx, rep.RelayerExecReporter(t), pathName, ibc.DefaultClientOpts()))
+		eRep := rep.RelayerExecReporter(t)
+		created, err := r.CreateClients(ctx, eRep, pathName, ibc.DefaultClientOpts())
+		req.NoError(err)
+
+		// The IDs reported by CreateClients must be those of the clients now on chain.
+		clients0, err := r.GetClients(ctx, eRep, c0.Config().ChainID)
+		req.NoError(err)
+		req.Len(clients0, 1)
+		clients1, err := r.GetClients(ctx, eRep, c1.Config().ChainID)
+		req.NoError(err)
+		req.Len(clients1, 1)
+		req.Equal(ibc.CreatedClients{ClientID: clients0[0].ClientID, CounterpartyClientID: clients1[0].ClientID}, created)
 	})
 	if t.Failed() {
 		return
@@ -93,7 +104,8 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		req := require.New(rep.TestifyT(t))
 
 		eRep := rep.RelayerExecReporter(t)
-		req.NoError(r.CreateConnections(ctx, eRep, pathName))
+		created, err := r.CreateConnections(ctx, eRep, pathName)
+		req.NoError(err)
 
 		// Assert against the singly created connections individually.
 		conns0, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
@@ -119,6 +131,9 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		req.Equal(conn0.Counterparty.ConnectionId, conn1.ID)
 		req.Equal(conn1.Counterparty.ClientId, conn0.ClientID)
 		req.Equal(conn1.Counterparty.ConnectionId, conn0.ID)
+
+		// The IDs reported by CreateConnections must be those of the connections now on chain.
+		req.Equal(ibc.CreatedConnection{ConnectionID: conn0.ID, CounterpartyConnectionID: conn1.ID}, created)
 	})
 	if t.Failed() {
 		return
@@ -129,12 +144,13 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		req := require.New(rep.TestifyT(t))
 
 		eRep := rep.RelayerExecReporter(t)
-		req.NoError(r.CreateChannel(ctx, eRep, pathName, ibc.CreateChannelOptions{
+		created, err := r.CreateChannel(ctx, eRep, pathName, ibc.CreateChannelOptions{
 			SourcePortName: "transfer",
 			DestPortName:   "transfer",
 			Order:          ibc.Unordered,
 			Version:        "ics20-1",
-		}))
+		})
+		req.NoError(err)
 
 		// Now validate that the channels correctly report as created.
 		// GetChannels takes around two seconds with rly,
@@ -159,16 +175,23 @@ func TestRelayerSetup(t *testing.T, ctx context.Context, cf interchaintest.Chain
 		req.Len(channels1, 1)
 		ch1 := channels1[0]
 
+		// The IDs reported by CreateChannel must be those of the channels now on chain.
+		req.Equal(ibc.CreatedChannel{ChannelID: ch0.ChannelID, CounterpartyChannelID: ch1.ChannelID}, created)
+
 		// Piecemeal assertions against each channel.
-		// Not asserting against ConnectionHops or ChannelID.
+		// Not asserting against ConnectionHops.
 		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch0.State})
 		req.Subset([]string{"ORDER_UNORDERED", "Unordered"}, []string{ch0.Ordering})
+		req.True(ch0.Open())
+		req.Equal(ibc.Unordered, ch0.Order())
 		req.Equal(ch0.Counterparty, ibc.ChannelCounterparty{PortID: "transfer", ChannelID: ch1.ChannelID})
 		req.Equal(ch0.Version, "ics20-1")
 		req.Equal(ch0.PortID, "transfer")
 
 		req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch1.State})
 		req.Subset([]string{"ORDER_UNORDERED", "Unordered"}, []string{ch1.Ordering})
+		req.True(ch1.Open())
+		req.Equal(ibc.Unordered, ch1.Order())
 		req.Equal(ch1.Counterparty, ibc.ChannelCounterparty{PortID: "transfer", ChannelID: ch0.ChannelID})
 		req.Equal(ch1.Version, "ics20-1")
 		req.Equal(ch1.PortID, "transfer")
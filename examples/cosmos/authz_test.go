@@ -0,0 +1,69 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestAuthz checks that a grantee can send the granter's funds once authorized, and only then.
+func TestAuthz(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), fundAmount, gaia, gaia)
+	granter, grantee := users[0].(*cosmos.CosmosWallet), users[1].(*cosmos.CosmosWallet)
+
+	denom := gaia.Config().Denom
+	const sendAmount = int64(1_000)
+	send := banktypes.NewMsgSend(granter.Address(), grantee.Address(), types.NewCoins(types.NewInt64Coin(denom, sendAmount)))
+
+	resp, err := gaia.ExecAuthz(ctx, grantee.KeyName(), send)
+	require.NoError(t, err)
+	require.NotZero(t, resp.Code, "unauthorized exec must fail")
+
+	require.NoError(t, gaia.GrantAuthorization(ctx, granter.KeyName(), grantee.FormattedAddress(),
+		"/cosmos.bank.v1beta1.MsgSend", time.Now().Add(time.Hour)))
+
+	resp, err = gaia.ExecAuthz(ctx, grantee.KeyName(), send)
+	require.NoError(t, err)
+	require.Zero(t, resp.Code, resp.RawLog)
+
+	granterBal, err := gaia.GetBalance(ctx, granter.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Less(t, granterBal, fundAmount-sendAmount, "granter must have sent the funds and paid the grant fees")
+}
@@ -62,6 +62,8 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeVers
 		NetworkID:         network,
 		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
 		SkipPathCreation:  true,
+		LivenessTimeout:   time.Minute,
+		LivenessFailer:    t,
 	}))
 	t.Cleanup(func() {
 		_ = ic.Close()
@@ -99,6 +101,10 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeVers
 	height, err = chain.Height(ctx)
 	require.NoError(t, err, "error fetching height before upgrade")
 
+	// the chain halts at the upgrade height on purpose, until it is restarted with the upgraded version.
+	resumeLiveness := ic.PauseLiveness()
+	defer resumeLiveness()
+
 	// this should timeout due to chain halt at upgrade height.
 	_ = testutil.WaitForBlocks(timeoutCtx, int(haltHeight-height)+1, chain)
 
@@ -126,6 +132,7 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeVers
 
 	err = testutil.WaitForBlocks(timeoutCtx, int(blocksAfterUpgrade), chain)
 	require.NoError(t, err, "chain did not produce blocks after upgrade")
+	resumeLiveness()
 
 	height, err = chain.Height(ctx)
 	require.NoError(t, err, "error fetching height after upgrade")
@@ -0,0 +1,81 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFeeGrant checks that a transaction paid through a fee grant is charged to the granter, not the grantee.
+func TestFeeGrant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), fundAmount, gaia, gaia, gaia)
+	granter, grantee, recipient := users[0], users[1], users[2]
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+
+	denom := gaia.Config().Denom
+	require.NoError(t, gaia.GrantFeeAllowance(ctx, granter.KeyName(), grantee.FormattedAddress(), ibc.FeeAllowance{
+		SpendLimit:      "1000000" + denom,
+		AllowedMessages: []string{"/cosmos.bank.v1beta1.MsgSend"},
+	}))
+
+	granterBefore, err := gaia.GetBalance(ctx, granter.FormattedAddress(), denom)
+	require.NoError(t, err)
+
+	const sendAmount = int64(1_000)
+	granteeWallet := grantee.(*cosmos.CosmosWallet)
+	msg := banktypes.NewMsgSend(
+		granteeWallet.Address(),
+		recipient.(*cosmos.CosmosWallet).Address(),
+		types.NewCoins(types.NewInt64Coin(denom, sendAmount)),
+	)
+	resp, err := gaia.BroadcastTxWithFeeGranter(ctx, granter.FormattedAddress(), granteeWallet, msg)
+	require.NoError(t, err)
+	require.Zero(t, resp.Code, resp.RawLog)
+
+	// The grantee only spent what it sent, and the granter paid the fees.
+	granteeBal, err := gaia.GetBalance(ctx, grantee.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, fundAmount-sendAmount, granteeBal)
+
+	granterAfter, err := gaia.GetBalance(ctx, granter.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Less(t, granterAfter, granterBefore)
+}
@@ -0,0 +1,73 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestJailValidator checks that a validator which stops signing is jailed for downtime,
+// while the chain keeps producing blocks with the remaining validators.
+func TestJailValidator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// With equal stakes, the other three validators keep more than two thirds of the voting power.
+	numVals, numFullNodes := 4, 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+
+			SignedBlocksWindow:   10,
+			MinSignedPerWindow:   "0.5",
+			DowntimeJailDuration: 10 * time.Second,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	operator, err := gaia.Validators[1].ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+
+	jailed, err := gaia.JailValidator(ctx, 1, 30)
+	require.NoError(t, err)
+	require.Equal(t, operator, jailed.OperatorAddress)
+	require.True(t, jailed.Jailed)
+
+	// The chain is still live without the jailed validator.
+	height, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		h, err := gaia.Height(ctx)
+		return err == nil && h > height+2
+	}, time.Minute, time.Second)
+
+	require.NoError(t, gaia.Validators[1].StartContainer(ctx))
+}
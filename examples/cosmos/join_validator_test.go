@@ -0,0 +1,72 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestJoinValidatorSet checks that a full node staked after genesis joins the validator set bonded.
+func TestJoinValidatorSet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	before, err := gaia.QueryValidators(ctx)
+	require.NoError(t, err)
+
+	// The funder's key is recovered on the first full node, which sends the new validator its funds.
+	funder := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, gaia)[0]
+
+	const selfDelegation = 1_000_000_000
+	fullNode := gaia.FullNodes[0]
+	require.NoError(t, gaia.JoinValidatorSet(ctx, fullNode, funder.KeyName(), selfDelegation, 1_000_000))
+
+	operator, err := fullNode.ValidatorOperatorAddress(ctx)
+	require.NoError(t, err)
+
+	after, err := gaia.QueryValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, after, len(before)+1)
+
+	var joined bool
+	for _, v := range after {
+		if v.OperatorAddress != operator {
+			continue
+		}
+		joined = true
+		require.Equal(t, stakingtypes.Bonded.String(), v.Status)
+		require.False(t, v.Jailed)
+		require.Equal(t, int64(selfDelegation), v.Tokens.Int64())
+	}
+	require.True(t, joined, "validator %s not in the validator set", operator)
+}
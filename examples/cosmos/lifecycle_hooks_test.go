@@ -0,0 +1,118 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestLifecycleHooks checks that PreGenesis runs before the genesis transactions are collected,
+// with its genesis accounts included in the chain's genesis, and that PostStart runs once the chain produces blocks.
+func TestLifecycleHooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const amount = 1_234_567
+	moduleAccount := sdk.MustBech32ifyAddressBytes("cosmos", make([]byte, 20))
+
+	// What each hook observed, asserted once Build returns.
+	var (
+		preGenesisGentxs  = -1
+		postStartGentxs   = -1
+		postStartHeight   uint64
+		postStartBalance  int64
+		preGenesisRunning bool
+	)
+
+	numVals, numFullNodes := 2, 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumValidators: &numVals, NumFullNodes: &numFullNodes, ChainConfig: ibc.ChainConfig{
+			PreGenesis: func(ctx context.Context, chain ibc.Chain) error {
+				c := chain.(*cosmos.CosmosChain)
+
+				var err error
+				preGenesisGentxs, err = genesisGentxs(ctx, c)
+				if err != nil {
+					return err
+				}
+
+				// No node has been started yet, so none has an RPC client.
+				for _, n := range c.Nodes() {
+					preGenesisRunning = preGenesisRunning || n.Client != nil
+				}
+
+				return c.Validators[0].AddGenesisAccount(ctx, moduleAccount, []sdk.Coin{sdk.NewInt64Coin(c.Config().Denom, amount)})
+			},
+			PostStart: func(ctx context.Context, chain ibc.Chain) error {
+				c := chain.(*cosmos.CosmosChain)
+
+				var err error
+				if postStartGentxs, err = genesisGentxs(ctx, c); err != nil {
+					return err
+				}
+				if postStartHeight, err = c.Height(ctx); err != nil {
+					return err
+				}
+				postStartBalance, err = c.GetBalance(ctx, moduleAccount, c.Config().Denom)
+				return err
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Before genesis, the gentxs of every validator are yet to be collected, and the chain is not running.
+	require.Zero(t, preGenesisGentxs)
+	require.False(t, preGenesisRunning)
+
+	// After start, the genesis holds a gentx per validator, the chain has produced blocks,
+	// and the account added by PreGenesis was funded at genesis.
+	require.Equal(t, numVals, postStartGentxs)
+	require.GreaterOrEqual(t, postStartHeight, uint64(5))
+	require.EqualValues(t, amount, postStartBalance)
+}
+
+// genesisGentxs returns the number of genesis transactions collected into the genesis file of the chain's first validator.
+func genesisGentxs(ctx context.Context, c *cosmos.CosmosChain) (int, error) {
+	bz, err := c.Validators[0].ReadFile(ctx, "config/genesis.json")
+	if err != nil {
+		return 0, err
+	}
+	var genesis struct {
+		AppState struct {
+			Genutil struct {
+				GenTxs []json.RawMessage `json:"gen_txs"`
+			} `json:"genutil"`
+		} `json:"app_state"`
+	}
+	if err := json.Unmarshal(bz, &genesis); err != nil {
+		return 0, err
+	}
+	return len(genesis.AppState.Genutil.GenTxs), nil
+}
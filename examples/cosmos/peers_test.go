@@ -0,0 +1,89 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestNodePeerInfo checks that every node of a chain is peered with every other node.
+func TestNodePeerInfo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	numVals, numFullNodes := 2, 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, NumValidators: &numVals, NumFullNodes: &numFullNodes},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	infos, err := gaia.NodePeerInfo(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, numVals+numFullNodes)
+
+	for i, info := range infos {
+		id, err := gaia.GetNodeID(ctx, i)
+		require.NoError(t, err)
+		require.Equal(t, id, info.NodeID)
+		require.Equal(t, id+"@"+gaia.Nodes()[i].HostName()+":26656", info.P2PAddress)
+		require.NotEmpty(t, info.HostP2PAddress)
+
+		// Every node is configured with the address of every node.
+		for _, peer := range infos {
+			require.Contains(t, info.PersistentPeers, peer.P2PAddress)
+		}
+	}
+
+	// Connections are established in the background, so each node eventually sees all the others.
+	require.Eventually(t, func() bool {
+		infos, err := gaia.NodePeerInfo(ctx)
+		if err != nil {
+			return false
+		}
+		for _, info := range infos {
+			if len(info.ConnectedPeers) != len(infos)-1 {
+				return false
+			}
+			for _, peer := range infos {
+				if peer.NodeID != info.NodeID && !containsString(info.ConnectedPeers, peer.NodeID) {
+					return false
+				}
+			}
+		}
+		return true
+	}, time.Minute, time.Second)
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestConfigTemplates checks that the templates in testdata/templates are the base of the files of every node,
+// with the harness settings, ConfigFileOverrides and the genesis modifications of the chain spec layered on top.
+func TestConfigTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				Templates: ibc.ConfigTemplates{
+					Genesis:    "testdata/templates/genesis.json",
+					AppToml:    "testdata/templates/app.toml",
+					ConfigToml: "testdata/templates/config.toml",
+				},
+				ConfigFileOverrides: map[string]any{
+					"config/config.toml": testutil.Toml{"p2p": testutil.Toml{"max_num_inbound_peers": 22}},
+					"config/app.toml":    testutil.Toml{"state-sync": testutil.Toml{"snapshot-keep-recent": 5}},
+				},
+			},
+			VotingPeriod: 10 * time.Second,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	for _, n := range gaia.Nodes() {
+		var config struct {
+			RPC     struct{ Laddr string }
+			Mempool struct{ Size int }
+			P2P     struct {
+				MaxNumInboundPeers int `toml:"max_num_inbound_peers"`
+			}
+		}
+		bz, err := n.ReadFile(ctx, "config/config.toml")
+		require.NoError(t, err)
+		_, err = toml.Decode(string(bz), &config)
+		require.NoError(t, err)
+		require.Equal(t, "tcp://0.0.0.0:26657", config.RPC.Laddr, "harness settings replace the template")
+		require.Equal(t, 1234, config.Mempool.Size, "the template replaces the defaults of init")
+		require.Equal(t, 22, config.P2P.MaxNumInboundPeers, "overrides replace the template")
+
+		var app struct {
+			MinimumGasPrices string `toml:"minimum-gas-prices"`
+			API              struct {
+				MaxOpenConnections int `toml:"max-open-connections"`
+			}
+			StateSync struct {
+				SnapshotKeepRecent int `toml:"snapshot-keep-recent"`
+			} `toml:"state-sync"`
+		}
+		bz, err = n.ReadFile(ctx, "config/app.toml")
+		require.NoError(t, err)
+		_, err = toml.Decode(string(bz), &app)
+		require.NoError(t, err)
+		require.Equal(t, gaia.Config().GasPrices, app.MinimumGasPrices, "harness settings replace the template")
+		require.Equal(t, 123, app.API.MaxOpenConnections, "the template replaces the defaults of init")
+		require.Equal(t, 5, app.StateSync.SnapshotKeepRecent, "overrides replace the template")
+
+		var genesis struct {
+			ChainID         string `json:"chain_id"`
+			ConsensusParams struct {
+				Block struct {
+					MaxGas string `json:"max_gas"`
+				}
+			} `json:"consensus_params"`
+			AppState struct {
+				Gov struct {
+					DepositParams struct {
+						MaxDepositPeriod string `json:"max_deposit_period"`
+					} `json:"deposit_params"`
+					VotingParams struct {
+						VotingPeriod string `json:"voting_period"`
+					} `json:"voting_params"`
+				}
+				Genutil struct {
+					GenTxs []json.RawMessage `json:"gen_txs"`
+				}
+			} `json:"app_state"`
+		}
+		bz, err = n.ReadFile(ctx, "config/genesis.json")
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &genesis))
+		require.Equal(t, gaia.Config().ChainID, genesis.ChainID, "the chain keeps its chain ID")
+		require.Equal(t, "100000000", genesis.ConsensusParams.Block.MaxGas)
+		require.Equal(t, "4321s", genesis.AppState.Gov.DepositParams.MaxDepositPeriod)
+		require.Equal(t, "10s", genesis.AppState.Gov.VotingParams.VotingPeriod, "ModifyGenesis replaces the template")
+		require.Len(t, genesis.AppState.Genutil.GenTxs, len(gaia.Validators), "gentxs are collected into the template")
+	}
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+}
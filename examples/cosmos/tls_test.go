@@ -0,0 +1,143 @@
+package cosmos_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestTLSNode runs a chain whose nodes serve their RPC over TLS only, with a self-signed certificate,
+// and checks that the harness, the commands run within the nodes and event subscriptions reach it.
+func TestTLSNode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: gaiaVersion, ChainConfig: ibc.ChainConfig{
+			// Once the nodes exist, and their host names are known, each is given the same certificate.
+			PreGenesis: writeNodeCertificates,
+			ConfigFileOverrides: map[string]any{
+				"config/config.toml": testutil.Toml{"rpc": testutil.Toml{
+					"tls_cert_file": "node.crt",
+					"tls_key_file":  "node.key",
+				}},
+			},
+			TLS: ibc.TLSConfig{
+				Enabled:            true,
+				InsecureSkipVerify: true,
+				CACertFile:         "config/node.crt",
+			},
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+
+	client, network := interchaintest.DockerSetup(t)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.Contains(t, chain.GetRPCAddress(), "https://")
+
+	// Subscribed over wss before the transfer, so that its events are seen.
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := chain.SubscribeEvents(subCtx, "tm.event='Tx'")
+	require.NoError(t, err)
+
+	// Funding runs a bank send within the node, verifying the node's certificate.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000, chain)
+
+	timeout := time.After(time.Minute)
+	for {
+		select {
+		case ev, ok := <-events:
+			require.True(t, ok, "subscription closed")
+			if ev.Type != "transfer" {
+				continue
+			}
+			recipient, _ := cosmos.AttributeValue([]abcitypes.Event{ev}, "transfer", "recipient")
+			if recipient == users[0].FormattedAddress() {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the transfer event")
+		}
+	}
+}
+
+// writeNodeCertificates writes a self-signed certificate, valid for the host names of every node of chain,
+// and its key into the config directory of each node.
+func writeNodeCertificates(ctx context.Context, chain ibc.Chain) error {
+	nodes := chain.(*cosmos.CosmosChain).Nodes()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: chain.Config().ChainID},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:              []string{"localhost"},
+	}
+	for _, n := range nodes {
+		tmpl.DNSNames = append(tmpl.DNSNames, n.HostName())
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	for _, n := range nodes {
+		if err := n.WriteFile(ctx, cert, "config/node.crt"); err != nil {
+			return err
+		}
+		if err := n.WriteFile(ctx, keyPEM, "config/node.key"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
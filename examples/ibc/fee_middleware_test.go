@@ -0,0 +1,129 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	feetypes "github.com/cosmos/ibc-go/v6/modules/apps/29-fee/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// simdChainConfig returns the config of an ibc-go simapp chain, whose transfer stack includes the ICS-29 fee middleware.
+func simdChainConfig(chainID string) ibc.ChainConfig {
+	return ibc.ChainConfig{
+		Type:    "cosmos",
+		ChainID: chainID,
+		Images: []ibc.DockerImage{{
+			Repository: "ghcr.io/cosmos/ibc-go-simd",
+			Version:    "v6.1.0",
+			UidGid:     "1025:1025",
+		}},
+		Bin:            "simd",
+		Bech32Prefix:   "cosmos",
+		Denom:          "stake",
+		GasPrices:      "0.00stake",
+		GasAdjustment:  1.3,
+		TrustingPeriod: "336h",
+	}
+}
+
+// TestFeeMiddleware relays a transfer over an ICS-29 fee-enabled channel
+// and checks that the counterparty payee registered by the relayer receives the packet's receive fee.
+func TestFeeMiddleware(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{ChainName: "simd-a", ChainConfig: simdChainConfig("simd-a")},
+		{ChainName: "simd-b", ChainConfig: simdChainConfig("simd-b")},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chainA, chainB := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const pathName = "fee"
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:            chainA,
+			Chain2:            chainB,
+			Relayer:           r,
+			Path:              pathName,
+			CreateChannelOpts: ibc.FeeChannelOpts(),
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, chainA.Config().ChainID, chainB.Config().ChainID)
+	require.NoError(t, err)
+	require.Equal(t, ibc.FeeChannelVersion("ics20-1"), channel.Version)
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), fundAmount, chainA, chainA, chainB)
+	sender, payee, receiver := users[0], users[1], users[2]
+
+	// The relayer relays the packet to chainB, so it registers, on chainB, where its receive fees are paid on chainA.
+	relayerB, ok := r.GetWallet(chainB.Config().ChainID)
+	require.True(t, ok)
+	require.NoError(t, chainB.RecoverKey(ctx, "relayer", relayerB.Mnemonic()))
+	require.NoError(t, chainB.RegisterCounterpartyPayee(
+		ctx, "relayer", channel.Counterparty.PortID, channel.Counterparty.ChannelID,
+		relayerB.FormattedAddress(), payee.FormattedAddress(),
+	))
+	registered, err := chainB.QueryCounterpartyPayee(ctx, channel.Counterparty.ChannelID, relayerB.FormattedAddress())
+	require.NoError(t, err)
+	require.Equal(t, payee.FormattedAddress(), registered)
+
+	denom := chainA.Config().Denom
+	tx, err := chainA.SendIBCTransfer(ctx, channel.ChannelID, sender.KeyName(), ibc.WalletAmount{
+		Address: receiver.FormattedAddress(),
+		Denom:   denom,
+		Amount:  1_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	const recvFee = int64(300)
+	require.NoError(t, chainA.PayPacketFee(
+		ctx, sender.KeyName(), channel.PortID, channel.ChannelID, tx.Packet.Sequence,
+		"300"+denom, "200"+denom, "100"+denom,
+	))
+
+	ack, err := testutil.WaitForAck(ctx, chainA, r, eRep, pathName, channel.ChannelID, tx.Packet.Sequence)
+	require.NoError(t, err)
+	// On a fee channel, the application acknowledgement is wrapped with the relayer that relayed the packet.
+	var res feetypes.IncentivizedAcknowledgement
+	require.NoError(t, feetypes.ModuleCdc.UnmarshalJSON(ack.Acknowledgement, &res))
+	require.True(t, res.UnderlyingAppSuccess, "transfer over the fee channel failed: %s", ack.Acknowledgement)
+	require.Equal(t, relayerB.FormattedAddress(), res.ForwardRelayerAddress)
+
+	// The receive fee is paid to the payee once the acknowledgement is relayed back to chainA.
+	bal, err := chainA.GetBalance(ctx, payee.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, fundAmount+recvFee, bal, "payee did not receive the receive fee")
+}
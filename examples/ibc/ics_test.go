@@ -0,0 +1,117 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// icsChainConfig returns the config of an Interchain Security chain run by bin from the ics image.
+func icsChainConfig(chainID, bin string) ibc.ChainConfig {
+	return ibc.ChainConfig{
+		Type:    "cosmos",
+		ChainID: chainID,
+		Images: []ibc.DockerImage{{
+			Repository: "ghcr.io/strangelove-ventures/heighliner/ics",
+			Version:    "v1.0.0",
+			UidGid:     "1025:1025",
+		}},
+		Bin:            bin,
+		Bech32Prefix:   "cosmos",
+		Denom:          "stake",
+		GasPrices:      "0.0stake",
+		GasAdjustment:  1.5,
+		TrustingPeriod: "336h",
+	}
+}
+
+// TestInterchainSecurity spawns a consumer chain from its provider and checks that
+// the CCV channel is opened between them and the consumer produces blocks with the provider's validators.
+func TestInterchainSecurity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// The consumer must have as many validators as its provider.
+	numVals, numFullNodes := 2, 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainName:     "provider",
+			ChainConfig:   icsChainConfig("provider-1", "interchain-security-pd"),
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+
+			// The consumer-addition proposal must pass within a few blocks.
+			VotingPeriod: 10 * time.Second,
+		},
+		{
+			ChainName:     "consumer",
+			ChainConfig:   icsChainConfig("consumer-1", "interchain-security-cd"),
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	provider, consumer := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const ccvPath = "ccv"
+	ic := interchaintest.NewInterchain().
+		AddChain(provider).
+		AddChain(consumer).
+		AddRelayer(r, "relayer").
+		AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+			Provider: provider,
+			Consumer: consumer,
+			Relayer:  r,
+			Path:     ccvPath,
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The CCV channel was opened over the client created with the consumer's genesis.
+	channels, err := r.GetChannels(ctx, eRep, consumer.Config().ChainID)
+	require.NoError(t, err)
+	var ccv *ibc.ChannelOutput
+	for i, ch := range channels {
+		if ch.PortID == cosmos.ConsumerPortID {
+			ccv = &channels[i]
+		}
+	}
+	require.NotNil(t, ccv, "no CCV channel on the consumer")
+	require.Equal(t, "STATE_OPEN", ccv.State)
+	require.Equal(t, cosmos.ProviderPortID, ccv.Counterparty.PortID)
+
+	connections, err := r.GetConnections(ctx, eRep, consumer.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, connections, 1)
+	require.Equal(t, cosmos.ConsumerClientID, connections[0].ClientID)
+
+	// The consumer keeps producing blocks, signed by the provider's validators.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 5, consumer))
+}
@@ -2,13 +2,9 @@ package ibc
 
 import (
 	"context"
-	"encoding/json"
-	"strconv"
-	"strings"
 	"testing"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
@@ -96,14 +92,14 @@ func TestInterchainAccounts(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create new clients
-	err = r.CreateClients(ctx, eRep, pathName, ibc.CreateClientOptions{TrustingPeriod: "330h"})
+	_, err = r.CreateClients(ctx, eRep, pathName, ibc.CreateClientOptions{TrustingPeriod: "330h"})
 	require.NoError(t, err)
 
 	err = testutil.WaitForBlocks(ctx, 5, chain1, chain2)
 	require.NoError(t, err)
 
 	// Create a new connection
-	err = r.CreateConnections(ctx, eRep, pathName)
+	_, err = r.CreateConnections(ctx, eRep, pathName)
 	require.NoError(t, err)
 
 	err = testutil.WaitForBlocks(ctx, 5, chain1, chain2)
@@ -114,22 +110,6 @@ func TestInterchainAccounts(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(connections))
 
-	// Register a new interchain account on chain2, on behalf of the user acc on chain1
-	chain1Addr := chain1User.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(chain1.Config().Bech32Prefix)
-
-	registerICA := []string{
-		chain1.Config().Bin, "tx", "intertx", "register",
-		"--from", chain1Addr,
-		"--connection-id", connections[0].ID,
-		"--chain-id", chain1.Config().ChainID,
-		"--home", chain1.HomeDir(),
-		"--node", chain1.GetRPCAddress(),
-		"--keyring-backend", keyring.BackendTest,
-		"-y",
-	}
-	_, _, err = chain1.Exec(ctx, registerICA, nil)
-	require.NoError(t, err)
-
 	// Start the relayer and set the cleanup function.
 	err = r.StartRelayer(ctx, eRep, pathName)
 	require.NoError(t, err)
@@ -143,21 +123,11 @@ func TestInterchainAccounts(t *testing.T) {
 		},
 	)
 
-	// Wait for relayer to start up and finish channel handshake
-	err = testutil.WaitForBlocks(ctx, 15, chain1, chain2)
-	require.NoError(t, err)
-
-	// Query for the newly registered interchain account
-	queryICA := []string{
-		chain1.Config().Bin, "query", "intertx", "interchainaccounts", connections[0].ID, chain1Addr,
-		"--chain-id", chain1.Config().ChainID,
-		"--home", chain1.HomeDir(),
-		"--node", chain1.GetRPCAddress(),
-	}
-	stdout, _, err := chain1.Exec(ctx, queryICA, nil)
+	// Register a new interchain account on chain2, on behalf of the user acc on chain1,
+	// and wait for the relayer to finish the handshake of its channel.
+	controller := chain1.(*cosmos.CosmosChain)
+	icaAddr, err := testutil.SetupInterchainAccount(ctx, controller, chain2, connections[0].ID, chain1User.KeyName())
 	require.NoError(t, err)
-
-	icaAddr := parseInterchainAccountField(stdout)
 	require.NotEmpty(t, icaAddr)
 
 	// Get initial account balances
@@ -191,32 +161,13 @@ func TestInterchainAccounts(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, icaOrigBal+transferAmount, icaBal)
 
-	// Build bank transfer msg
-	rawMsg, err := json.Marshal(map[string]any{
-		"@type":        "/cosmos.bank.v1beta1.MsgSend",
-		"from_address": icaAddr,
-		"to_address":   chain2Addr,
-		"amount": []map[string]any{
-			{
-				"denom":  chain2.Config().Denom,
-				"amount": strconv.Itoa(transferAmount),
-			},
-		},
-	})
-	require.NoError(t, err)
-
 	// Send bank transfer msg to ICA on chain2 from the user account on chain1
-	sendICATransfer := []string{
-		chain1.Config().Bin, "tx", "intertx", "submit", string(rawMsg),
-		"--connection-id", connections[0].ID,
-		"--from", chain1Addr,
-		"--chain-id", chain1.Config().ChainID,
-		"--home", chain1.HomeDir(),
-		"--node", chain1.GetRPCAddress(),
-		"--keyring-backend", keyring.BackendTest,
-		"-y",
+	icaTransfer := ibc.WalletAmount{
+		Address: chain2Addr,
+		Denom:   chain2.Config().Denom,
+		Amount:  transferAmount,
 	}
-	_, _, err = chain1.Exec(ctx, sendICATransfer, nil)
+	err = testutil.SendICATx(ctx, controller, chain1User.KeyName(), connections[0].ID, icaAddr, icaTransfer)
 	require.NoError(t, err)
 
 	// Wait for tx to be relayed
@@ -242,7 +193,7 @@ func TestInterchainAccounts(t *testing.T) {
 
 	// Send another bank transfer msg to ICA on chain2 from the user account on chain1.
 	// This message should timeout and the channel will be closed when we re-start the relayer.
-	_, _, err = chain1.Exec(ctx, sendICATransfer, nil)
+	err = testutil.SendICATx(ctx, controller, chain1User.KeyName(), connections[0].ID, icaAddr, icaTransfer)
 	require.NoError(t, err)
 
 	// Wait for approximately one minute to allow packet timeout threshold to be hit
@@ -275,8 +226,9 @@ func TestInterchainAccounts(t *testing.T) {
 	require.Equal(t, 1, len(chain2Chans))
 	require.Subset(t, []string{"STATE_CLOSED", "Closed"}, []string{chain2Chans[0].State})
 
-	// Attempt to open another channel for the same ICA
-	_, _, err = chain1.Exec(ctx, registerICA, nil)
+	// Attempt to open another channel for the same ICA.
+	// The account already exists, so its address is returned before the new channel is open.
+	newICA, err := controller.RegisterInterchainAccount(ctx, chain1User.KeyName(), connections[0].ID)
 	require.NoError(t, err)
 
 	// Wait for channel handshake to finish
@@ -284,11 +236,6 @@ func TestInterchainAccounts(t *testing.T) {
 	require.NoError(t, err)
 
 	// Assert that a new channel has been opened and the same ICA is in use
-	stdout, _, err = chain1.Exec(ctx, queryICA, nil)
-	require.NoError(t, err)
-
-	newICA := parseInterchainAccountField(stdout)
-	require.NotEmpty(t, newICA)
 	require.Equal(t, icaAddr, newICA)
 
 	chain1Chans, err = r.GetChannels(ctx, eRep, chain1.Config().ChainID)
@@ -301,14 +248,3 @@ func TestInterchainAccounts(t *testing.T) {
 	require.Equal(t, 2, len(chain2Chans))
 	require.Subset(t, []string{"STATE_OPEN", "Open"}, []string{chain2Chans[1].State})
 }
-
-// parseInterchainAccountField takes a slice of bytes which should be returned when querying for an ICA via
-// the 'intertx interchainaccounts' cmd and splices out the actual address portion.
-func parseInterchainAccountField(stdout []byte) string {
-	// After querying an ICA the stdout should look like the following,
-	// interchain_account_address: cosmos1p76n3mnanllea4d3av0v0e42tjj03cae06xq8fwn9at587rqp23qvxsv0j
-	// So we split the string at the : and then grab the address and return.
-	parts := strings.SplitN(string(stdout), ":", 2)
-	icaAddr := strings.TrimSpace(parts[1])
-	return icaAddr
-}
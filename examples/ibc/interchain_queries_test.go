@@ -84,7 +84,7 @@ func TestInterchainQueries(t *testing.T) {
 	r := interchaintest.NewBuiltinRelayerFactory(
 		ibc.CosmosRly,
 		zaptest.NewLogger(t),
-		relayer.StartupFlags("-b", "100"),
+		relayer.BlockHistory(100),
 	).Build(t, client, network)
 
 	// Build the network; spin up the chains and configure the relayer
@@ -96,16 +96,11 @@ func TestInterchainQueries(t *testing.T) {
 		AddChain(chain2).
 		AddRelayer(r, relayerName).
 		AddLink(interchaintest.InterchainLink{
-			Chain1:  chain1,
-			Chain2:  chain2,
-			Relayer: r,
-			Path:    pathName,
-			CreateChannelOpts: ibc.CreateChannelOptions{
-				SourcePortName: "interquery",
-				DestPortName:   "icqhost",
-				Order:          ibc.Unordered,
-				Version:        "icq-1",
-			},
+			Chain1:            chain1,
+			Chain2:            chain2,
+			Relayer:           r,
+			Path:              pathName,
+			CreateChannelOpts: ibc.ICQChannelOpts("interquery"),
 		})
 
 	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
@@ -119,6 +114,11 @@ func TestInterchainQueries(t *testing.T) {
 		_ = ic.Close()
 	})
 
+	// Check that the host chain allows the query whitelisted through ModifyGenesis.
+	allowQueries, err := chain2.(*cosmos.CosmosChain).QueryParams(ctx, "interchainquery", "AllowQueries")
+	require.NoError(t, err)
+	require.JSONEq(t, `["/cosmos.bank.v1beta1.Query/AllBalances"]`, string(allowQueries))
+
 	// Fund user accounts, so we can query balances and make assertions.
 	const userFunds = int64(10_000_000_000)
 	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain1, chain2)
@@ -132,20 +132,16 @@ func TestInterchainQueries(t *testing.T) {
 	// Query for the recently created channel-id.
 	channels, err := r.GetChannels(ctx, eRep, chain1.Config().ChainID)
 	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	require.Equal(t, "icq-1", channels[0].Version)
+	require.Equal(t, ibc.Unordered, channels[0].Order())
+	require.Equal(t, "interquery", channels[0].PortID)
+	require.Equal(t, "icqhost", channels[0].Counterparty.PortID)
 
-	// Start the relayer and set the cleanup function.
+	// Start the relayer, which ic.Close stops when the test ends.
 	err = r.StartRelayer(ctx, eRep, pathName)
 	require.NoError(t, err)
 
-	t.Cleanup(
-		func() {
-			err := r.StopRelayer(ctx, eRep)
-			if err != nil {
-				t.Logf("an error occured while stopping the relayer: %s", err)
-			}
-		},
-	)
-
 	// Wait a few blocks for the relayer to start.
 	err = testutil.WaitForBlocks(ctx, 5, chain1, chain2)
 	require.NoError(t, err)
@@ -177,17 +173,8 @@ func TestInterchainQueries(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check the results from the interchain query above.
-	cmd = []string{"icq", "query", "interquery", "query-state", strconv.Itoa(1),
-		"--node", chain1.GetRPCAddress(),
-		"--home", chain1.HomeDir(),
-		"--chain-id", chain1.Config().ChainID,
-		"--output", "json",
-	}
-	stdout, _, err := chain1.Exec(ctx, cmd, nil)
-	require.NoError(t, err)
-
 	results := &icqResults{}
-	err = json.Unmarshal(stdout, results)
+	err = chain1.(*cosmos.CosmosChain).QueryJSON(ctx, results, "interquery", "query-state", strconv.Itoa(1))
 	require.NoError(t, err)
 	require.NotEmpty(t, results.Request)
 	require.NotEmpty(t, results.Response)
@@ -8,6 +8,7 @@ import (
 
 	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
 	"github.com/stretchr/testify/require"
@@ -114,6 +115,11 @@ func TestLearn(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedBal, gaiaUserBalNew)
 
+	// test the sent funds are held in the channel's escrow account
+	escrowBal, err := gaia.(*cosmos.CosmosChain).GetTransferEscrowBalance(ctx, "transfer", gaiaChannelID, gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, amountToSend, escrowBal)
+
 	// Trace IBC Denom
 	srcDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom("transfer", gaiaChannelID, gaia.Config().Denom))
 	dstIbcDenom := srcDenomTrace.IBCDenom()
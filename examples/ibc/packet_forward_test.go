@@ -128,15 +128,6 @@ func TestPacketForwardMiddleware(t *testing.T) {
 	err = r.StartRelayer(ctx, eRep, pathAB, pathBC, pathCD)
 	require.NoError(t, err)
 
-	t.Cleanup(
-		func() {
-			err := r.StopRelayer(ctx, eRep)
-			if err != nil {
-				t.Logf("an error occured while stopping the relayer: %s", err)
-			}
-		},
-	)
-
 	// Get original account balances
 	userA, userB, userC, userD := users[0], users[1], users[2], users[3]
 
@@ -0,0 +1,85 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// BenchmarkRelayerThroughput measures how fast the relayer clears a backlog of transfers between two chains,
+// e.g. with `go test -run=^$ -bench=RelayerThroughput -benchtime=50x ./examples/ibc`,
+// where the number of iterations is the number of transfers.
+// The chains are started anew for every run of the benchmark, so a fixed count of transfers is recommended.
+func BenchmarkRelayerThroughput(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping in short mode")
+	}
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(b), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-1", Version: "v7.0.0"},
+		{Name: "gaia", ChainName: "gaia-2", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(b.Name())
+	require.NoError(b, err)
+	src, dst := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(b)
+	r := rly.NewCosmosRelayer(zaptest.NewLogger(b), b.Name(), client, network)
+
+	const pathName = "throughput"
+	ic := interchaintest.NewInterchain().
+		AddChain(src).
+		AddChain(dst).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  src,
+			Chain2:  dst,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(b)
+
+	require.NoError(b, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  b.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	b.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(b, ctx, b.Name(), 10_000_000, src, dst)
+	srcUser, dstUser := users[0], users[1]
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, src.Config().ChainID, dst.Config().ChainID)
+	require.NoError(b, err)
+
+	packets, err := testutil.FloodTransfers(ctx, src, channel.ChannelID, srcUser.KeyName(), ibc.WalletAmount{
+		Address: dstUser.FormattedAddress(),
+		Denom:   src.Config().Denom,
+		Amount:  1,
+	}, b.N)
+	require.NoError(b, err)
+
+	measureCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	b.ResetTimer()
+	res, err := testutil.MeasureThroughput(measureCtx, src, r, eRep, pathName, packets)
+	b.StopTimer()
+	require.NoError(b, err)
+
+	res.ReportMetrics(b)
+}
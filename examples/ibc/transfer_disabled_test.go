@@ -0,0 +1,123 @@
+package ibc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestTransferDisabled checks that transfers are rejected by a chain with the transfer module's send disabled,
+// and refunded when sent from a chain with send enabled to a chain with receive disabled.
+func TestTransferDisabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// Registered first, so the check runs after every other cleanup.
+	interchaintest.AssertNoLeakedResources(t)
+
+	ctx := context.Background()
+
+	// The first chain cannot send transfers, the second cannot receive them, and the third has both enabled.
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia-no-send", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: interchaintest.ModifyGenesisTransferParams(false, true),
+		}},
+		{Name: "gaia", ChainName: "gaia-no-receive", Version: "v7.0.0", ChainConfig: ibc.ChainConfig{
+			ModifyGenesis: interchaintest.ModifyGenesisTransferParams(true, false),
+		}},
+		{Name: "gaia", ChainName: "gaia", Version: "v7.0.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	noSend, noReceive, sender := chains[0], chains[1], chains[2]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const (
+		noSendPath    = "no-send"
+		noReceivePath = "no-receive"
+	)
+	ic := interchaintest.NewInterchain().
+		AddChain(noSend).
+		AddChain(noReceive).
+		AddChain(sender).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  noSend,
+			Chain2:  noReceive,
+			Relayer: r,
+			Path:    noSendPath,
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  sender,
+			Chain2:  noReceive,
+			Relayer: r,
+			Path:    noReceivePath,
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), fundAmount, noSend, noReceive, sender)
+	noSendUser, noReceiveUser, senderUser := users[0], users[1], users[2]
+
+	t.Run("send disabled", func(t *testing.T) {
+		channel, err := ibc.GetTransferChannel(ctx, r, eRep, noSend.Config().ChainID, noReceive.Config().ChainID)
+		require.NoError(t, err)
+
+		_, err = noSend.SendIBCTransfer(ctx, channel.ChannelID, noSendUser.KeyName(), ibc.WalletAmount{
+			Address: noReceiveUser.FormattedAddress(),
+			Denom:   noSend.Config().Denom,
+			Amount:  1_000,
+		}, ibc.TransferOptions{})
+		require.True(t, errors.Is(err, cosmos.ErrSendDisabled), "unexpected error: %v", err)
+	})
+
+	t.Run("receive disabled", func(t *testing.T) {
+		channel, err := ibc.GetTransferChannel(ctx, r, eRep, sender.Config().ChainID, noReceive.Config().ChainID)
+		require.NoError(t, err)
+
+		denom := sender.Config().Denom
+		tx, err := sender.SendIBCTransfer(ctx, channel.ChannelID, senderUser.KeyName(), ibc.WalletAmount{
+			Address: noReceiveUser.FormattedAddress(),
+			Denom:   denom,
+			Amount:  1_000,
+		}, ibc.TransferOptions{})
+		require.NoError(t, err)
+
+		ack, err := testutil.WaitForAck(ctx, sender.(*cosmos.CosmosChain), r, eRep, noReceivePath, channel.ChannelID, tx.Packet.Sequence)
+		require.NoError(t, err)
+
+		var res chantypes.Acknowledgement
+		require.NoError(t, chantypes.SubModuleCdc.UnmarshalJSON(ack.Acknowledgement, &res))
+		require.False(t, res.Success(), "transfer to a chain with receive disabled must fail")
+
+		// The sender is refunded, so only the fees were spent.
+		bal, err := sender.GetBalance(ctx, senderUser.FormattedAddress(), denom)
+		require.NoError(t, err)
+		require.Equal(t, fundAmount-sender.GetGasFeesInNativeDenom(tx.GasSpent), bal)
+	})
+}
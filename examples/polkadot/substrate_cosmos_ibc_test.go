@@ -104,7 +104,7 @@ func TestSubstrateToCosmosIBC(t *testing.T) {
 	r := interchaintest.NewBuiltinRelayerFactory(
 		ibc.CosmosRly,
 		zaptest.NewLogger(t),
-		relayer.StartupFlags("-b", "100"),
+		relayer.BlockHistory(100),
 		// These two fields are used to pass in a custom Docker image built locally
 		//relayer.ImagePull(false),
 		relayer.CustomDockerImage("ghcr.io/composablefi/relayer", "sub-create-client", "100:1000"),
@@ -146,7 +146,7 @@ func TestSubstrateToCosmosIBC(t *testing.T) {
 	require.NoError(t, err)
 
 	// Attempt to create the light clients for both chains on the counterparty chain
-	err = r.CreateClients(ctx, rep.RelayerExecReporter(t), pathName, ibc.DefaultClientOpts())
+	_, err = r.CreateClients(ctx, rep.RelayerExecReporter(t), pathName, ibc.DefaultClientOpts())
 	require.NoError(t, err)
 
 	// Once client, connection, and handshake logic is implemented for the Substrate provider
@@ -0,0 +1,490 @@
+package interchaintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// modifyGenesisJSON returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that calls mutate with the JSON object at path in the genesis file, e.g. {"app_state", "gov"},
+// or with the whole genesis file if path is empty, and returns the genesis file with the changes made by mutate.
+//
+// Numbers are decoded as json.Number, so that values mutate leaves alone are not rounded.
+func modifyGenesisJSON(path []string, mutate func(cfg ibc.ChainConfig, obj map[string]interface{}) error) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		v, err := decodeJSON(genbz)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		g, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("genesis file is not a JSON object")
+		}
+
+		obj := g
+		for i, key := range path {
+			if obj, ok = obj[key].(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("genesis file has no %s", strings.Join(path[:i+1], "."))
+			}
+		}
+		if err := mutate(cfg, obj); err != nil {
+			return nil, err
+		}
+
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// decodeJSON decodes bz keeping numbers as json.Number, so large amounts are not rounded.
+func decodeJSON(bz []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// chainModifyGenesis returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that applies each of the non-nil fns in order.
+func chainModifyGenesis(fns ...func(ibc.ChainConfig, []byte) ([]byte, error)) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		for _, fn := range fns {
+			if fn == nil {
+				continue
+			}
+			var err error
+			if genbz, err = fn(cfg, genbz); err != nil {
+				return nil, err
+			}
+		}
+		return genbz, nil
+	}
+}
+
+// ModifyGenesisAmounts returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that multiplies every account balance and staking amount in the genesis file by multiplier.
+//
+// The bank supply is recomputed from the scaled balances so that it always equals their sum,
+// which genesis validation requires.
+// Gentxs are left untouched, since changing them would invalidate their signatures;
+// a multiplier of at least 1 keeps every gentx's self-delegation covered by its account.
+// See ModifyGenesisAmountsAbsolute to set the balances to a given amount instead.
+func ModifyGenesisAmounts(multiplier int64) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state"}, func(_ ibc.ChainConfig, appState map[string]interface{}) error {
+		if multiplier < 1 {
+			return fmt.Errorf("genesis amount multiplier must be at least 1, got %d", multiplier)
+		}
+		if err := scaleBankAmounts(appState, multiplier); err != nil {
+			return err
+		}
+		return scaleStakingAmounts(appState, multiplier)
+	})
+}
+
+// ModifyGenesisAmountsAbsolute returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that sets every coin of every account balance in the genesis file to amount,
+// e.g. to give each genesis account exactly the same funds regardless of the chain's defaults.
+//
+// As with ModifyGenesisAmounts, the bank supply is recomputed so that it equals the sum of the balances.
+// Staking state cannot be set to an absolute amount consistently with the bonded pool,
+// so genesis files with validators already in the staking state are rejected,
+// as are those with a gentx delegating more than amount, which the account could no longer cover.
+func ModifyGenesisAmountsAbsolute(amount int64) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state"}, func(_ ibc.ChainConfig, appState map[string]interface{}) error {
+		if amount < 1 {
+			return fmt.Errorf("genesis amount must be at least 1, got %d", amount)
+		}
+		if staking, ok := appState["staking"].(map[string]interface{}); ok {
+			if validators, _ := staking["validators"].([]interface{}); len(validators) > 0 {
+				return fmt.Errorf("cannot set absolute genesis amounts with %d validators in the staking state", len(validators))
+			}
+		}
+		if err := checkGentxDelegations(appState, sdk.NewInt(amount)); err != nil {
+			return err
+		}
+		return setBankAmounts(appState, sdk.NewInt(amount))
+	})
+}
+
+// ModifyGenesisTransferParams returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that sets the send_enabled and receive_enabled params of the IBC transfer module,
+// e.g. to check that transfers are rejected while disabled.
+//
+// Transfers sent from a chain with send disabled fail with cosmos.ErrSendDisabled,
+// and those received by a chain with receive disabled are acknowledged with an error and refunded.
+func ModifyGenesisTransferParams(sendEnabled, receiveEnabled bool) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state", "transfer", "params"}, func(_ ibc.ChainConfig, params map[string]interface{}) error {
+		params["send_enabled"] = sendEnabled
+		params["receive_enabled"] = receiveEnabled
+		return nil
+	})
+}
+
+// MintParams are overrides of the mint module genesis for ModifyGenesisMintParams.
+// Decimals are strings such as "0.13", and empty or zero fields keep the chain's own values.
+type MintParams struct {
+	// Inflation is the chain's inflation rate at genesis, i.e. that of the minter.
+	Inflation string
+	// InflationMin and InflationMax bound the inflation rate, which moves by at most InflationRateChange per year
+	// towards the rate reaching GoalBonded.
+	InflationMin        string
+	InflationMax        string
+	InflationRateChange string
+	GoalBonded          string
+	BlocksPerYear       uint64
+	// AnnualProvisions is the minter's annual provisions at genesis, recomputed by the chain every block.
+	AnnualProvisions string
+}
+
+// ZeroInflation returns the MintParams that stop the mint module from minting,
+// so that balance assertions are not offset by staking rewards.
+func ZeroInflation() MintParams {
+	return MintParams{
+		Inflation:           "0",
+		InflationMin:        "0",
+		InflationMax:        "0",
+		InflationRateChange: "0",
+		AnnualProvisions:    "0",
+	}
+}
+
+// ModifyGenesisMintParams returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that overrides the set fields of params in the mint module genesis,
+// e.g. ZeroInflation for deterministic balances or a high Inflation for reward tests.
+func ModifyGenesisMintParams(params MintParams) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state", "mint"}, func(_ ibc.ChainConfig, mint map[string]interface{}) error {
+		decs := []struct {
+			value string
+			path  []interface{}
+		}{
+			{params.Inflation, []interface{}{"minter", "inflation"}},
+			{params.AnnualProvisions, []interface{}{"minter", "annual_provisions"}},
+			{params.InflationMin, []interface{}{"params", "inflation_min"}},
+			{params.InflationMax, []interface{}{"params", "inflation_max"}},
+			{params.InflationRateChange, []interface{}{"params", "inflation_rate_change"}},
+			{params.GoalBonded, []interface{}{"params", "goal_bonded"}},
+		}
+		for _, d := range decs {
+			if d.value == "" {
+				continue
+			}
+			dec, err := sdk.NewDecFromStr(d.value)
+			if err != nil {
+				return fmt.Errorf("invalid mint %s %q: %w", d.path[1], d.value, err)
+			}
+			if err := dyno.Set(mint, dec.String(), d.path...); err != nil {
+				return fmt.Errorf("failed to set mint %s in genesis json: %w", d.path[1], err)
+			}
+		}
+		if params.BlocksPerYear != 0 {
+			if err := dyno.Set(mint, strconv.FormatUint(params.BlocksPerYear, 10), "params", "blocks_per_year"); err != nil {
+				return fmt.Errorf("failed to set mint blocks_per_year in genesis json: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// scaleBankAmounts multiplies every balance in the bank genesis and recomputes the total supply.
+func scaleBankAmounts(appState map[string]interface{}, multiplier int64) error {
+	return mapBankAmounts(appState, func(v interface{}) (sdk.Int, error) {
+		return scaleInt(v, multiplier)
+	})
+}
+
+// setBankAmounts sets every balance in the bank genesis to amount and recomputes the total supply.
+func setBankAmounts(appState map[string]interface{}, amount sdk.Int) error {
+	return mapBankAmounts(appState, func(interface{}) (sdk.Int, error) {
+		return amount, nil
+	})
+}
+
+// mapBankAmounts replaces the amount of every coin of every balance in the bank genesis by that returned by f,
+// and sets the total supply to the sum of the resulting balances.
+func mapBankAmounts(appState map[string]interface{}, f func(amount interface{}) (sdk.Int, error)) error {
+	bank, ok := appState["bank"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	supply := sdk.NewCoins()
+	balances, _ := bank["balances"].([]interface{})
+	for i, b := range balances {
+		balance, ok := b.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("malformed bank balance at index %d", i)
+		}
+		coins, _ := balance["coins"].([]interface{})
+		for _, c := range coins {
+			coin, ok := c.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("malformed coin in bank balance at index %d", i)
+			}
+			amount, err := f(coin["amount"])
+			if err != nil {
+				return fmt.Errorf("bank balance at index %d: %w", i, err)
+			}
+			coin["amount"] = amount.String()
+
+			denom, _ := coin["denom"].(string)
+			supply = supply.Add(sdk.Coin{Denom: denom, Amount: amount})
+		}
+	}
+
+	supplyJSON := make([]interface{}, len(supply))
+	for i, c := range supply {
+		supplyJSON[i] = map[string]interface{}{
+			"denom":  c.Denom,
+			"amount": c.Amount.String(),
+		}
+	}
+	bank["supply"] = supplyJSON
+	return nil
+}
+
+// checkGentxDelegations returns an error if a gentx in the genutil genesis self-delegates more than max.
+func checkGentxDelegations(appState map[string]interface{}, max sdk.Int) error {
+	genutil, _ := appState["genutil"].(map[string]interface{})
+	gentxs, _ := genutil["gen_txs"].([]interface{})
+	for i, tx := range gentxs {
+		msgs, err := dyno.GetSlice(tx, "body", "messages")
+		if err != nil {
+			return fmt.Errorf("malformed gentx at index %d: %w", i, err)
+		}
+		for _, msg := range msgs {
+			delegation, err := dyno.GetString(msg, "value", "amount")
+			if err != nil {
+				// Not a MsgCreateValidator.
+				continue
+			}
+			amount, ok := sdk.NewIntFromString(delegation)
+			if !ok {
+				return fmt.Errorf("gentx at index %d: invalid delegation amount %q", i, delegation)
+			}
+			if amount.GT(max) {
+				return fmt.Errorf("gentx at index %d delegates %s, more than the genesis amount %s", i, amount, max)
+			}
+		}
+	}
+	return nil
+}
+
+// scaleStakingAmounts multiplies the tokens and shares of any validators and delegations
+// already present in the staking genesis.
+func scaleStakingAmounts(appState map[string]interface{}, multiplier int64) error {
+	staking, ok := appState["staking"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	validators, _ := staking["validators"].([]interface{})
+	for i, v := range validators {
+		val, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("malformed staking validator at index %d", i)
+		}
+		tokens, err := scaleInt(val["tokens"], multiplier)
+		if err != nil {
+			return fmt.Errorf("staking validator at index %d: %w", i, err)
+		}
+		val["tokens"] = tokens.String()
+
+		shares, err := scaleDec(val["delegator_shares"], multiplier)
+		if err != nil {
+			return fmt.Errorf("staking validator at index %d: %w", i, err)
+		}
+		val["delegator_shares"] = shares.String()
+	}
+
+	delegations, _ := staking["delegations"].([]interface{})
+	for i, d := range delegations {
+		del, ok := d.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("malformed staking delegation at index %d", i)
+		}
+		shares, err := scaleDec(del["shares"], multiplier)
+		if err != nil {
+			return fmt.Errorf("staking delegation at index %d: %w", i, err)
+		}
+		del["shares"] = shares.String()
+	}
+
+	if lastPower, ok := staking["last_total_power"]; ok {
+		power, err := scaleInt(lastPower, multiplier)
+		if err != nil {
+			return fmt.Errorf("staking last_total_power: %w", err)
+		}
+		staking["last_total_power"] = power.String()
+	}
+	return nil
+}
+
+func scaleInt(v interface{}, multiplier int64) (sdk.Int, error) {
+	s, ok := v.(string)
+	if !ok {
+		return sdk.Int{}, fmt.Errorf("amount %v is not a string", v)
+	}
+	i, ok := sdk.NewIntFromString(s)
+	if !ok {
+		return sdk.Int{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return i.MulRaw(multiplier), nil
+}
+
+func scaleDec(v interface{}, multiplier int64) (sdk.Dec, error) {
+	s, ok := v.(string)
+	if !ok {
+		return sdk.Dec{}, fmt.Errorf("shares %v is not a string", v)
+	}
+	d, err := sdk.NewDecFromStr(s)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("invalid shares %q: %w", s, err)
+	}
+	return d.MulInt64(multiplier), nil
+}
+
+// modifyGenesisGov returns a ModifyGenesis function that overrides the gov module's voting period,
+// max deposit period, and min deposit amount, wherever set.
+//
+// Both the pre-v0.47 voting_params and deposit_params layout and the v0.47 params layout are supported.
+func modifyGenesisGov(votingPeriod, maxDepositPeriod time.Duration, minDeposit *int64) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state", "gov"}, func(cfg ibc.ChainConfig, gov map[string]interface{}) error {
+		votingParams, _ := gov["voting_params"].(map[string]interface{})
+		depositParams, _ := gov["deposit_params"].(map[string]interface{})
+		if params, ok := gov["params"].(map[string]interface{}); ok {
+			votingParams, depositParams = params, params
+		}
+		if votingParams == nil || depositParams == nil {
+			return fmt.Errorf("genesis file has no gov voting or deposit params")
+		}
+
+		if votingPeriod != 0 {
+			votingParams["voting_period"] = protoDuration(votingPeriod)
+		}
+		if maxDepositPeriod != 0 {
+			depositParams["max_deposit_period"] = protoDuration(maxDepositPeriod)
+		}
+		if minDeposit != nil {
+			depositParams["min_deposit"] = []interface{}{
+				map[string]interface{}{"denom": cfg.Denom, "amount": strconv.FormatInt(*minDeposit, 10)},
+			}
+		}
+		return nil
+	})
+}
+
+// modifyGenesisSlashing returns a ModifyGenesis function that overrides the slashing module's
+// signed blocks window, min signed per window, and downtime jail duration, wherever set.
+func modifyGenesisSlashing(signedBlocksWindow int64, minSignedPerWindow string, downtimeJailDuration time.Duration) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state", "slashing", "params"}, func(_ ibc.ChainConfig, params map[string]interface{}) error {
+		if signedBlocksWindow != 0 {
+			params["signed_blocks_window"] = strconv.FormatInt(signedBlocksWindow, 10)
+		}
+		if minSignedPerWindow != "" {
+			d, err := sdk.NewDecFromStr(minSignedPerWindow)
+			if err != nil {
+				return fmt.Errorf("invalid min signed per window %q: %w", minSignedPerWindow, err)
+			}
+			params["min_signed_per_window"] = d.String()
+		}
+		if downtimeJailDuration != 0 {
+			params["downtime_jail_duration"] = protoDuration(downtimeJailDuration)
+		}
+		return nil
+	})
+}
+
+// modifyGenesisStart returns a ModifyGenesis function that overrides the genesis time and initial height
+// of the genesis file, wherever set.
+func modifyGenesisStart(genesisTime time.Time, initialHeight int64) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON(nil, func(_ ibc.ChainConfig, g map[string]interface{}) error {
+		if initialHeight < 0 {
+			return fmt.Errorf("invalid initial height %d: must not be negative", initialHeight)
+		}
+		if !genesisTime.IsZero() {
+			g["genesis_time"] = genesisTime.UTC().Format(time.RFC3339Nano)
+		}
+		if initialHeight != 0 {
+			g["initial_height"] = strconv.FormatInt(initialHeight, 10)
+		}
+		return nil
+	})
+}
+
+// modifyGenesisBank returns a ModifyGenesis function that overrides whether each denom of sendEnabled can be sent,
+// and the bank module's default_send_enabled if not nil.
+//
+// Both the pre-v0.47 layout, with send_enabled in the bank params, and the v0.47 layout,
+// with send_enabled at the top level of the bank module genesis, are supported.
+func modifyGenesisBank(sendEnabled map[string]bool, defaultSendEnabled *bool) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return modifyGenesisJSON([]string{"app_state", "bank"}, func(_ ibc.ChainConfig, bank map[string]interface{}) error {
+		params, ok := bank["params"].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("genesis file has no bank params")
+		}
+
+		if defaultSendEnabled != nil {
+			params["default_send_enabled"] = *defaultSendEnabled
+		}
+
+		if len(sendEnabled) > 0 {
+			holder := params
+			if _, ok := bank["send_enabled"]; ok {
+				holder = bank
+			}
+			holder["send_enabled"] = mergeSendEnabled(holder["send_enabled"], sendEnabled)
+		}
+		return nil
+	})
+}
+
+// mergeSendEnabled returns the send_enabled entries of existing with the denoms of overrides replaced,
+// followed by the remaining overrides in denom order.
+func mergeSendEnabled(existing interface{}, overrides map[string]bool) []interface{} {
+	entries, _ := existing.([]interface{})
+	out := make([]interface{}, 0, len(entries)+len(overrides))
+	seen := make(map[string]bool, len(overrides))
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if ok {
+			denom, _ := entry["denom"].(string)
+			if enabled, override := overrides[denom]; override {
+				entry["enabled"] = enabled
+				seen[denom] = true
+			}
+		}
+		out = append(out, e)
+	}
+
+	denoms := make([]string, 0, len(overrides))
+	for denom := range overrides {
+		if !seen[denom] {
+			denoms = append(denoms, denom)
+		}
+	}
+	sort.Strings(denoms)
+	for _, denom := range denoms {
+		out = append(out, map[string]interface{}{"denom": denom, "enabled": overrides[denom]})
+	}
+	return out
+}
+
+// protoDuration formats d the way protobuf JSON encodes a google.protobuf.Duration, e.g. "10s".
+func protoDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,64 @@
+package interchaintest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// JSONPatchOperation is a single operation of an RFC 6902 JSON Patch document,
+// e.g. to build the patch passed to ModifyGenesisJSONPatch with json.Marshal.
+type JSONPatchOperation struct {
+	// Op is one of "add", "remove", "replace", "move", "copy", or "test".
+	Op string `json:"op"`
+	// Path is the RFC 6901 JSON Pointer the operation applies to, e.g. "/app_state/gov/voting_params".
+	// The last token of an add may be "-" to append to an array.
+	Path string `json:"path"`
+	// From is the pointer to the value moved or copied by a move or copy operation.
+	From string `json:"from,omitempty"`
+	// Value is the value added, replaced with, or tested against.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ModifyGenesisJSONPatch returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that applies the RFC 6902 JSON Patch document patch to the genesis file, e.g.
+//
+//	[{"op": "add", "path": "/app_state/interchainquery/host_port", "value": "icqhost"},
+//	 {"op": "remove", "path": "/app_state/interchainquery/params/allow_queries/0"}]
+//
+// Unlike setting a value at a path, a patch can insert into and remove from arrays.
+// The operations are applied in order. If any of them fails, such as a test operation or one on a missing path,
+// the error identifies the index, op and path of the failing operation.
+// Values the patch does not touch, such as large amounts, are kept as they are.
+func ModifyGenesisJSONPatch(patch []byte) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		ops, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal json patch: %w", err)
+		}
+		if !json.Valid(genbz) {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: invalid json")
+		}
+
+		opts := jsonpatch.NewApplyOptions()
+		// Array indices are only those of RFC 6901, not negative ones counting from the end.
+		opts.SupportNegativeIndices = false
+
+		// Each operation is applied on its own, so that an error names the failing one.
+		for i, op := range ops {
+			path, _ := op.Path()
+			switch _, hasValue := op["value"]; op.Kind() {
+			case "add", "replace", "test":
+				if !hasValue {
+					return nil, fmt.Errorf("json patch operation %d (%s %s): %w", i, op.Kind(), path, jsonpatch.ErrMissing)
+				}
+			}
+			if genbz, err = (jsonpatch.Patch{op}).ApplyWithOptions(genbz, opts); err != nil {
+				return nil, fmt.Errorf("json patch operation %d (%s %s): %w", i, op.Kind(), path, err)
+			}
+		}
+		return genbz, nil
+	}
+}
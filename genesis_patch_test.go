@@ -0,0 +1,142 @@
+package interchaintest
+
+import (
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyGenesisJSONPatch(t *testing.T) {
+	const genesis = `{
+  "chain_id": "test-1",
+  "app_state": {
+    "interchainquery": {"params": {"allow_queries": ["/a", "/b"]}},
+    "bank": {"supply": [{"denom": "uatom", "amount": "100000000000000000000000"}]},
+    "a/b": {"c~d": 1}
+  }
+}`
+
+	apply := func(patch string) (string, error) {
+		out, err := ModifyGenesisJSONPatch([]byte(patch))(ibc.ChainConfig{}, []byte(genesis))
+		return string(out), err
+	}
+
+	t.Run("array operations", func(t *testing.T) {
+		out, err := apply(`[
+  {"op": "test", "path": "/app_state/interchainquery/params/allow_queries/0", "value": "/a"},
+  {"op": "add", "path": "/app_state/interchainquery/params/allow_queries/1", "value": "/inserted"},
+  {"op": "add", "path": "/app_state/interchainquery/params/allow_queries/-", "value": "/appended"},
+  {"op": "remove", "path": "/app_state/interchainquery/params/allow_queries/0"},
+  {"op": "add", "path": "/app_state/interchainquery/host_enabled", "value": true}
+]`)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "chain_id": "test-1",
+  "app_state": {
+    "interchainquery": {"params": {"allow_queries": ["/inserted", "/b", "/appended"]}, "host_enabled": true},
+    "bank": {"supply": [{"denom": "uatom", "amount": "100000000000000000000000"}]},
+    "a/b": {"c~d": 1}
+  }
+}`, out)
+	})
+
+	t.Run("replace, move and copy", func(t *testing.T) {
+		out, err := apply(`[
+  {"op": "replace", "path": "/chain_id", "value": "test-2"},
+  {"op": "copy", "from": "/app_state/bank/supply/0", "path": "/app_state/bank/supply/-"},
+  {"op": "replace", "path": "/app_state/bank/supply/1/denom", "value": "ustake"},
+  {"op": "move", "from": "/app_state/a~1b/c~0d", "path": "/app_state/moved"},
+  {"op": "test", "path": "/app_state/moved", "value": 1}
+]`)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "chain_id": "test-2",
+  "app_state": {
+    "interchainquery": {"params": {"allow_queries": ["/a", "/b"]}},
+    "bank": {"supply": [
+      {"denom": "uatom", "amount": "100000000000000000000000"},
+      {"denom": "ustake", "amount": "100000000000000000000000"}
+    ]},
+    "a/b": {},
+    "moved": 1
+  }
+}`, out)
+	})
+
+	for _, tt := range []struct {
+		name, patch, wantErr string
+		wantIs               error
+	}{
+		{
+			name:    "missing parent",
+			patch:   `[{"op": "add", "path": "/app_state/missing/key", "value": 1}]`,
+			wantErr: "json patch operation 0 (add /app_state/missing/key): ",
+			wantIs:  jsonpatch.ErrMissing,
+		},
+		{
+			name:    "index out of bounds",
+			patch:   `[{"op": "remove", "path": "/chain_id"}, {"op": "remove", "path": "/app_state/interchainquery/params/allow_queries/2"}]`,
+			wantErr: "json patch operation 1 (remove /app_state/interchainquery/params/allow_queries/2): ",
+			wantIs:  jsonpatch.ErrInvalidIndex,
+		},
+		{
+			name:    "negative index",
+			patch:   `[{"op": "remove", "path": "/app_state/interchainquery/params/allow_queries/-1"}]`,
+			wantErr: "json patch operation 0 (remove /app_state/interchainquery/params/allow_queries/-1): ",
+			wantIs:  jsonpatch.ErrInvalidIndex,
+		},
+		{
+			name:    "failed test",
+			patch:   `[{"op": "test", "path": "/chain_id", "value": "other-1"}]`,
+			wantErr: "json patch operation 0 (test /chain_id): ",
+			wantIs:  jsonpatch.ErrTestFailed,
+		},
+		{
+			name:    "move into child",
+			patch:   `[{"op": "move", "from": "/app_state", "path": "/app_state/bank/nested"}]`,
+			wantErr: "json patch operation 0 (move /app_state/bank/nested): ",
+			wantIs:  jsonpatch.ErrMissing,
+		},
+		{
+			name:    "unknown op",
+			patch:   `[{"op": "merge", "path": "/chain_id", "value": 1}]`,
+			wantErr: "json patch operation 0 (merge /chain_id): Unexpected kind: merge",
+		},
+		{
+			name:    "missing value",
+			patch:   `[{"op": "add", "path": "/chain_id"}]`,
+			wantErr: "json patch operation 0 (add /chain_id): ",
+			wantIs:  jsonpatch.ErrMissing,
+		},
+		{
+			name:    "invalid patch",
+			patch:   `{"op": "add"}`,
+			wantErr: "failed to unmarshal json patch",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := apply(tt.patch)
+			require.ErrorContains(t, err, tt.wantErr)
+			if tt.wantIs != nil {
+				require.ErrorIs(t, err, tt.wantIs)
+			}
+		})
+	}
+
+	t.Run("large numbers", func(t *testing.T) {
+		out, err := ModifyGenesisJSONPatch([]byte(`[{"op": "add", "path": "/b", "value": 1}]`))(
+			ibc.ChainConfig{}, []byte(`{"a": 123456789012345678901234567890}`),
+		)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"a": 123456789012345678901234567890, "b": 1}`, string(out))
+		require.Contains(t, string(out), "123456789012345678901234567890")
+	})
+
+	t.Run("invalid genesis", func(t *testing.T) {
+		_, err := ModifyGenesisJSONPatch([]byte(`[]`))(ibc.ChainConfig{}, []byte(`{"chain_id": `))
+		require.ErrorContains(t, err, "failed to unmarshal genesis file")
+	})
+}
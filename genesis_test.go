@@ -0,0 +1,322 @@
+package interchaintest
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyGenesisJSON(t *testing.T) {
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	setDenom := modifyGenesisJSON([]string{"app_state", "staking", "params"}, func(cfg ibc.ChainConfig, params map[string]interface{}) error {
+		params["bond_denom"] = cfg.Denom
+		return nil
+	})
+
+	// Numbers left alone keep their exact value.
+	out, err := setDenom(cfg, []byte(`{"max_gas":18446744073709551615,"app_state":{"staking":{"params":{"bond_denom":"stake"}}}}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"max_gas":18446744073709551615,"app_state":{"staking":{"params":{"bond_denom":"uatom"}}}}`, string(out))
+
+	_, err = setDenom(cfg, []byte(`{"app_state":{"staking":{}}}`))
+	require.EqualError(t, err, "genesis file has no app_state.staking.params")
+
+	_, err = setDenom(cfg, []byte(`[]`))
+	require.EqualError(t, err, "genesis file is not a JSON object")
+}
+
+func TestChainModifyGenesis(t *testing.T) {
+	appendByte := func(b byte) func(ibc.ChainConfig, []byte) ([]byte, error) {
+		return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+			return append(genbz, b), nil
+		}
+	}
+
+	out, err := chainModifyGenesis(appendByte('a'), nil, appendByte('b'))(ibc.ChainConfig{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ab", string(out))
+
+	fail := func(ibc.ChainConfig, []byte) ([]byte, error) { return nil, errors.New("boom") }
+	_, err = chainModifyGenesis(fail, appendByte('a'))(ibc.ChainConfig{}, nil)
+	require.EqualError(t, err, "boom")
+}
+
+func TestModifyGenesisAmounts(t *testing.T) {
+	const genesis = `{
+  "chain_id": "test-1",
+  "app_state": {
+    "bank": {
+      "balances": [
+        {"address": "a", "coins": [{"denom": "uatom", "amount": "100"}, {"denom": "ustake", "amount": "5"}]},
+        {"address": "b", "coins": [{"denom": "uatom", "amount": "50"}]}
+      ],
+      "supply": [{"denom": "uatom", "amount": "150"}, {"denom": "ustake", "amount": "5"}]
+    },
+    "staking": {
+      "validators": [{"tokens": "5", "delegator_shares": "5.000000000000000000"}],
+      "delegations": [{"shares": "5.000000000000000000"}],
+      "last_total_power": "0"
+    }
+  }
+}`
+
+	out, err := ModifyGenesisAmounts(10)(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		ChainID  string `json:"chain_id"`
+		AppState struct {
+			Bank struct {
+				Balances []struct {
+					Coins []struct{ Denom, Amount string }
+				}
+				Supply []struct{ Denom, Amount string }
+			}
+			Staking struct {
+				Validators []struct {
+					Tokens          string
+					DelegatorShares string `json:"delegator_shares"`
+				}
+				Delegations []struct{ Shares string }
+			}
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	require.Equal(t, "test-1", g.ChainID)
+	require.Equal(t, "1000", g.AppState.Bank.Balances[0].Coins[0].Amount)
+	require.Equal(t, "50", g.AppState.Bank.Balances[0].Coins[1].Amount)
+	require.Equal(t, "500", g.AppState.Bank.Balances[1].Coins[0].Amount)
+	require.Equal(t, []struct{ Denom, Amount string }{
+		{Denom: "uatom", Amount: "1500"},
+		{Denom: "ustake", Amount: "50"},
+	}, g.AppState.Bank.Supply)
+	require.Equal(t, "50", g.AppState.Staking.Validators[0].Tokens)
+	require.Equal(t, "50.000000000000000000", g.AppState.Staking.Validators[0].DelegatorShares)
+	require.Equal(t, "50.000000000000000000", g.AppState.Staking.Delegations[0].Shares)
+
+	_, err = ModifyGenesisAmounts(0)(ibc.ChainConfig{}, []byte(genesis))
+	require.Error(t, err)
+}
+
+func TestModifyGenesisAmountsAbsolute(t *testing.T) {
+	const genesis = `{"app_state":{
+  "bank":{
+    "balances":[
+      {"address":"a","coins":[{"denom":"uatom","amount":"100"},{"denom":"ustake","amount":"5"}]},
+      {"address":"b","coins":[{"denom":"uatom","amount":"50"}]}
+    ],
+    "supply":[{"denom":"uatom","amount":"150"},{"denom":"ustake","amount":"5"}]
+  },
+  "genutil":{"gen_txs":[{"body":{"messages":[
+    {"@type":"/cosmos.staking.v1beta1.MsgCreateValidator","value":{"denom":"ustake","amount":"5"}}
+  ]}}]},
+  "staking":{"validators":[]}
+}}`
+
+	out, err := ModifyGenesisAmountsAbsolute(1000)(ibc.ChainConfig{}, []byte(genesis))
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Bank json.RawMessage `json:"bank"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+	require.JSONEq(t, `{
+  "balances":[
+    {"address":"a","coins":[{"denom":"uatom","amount":"1000"},{"denom":"ustake","amount":"1000"}]},
+    {"address":"b","coins":[{"denom":"uatom","amount":"1000"}]}
+  ],
+  "supply":[{"denom":"uatom","amount":"2000"},{"denom":"ustake","amount":"1000"}]
+}`, string(g.AppState.Bank))
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ModifyGenesisAmountsAbsolute(0)(ibc.ChainConfig{}, []byte(genesis))
+		require.ErrorContains(t, err, "must be at least 1")
+
+		_, err = ModifyGenesisAmountsAbsolute(4)(ibc.ChainConfig{}, []byte(genesis))
+		require.ErrorContains(t, err, "gentx at index 0 delegates 5, more than the genesis amount 4")
+
+		_, err = ModifyGenesisAmountsAbsolute(1000)(ibc.ChainConfig{}, []byte(`{"app_state":{"staking":{"validators":[{"tokens":"5"}]}}}`))
+		require.ErrorContains(t, err, "with 1 validators in the staking state")
+	})
+}
+
+func TestModifyGenesisGov(t *testing.T) {
+	minDeposit := int64(1000)
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+
+	t.Run("legacy params", func(t *testing.T) {
+		const genesis = `{"app_state":{"gov":{
+  "voting_params":{"voting_period":"172800s"},
+  "deposit_params":{"max_deposit_period":"172800s","min_deposit":[{"denom":"stake","amount":"10000000"}]}
+}}}`
+		out, err := modifyGenesisGov(1500*time.Millisecond, time.Minute, &minDeposit)(cfg, []byte(genesis))
+		require.NoError(t, err)
+
+		var g struct {
+			AppState struct {
+				Gov map[string]json.RawMessage `json:"gov"`
+			} `json:"app_state"`
+		}
+		require.NoError(t, json.Unmarshal(out, &g))
+		require.JSONEq(t, `{"voting_period":"1.5s"}`, string(g.AppState.Gov["voting_params"]))
+		require.JSONEq(t, `{"max_deposit_period":"60s","min_deposit":[{"denom":"uatom","amount":"1000"}]}`, string(g.AppState.Gov["deposit_params"]))
+	})
+
+	t.Run("v0.47 params", func(t *testing.T) {
+		const genesis = `{"app_state":{"gov":{"params":{"voting_period":"172800s","max_deposit_period":"172800s"}}}}`
+
+		out, err := modifyGenesisGov(10*time.Second, 0, nil)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"app_state":{"gov":{"params":{"voting_period":"10s","max_deposit_period":"172800s"}}}}`, string(out))
+	})
+
+	t.Run("missing gov state", func(t *testing.T) {
+		_, err := modifyGenesisGov(10*time.Second, 0, nil)(cfg, []byte(`{"app_state":{}}`))
+		require.ErrorContains(t, err, "genesis file has no app_state.gov")
+	})
+}
+
+func TestModifyGenesisSlashing(t *testing.T) {
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	const genesis = `{"app_state":{"slashing":{"params":{
+  "signed_blocks_window":"100","min_signed_per_window":"0.500000000000000000",
+  "downtime_jail_duration":"600s","slash_fraction_downtime":"0.010000000000000000"
+}}}}`
+
+	t.Run("overrides", func(t *testing.T) {
+		out, err := modifyGenesisSlashing(10, "0.1", 5*time.Second)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"app_state":{"slashing":{"params":{
+  "signed_blocks_window":"10","min_signed_per_window":"0.100000000000000000",
+  "downtime_jail_duration":"5s","slash_fraction_downtime":"0.010000000000000000"
+}}}}`, string(out))
+	})
+
+	t.Run("defaults kept", func(t *testing.T) {
+		out, err := modifyGenesisSlashing(0, "", 0)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, genesis, string(out))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := modifyGenesisSlashing(10, "half", 0)(cfg, []byte(genesis))
+		require.ErrorContains(t, err, "invalid min signed per window")
+
+		_, err = modifyGenesisSlashing(10, "", 0)(cfg, []byte(`{"app_state":{}}`))
+		require.ErrorContains(t, err, "genesis file has no app_state.slashing")
+	})
+}
+
+func TestModifyGenesisStart(t *testing.T) {
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	const genesis = `{"genesis_time":"2022-01-01T00:00:00Z","initial_height":"1","app_state":{}}`
+
+	t.Run("overrides", func(t *testing.T) {
+		genesisTime := time.Date(2023, 6, 1, 12, 0, 0, 500, time.FixedZone("CEST", 2*60*60))
+		out, err := modifyGenesisStart(genesisTime, 100)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"genesis_time":"2023-06-01T10:00:00.0000005Z","initial_height":"100","app_state":{}}`, string(out))
+	})
+
+	t.Run("defaults kept", func(t *testing.T) {
+		out, err := modifyGenesisStart(time.Time{}, 0)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, genesis, string(out))
+	})
+
+	t.Run("negative height", func(t *testing.T) {
+		_, err := modifyGenesisStart(time.Time{}, -1)(cfg, []byte(genesis))
+		require.ErrorContains(t, err, "invalid initial height")
+	})
+}
+
+func TestModifyGenesisBank(t *testing.T) {
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	disabled := false
+
+	t.Run("params layout", func(t *testing.T) {
+		const genesis = `{"app_state":{"bank":{"params":{
+  "send_enabled":[{"denom":"uosmo","enabled":true}],"default_send_enabled":true
+}}}}`
+		out, err := modifyGenesisBank(map[string]bool{"uosmo": false, "uatom": true, "stake": false}, &disabled)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"app_state":{"bank":{"params":{
+  "send_enabled":[{"denom":"uosmo","enabled":false},{"denom":"stake","enabled":false},{"denom":"uatom","enabled":true}],
+  "default_send_enabled":false
+}}}}`, string(out))
+	})
+
+	t.Run("v0.47 layout", func(t *testing.T) {
+		const genesis = `{"app_state":{"bank":{"params":{"default_send_enabled":true},"send_enabled":[]}}}`
+		out, err := modifyGenesisBank(map[string]bool{"uatom": false}, nil)(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"app_state":{"bank":{"params":{"default_send_enabled":true},"send_enabled":[{"denom":"uatom","enabled":false}]}}}`, string(out))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := modifyGenesisBank(map[string]bool{"uatom": false}, nil)(cfg, []byte(`{"app_state":{}}`))
+		require.ErrorContains(t, err, "genesis file has no app_state.bank")
+	})
+}
+
+func TestModifyGenesisMintParams(t *testing.T) {
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	const genesis = `{"app_state":{"mint":{
+		"minter":{"inflation":"0.130000000000000000","annual_provisions":"0.000000000000000000"},
+		"params":{"mint_denom":"uatom","inflation_rate_change":"0.130000000000000000","inflation_max":"0.200000000000000000",
+			"inflation_min":"0.070000000000000000","goal_bonded":"0.670000000000000000","blocks_per_year":"6311520"}
+	}}}`
+
+	t.Run("zero inflation", func(t *testing.T) {
+		out, err := ModifyGenesisMintParams(ZeroInflation())(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"app_state":{"mint":{
+			"minter":{"inflation":"0.000000000000000000","annual_provisions":"0.000000000000000000"},
+			"params":{"mint_denom":"uatom","inflation_rate_change":"0.000000000000000000","inflation_max":"0.000000000000000000",
+				"inflation_min":"0.000000000000000000","goal_bonded":"0.670000000000000000","blocks_per_year":"6311520"}
+		}}}`, string(out))
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		out, err := ModifyGenesisMintParams(MintParams{Inflation: "0.5", InflationMax: "0.9", BlocksPerYear: 100})(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"app_state":{"mint":{
+			"minter":{"inflation":"0.500000000000000000","annual_provisions":"0.000000000000000000"},
+			"params":{"mint_denom":"uatom","inflation_rate_change":"0.130000000000000000","inflation_max":"0.900000000000000000",
+				"inflation_min":"0.070000000000000000","goal_bonded":"0.670000000000000000","blocks_per_year":"100"}
+		}}}`, string(out))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		out, err := ModifyGenesisMintParams(MintParams{})(cfg, []byte(genesis))
+		require.NoError(t, err)
+		require.JSONEq(t, genesis, string(out))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ModifyGenesisMintParams(MintParams{Inflation: "lots"})(cfg, []byte(genesis))
+		require.ErrorContains(t, err, `invalid mint inflation "lots"`)
+
+		_, err = ModifyGenesisMintParams(ZeroInflation())(cfg, []byte(`{"app_state":{}}`))
+		require.ErrorContains(t, err, "genesis file has no app_state.mint")
+	})
+}
+
+func TestModifyGenesisTransferParams(t *testing.T) {
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	const genesis = `{"app_state":{"transfer":{"port_id":"transfer","params":{"send_enabled":true,"receive_enabled":true}}}}`
+
+	out, err := ModifyGenesisTransferParams(false, true)(cfg, []byte(genesis))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app_state":{"transfer":{"port_id":"transfer","params":{"send_enabled":false,"receive_enabled":true}}}}`, string(out))
+
+	_, err = ModifyGenesisTransferParams(false, false)(cfg, []byte(`{"app_state":{}}`))
+	require.ErrorContains(t, err, "genesis file has no app_state.transfer")
+}
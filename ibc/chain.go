@@ -90,4 +90,8 @@ type Chain interface {
 type TransferOptions struct {
 	Timeout *IBCTimeout
 	Memo    string
+	// Fees is an exact flat fee for the transfer, e.g. "5000uatom",
+	// paid instead of the fee computed from the chain's GasPrices.
+	// Only cosmos chains support it.
+	Fees string
 }
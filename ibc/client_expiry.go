@@ -0,0 +1,115 @@
+package ibc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ClientHeight is the height of a light client, as printed by the relayers in client states.
+type ClientHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// UnmarshalJSON accepts the revision number and height both as JSON numbers
+// and as the strings the protobuf JSON encoding uses for 64-bit integers.
+func (h *ClientHeight) UnmarshalJSON(bz []byte) error {
+	var raw struct {
+		RevisionNumber json.Number `json:"revision_number"`
+		RevisionHeight json.Number `json:"revision_height"`
+	}
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return err
+	}
+	var err error
+	if h.RevisionNumber, err = parseClientHeightPart(raw.RevisionNumber); err != nil {
+		return fmt.Errorf("invalid revision number: %w", err)
+	}
+	if h.RevisionHeight, err = parseClientHeightPart(raw.RevisionHeight); err != nil {
+		return fmt.Errorf("invalid revision height: %w", err)
+	}
+	return nil
+}
+
+func parseClientHeightPart(n json.Number) (uint64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(string(n), 10, 64)
+}
+
+// IsZero reports whether h is the zero height, e.g. the frozen height of a client that is not frozen.
+func (h ClientHeight) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
+
+func (h ClientHeight) String() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// TrustingPeriodDuration parses the trusting period of the client state.
+func (s ClientState) TrustingPeriodDuration() (time.Duration, error) {
+	if s.TrustingPeriod == "" {
+		return 0, errors.New("client state has no trusting period")
+	}
+	d, err := time.ParseDuration(s.TrustingPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid trusting period %q: %w", s.TrustingPeriod, err)
+	}
+	return d, nil
+}
+
+// ClientExpiry describes when a light client expires, after which it can no longer be updated
+// and packets can no longer be relayed over its connections until it is recovered, e.g. by governance.
+type ClientExpiry struct {
+	// ChainID is the chain hosting the client.
+	ChainID  string
+	ClientID string
+
+	// TrackedChainID is the counterparty chain tracked by the client.
+	TrackedChainID string
+
+	TrustingPeriod time.Duration
+
+	// LastUpdate is the timestamp of the client's latest consensus state,
+	// i.e. the block time of the tracked chain at the height the client was last updated to.
+	LastUpdate time.Time
+}
+
+// NewClientExpiry returns the expiry of the client on chainID, whose latest consensus state has the timestamp lastUpdate.
+func NewClientExpiry(chainID string, client ClientOutput, lastUpdate time.Time) (ClientExpiry, error) {
+	tp, err := client.ClientState.TrustingPeriodDuration()
+	if err != nil {
+		return ClientExpiry{}, fmt.Errorf("client %s on %s: %w", client.ClientID, chainID, err)
+	}
+	return ClientExpiry{
+		ChainID:        chainID,
+		ClientID:       client.ClientID,
+		TrackedChainID: client.ClientState.ChainID,
+		TrustingPeriod: tp,
+		LastUpdate:     lastUpdate,
+	}, nil
+}
+
+// ExpiresAt returns when the client expires unless it is updated before.
+func (e ClientExpiry) ExpiresAt() time.Time {
+	return e.LastUpdate.Add(e.TrustingPeriod)
+}
+
+// Expired reports whether the client has expired at now.
+// Like the light client itself, it treats the client as expired from ExpiresAt on.
+// Note that the host chain checks against its block time, which may lag behind the wall clock.
+func (e ClientExpiry) Expired(now time.Time) bool {
+	return !e.ExpiresAt().After(now)
+}
+
+// ClientExpiryQuerier is implemented by relayers that can query when the clients at both ends of a path expire,
+// such as rly.CosmosRelayer.
+type ClientExpiryQuerier interface {
+	// ClientExpiries returns the expiry of the client on the source chain of the path, followed by that on the destination chain.
+	ClientExpiries(ctx context.Context, rep RelayerExecReporter, pathName string) ([]ClientExpiry, error)
+}
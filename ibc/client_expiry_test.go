@@ -0,0 +1,44 @@
+package ibc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientExpiry(t *testing.T) {
+	const out = `{"client_id":"07-tendermint-0","client_state":{"@type":"/ibc.lightclients.tendermint.v1.ClientState",` +
+		`"chain_id":"gaia-2","trust_level":{"numerator":"1","denominator":"3"},"trusting_period":"90s",` +
+		`"frozen_height":{"revision_number":"0","revision_height":"0"},"latest_height":{"revision_number":"2","revision_height":"42"}}}`
+
+	var client ClientOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &client))
+	require.Equal(t, "gaia-2", client.ClientState.ChainID)
+	require.Equal(t, ClientHeight{RevisionNumber: 2, RevisionHeight: 42}, client.ClientState.LatestHeight)
+	require.True(t, client.ClientState.FrozenHeight.IsZero())
+	require.Equal(t, "2-42", client.ClientState.LatestHeight.String())
+
+	lastUpdate := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	e, err := NewClientExpiry("gaia-1", client, lastUpdate)
+	require.NoError(t, err)
+	require.Equal(t, ClientExpiry{
+		ChainID:        "gaia-1",
+		ClientID:       "07-tendermint-0",
+		TrackedChainID: "gaia-2",
+		TrustingPeriod: 90 * time.Second,
+		LastUpdate:     lastUpdate,
+	}, e)
+	require.Equal(t, lastUpdate.Add(90*time.Second), e.ExpiresAt())
+	require.False(t, e.Expired(lastUpdate.Add(89*time.Second)))
+	require.True(t, e.Expired(lastUpdate.Add(90*time.Second)))
+
+	var h ClientHeight
+	require.NoError(t, json.Unmarshal([]byte(`{"revision_number":1,"revision_height":7}`), &h))
+	require.Equal(t, ClientHeight{RevisionNumber: 1, RevisionHeight: 7}, h)
+	require.Error(t, json.Unmarshal([]byte(`{"revision_height":"-1"}`), &h))
+
+	_, err = NewClientExpiry("gaia-1", ClientOutput{ClientID: "07-tendermint-1"}, lastUpdate)
+	require.EqualError(t, err, "client 07-tendermint-1 on gaia-1: client state has no trusting period")
+}
@@ -0,0 +1,63 @@
+package ibc
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+)
+
+// DenomTrace contains the base denomination of an ICS-20 token
+// and the path of ports and channels it was transferred through.
+type DenomTrace struct {
+	// Path is a sequence of port and channel identifiers, e.g. "transfer/channel-0/transfer/channel-4".
+	// It is empty for native tokens.
+	Path string
+
+	// BaseDenom is the denomination of the token on its originating chain.
+	BaseDenom string
+}
+
+// IBCDenom returns the "ibc/<hash>" voucher denom for the trace,
+// or the base denom if the path is empty.
+func (dt DenomTrace) IBCDenom() string {
+	return transfertypes.DenomTrace{Path: dt.Path, BaseDenom: dt.BaseDenom}.IBCDenom()
+}
+
+// GetTransferDenom returns the voucher denom that a token will have
+// after it is received on the given port and channel of the destination chain,
+// i.e. the counterparty of the channel it was sent on.
+//
+// The denom may already carry a path from earlier transfers, e.g. "transfer/channel-1/uatom",
+// in which case the new hop is prepended to the existing path.
+func GetTransferDenom(portID, channelID, denom string) string {
+	prefixed := transfertypes.GetPrefixedDenom(portID, channelID, denom)
+	return transfertypes.ParseDenomTrace(prefixed).IBCDenom()
+}
+
+// GetMultiHopTransferDenom returns the voucher denom that a token will have
+// after it is received over each of the given channels in turn, e.g. when forwarded by packet-forward-middleware.
+// Each hop is the receiving port and channel, i.e. the counterparty of the channel the token was sent on.
+func GetMultiHopTransferDenom(denom string, hops ...ChannelCounterparty) string {
+	prefixed := denom
+	for _, hop := range hops {
+		prefixed = transfertypes.GetPrefixedDenom(hop.PortID, hop.ChannelID, prefixed)
+	}
+	return transfertypes.ParseDenomTrace(prefixed).IBCDenom()
+}
+
+// GetTransferEscrowAddress returns the bech32 address of the account holding the native tokens
+// sent out over the given port and channel, until they are transferred back or refunded.
+// The address is derived from the port and channel alone, so it is the same on every chain except for its prefix.
+func GetTransferEscrowAddress(bech32Prefix, portID, channelID string) (string, error) {
+	return bech32.ConvertAndEncode(bech32Prefix, transfertypes.GetEscrowAddress(portID, channelID))
+}
+
+// WasmPortID returns the IBC port bound to a CosmWasm contract, such as a cw20-ics20 contract.
+func WasmPortID(contractAddress string) string {
+	return "wasm." + contractAddress
+}
+
+// CW20Denom returns the ICS-20 denom of the cw20 token contract sent by a cw20-ics20 contract, i.e. "cw20:<address>".
+// The voucher denom on the receiving chain is GetTransferDenom of it.
+func CW20Denom(contractAddress string) string {
+	return "cw20:" + contractAddress
+}
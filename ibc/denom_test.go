@@ -0,0 +1,73 @@
+package ibc
+
+import (
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransferDenom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single hop", func(t *testing.T) {
+		got := GetTransferDenom("transfer", "channel-0", "uatom")
+		want := transfertypes.DenomTrace{Path: "transfer/channel-0", BaseDenom: "uatom"}.IBCDenom()
+		require.Equal(t, want, got)
+	})
+
+	t.Run("multi hop", func(t *testing.T) {
+		got := GetTransferDenom("transfer", "channel-2", "transfer/channel-1/uatom")
+		want := transfertypes.DenomTrace{Path: "transfer/channel-2/transfer/channel-1", BaseDenom: "uatom"}.IBCDenom()
+		require.Equal(t, want, got)
+	})
+}
+
+func TestDenomTrace_IBCDenom(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "uatom", DenomTrace{BaseDenom: "uatom"}.IBCDenom())
+
+	trace := DenomTrace{Path: "transfer/channel-0", BaseDenom: "uatom"}
+	require.Equal(t, GetTransferDenom("transfer", "channel-0", "uatom"), trace.IBCDenom())
+}
+
+func TestGetMultiHopTransferDenom(t *testing.T) {
+	t.Parallel()
+
+	got := GetMultiHopTransferDenom("uatom",
+		ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"},
+		ChannelCounterparty{PortID: "transfer", ChannelID: "channel-2"},
+	)
+	want := transfertypes.DenomTrace{Path: "transfer/channel-2/transfer/channel-1", BaseDenom: "uatom"}.IBCDenom()
+	require.Equal(t, want, got)
+
+	require.Equal(t, "uatom", GetMultiHopTransferDenom("uatom"))
+}
+
+func TestGetTransferEscrowAddress(t *testing.T) {
+	t.Parallel()
+
+	addr, err := GetTransferEscrowAddress("cosmos", "transfer", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos1a53udazy8ayufvy0s434pfwjcedzqv34kvz9tw", addr)
+
+	addr, err = GetTransferEscrowAddress("osmo", "transfer", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, "osmo1a53udazy8ayufvy0s434pfwjcedzqv347h34au", addr)
+
+	other, err := GetTransferEscrowAddress("osmo", "transfer", "channel-1")
+	require.NoError(t, err)
+	require.NotEqual(t, addr, other)
+}
+
+func TestCW20Denom(t *testing.T) {
+	t.Parallel()
+
+	const contract = "juno14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9skjuwg8"
+	require.Equal(t, "wasm."+contract, WasmPortID(contract))
+	require.Equal(t, "cw20:"+contract, CW20Denom(contract))
+
+	want := transfertypes.DenomTrace{Path: "transfer/channel-3", BaseDenom: "cw20:" + contract}.IBCDenom()
+	require.Equal(t, want, GetTransferDenom("transfer", "channel-3", CW20Denom(contract)))
+}
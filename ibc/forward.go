@@ -0,0 +1,78 @@
+package ibc
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ForwardHop is one hop of a transfer routed by packet-forward-middleware (PFM),
+// sent from an intermediate chain that has received the tokens.
+type ForwardHop struct {
+	// Receiver is the address on the chain the hop sends to.
+	Receiver string
+
+	// PortID and ChannelID identify the channel on the forwarding chain.
+	// PortID defaults to "transfer" when empty.
+	PortID, ChannelID string
+
+	// Timeout of the forwarded packet, and how many times PFM retries it after a timeout.
+	// The middleware's defaults are used when zero and nil respectively.
+	Timeout time.Duration
+	Retries *uint8
+}
+
+// forwardMemo is the memo format parsed by packet-forward-middleware.
+type forwardMemo struct {
+	Forward forwardMetadata `json:"forward"`
+}
+
+type forwardMetadata struct {
+	Receiver string       `json:"receiver"`
+	Port     string       `json:"port"`
+	Channel  string       `json:"channel"`
+	Timeout  string       `json:"timeout,omitempty"`
+	Retries  *uint8       `json:"retries,omitempty"`
+	Next     *forwardMemo `json:"next,omitempty"`
+}
+
+// ForwardMemo returns the transfer memo that makes packet-forward-middleware route the received tokens
+// through each hop in order, e.g. with the first hop sent from chain B to C and the second from C to D.
+// It is set as the TransferOptions.Memo of the transfer to the first intermediate chain.
+func ForwardMemo(hops ...ForwardHop) (string, error) {
+	if len(hops) == 0 {
+		return "", errors.New("at least one forward hop is required")
+	}
+
+	var next *forwardMemo
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := hops[i]
+		if hop.Receiver == "" || hop.ChannelID == "" {
+			return "", errors.New("forward hop requires a receiver and channel ID")
+		}
+
+		port := hop.PortID
+		if port == "" {
+			port = "transfer"
+		}
+		var timeout string
+		if hop.Timeout > 0 {
+			timeout = hop.Timeout.String()
+		}
+
+		next = &forwardMemo{Forward: forwardMetadata{
+			Receiver: hop.Receiver,
+			Port:     port,
+			Channel:  hop.ChannelID,
+			Timeout:  timeout,
+			Retries:  hop.Retries,
+			Next:     next,
+		}}
+	}
+
+	bz, err := json.Marshal(next)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
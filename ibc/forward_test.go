@@ -0,0 +1,39 @@
+package ibc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardMemo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single hop", func(t *testing.T) {
+		memo, err := ForwardMemo(ForwardHop{Receiver: "osmo1abc", ChannelID: "channel-1"})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"forward":{"receiver":"osmo1abc","port":"transfer","channel":"channel-1"}}`, memo)
+	})
+
+	t.Run("nested hops", func(t *testing.T) {
+		retries := uint8(2)
+		memo, err := ForwardMemo(
+			ForwardHop{Receiver: "osmo1abc", ChannelID: "channel-1", Timeout: 10 * time.Minute, Retries: &retries},
+			ForwardHop{Receiver: "juno1def", PortID: "custom", ChannelID: "channel-7"},
+		)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"forward":{
+			"receiver":"osmo1abc","port":"transfer","channel":"channel-1","timeout":"10m0s","retries":2,
+			"next":{"forward":{"receiver":"juno1def","port":"custom","channel":"channel-7"}}
+		}}`, memo)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ForwardMemo()
+		require.Error(t, err)
+
+		_, err = ForwardMemo(ForwardHop{Receiver: "osmo1abc"})
+		require.ErrorContains(t, err, "channel ID")
+	})
+}
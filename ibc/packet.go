@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	transfertypes "github.com/cosmos/ibc-go/v6/modules/apps/transfer/types"
 	host "github.com/cosmos/ibc-go/v6/modules/core/24-host"
 	"go.uber.org/multierr"
 )
@@ -60,6 +61,34 @@ func (packet Packet) Equal(other Packet) bool {
 	return reflect.DeepEqual(packet, other)
 }
 
+// TransferPacketData is the data of an ICS-20 fungible token transfer packet.
+type TransferPacketData struct {
+	// Denom is the denom sent, prefixed with the path it was received through, e.g. "transfer/channel-0/uatom".
+	Denom    string
+	Amount   string
+	Sender   string
+	Receiver string
+	// Memo is the transfer's TransferOptions.Memo, e.g. a ForwardMemo.
+	// An empty memo is omitted from the packet data, so it cannot be told apart from an absent one.
+	Memo string
+}
+
+// TransferData decodes the data of an ICS-20 transfer packet.
+// It returns an error if the data is not of a transfer, e.g. a packet of another application.
+func (packet Packet) TransferData() (TransferPacketData, error) {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.Data, &data); err != nil {
+		return TransferPacketData{}, fmt.Errorf("packet %d on %s/%s is not a transfer: %w", packet.Sequence, packet.SourcePort, packet.SourceChannel, err)
+	}
+	return TransferPacketData{
+		Denom:    data.Denom,
+		Amount:   data.Amount,
+		Sender:   data.Sender,
+		Receiver: data.Receiver,
+		Memo:     data.Memo,
+	}, nil
+}
+
 // PacketAcknowledgement signals the packet was processed and accepted by the counterparty chain.
 // See: https://github.com/cosmos/ibc/blob/52a9094a5bc8c5275e25c19d0b2d9e6fd80ba31c/spec/core/ics-004-channel-and-packet-semantics/README.md#writing-acknowledgements
 type PacketAcknowledgement struct {
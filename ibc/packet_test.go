@@ -137,3 +137,28 @@ func TestPacketTimeout_Validate(t *testing.T) {
 	timeout.Packet = validPacket()
 	require.NoError(t, timeout.Validate())
 }
+
+func TestPacket_TransferData(t *testing.T) {
+	packet := validPacket()
+
+	packet.Data = []byte(`{"amount":"100","denom":"transfer/channel-0/uatom","memo":"{\"forward\":{}}","receiver":"osmo1receiver","sender":"cosmos1sender"}`)
+	data, err := packet.TransferData()
+	require.NoError(t, err)
+	require.Equal(t, TransferPacketData{
+		Denom:    "transfer/channel-0/uatom",
+		Amount:   "100",
+		Sender:   "cosmos1sender",
+		Receiver: "osmo1receiver",
+		Memo:     `{"forward":{}}`,
+	}, data)
+
+	// An empty memo is omitted by the sender, and decodes the same as an absent one.
+	packet.Data = []byte(`{"amount":"100","denom":"uatom","receiver":"osmo1receiver","sender":"cosmos1sender"}`)
+	data, err = packet.TransferData()
+	require.NoError(t, err)
+	require.Empty(t, data.Memo)
+
+	packet.Data = []byte(`fake data`)
+	_, err = packet.TransferData()
+	require.ErrorContains(t, err, "packet 1 on transfer/channel-0 is not a transfer")
+}
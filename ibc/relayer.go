@@ -2,12 +2,16 @@ package ibc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	feetypes "github.com/cosmos/ibc-go/v6/modules/apps/29-fee/types"
 	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
 	ptypes "github.com/cosmos/ibc-go/v6/modules/core/05-port/types"
 	host "github.com/cosmos/ibc-go/v6/modules/core/24-host"
+	"go.uber.org/multierr"
 )
 
 // Relayer represents an instance of a relayer that can be support IBC.
@@ -41,6 +45,10 @@ type Relayer interface {
 	// setup channels, connections, and clients
 	LinkPath(ctx context.Context, rep RelayerExecReporter, pathName string, channelOpts CreateChannelOptions, clientOptions CreateClientOptions) error
 
+	// LinkPathOverConnection creates a channel on the given path over an existing client and connection pair,
+	// instead of creating new clients and connections as LinkPath does.
+	LinkPathOverConnection(ctx context.Context, rep RelayerExecReporter, pathName string, conn PathConnection, channelOpts CreateChannelOptions) error
+
 	// update path channel filter
 	UpdatePath(ctx context.Context, rep RelayerExecReporter, pathName string, filter ChannelFilter) error
 
@@ -64,22 +72,36 @@ type Relayer interface {
 	// StopRelayer stops a relayer that started work through StartRelayer.
 	StopRelayer(ctx context.Context, rep RelayerExecReporter) error
 
+	// RestartRelayer stops a relayer that started work through StartRelayer
+	// and starts it again on the same paths, keeping all configured chains, paths, and keys.
+	RestartRelayer(ctx context.Context, rep RelayerExecReporter) error
+
 	// FlushPackets flushes any outstanding packets and then returns.
 	FlushPackets(ctx context.Context, rep RelayerExecReporter, pathName string, channelID string) error
 
 	// FlushAcknowledgements flushes any outstanding acknowledgements and then returns.
 	FlushAcknowledgements(ctx context.Context, rep RelayerExecReporter, pathName string, channelID string) error
 
+	// RelayPacket relays only the packet with the given sequence sent on the src channel, and then its acknowledgement,
+	// without naming the path connecting the channel.
+	// The error wraps ErrSingleSequenceUnsupported if the relayer cannot relay that packet alone.
+	RelayPacket(ctx context.Context, rep RelayerExecReporter, src ChannelRef, sequence uint64) error
+
 	// CreateClients performs the client handshake steps necessary for creating a light client
-	// on src that tracks the state of dst, and a light client on dst that tracks the state of src.
-	CreateClients(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateClientOptions) error
+	// on src that tracks the state of dst, and a light client on dst that tracks the state of src,
+	// and returns the IDs of the new clients as reported by the relayer, or empty IDs if it does not report them.
+	CreateClients(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateClientOptions) (CreatedClients, error)
 
 	// CreateConnections performs the connection handshake steps necessary for creating a connection
-	// between the src and dst chains.
-	CreateConnections(ctx context.Context, rep RelayerExecReporter, pathName string) error
+	// between the src and dst chains, and returns the IDs of the new connection on both chains as reported by the relayer,
+	// or empty IDs if it does not report them.
+	// Together with CreateClients and CreateChannel, it lets a test built with SkipPathCreation
+	// drive the handshakes one step at a time, inspecting the state in between.
+	CreateConnections(ctx context.Context, rep RelayerExecReporter, pathName string) (CreatedConnection, error)
 
-	// CreateChannel creates a channel on the given path with the provided options.
-	CreateChannel(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateChannelOptions) error
+	// CreateChannel creates a channel on the given path with the provided options,
+	// and returns the IDs of the new channel on both chains as reported by the relayer.
+	CreateChannel(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateChannelOptions) (CreatedChannel, error)
 
 	// UseDockerNetwork reports whether the relayer is run in the same docker network as the other chains.
 	//
@@ -98,6 +120,18 @@ type Relayer interface {
 	Exec(ctx context.Context, rep RelayerExecReporter, cmd []string, env []string) RelayerExecResult
 }
 
+// ClientLinker is implemented by relayers that can link a path over an existing pair of light clients,
+// such as relayer.DockerRelayer and hermes.Relayer. Interchain Security links require it.
+type ClientLinker interface {
+	// LinkPathOverClients creates a connection and a channel on the given path over an existing pair of light clients,
+	// such as the clients created by a consumer chain's genesis and its provider under Interchain Security.
+	LinkPathOverClients(ctx context.Context, rep RelayerExecReporter, pathName, srcClientID, dstClientID string, channelOpts CreateChannelOptions) error
+}
+
+// ErrSingleSequenceUnsupported is returned by Relayer.RelayPacket when the relayer cannot relay a single packet,
+// e.g. because other packets are pending on the same channel.
+var ErrSingleSequenceUnsupported = errors.New("relayer cannot relay a single packet sequence")
+
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
@@ -174,6 +208,182 @@ func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter,
 	return srcChan, nil
 }
 
+// ChannelQuery selects channels from the output of Relayer.GetChannels.
+// Empty fields match every channel.
+type ChannelQuery struct {
+	// PortID is the port of the channel on the queried chain.
+	PortID string
+	// CounterpartyPortID is the port of the channel on the counterparty chain.
+	CounterpartyPortID string
+	// OpenOnly excludes channels whose handshake has not completed or that have been closed.
+	OpenOnly bool
+}
+
+// Matches reports whether the channel satisfies every field set on q.
+func (q ChannelQuery) Matches(c ChannelOutput) bool {
+	if q.PortID != "" && c.PortID != q.PortID {
+		return false
+	}
+	if q.CounterpartyPortID != "" && c.Counterparty.PortID != q.CounterpartyPortID {
+		return false
+	}
+	if q.OpenOnly && !c.Open() {
+		return false
+	}
+	return true
+}
+
+// FilterChannels returns the channels matching q, in their original order.
+func FilterChannels(channels []ChannelOutput, q ChannelQuery) []ChannelOutput {
+	var out []ChannelOutput
+	for _, c := range channels {
+		if q.Matches(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// GetChannel returns the only channel on chainID matching q.
+// Unlike indexing into the result of Relayer.GetChannels, it fails when no channel or several channels match,
+// so it keeps selecting the intended channel when additional channels are opened.
+func GetChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID string, q ChannelQuery) (*ChannelOutput, error) {
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+
+	matched := FilterChannels(channels, q)
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no channel on %s matches %+v", chainID, q)
+	case 1:
+		return &matched[0], nil
+	default:
+		ids := make([]string, len(matched))
+		for i, c := range matched {
+			ids[i] = c.PortID + "/" + c.ChannelID
+		}
+		return nil, fmt.Errorf("%d channels on %s match %+v: %v", len(matched), chainID, q, ids)
+	}
+}
+
+// CreateVerifiedChannel creates a channel on pathName like Relayer.CreateChannel,
+// then checks that the version negotiated by the new channel on srcChainID is exactly opts.Version.
+// The new channel is identified as the one on opts.SourcePortName that did not exist before,
+// so the source port may be one derived at runtime, e.g. the IBC port of a wasm contract.
+func CreateVerifiedChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, pathName string, opts CreateChannelOptions) (*ChannelOutput, error) {
+	before, err := r.GetChannels(ctx, rep, srcChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on source chain: %w", err)
+	}
+
+	if _, err := r.CreateChannel(ctx, rep, pathName, opts); err != nil {
+		return nil, err
+	}
+
+	after, err := r.GetChannels(ctx, rep, srcChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on source chain: %w", err)
+	}
+
+	channel, err := NewChannelOnPort(before, after, opts.SourcePortName)
+	if err != nil {
+		return nil, fmt.Errorf("on %s: %w", srcChainID, err)
+	}
+	if channel.Version != opts.Version {
+		return nil, fmt.Errorf(
+			"channel %s on port %s of %s negotiated version %q, expected %q",
+			channel.ChannelID, channel.PortID, srcChainID, channel.Version, opts.Version,
+		)
+	}
+	return channel, nil
+}
+
+// GetOrCreateChannel returns an open channel on srcChainID matching the ports and version of opts,
+// creating one on pathName like Relayer.CreateChannel only if none exists yet.
+// The returned bool reports whether an existing channel was reused,
+// so calling both Interchain.AddLink and GetOrCreateChannel for the same channel does not open a second one.
+//
+// If r is a PathGetter, only channels over the connection of pathName are reused,
+// so that creating the same channel on another path between the chains opens a new one.
+func GetOrCreateChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, pathName string, opts CreateChannelOptions) (*ChannelOutput, bool, error) {
+	var connectionID string
+	if pg, ok := r.(PathGetter); ok {
+		path, err := pg.GetPath(ctx, rep, pathName)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get path %s: %w", pathName, err)
+		}
+		if connectionID, err = path.ConnectionID(srcChainID); err != nil {
+			return nil, false, fmt.Errorf("path %s: %w", pathName, err)
+		}
+		if connectionID == "" {
+			// Without a connection there is no channel on the path to reuse.
+			return createChannel(ctx, r, rep, srcChainID, pathName, opts)
+		}
+	}
+
+	channels, err := r.GetChannels(ctx, rep, srcChainID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get channels on source chain: %w", err)
+	}
+	if channel := existingChannel(channels, connectionID, opts); channel != nil {
+		return channel, true, nil
+	}
+	return createChannel(ctx, r, rep, srcChainID, pathName, opts)
+}
+
+// createChannel creates a channel for GetOrCreateChannel.
+func createChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, pathName string, opts CreateChannelOptions) (*ChannelOutput, bool, error) {
+	channel, err := CreateVerifiedChannel(ctx, r, rep, srcChainID, pathName, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	return channel, false, nil
+}
+
+// existingChannel returns the first open channel over connectionID matching the ports and version of opts, or nil.
+// An empty connectionID matches channels over any connection.
+func existingChannel(channels []ChannelOutput, connectionID string, opts CreateChannelOptions) *ChannelOutput {
+	q := ChannelQuery{PortID: opts.SourcePortName, CounterpartyPortID: opts.DestPortName, OpenOnly: true}
+	for _, c := range FilterChannels(channels, q) {
+		if connectionID != "" && (len(c.ConnectionHops) == 0 || c.ConnectionHops[0] != connectionID) {
+			continue
+		}
+		if c.Version == opts.Version {
+			c := c
+			return &c
+		}
+	}
+	return nil
+}
+
+// NewChannelOnPort returns the only channel on portID in after which is absent from before,
+// i.e. the channel opened between two calls to Relayer.GetChannels.
+func NewChannelOnPort(before, after []ChannelOutput, portID string) (*ChannelOutput, error) {
+	existing := make(map[string]bool, len(before))
+	for _, c := range before {
+		existing[c.PortID+"/"+c.ChannelID] = true
+	}
+
+	var found *ChannelOutput
+	for _, c := range after {
+		c := c
+		if c.PortID != portID || existing[c.PortID+"/"+c.ChannelID] {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple new channels on port %s: %s and %s", portID, found.ChannelID, c.ChannelID)
+		}
+		found = &c
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no new channel found on port %s", portID)
+	}
+	return found, nil
+}
+
 // RelyaerExecResult holds the details of a call to Relayer.Exec.
 type RelayerExecResult struct {
 	// This type is a redeclaration of dockerutil.ContainerExecResult.
@@ -207,7 +417,13 @@ type CreateChannelOptions struct {
 }
 
 // DefaultChannelOpts returns the default settings for creating an ics20 fungible token transfer channel.
+// It is the same as DefaultTransferChannelOpts, and is what a link uses when no channel options are given.
 func DefaultChannelOpts() CreateChannelOptions {
+	return DefaultTransferChannelOpts()
+}
+
+// DefaultTransferChannelOpts returns the options for an ics20 fungible token transfer channel.
+func DefaultTransferChannelOpts() CreateChannelOptions {
 	return CreateChannelOptions{
 		SourcePortName: "transfer",
 		DestPortName:   "transfer",
@@ -216,19 +432,65 @@ func DefaultChannelOpts() CreateChannelOptions {
 	}
 }
 
+// ICQChannelOpts returns the options for an interchain queries channel
+// from the querying module bound to controllerPort, e.g. "interquery", to the icq host module.
+func ICQChannelOpts(controllerPort string) CreateChannelOptions {
+	return CreateChannelOptions{
+		SourcePortName: controllerPort,
+		DestPortName:   "icqhost",
+		Order:          Unordered,
+		Version:        "icq-1",
+	}
+}
+
+// ICAChannelOpts returns the options for an ics27 interchain accounts channel of owner,
+// over the given connection on the controller chain and its counterparty on the host chain.
+//
+// Interchain account channels are normally opened by registering the account, e.g. with cosmos.CosmosChain.RegisterInterchainAccount;
+// these options are for opening one through the relayer instead.
+func ICAChannelOpts(owner, controllerConnectionID, hostConnectionID string) CreateChannelOptions {
+	return CreateChannelOptions{
+		SourcePortName: icatypes.ControllerPortPrefix + owner,
+		DestPortName:   icatypes.HostPortID,
+		Order:          Ordered,
+		Version:        icatypes.NewDefaultMetadataString(controllerConnectionID, hostConnectionID),
+	}
+}
+
+// FeeChannelOpts returns the default ics20 transfer channel options
+// with the version wrapped in ICS-29 fee middleware metadata, so that the channel supports incentivized relaying.
+func FeeChannelOpts() CreateChannelOptions {
+	opts := DefaultChannelOpts()
+	opts.Version = FeeChannelVersion(opts.Version)
+	return opts
+}
+
+// FeeChannelVersion wraps the given application version in the metadata expected by the ICS-29 fee middleware,
+// e.g. {"fee_version":"ics29-1","app_version":"ics20-1"}.
+func FeeChannelVersion(appVersion string) string {
+	return string(feetypes.ModuleCdc.MustMarshalJSON(&feetypes.Metadata{
+		FeeVersion: feetypes.Version,
+		AppVersion: appVersion,
+	}))
+}
+
 // Validate will check that the specified CreateChannelOptions are valid.
+// The returned error names every invalid field.
 func (opts CreateChannelOptions) Validate() error {
-	switch {
-	case host.PortIdentifierValidator(opts.SourcePortName) != nil:
-		return ptypes.ErrInvalidPort
-	case host.PortIdentifierValidator(opts.DestPortName) != nil:
-		return ptypes.ErrInvalidPort
-	case opts.Version == "":
-		return fmt.Errorf("invalid channel version")
-	case opts.Order.Validate() != nil:
-		return chantypes.ErrInvalidChannelOrdering
+	var err error
+	if vErr := host.PortIdentifierValidator(opts.SourcePortName); vErr != nil {
+		err = multierr.Append(err, fmt.Errorf("SourcePortName %q: %w: %v", opts.SourcePortName, ptypes.ErrInvalidPort, vErr))
 	}
-	return nil
+	if vErr := host.PortIdentifierValidator(opts.DestPortName); vErr != nil {
+		err = multierr.Append(err, fmt.Errorf("DestPortName %q: %w: %v", opts.DestPortName, ptypes.ErrInvalidPort, vErr))
+	}
+	if opts.Version == "" {
+		err = multierr.Append(err, errors.New("Version: channel version must not be empty"))
+	}
+	if vErr := opts.Order.Validate(); vErr != nil {
+		err = multierr.Append(err, fmt.Errorf("Order %d: %w: must be ibc.Ordered or ibc.Unordered", opts.Order, vErr))
+	}
+	return err
 }
 
 // Order represents an IBC channel's ordering.
@@ -262,7 +524,15 @@ func (o Order) Validate() error {
 
 // CreateClientOptions contains the configuration for creating a client.
 type CreateClientOptions struct {
+	// Trusting period of the client as a Go duration string, or "0" for the relayer's default.
 	TrustingPeriod string
+
+	// Trust level of the client as a fraction, e.g. "1/3". If empty, the relayer's default is used.
+	// Not every relayer supports overriding the trust level.
+	TrustLevel string
+
+	// Maximum clock drift of the client as a Go duration string. If empty, the relayer's default is used.
+	MaxClockDrift string
 }
 
 // DefaultClientOpts returns the default settings for creating clients.
@@ -278,6 +548,24 @@ func (opts CreateClientOptions) Validate() error {
 	if err != nil {
 		return err
 	}
+
+	if opts.MaxClockDrift != "" {
+		if _, err := time.ParseDuration(opts.MaxClockDrift); err != nil {
+			return fmt.Errorf("invalid MaxClockDrift: %w", err)
+		}
+	}
+
+	if opts.TrustLevel != "" {
+		// Light clients require a trust level in [1/3, 1].
+		var num, denom uint64
+		if _, err := fmt.Sscanf(opts.TrustLevel, "%d/%d", &num, &denom); err != nil {
+			return fmt.Errorf("invalid TrustLevel %q, expected a fraction such as 1/3: %w", opts.TrustLevel, err)
+		}
+		if denom == 0 || num > denom || 3*num < denom {
+			return fmt.Errorf("invalid TrustLevel %q, must be between 1/3 and 1", opts.TrustLevel)
+		}
+	}
+
 	return nil
 }
 
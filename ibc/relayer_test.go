@@ -1,9 +1,12 @@
 package ibc
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	ptypes "github.com/cosmos/ibc-go/v6/modules/core/05-port/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,7 +26,9 @@ func TestChannelOptsConfigured(t *testing.T) {
 		Order:          3,
 		Version:        "123",
 	}
-	require.Error(t, opts.Validate())
+	err := opts.Validate()
+	require.ErrorIs(t, err, chantypes.ErrInvalidChannelOrdering)
+	require.ErrorContains(t, err, "Order")
 	require.Equal(t, chantypes.ErrInvalidChannelOrdering, opts.Order.Validate())
 
 	// Test partial channel opts
@@ -32,5 +37,254 @@ func TestChannelOptsConfigured(t *testing.T) {
 		DestPortName:   "",
 		Order:          0,
 	}
-	require.Error(t, opts.Validate())
+	err = opts.Validate()
+	require.ErrorIs(t, err, ptypes.ErrInvalidPort)
+	for _, field := range []string{"SourcePortName", "DestPortName", "Version", "Order"} {
+		require.ErrorContains(t, err, field)
+	}
+}
+
+func TestFeeChannelOpts(t *testing.T) {
+	opts := FeeChannelOpts()
+	require.NoError(t, opts.Validate())
+	require.JSONEq(t, `{"fee_version":"ics29-1","app_version":"ics20-1"}`, opts.Version)
+}
+
+func TestAppChannelOpts(t *testing.T) {
+	require.Equal(t, DefaultChannelOpts(), DefaultTransferChannelOpts())
+
+	icq := ICQChannelOpts("interquery")
+	require.NoError(t, icq.Validate())
+	require.Equal(t, CreateChannelOptions{SourcePortName: "interquery", DestPortName: "icqhost", Order: Unordered, Version: "icq-1"}, icq)
+
+	ica := ICAChannelOpts("cosmos1owner", "connection-0", "connection-1")
+	require.NoError(t, ica.Validate())
+	require.Equal(t, "icacontroller-cosmos1owner", ica.SourcePortName)
+	require.Equal(t, "icahost", ica.DestPortName)
+	require.Equal(t, Ordered, ica.Order)
+	require.JSONEq(t, `{
+		"version":"ics27-1","controller_connection_id":"connection-0","host_connection_id":"connection-1",
+		"address":"","encoding":"proto3","tx_type":"sdk_multi_msg"
+	}`, ica.Version)
+}
+
+func TestPathConnectionValidate(t *testing.T) {
+	conn := PathConnection{
+		SrcClientID: "07-tendermint-0", SrcConnectionID: "connection-0",
+		DstClientID: "07-tendermint-0", DstConnectionID: "connection-0",
+	}
+	require.NoError(t, conn.Validate())
+
+	conn.DstConnectionID = ""
+	require.Error(t, conn.Validate())
+}
+
+func TestClientOptsValidate(t *testing.T) {
+	require.NoError(t, DefaultClientOpts().Validate())
+
+	opts := CreateClientOptions{TrustingPeriod: "1m", TrustLevel: "2/3", MaxClockDrift: "5s"}
+	require.NoError(t, opts.Validate())
+
+	for _, level := range []string{"1/4", "4/3", "1/0", "one third"} {
+		opts := CreateClientOptions{TrustingPeriod: "0", TrustLevel: level}
+		require.Error(t, opts.Validate(), level)
+	}
+
+	opts = CreateClientOptions{TrustingPeriod: "0", MaxClockDrift: "soon"}
+	require.ErrorContains(t, opts.Validate(), "MaxClockDrift")
+}
+
+func TestNewChannelOnPort(t *testing.T) {
+	const wasmPort = "wasm.juno14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9skjuwg8"
+	before := []ChannelOutput{
+		{PortID: "transfer", ChannelID: "channel-0", Version: "ics20-1"},
+	}
+
+	t.Run("single new channel", func(t *testing.T) {
+		after := append(before, ChannelOutput{PortID: wasmPort, ChannelID: "channel-1", Version: "icq-1"})
+		c, err := NewChannelOnPort(before, after, wasmPort)
+		require.NoError(t, err)
+		require.Equal(t, "channel-1", c.ChannelID)
+		require.Equal(t, "icq-1", c.Version)
+	})
+
+	t.Run("existing channel is ignored", func(t *testing.T) {
+		_, err := NewChannelOnPort(before, before, "transfer")
+		require.ErrorContains(t, err, "no new channel found on port transfer")
+	})
+
+	t.Run("multiple new channels", func(t *testing.T) {
+		after := append(before,
+			ChannelOutput{PortID: "transfer", ChannelID: "channel-1"},
+			ChannelOutput{PortID: "transfer", ChannelID: "channel-2"},
+		)
+		_, err := NewChannelOnPort(before, after, "transfer")
+		require.ErrorContains(t, err, "found multiple new channels on port transfer")
+	})
+}
+
+func TestExistingChannel(t *testing.T) {
+	opts := DefaultTransferChannelOpts()
+	channels := []ChannelOutput{
+		{State: "STATE_TRYOPEN", PortID: "transfer", ChannelID: "channel-0", Version: "ics20-1", Counterparty: ChannelCounterparty{PortID: "transfer"}},
+		{State: "STATE_OPEN", PortID: "transfer", ChannelID: "channel-1", Version: "ics20-2", Counterparty: ChannelCounterparty{PortID: "transfer"}},
+		{State: "STATE_OPEN", PortID: "transfer", ChannelID: "channel-2", Version: "ics20-1", Counterparty: ChannelCounterparty{PortID: "icahost"}},
+	}
+	require.Nil(t, existingChannel(channels, "", opts))
+
+	channels = append(channels,
+		ChannelOutput{State: "Open", PortID: "transfer", ChannelID: "channel-3", Version: "ics20-1", ConnectionHops: []string{"connection-0"}, Counterparty: ChannelCounterparty{PortID: "transfer"}},
+		ChannelOutput{State: "STATE_OPEN", PortID: "transfer", ChannelID: "channel-4", Version: "ics20-1", ConnectionHops: []string{"connection-1"}, Counterparty: ChannelCounterparty{PortID: "transfer"}},
+	)
+	c := existingChannel(channels, "", opts)
+	require.NotNil(t, c)
+	require.Equal(t, "channel-3", c.ChannelID)
+
+	c = existingChannel(channels, "connection-1", opts)
+	require.NotNil(t, c)
+	require.Equal(t, "channel-4", c.ChannelID)
+
+	require.Nil(t, existingChannel(channels, "connection-2", opts))
+}
+
+// twoPathRelayer is a Relayer with two paths between chains a and b, each over its own connection;
+// its methods other than those of a PathGetter, GetChannels and CreateChannel are unimplemented.
+type twoPathRelayer struct {
+	Relayer
+
+	paths    map[string]PathOutput
+	channels map[string][]ChannelOutput // By chain ID.
+}
+
+func (r *twoPathRelayer) GetPath(_ context.Context, _ RelayerExecReporter, pathName string) (PathOutput, error) {
+	p, ok := r.paths[pathName]
+	if !ok {
+		return PathOutput{}, fmt.Errorf("path %s not found", pathName)
+	}
+	return p, nil
+}
+
+func (r *twoPathRelayer) GetChannels(_ context.Context, _ RelayerExecReporter, chainID string) ([]ChannelOutput, error) {
+	return r.channels[chainID], nil
+}
+
+func (r *twoPathRelayer) CreateChannel(_ context.Context, _ RelayerExecReporter, pathName string, opts CreateChannelOptions) (CreatedChannel, error) {
+	p := r.paths[pathName]
+	channelID := fmt.Sprintf("channel-%d", len(r.channels[p.SrcChainID]))
+	r.channels[p.SrcChainID] = append(r.channels[p.SrcChainID], ChannelOutput{
+		State:          "STATE_OPEN",
+		PortID:         opts.SourcePortName,
+		ChannelID:      channelID,
+		Version:        opts.Version,
+		ConnectionHops: []string{p.SrcConnectionID},
+		Counterparty:   ChannelCounterparty{PortID: opts.DestPortName},
+	})
+	return CreatedChannel{ChannelID: channelID}, nil
+}
+
+func TestGetOrCreateChannel_TwoPaths(t *testing.T) {
+	ctx := context.Background()
+	r := &twoPathRelayer{
+		paths: map[string]PathOutput{
+			"ab":        {SrcChainID: "a", DstChainID: "b", PathConnection: PathConnection{SrcConnectionID: "connection-0", DstConnectionID: "connection-0"}},
+			"ab-second": {SrcChainID: "a", DstChainID: "b", PathConnection: PathConnection{SrcConnectionID: "connection-1", DstConnectionID: "connection-1"}},
+			"ab-new":    {SrcChainID: "a", DstChainID: "b"},
+		},
+		channels: map[string][]ChannelOutput{},
+	}
+	opts := DefaultTransferChannelOpts()
+
+	first, reused, err := GetOrCreateChannel(ctx, r, nil, "a", "ab", opts)
+	require.NoError(t, err)
+	require.False(t, reused)
+	require.Equal(t, "channel-0", first.ChannelID)
+
+	again, reused, err := GetOrCreateChannel(ctx, r, nil, "a", "ab", opts)
+	require.NoError(t, err)
+	require.True(t, reused)
+	require.Equal(t, "channel-0", again.ChannelID)
+
+	// The same ports and version on another path between the chains open a second channel.
+	second, reused, err := GetOrCreateChannel(ctx, r, nil, "a", "ab-second", opts)
+	require.NoError(t, err)
+	require.False(t, reused)
+	require.Equal(t, "channel-1", second.ChannelID)
+	require.Equal(t, []string{"connection-1"}, second.ConnectionHops)
+
+	// A path without a connection yet has no channel to reuse.
+	_, reused, err = GetOrCreateChannel(ctx, r, nil, "a", "ab-new", opts)
+	require.NoError(t, err)
+	require.False(t, reused)
+
+	_, _, err = GetOrCreateChannel(ctx, r, nil, "c", "ab", opts)
+	require.ErrorContains(t, err, "chain c is at neither end")
+}
+
+func TestPathOutputConnectionID(t *testing.T) {
+	p := PathOutput{SrcChainID: "a", DstChainID: "b", PathConnection: PathConnection{SrcConnectionID: "connection-0", DstConnectionID: "connection-3"}}
+
+	id, err := p.ConnectionID("a")
+	require.NoError(t, err)
+	require.Equal(t, "connection-0", id)
+
+	id, err = p.ConnectionID("b")
+	require.NoError(t, err)
+	require.Equal(t, "connection-3", id)
+
+	_, err = p.ConnectionID("c")
+	require.Error(t, err)
+}
+
+func TestChannelFilterValidate(t *testing.T) {
+	filter := ChannelFilter{Rule: ChannelFilterAllowlist, ChannelList: []string{"channel-0", "channel-12"}}
+	require.NoError(t, filter.Validate())
+
+	filter.Rule = ChannelFilterDenylist
+	require.NoError(t, filter.Validate())
+
+	filter.Rule = "allow"
+	require.ErrorContains(t, filter.Validate(), "channel filter rule")
+
+	filter.Rule = ChannelFilterAllowlist
+	filter.ChannelList = []string{"channel 0"}
+	require.ErrorContains(t, filter.Validate(), "invalid channel")
+}
+
+func TestFilterChannels(t *testing.T) {
+	channels := []ChannelOutput{
+		{PortID: "transfer", ChannelID: "channel-0", State: "STATE_OPEN", Counterparty: ChannelCounterparty{PortID: "transfer"}},
+		{PortID: "icqhost", ChannelID: "channel-1", State: "Open", Counterparty: ChannelCounterparty{PortID: "wasm.juno1abc"}},
+		{PortID: "transfer", ChannelID: "channel-2", State: "STATE_INIT", Counterparty: ChannelCounterparty{PortID: "transfer"}},
+	}
+
+	require.Len(t, FilterChannels(channels, ChannelQuery{}), 3)
+	require.Len(t, FilterChannels(channels, ChannelQuery{PortID: "transfer"}), 2)
+
+	open := FilterChannels(channels, ChannelQuery{PortID: "transfer", OpenOnly: true})
+	require.Len(t, open, 1)
+	require.Equal(t, "channel-0", open[0].ChannelID)
+
+	byCounterparty := FilterChannels(channels, ChannelQuery{CounterpartyPortID: "wasm.juno1abc", OpenOnly: true})
+	require.Len(t, byCounterparty, 1)
+	require.Equal(t, "channel-1", byCounterparty[0].ChannelID)
+
+	require.Empty(t, FilterChannels(channels, ChannelQuery{PortID: "icacontroller"}))
+}
+
+func TestChannelOutputOrder(t *testing.T) {
+	// The Go relayer and hermes name the ordering and state differently.
+	for ordering, want := range map[string]Order{
+		"ORDER_ORDERED":          Ordered,
+		"Ordered":                Ordered,
+		"ORDER_UNORDERED":        Unordered,
+		"Unordered":              Unordered,
+		"ORDER_NONE_UNSPECIFIED": Invalid,
+		"":                       Invalid,
+	} {
+		require.Equal(t, want, ChannelOutput{Ordering: ordering}.Order(), ordering)
+	}
+
+	require.True(t, ChannelOutput{State: "STATE_OPEN"}.Open())
+	require.True(t, ChannelOutput{State: "Open"}.Open())
+	require.False(t, ChannelOutput{State: "STATE_CLOSED"}.Open())
 }
@@ -0,0 +1,50 @@
+package ibc
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Validator is a member of a chain's staking validator set, bonded or not.
+type Validator struct {
+	OperatorAddress string
+	Moniker         string
+
+	// Status is the chain-specific bond status, e.g. "BOND_STATUS_BONDED" on cosmos chains.
+	Status string
+	Jailed bool
+
+	Tokens          sdk.Int
+	DelegatorShares sdk.Dec
+}
+
+// Delegation is a delegator's stake with a single validator.
+type Delegation struct {
+	DelegatorAddress string
+	ValidatorAddress string
+
+	Shares sdk.Dec
+
+	// Balance is the amount of tokens the shares are currently worth.
+	Balance sdk.Coin
+}
+
+// UnbondingDelegation is the stake a delegator is unbonding from a single validator.
+type UnbondingDelegation struct {
+	DelegatorAddress string
+	ValidatorAddress string
+
+	Entries []UnbondingDelegationEntry
+}
+
+// UnbondingDelegationEntry is a single unbonding started at CreationHeight.
+type UnbondingDelegationEntry struct {
+	CreationHeight int64
+	CompletionTime time.Time
+
+	// InitialBalance is the amount unbonded, and Balance the amount to be returned
+	// after any slashing since CreationHeight.
+	InitialBalance sdk.Int
+	Balance        sdk.Int
+}
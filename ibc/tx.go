@@ -14,6 +14,8 @@ type Tx struct {
 	TxHash string
 	// Amount of gas charged to the account.
 	GasSpent int64
+	// Amount of gas consumed by the transaction, if known, which may be less than GasSpent.
+	GasUsed int64
 
 	Packet Packet
 }
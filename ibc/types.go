@@ -1,11 +1,19 @@
 package ibc
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 	ibcexported "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v6/modules/core/24-host"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // ChainConfig defines the chain parameters requires to run an interchaintest testnet for a chain.
@@ -22,23 +30,127 @@ type ChainConfig struct {
 	Bin string `yaml:"bin"`
 	// Bech32 prefix for chain addresses, e.g. cosmos.
 	Bech32Prefix string `yaml:"bech32-prefix"`
+	// Bech32 prefixes of validator operator and consensus addresses, for chains customizing them.
+	// If empty, they are derived from Bech32Prefix like the Cosmos SDK does, e.g. cosmosvaloper and cosmosvalcons.
+	Bech32ValidatorPrefix string `yaml:"bech32-validator-prefix"`
+	Bech32ConsensusPrefix string `yaml:"bech32-consensus-prefix"`
 	// Denomination of native currency, e.g. uatom.
 	Denom string `yaml:"denom"`
-	// Coin type
+	// BIP-44 coin type used to derive every key on the chain, e.g. 60 for Ethermint chains.
+	// Defaults to 118 when empty.
 	CoinType string `default:"118" yaml:"coin-type"`
+	// Key algorithm passed to the chain binary with --algo, e.g. "eth_secp256k1" for Ethermint chains.
+	// If empty, the binary's default is used and keys are assumed to be secp256k1.
+	// Currently used for cosmos chains only.
+	SigningAlgorithm string `yaml:"signing-algorithm"`
+	// Keyring backend holding every node's keys, passed with --keyring-backend to key and tx commands.
+	// Defaults to "test" when empty. With "file", the keyring's passphrase is cosmos.FileKeyringPassphrase,
+	// given to the commands run within the nodes when they prompt for it.
+	// Backends keeping the keys outside the node's home directory, such as "os", cannot be used.
+	// Currently used for cosmos chains only.
+	KeyringBackend string `yaml:"keyring-backend"`
+	// Keys recovered from their mnemonics into the keyring of every node during init,
+	// so they can sign txs through the chain binary without each test recovering them.
+	// Currently used for cosmos chains only.
+	GenesisKeys []GenesisKey `yaml:"genesis-keys"`
 	// Minimum gas prices for sending transactions, in native currency denom.
 	GasPrices string `yaml:"gas-prices"`
+	// Minimum gas prices accepted by every node, i.e. the minimum-gas-prices of app.toml.
+	// Transactions paying less are rejected with cosmos.ErrInsufficientFee.
+	// If empty, GasPrices is used, so the transactions sent by the harness are always accepted.
+	// Currently used for cosmos chains only.
+	MinGasPrices string `yaml:"min-gas-prices"`
 	// Adjustment multiplier for gas fees.
 	GasAdjustment float64 `yaml:"gas-adjustment"`
 	// Trusting period of the chain.
 	TrustingPeriod string `yaml:"trusting-period"`
+	// Target time between blocks, as a Go duration string, e.g. "500ms" or "5s".
+	// Written to the consensus timeouts of every node, so that all validators agree.
+	// If empty, the chain implementation's default is used.
+	BlockTime string `yaml:"block-time"`
+	// How long transaction helpers, like SendFunds or ExecuteContract, wait for their transaction to be committed,
+	// as a Go duration string, e.g. "30s", before failing with an error naming the transaction hash.
+	// A transaction already broadcast when the timeout elapses may still be committed afterwards.
+	// If empty, the chain implementation's default is used.
+	// Currently used for cosmos chains only.
+	TxConfirmationTimeout string `yaml:"tx-confirmation-timeout"`
+	// Genesis balance of the faucet account that funds test users, in units of Denom.
+	// If zero, the harness default is used.
+	FaucetBalance int64 `yaml:"faucet-balance"`
+	// Genesis balances of the faucet in denoms other than Denom, keyed by denom, e.g. a fee token,
+	// so that interchaintest.GetAndFundTestUsersWithDenom can fund users with them.
+	FaucetDenoms map[string]int64 `yaml:"faucet-denoms"`
+	// Genesis self-delegation of each validator, in units of Denom, indexed by validator number.
+	// Validators beyond the end of the slice, or with a zero entry, use the chain implementation's default.
+	// Currently used for cosmos chains only.
+	ValidatorStakes []int64 `yaml:"validator-stakes"`
+	// Command starting every validator and full node, replacing the default
+	// "<Bin> start --home <home> --x-crisis-skip-assert-invariants", e.g. to run the binary through a wrapper script.
+	// All nodes share the same home directory path, see cosmos.ChainNode.HomeDir,
+	// suffixed with "_nomnt" when NoHostMount is set.
+	// Currently used for cosmos chains only.
+	StartCmd []string `yaml:"start-cmd"`
+	// Arguments appended to the start command of every validator and full node, e.g. extra flags of the binary.
+	// Currently used for cosmos chains only.
+	AdditionalStartArgs []string `yaml:"additional-start-args"`
+	// Environment variables set in the container of every validator and full node, e.g. to enable experimental features
+	// that the binary reads from its environment. Commands executed on the nodes, like transactions, do not get them.
+	// Currently used for cosmos chains only.
+	Env map[string]string `yaml:"env"`
+	// Pruning strategy of every node, i.e. the pruning of app.toml, such as "nothing" to keep the state of every height
+	// for historical queries like cosmos.ChainNode.DumpContractState or cosmos.CosmosChain.GetBalanceAtHeight at a past height.
+	// If empty, the binary's default is used.
+	// Currently used for cosmos chains only.
+	Pruning string `yaml:"pruning"`
+	// Transaction indexer of every node, i.e. the tx_index.indexer of config.toml, "kv" or "null".
+	// If empty, the binary's default is used.
+	// Currently used for cosmos chains only.
+	TxIndexer string `yaml:"tx-indexer"`
+	// Number of CPUs, possibly fractional, that each node container of the chain may use, e.g. 1.5.
+	// If zero, the containers are unconstrained.
+	CPUs float64 `yaml:"cpus"`
+	// Memory limit of each node container of the chain, e.g. "512m" or "2g".
+	// If empty, the containers are unconstrained.
+	Memory string `yaml:"memory"`
 	// Do not use docker host mount.
 	NoHostMount bool `yaml:"no-host-mount"`
+	// Serve Prometheus metrics from every node, published to the host.
+	// Currently used for cosmos chains only.
+	EnableMetrics bool `yaml:"enable-metrics"`
 	// When provided, genesis file contents will be altered before sharing for genesis.
 	ModifyGenesis func(ChainConfig, []byte) ([]byte, error)
-	// Override config parameters for files at filepath.
+	// When provided, called before the genesis transactions are collected,
+	// e.g. to run add-genesis-account for a module account through the chain binary.
+	// Currently used for cosmos chains only.
+	PreGenesis func(ctx context.Context, chain Chain) error
+	// When provided, called once the chain has started producing blocks,
+	// e.g. to store and instantiate a contract that the test depends on.
+	// Currently used for cosmos and ethereum chains only.
+	PostStart func(ctx context.Context, chain Chain) error
+	// When provided, returns the arguments following "tx" of the command sending a packet with data
+	// through the chain's own IBC application, e.g. {"myapp", "send", channelID, hex.EncodeToString(data)},
+	// so that the app's packet handlers and middleware can be exercised with arbitrary data.
+	// The sender, gas and chain flags are added by the harness.
+	// Currently used by cosmos.CosmosChain.SendPacket only.
+	SendPacketCmd func(portID, channelID string, data []byte, timeout IBCTimeout) []string
+	// TLS configures dialing the RPC and gRPC servers of the chain's nodes over TLS,
+	// for images that serve them like production nodes. Plaintext is used by default.
+	// Currently used for cosmos chains only.
+	TLS TLSConfig `yaml:"tls"`
+	// Override config parameters for files at filepath, relative to each node's home directory,
+	// e.g. {"config/app.toml": testutil.Toml{"api": testutil.Toml{"enable": true}}}.
+	// Overrides are deep-merged into the TOML file of every validator and full node before start.
 	ConfigFileOverrides map[string]any
+	// Files or directories on the host to copy into every node before it starts,
+	// keyed by destination path relative to the node's home directory.
+	// Copied files keep their mode and are owned by the image's UidGid. Currently used for cosmos chains only.
+	HostFiles map[string]string `yaml:"host-files"`
+	// Complete genesis and config files on the host, such as a known-good production set,
+	// used as the base of the files generated for every node. See ConfigTemplates.
+	// Currently used for cosmos chains only.
+	Templates ConfigTemplates `yaml:"templates"`
 	// Non-nil will override the encoding config, used for cosmos chains only.
+	// Use cosmos.DefaultEncodingWith to keep the default module registrations while adding custom modules.
 	EncodingConfig *simappparams.EncodingConfig
 }
 
@@ -47,9 +159,108 @@ func (c ChainConfig) Clone() ChainConfig {
 	images := make([]DockerImage, len(c.Images))
 	copy(images, c.Images)
 	x.Images = images
+	if c.ValidatorStakes != nil {
+		x.ValidatorStakes = append([]int64(nil), c.ValidatorStakes...)
+	}
+	if c.GenesisKeys != nil {
+		x.GenesisKeys = append([]GenesisKey(nil), c.GenesisKeys...)
+	}
+	if c.StartCmd != nil {
+		x.StartCmd = append([]string(nil), c.StartCmd...)
+	}
+	if c.AdditionalStartArgs != nil {
+		x.AdditionalStartArgs = append([]string(nil), c.AdditionalStartArgs...)
+	}
+	if c.Env != nil {
+		x.Env = make(map[string]string, len(c.Env))
+		for k, v := range c.Env {
+			x.Env[k] = v
+		}
+	}
+	if c.FaucetDenoms != nil {
+		x.FaucetDenoms = make(map[string]int64, len(c.FaucetDenoms))
+		for k, v := range c.FaucetDenoms {
+			x.FaucetDenoms[k] = v
+		}
+	}
 	return x
 }
 
+// ValidatorBech32Prefix returns the bech32 prefix of validator operator addresses on the chain.
+func (c ChainConfig) ValidatorBech32Prefix() string {
+	if c.Bech32ValidatorPrefix != "" {
+		return c.Bech32ValidatorPrefix
+	}
+	return c.Bech32Prefix + "valoper"
+}
+
+// ConsensusBech32Prefix returns the bech32 prefix of validator consensus addresses on the chain.
+func (c ChainConfig) ConsensusBech32Prefix() string {
+	if c.Bech32ConsensusPrefix != "" {
+		return c.Bech32ConsensusPrefix
+	}
+	return c.Bech32Prefix + "valcons"
+}
+
+// ConfigTemplates are paths on the host to complete files used as the base of the files generated for every node,
+// so that a chain runs with the shape of an existing configuration without setting it field by field.
+//
+// Each template is deep-merged over the file generated by the chain binary's init, so that settings it omits keep their defaults.
+// The settings the harness depends on, such as listen addresses, BlockTime and the chain ID, are then applied on top,
+// followed by ConfigFileOverrides. The genesis template then receives the genesis accounts and gentxs,
+// and ModifyGenesis is applied last; its bank supply should be left empty, so it is computed from the balances.
+type ConfigTemplates struct {
+	// Genesis is the template of config/genesis.json.
+	Genesis string `yaml:"genesis"`
+	// AppToml is the template of config/app.toml.
+	AppToml string `yaml:"app-toml"`
+	// ConfigToml is the template of config/config.toml.
+	ConfigToml string `yaml:"config-toml"`
+}
+
+// TLSConfig configures how the servers of a chain's nodes are dialed over TLS.
+type TLSConfig struct {
+	// Enabled dials the RPC and gRPC servers over TLS, i.e. https and wss instead of http and ws.
+	// A node serving TLS serves it only, so this applies to every client of the nodes: the harness,
+	// the commands run within the nodes, such as txs and queries, and the relayers, given https RPC addresses.
+	Enabled bool `yaml:"enabled"`
+	// InsecureSkipVerify accepts any certificate presented by the nodes, e.g. a self-signed dev certificate.
+	// It only applies to the harness. Commands run within the nodes verify the certificate, see CACertFile,
+	// and so do relayers, with the trust store of their image.
+	InsecureSkipVerify bool `yaml:"insecure-skip-verify"`
+	// CACertFile is the path, relative to each node's home directory, of a PEM file with the certificates
+	// trusted by the commands run within the nodes, e.g. that of a self-signed dev certificate, instead of
+	// the trust store of the image. The nodes' certificates must be valid for their host names.
+	CACertFile string `yaml:"ca-cert-file"`
+}
+
+// RPCScheme returns the URL scheme of the nodes' RPC and gRPC servers, "https" or "http".
+func (c TLSConfig) RPCScheme() string {
+	if c.Enabled {
+		return "https"
+	}
+	return "http"
+}
+
+// ClientConfig returns the TLS configuration for dialing the nodes, or nil if they are dialed in plaintext.
+func (c TLSConfig) ClientConfig() *tls.Config {
+	if !c.Enabled {
+		return nil
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+}
+
+// GRPCCredentials returns the transport credentials for dialing the nodes' gRPC servers.
+func (c TLSConfig) GRPCCredentials() credentials.TransportCredentials {
+	if !c.Enabled {
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(c.ClientConfig())
+}
+
 func (c ChainConfig) VerifyCoinType() (string, error) {
 	// If coin-type is left blank in the ChainConfig,
 	// the Cosmos SDK default of 118 is used.
@@ -98,6 +309,14 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.Bech32Prefix = other.Bech32Prefix
 	}
 
+	if other.Bech32ValidatorPrefix != "" {
+		c.Bech32ValidatorPrefix = other.Bech32ValidatorPrefix
+	}
+
+	if other.Bech32ConsensusPrefix != "" {
+		c.Bech32ConsensusPrefix = other.Bech32ConsensusPrefix
+	}
+
 	if other.Denom != "" {
 		c.Denom = other.Denom
 	}
@@ -106,10 +325,26 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.CoinType = other.CoinType
 	}
 
+	if other.SigningAlgorithm != "" {
+		c.SigningAlgorithm = other.SigningAlgorithm
+	}
+
+	if other.KeyringBackend != "" {
+		c.KeyringBackend = other.KeyringBackend
+	}
+
+	if other.GenesisKeys != nil {
+		c.GenesisKeys = append([]GenesisKey(nil), other.GenesisKeys...)
+	}
+
 	if other.GasPrices != "" {
 		c.GasPrices = other.GasPrices
 	}
 
+	if other.MinGasPrices != "" {
+		c.MinGasPrices = other.MinGasPrices
+	}
+
 	if other.GasAdjustment > 0 && c.GasAdjustment == 0 {
 		c.GasAdjustment = other.GasAdjustment
 	}
@@ -118,16 +353,113 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.TrustingPeriod = other.TrustingPeriod
 	}
 
+	if other.BlockTime != "" {
+		c.BlockTime = other.BlockTime
+	}
+
+	if other.TxConfirmationTimeout != "" {
+		c.TxConfirmationTimeout = other.TxConfirmationTimeout
+	}
+
+	if other.FaucetBalance != 0 {
+		c.FaucetBalance = other.FaucetBalance
+	}
+
+	if other.FaucetDenoms != nil {
+		denoms := make(map[string]int64, len(c.FaucetDenoms)+len(other.FaucetDenoms))
+		for k, v := range c.FaucetDenoms {
+			denoms[k] = v
+		}
+		for k, v := range other.FaucetDenoms {
+			denoms[k] = v
+		}
+		c.FaucetDenoms = denoms
+	}
+
+	if other.ValidatorStakes != nil {
+		c.ValidatorStakes = other.ValidatorStakes
+	}
+
+	if other.Pruning != "" {
+		c.Pruning = other.Pruning
+	}
+
+	if other.TxIndexer != "" {
+		c.TxIndexer = other.TxIndexer
+	}
+
+	if other.CPUs != 0 {
+		c.CPUs = other.CPUs
+	}
+
+	if other.Memory != "" {
+		c.Memory = other.Memory
+	}
+
+	if other.StartCmd != nil {
+		c.StartCmd = append([]string(nil), other.StartCmd...)
+	}
+
+	if other.AdditionalStartArgs != nil {
+		c.AdditionalStartArgs = append([]string(nil), other.AdditionalStartArgs...)
+	}
+
+	if other.Env != nil {
+		// Variables are merged, so other only needs to list those it adds or changes.
+		env := make(map[string]string, len(c.Env)+len(other.Env))
+		for k, v := range c.Env {
+			env[k] = v
+		}
+		for k, v := range other.Env {
+			env[k] = v
+		}
+		c.Env = env
+	}
+
 	// Skip NoHostMount so that false can be distinguished.
 
+	if other.EnableMetrics {
+		c.EnableMetrics = true
+	}
+
 	if other.ModifyGenesis != nil {
 		c.ModifyGenesis = other.ModifyGenesis
 	}
 
+	if other.PreGenesis != nil {
+		c.PreGenesis = other.PreGenesis
+	}
+
+	if other.PostStart != nil {
+		c.PostStart = other.PostStart
+	}
+
+	if other.SendPacketCmd != nil {
+		c.SendPacketCmd = other.SendPacketCmd
+	}
+
+	if other.TLS != (TLSConfig{}) {
+		c.TLS = other.TLS
+	}
+
 	if other.ConfigFileOverrides != nil {
 		c.ConfigFileOverrides = other.ConfigFileOverrides
 	}
 
+	if other.HostFiles != nil {
+		c.HostFiles = other.HostFiles
+	}
+
+	if other.Templates.Genesis != "" {
+		c.Templates.Genesis = other.Templates.Genesis
+	}
+	if other.Templates.AppToml != "" {
+		c.Templates.AppToml = other.Templates.AppToml
+	}
+	if other.Templates.ConfigToml != "" {
+		c.Templates.ConfigToml = other.Templates.ConfigToml
+	}
+
 	if other.EncodingConfig != nil {
 		c.EncodingConfig = other.EncodingConfig
 	}
@@ -150,12 +482,39 @@ func (c ChainConfig) IsFullyConfigured() bool {
 		c.TrustingPeriod != ""
 }
 
+// GenesisKey is a named key recovered from Mnemonic into the keyring of every chain node.
+// Unless Amount is zero, the key's account is funded at genesis with Amount of the chain's Denom.
+type GenesisKey struct {
+	Name     string `yaml:"name"`
+	Mnemonic string `yaml:"mnemonic"`
+	Amount   int64  `yaml:"amount"`
+}
+
 type DockerImage struct {
 	Repository string `yaml:"repository"`
 	Version    string `yaml:"version"`
 	UidGid     string `yaml:"uid-gid"`
+	// PullPolicy determines whether the image is pulled from its registry before use.
+	// The zero value is equivalent to PullAlways.
+	PullPolicy PullPolicy `yaml:"pull-policy"`
+	// Platform is the variant of the image to pull, e.g. "linux/amd64".
+	// If empty, the variant for the host's architecture is pulled, falling back to linux/amd64,
+	// run under emulation, if the image has none.
+	Platform string `yaml:"platform"`
 }
 
+// PullPolicy controls when a DockerImage is pulled from its registry.
+type PullPolicy string
+
+const (
+	// PullAlways pulls the image every time it is used, so that mutable tags such as latest are refreshed.
+	PullAlways PullPolicy = "always"
+	// PullIfNotPresent only pulls the image if it does not already exist locally.
+	PullIfNotPresent PullPolicy = "if-not-present"
+	// PullNever never pulls the image, which must already exist locally, e.g. because it was built by CI.
+	PullNever PullPolicy = "never"
+)
+
 // Ref returns the reference to use when e.g. creating a container.
 func (i DockerImage) Ref() string {
 	if i.Version == "" {
@@ -171,6 +530,25 @@ type WalletAmount struct {
 	Amount  int64
 }
 
+// FeeAllowance describes a fee grant, allowing a grantee to pay transaction fees from a granter's balance.
+// The zero value is an unlimited allowance that never expires.
+type FeeAllowance struct {
+	// Maximum total fees the grantee may spend, e.g. "1000000uatom". Unlimited if empty.
+	SpendLimit string
+	// Time at which the allowance expires. Never expires if zero.
+	Expiration time.Time
+	// Period and PeriodLimit make the allowance periodic, e.g. at most "1000uatom" per hour.
+	// Both must be set together.
+	Period      time.Duration
+	PeriodLimit string
+	// Message type URLs the allowance may pay for, e.g. "/cosmos.bank.v1beta1.MsgSend". Any message if empty.
+	AllowedMessages []string
+}
+
+// IBCTimeout describes the timeout of a packet, relative to the state of the destination chain
+// as seen by the source chain's light client.
+// A zero field disables that kind of timeout.
+// If both fields are zero, the chain's default timeouts are used.
 type IBCTimeout struct {
 	NanoSeconds uint64
 	Height      uint64
@@ -181,14 +559,71 @@ type ChannelCounterparty struct {
 	ChannelID string `json:"channel_id"`
 }
 
+// ChannelOutput is a channel end on a chain, as reported by a relayer's GetChannels.
 type ChannelOutput struct {
-	State          string              `json:"state"`
-	Ordering       string              `json:"ordering"`
-	Counterparty   ChannelCounterparty `json:"counterparty"`
-	ConnectionHops []string            `json:"connection_hops"`
-	Version        string              `json:"version"`
-	PortID         string              `json:"port_id"`
-	ChannelID      string              `json:"channel_id"`
+	// State is the name of the channel state, e.g. "STATE_OPEN"; see Open.
+	State string `json:"state"`
+	// Ordering is the name of the channel ordering, e.g. "ORDER_UNORDERED"; see Order.
+	Ordering string `json:"ordering"`
+	// Counterparty is the port and channel of the other end.
+	Counterparty ChannelCounterparty `json:"counterparty"`
+	// ConnectionHops are the connections the channel runs over, starting on this chain.
+	ConnectionHops []string `json:"connection_hops"`
+	// Version is the application version negotiated in the handshake, e.g. "ics20-1".
+	Version string `json:"version"`
+	// PortID and ChannelID identify this end.
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+}
+
+// Open reports whether the channel has completed its handshake and is not closed.
+// The Go relayer reports the protobuf enum name of the state, while hermes reports "Open".
+func (c ChannelOutput) Open() bool {
+	return c.State == chantypes.OPEN.String() || c.State == "Open"
+}
+
+// Order returns the ordering of the channel, or Invalid if it is not recognized.
+// Like the state, the ordering is reported as "ORDER_UNORDERED" by the Go relayer and "Unordered" by hermes.
+func (c ChannelOutput) Order() Order {
+	switch c.Ordering {
+	case chantypes.ORDERED.String(), "Ordered":
+		return Ordered
+	case chantypes.UNORDERED.String(), "Unordered":
+		return Unordered
+	default:
+		return Invalid
+	}
+}
+
+// ChannelRef identifies a channel end on a chain.
+type ChannelRef struct {
+	ChainID   string
+	PortID    string
+	ChannelID string
+}
+
+// CreatedClients identifies the light clients created by Relayer.CreateClients.
+type CreatedClients struct {
+	// ClientID is the client on the source chain of the path, tracking the destination chain.
+	ClientID string
+	// CounterpartyClientID is the client on the destination chain of the path, tracking the source chain.
+	CounterpartyClientID string
+}
+
+// CreatedConnection identifies both ends of a connection opened by Relayer.CreateConnections.
+type CreatedConnection struct {
+	// ConnectionID is the connection on the source chain of the path.
+	ConnectionID string
+	// CounterpartyConnectionID is the connection on the destination chain of the path.
+	CounterpartyConnectionID string
+}
+
+// CreatedChannel identifies both ends of a channel opened by Relayer.CreateChannel.
+type CreatedChannel struct {
+	// ChannelID is the channel on the source chain of the path.
+	ChannelID string
+	// CounterpartyChannelID is the channel on the destination chain of the path.
+	CounterpartyChannelID string
 }
 
 // ConnectionOutput represents the IBC connection information queried from a chain's state for a particular connection.
@@ -210,6 +645,14 @@ type ClientOutput struct {
 
 type ClientState struct {
 	ChainID string `json:"chain_id"`
+
+	// TrustingPeriod is the trusting period of a tendermint client as a duration string, e.g. "1209600s".
+	// See ClientState.TrustingPeriodDuration and ClientExpiry.
+	TrustingPeriod string `json:"trusting_period,omitempty"`
+	// LatestHeight is the latest height of the counterparty chain the client was updated to.
+	LatestHeight ClientHeight `json:"latest_height"`
+	// FrozenHeight is non-zero if the client was frozen for misbehaviour.
+	FrozenHeight ClientHeight `json:"frozen_height"`
 }
 
 type ClientOutputs []*ClientOutput
@@ -228,9 +671,71 @@ const (
 	Hermes
 )
 
+// PathConnection identifies an existing light client and connection on each end of a path,
+// so that additional channels can be multiplexed over them.
+type PathConnection struct {
+	SrcClientID, SrcConnectionID string
+	DstClientID, DstConnectionID string
+}
+
+// Validate returns an error if any of the identifiers are empty.
+func (c PathConnection) Validate() error {
+	if c.SrcClientID == "" || c.SrcConnectionID == "" || c.DstClientID == "" || c.DstConnectionID == "" {
+		return fmt.Errorf("client and connection IDs must be set on both ends of the path: %+v", c)
+	}
+	return nil
+}
+
+// PathOutput is the chains at both ends of a path, together with the clients and connections created on it.
+// The client and connection IDs are empty until they are created on the path.
+type PathOutput struct {
+	SrcChainID, DstChainID string
+	PathConnection
+}
+
+// ConnectionID returns the ID of the path's connection on chainID, which must be at one end of the path.
+func (p PathOutput) ConnectionID(chainID string) (string, error) {
+	switch chainID {
+	case p.SrcChainID:
+		return p.SrcConnectionID, nil
+	case p.DstChainID:
+		return p.DstConnectionID, nil
+	default:
+		return "", fmt.Errorf("chain %s is at neither end of the path between %s and %s", chainID, p.SrcChainID, p.DstChainID)
+	}
+}
+
+// PathGetter is implemented by relayers that can report the clients and connections of a path,
+// such as rly.CosmosRelayer and hermes.Relayer.
+type PathGetter interface {
+	GetPath(ctx context.Context, rep RelayerExecReporter, pathName string) (PathOutput, error)
+}
+
 // ChannelFilter provides the means for either creating an allowlist or a denylist of channels on the src chain
 // which will be used to narrow down the list of channels a user wants to relay on.
+// Packets on channels excluded by the filter are left unrelayed.
 type ChannelFilter struct {
+	// Rule is either ChannelFilterAllowlist or ChannelFilterDenylist.
 	Rule        string
 	ChannelList []string
 }
+
+const (
+	// ChannelFilterAllowlist relays only the channels in ChannelFilter.ChannelList.
+	ChannelFilterAllowlist = "allowlist"
+	// ChannelFilterDenylist relays every channel except those in ChannelFilter.ChannelList.
+	ChannelFilterDenylist = "denylist"
+)
+
+// Validate returns an error if the filter has an unknown rule or an invalid channel ID.
+func (f ChannelFilter) Validate() error {
+	if f.Rule != ChannelFilterAllowlist && f.Rule != ChannelFilterDenylist {
+		return fmt.Errorf("channel filter rule must be %q or %q, got %q", ChannelFilterAllowlist, ChannelFilterDenylist, f.Rule)
+	}
+	for _, ch := range f.ChannelList {
+		if err := host.ChannelIdentifierValidator(ch); err != nil {
+			return fmt.Errorf("invalid channel %q in channel filter: %w", ch, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainConfig_MergeChainSpecConfig(t *testing.T) {
+	base := ChainConfig{
+		Type:         "cosmos",
+		ChainID:      "gaia-1",
+		Bin:          "gaiad",
+		Env:          map[string]string{"A": "1", "B": "2"},
+		FaucetDenoms: map[string]int64{"ufee": 1},
+		Templates:    ConfigTemplates{Genesis: "base/genesis.json", AppToml: "base/app.toml"},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		other ChainConfig
+		want  func(c *ChainConfig)
+	}{
+		{
+			name:  "zero value keeps base",
+			other: ChainConfig{},
+			want:  func(*ChainConfig) {},
+		},
+		{
+			name:  "keyring",
+			other: ChainConfig{KeyringBackend: "os", GenesisKeys: []GenesisKey{{Name: "alice", Mnemonic: "abandon art", Amount: 1}}},
+			want: func(c *ChainConfig) {
+				c.KeyringBackend = "os"
+				c.GenesisKeys = []GenesisKey{{Name: "alice", Mnemonic: "abandon art", Amount: 1}}
+			},
+		},
+		{
+			name:  "start command",
+			other: ChainConfig{StartCmd: []string{"gaiad", "start"}, AdditionalStartArgs: []string{"--log_level", "debug"}},
+			want: func(c *ChainConfig) {
+				c.StartCmd = []string{"gaiad", "start"}
+				c.AdditionalStartArgs = []string{"--log_level", "debug"}
+			},
+		},
+		{
+			name:  "history",
+			other: ChainConfig{Pruning: "nothing", TxIndexer: "kv"},
+			want: func(c *ChainConfig) {
+				c.Pruning = "nothing"
+				c.TxIndexer = "kv"
+			},
+		},
+		{
+			name:  "resource limits",
+			other: ChainConfig{CPUs: 1.5, Memory: "2g"},
+			want: func(c *ChainConfig) {
+				c.CPUs = 1.5
+				c.Memory = "2g"
+			},
+		},
+		{
+			name:  "env merged by variable",
+			other: ChainConfig{Env: map[string]string{"B": "3", "C": "4"}},
+			want: func(c *ChainConfig) {
+				c.Env = map[string]string{"A": "1", "B": "3", "C": "4"}
+			},
+		},
+		{
+			name:  "faucet denoms merged by denom",
+			other: ChainConfig{FaucetDenoms: map[string]int64{"uatom": 10}},
+			want: func(c *ChainConfig) {
+				c.FaucetDenoms = map[string]int64{"ufee": 1, "uatom": 10}
+			},
+		},
+		{
+			name:  "tls",
+			other: ChainConfig{TLS: TLSConfig{Enabled: true, InsecureSkipVerify: true}},
+			want: func(c *ChainConfig) {
+				c.TLS = TLSConfig{Enabled: true, InsecureSkipVerify: true}
+			},
+		},
+		{
+			name:  "templates merged by file",
+			other: ChainConfig{Templates: ConfigTemplates{AppToml: "other/app.toml", ConfigToml: "other/config.toml"}},
+			want: func(c *ChainConfig) {
+				c.Templates = ConfigTemplates{Genesis: "base/genesis.json", AppToml: "other/app.toml", ConfigToml: "other/config.toml"}
+			},
+		},
+		{
+			name:  "tx confirmation timeout",
+			other: ChainConfig{TxConfirmationTimeout: "30s"},
+			want: func(c *ChainConfig) {
+				c.TxConfirmationTimeout = "30s"
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			want := base.Clone()
+			tc.want(&want)
+
+			got := base.Clone().MergeChainSpecConfig(tc.other)
+			require.Equal(t, want, got)
+		})
+	}
+
+	t.Run("merged maps do not alias base", func(t *testing.T) {
+		b := base.Clone()
+		got := b.MergeChainSpecConfig(ChainConfig{Env: map[string]string{"C": "4"}, FaucetDenoms: map[string]int64{"uatom": 10}})
+		got.Env["A"] = "changed"
+		got.FaucetDenoms["ufee"] = 100
+
+		require.Equal(t, "1", b.Env["A"])
+		require.Equal(t, int64(1), b.FaucetDenoms["ufee"])
+	})
+}
@@ -3,10 +3,15 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -37,6 +42,26 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Docker settings recorded during Build, used to manipulate network connectivity.
+	dockerClient *client.Client
+	networkID    string
+	testName     string
+
+	// Set by PartitionNetwork and cleared by HealNetwork.
+	partition *networkPartition
+
+	// Relayers halted by Stop, with the paths they were relaying, to be resumed by Start.
+	stoppedRelayers map[ibc.Relayer][]string
+
+	// Reporter passed to Build, which receives the output of the relayers stopped by Close.
+	rep *testreporter.RelayerExecReporter
+
+	// Started by Build if InterchainBuildOptions.LivenessTimeout is set, and stopped by Close.
+	liveness *livenessWatchdog
+
+	// Set to true after Close is called once.
+	closed bool
 }
 
 type interchainLink struct {
@@ -50,6 +75,16 @@ type interchainLink struct {
 	// If a zero value initialization is used, e.g. CreateChannelOptions{},
 	// then the default values will be used via ibc.DefaultChannelOpts.
 	createChannelOpts ibc.CreateChannelOptions
+
+	// If set, the channel is opened over this existing client and connection pair.
+	connection *ibc.PathConnection
+
+	// If set, applied to the node containers of both chains during Build.
+	networkConditions *NetworkConditions
+
+	// If set, chains[0] is an Interchain Security consumer of chains[1],
+	// and the path is linked over their existing clients with a CCV channel.
+	ccv bool
 }
 
 // NewInterchain returns a new Interchain.
@@ -111,6 +146,26 @@ func (ic *Interchain) AddChain(chain ibc.Chain, additionalGenesisWallets ...ibc.
 	return ic
 }
 
+// FindChain returns the chain previously added with the given name, i.e. its configured Name,
+// or an error if no such chain was added.
+func (ic *Interchain) FindChain(chainName string) (ibc.Chain, error) {
+	for c := range ic.chains {
+		if c.Config().Name == chainName {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no chain with name %s was added to Interchain", chainName)
+}
+
+// GetChain is like FindChain, but panics if no chain with the given name was added.
+func (ic *Interchain) GetChain(chainName string) ibc.Chain {
+	c, err := ic.FindChain(chainName)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
 // AddRelayer adds the given relayer with the given name to the Interchain.
 func (ic *Interchain) AddRelayer(relayer ibc.Relayer, name string) *Interchain {
 	if relayer == nil {
@@ -133,6 +188,9 @@ func (ic *Interchain) AddRelayer(relayer ibc.Relayer, name string) *Interchain {
 // InterchainLink describes a link between two chains,
 // by specifying the chain names, the relayer name,
 // and the name of the path to create.
+//
+// Each link is handled by exactly one relayer, but an Interchain may hold several relayers,
+// for instance a Go relayer and Hermes linking the same pair of chains over separate paths.
 type InterchainLink struct {
 	// Chains involved.
 	Chain1, Chain2 ibc.Chain
@@ -152,6 +210,17 @@ type InterchainLink struct {
 	// If a zero value initialization is used, e.g. CreateChannelOptions{},
 	// then the default values will be used via ibc.DefaultChannelOpts.
 	CreateChannelOpts ibc.CreateChannelOptions
+
+	// If set, the channel is created over this existing client and connection pair
+	// instead of creating new ones, and CreateClientOpts is ignored.
+	// Links with a Connection are linked after all other links,
+	// so they may reuse a connection created by another link in the same Build,
+	// e.g. connection-0 on freshly started chains.
+	Connection *ibc.PathConnection
+
+	// If set, these conditions are applied to the node containers of both chains during Build,
+	// overriding InterchainBuildOptions.NetworkConditions.
+	NetworkConditions *NetworkConditions
 }
 
 // AddLink adds the given link to the Interchain.
@@ -182,14 +251,124 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
 	}
 
+	// A zero value is replaced by the defaults during Build, so only validate explicit options.
+	if link.CreateChannelOpts != (ibc.CreateChannelOptions{}) {
+		if err := link.CreateChannelOpts.Validate(); err != nil {
+			panic(fmt.Errorf("invalid CreateChannelOpts for path %q: %w", link.Path, err))
+		}
+	}
+
+	if link.Connection != nil {
+		if err := link.Connection.Validate(); err != nil {
+			panic(fmt.Errorf("invalid Connection for path %q: %w", link.Path, err))
+		}
+	}
+
 	ic.links[key] = interchainLink{
 		chains:            [2]ibc.Chain{link.Chain1, link.Chain2},
 		createChannelOpts: link.CreateChannelOpts,
 		createClientOpts:  link.CreateClientOpts,
+		connection:        link.Connection,
+		networkConditions: link.NetworkConditions,
 	}
 	return ic
 }
 
+// StarLinks describes a star topology, in which every one of the Spokes is linked to the Hub chain.
+type StarLinks struct {
+	Hub    ibc.Chain
+	Spokes []ibc.Chain
+
+	// Relayer to use for every link.
+	Relayer ibc.Relayer
+
+	// Default options of every link. Its Chain1, Chain2, Relayer and Path are ignored.
+	Link InterchainLink
+
+	// Links of individual spokes, used instead of Link to e.g. open a channel on other ports.
+	// As with Link, their Chain1, Chain2, Relayer and Path are ignored.
+	Overrides map[ibc.Chain]InterchainLink
+}
+
+// StarPathName returns the name of the path between the hub and a spoke of StarLinks.
+func StarPathName(hub, spoke ibc.Chain) string {
+	return hub.Config().ChainID + "-" + spoke.Config().ChainID
+}
+
+// AddStarLinks adds a link between the hub and each spoke to the Interchain,
+// with the hub as Chain1 and the path named by StarPathName.
+// If any validation fails, AddStarLinks panics.
+func (ic *Interchain) AddStarLinks(star StarLinks) *Interchain {
+	for _, spoke := range star.Spokes {
+		link, ok := star.Overrides[spoke]
+		if !ok {
+			link = star.Link
+		}
+		link.Chain1 = star.Hub
+		link.Chain2 = spoke
+		link.Relayer = star.Relayer
+		link.Path = StarPathName(star.Hub, spoke)
+		ic.AddLink(link)
+	}
+	return ic
+}
+
+// ProviderConsumerLink describes an Interchain Security relationship, in which the Consumer chain
+// is spawned by the Provider chain and secured by the provider's validator set.
+//
+// During Build, the consumer is started after the provider through a consumer-addition proposal,
+// and the relayer opens the CCV channel between the two chains on the given path.
+// The provider's governance voting period must be short enough for the proposal to pass within a few blocks.
+// Both chains must be cosmos chains, the consumer must have as many validators as the provider,
+// and the relayer must implement ibc.ClientLinker.
+type ProviderConsumerLink struct {
+	Provider, Consumer ibc.Chain
+
+	// Relayer to use for link.
+	Relayer ibc.Relayer
+
+	// Name of path to create.
+	Path string
+}
+
+// AddProviderConsumerLink adds the given Interchain Security link to the Interchain.
+// If any validation fails, AddProviderConsumerLink panics.
+func (ic *Interchain) AddProviderConsumerLink(link ProviderConsumerLink) *Interchain {
+	if _, ok := link.Relayer.(ibc.ClientLinker); !ok {
+		panic(fmt.Errorf("relayer %T cannot link a path over existing clients", link.Relayer))
+	}
+	for _, l := range ic.links {
+		if l.ccv && l.chains[0] == link.Consumer {
+			cfg := link.Consumer.Config()
+			panic(fmt.Errorf("chain with name=%s and id=%s already has a provider", cfg.Name, cfg.ChainID))
+		}
+	}
+
+	ic.AddLink(InterchainLink{
+		Chain1:  link.Consumer,
+		Chain2:  link.Provider,
+		Relayer: link.Relayer,
+		Path:    link.Path,
+	})
+
+	key := relayerPath{Relayer: link.Relayer, Path: link.Path}
+	l := ic.links[key]
+	l.ccv = true
+	ic.links[key] = l
+	return ic
+}
+
+// consumers returns the provider of each Interchain Security consumer chain.
+func (ic *Interchain) consumers() map[ibc.Chain]ibc.Chain {
+	out := make(map[ibc.Chain]ibc.Chain)
+	for _, l := range ic.links {
+		if l.ccv {
+			out[l.chains[0]] = l.chains[1]
+		}
+	}
+	return out
+}
+
 // InterchainBuildOptions describes configuration for (*Interchain).Build.
 type InterchainBuildOptions struct {
 	TestName string
@@ -208,17 +387,83 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// How long Build waits for every node to report a non-zero height and finish catching up
+	// after the chains start. Defaults to DefaultReadinessTimeout if zero.
+	ReadinessTimeout time.Duration
+
+	// If set, these conditions are applied to the node containers of every chain once the chains are ready,
+	// unless a link sets its own NetworkConditions.
+	// See (*Interchain).SetNetworkConditions.
+	NetworkConditions *NetworkConditions
+
+	// If set, these hooks are called between the phases of Build, e.g. to log progress or to assert on
+	// the intermediate state of a large topology.
+	Hooks BuildHooks
+
+	// If positive, a watchdog is started once the chains are ready, failing the test through LivenessFailer
+	// if the height of any chain does not advance for this long. The watchdog runs until Close, the end of the test
+	// or a failed Build, and is paused while Stop, Snapshot or Restore halt the chains on purpose.
+	// Halt the chains any other way, e.g. for a chain upgrade, within PauseLiveness. Off if zero.
+	LivenessTimeout time.Duration
+
+	// What the liveness watchdog fails the test through, and registers its cleanup with;
+	// required if LivenessTimeout is set. Typically t.
+	LivenessFailer LivenessFailer
+}
+
+// DefaultReadinessTimeout is the ReadinessTimeout used when InterchainBuildOptions leaves it unset.
+const DefaultReadinessTimeout = 2 * time.Minute
+
+// BuildHooks are called by Build once it completes each phase.
+// A hook returning an error stops Build, which returns the error.
+// Hooks left nil are skipped, and none after ChainsStarted are called if SkipPathCreation is set.
+type BuildHooks struct {
+	// ChainsStarted is called once every chain is producing blocks and the relayer wallets are funded.
+	ChainsStarted func(ctx context.Context) error
+
+	// ClientsCreated and ConnectionsCreated are called once the clients, respectively connections,
+	// of every link are created.
+	// Setting either links the paths one handshake at a time, instead of a single relayer command per path.
+	// Links over an existing connection and CCV links still link in the final phase, after ConnectionsCreated.
+	ClientsCreated     func(ctx context.Context) error
+	ConnectionsCreated func(ctx context.Context) error
+
+	// ChannelsOpened is called once the channels of every link are open, right before Build returns.
+	ChannelsOpened func(ctx context.Context) error
+}
+
+// runHook calls hook if it is set, annotating its error with the phase it follows.
+func runHook(ctx context.Context, phase string, hook func(ctx context.Context) error) error {
+	if hook == nil {
+		return nil
+	}
+	if err := hook(ctx); err != nil {
+		return fmt.Errorf("%s hook: %w", phase, err)
+	}
+	return nil
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
 // It is the caller's responsibility to directly call StartRelayer on the relayer implementations.
 //
 // Calling Build more than once will cause a panic.
-func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
+func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) (err error) {
 	if ic.built {
 		panic(fmt.Errorf("Interchain.Build called more than once"))
 	}
 	ic.built = true
+	ic.rep = rep
+	ic.dockerClient = opts.Client
+	ic.networkID = opts.NetworkID
+	ic.testName = opts.TestName
+
+	if opts.LivenessTimeout < 0 {
+		return fmt.Errorf("invalid liveness timeout %s: must not be negative", opts.LivenessTimeout)
+	}
+	if opts.LivenessTimeout > 0 && opts.LivenessFailer == nil {
+		return fmt.Errorf("liveness timeout %s set without a LivenessFailer", opts.LivenessTimeout)
+	}
 
 	chains := make([]ibc.Chain, 0, len(ic.chains))
 	for chain := range ic.chains {
@@ -226,12 +471,17 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 	ic.cs = newChainSet(ic.log, chains)
 
+	networkConditions, err := ic.buildNetworkConditions(opts.NetworkConditions)
+	if err != nil {
+		return err
+	}
+
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
 	}
 
-	err := ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
+	err = ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
 	if err != nil {
 		return err
 	}
@@ -242,19 +492,48 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return err
 	}
 
-	if err := ic.cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
+	if err := ic.cs.Start(ctx, opts.TestName, walletAmounts, ic.consumers()); err != nil {
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
 
+	readinessTimeout := opts.ReadinessTimeout
+	if readinessTimeout == 0 {
+		readinessTimeout = DefaultReadinessTimeout
+	}
+	if err := ic.cs.WaitForReady(ctx, readinessTimeout); err != nil {
+		return fmt.Errorf("failed waiting for chains to be ready: %w", err)
+	}
+
+	if opts.LivenessTimeout > 0 {
+		ic.liveness = startLivenessWatchdog(ic.log, ic.chains, opts.LivenessTimeout, opts.LivenessFailer)
+		opts.LivenessFailer.Cleanup(ic.liveness.stop)
+		defer func() {
+			// The chains of a failed Build are not expected to keep producing blocks.
+			if err != nil {
+				ic.liveness.stop()
+			}
+		}()
+	}
+
 	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
 
+	for c, nc := range networkConditions {
+		if err := ic.SetNetworkConditions(ctx, c, nc); err != nil {
+			return err
+		}
+	}
+
 	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
 	}
 
+	if err := runHook(ctx, "chains started", opts.Hooks.ChainsStarted); err != nil {
+		return err
+	}
+
 	// Some tests may want to configure the relayer from a lower level,
 	// but still have wallets configured.
 	if opts.SkipPathCreation {
@@ -278,46 +557,210 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 
 	// Now link the paths in parallel
 	// Creates clients, connections, and channels for each link/path.
+	if err := ic.linkAllPaths(ctx, rep, opts.Hooks); err != nil {
+		return err
+	}
+	ic.trackLinks(rep)
+
+	return runHook(ctx, "channels opened", opts.Hooks.ChannelsOpened)
+}
+
+// trackLinks reports every linked path to rep, ordered by path name, for the test's report.
+func (ic *Interchain) trackLinks(rep *testreporter.RelayerExecReporter) {
+	paths := make([]relayerPath, 0, len(ic.links))
+	for rp := range ic.links {
+		paths = append(paths, rp)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Path != paths[j].Path {
+			return paths[i].Path < paths[j].Path
+		}
+		return ic.relayers[paths[i].Relayer] < ic.relayers[paths[j].Relayer]
+	})
+	for _, rp := range paths {
+		link := ic.links[rp]
+		rep.TrackLink(rp.Path, ic.relayers[rp.Relayer], ic.chains[link.chains[0]], ic.chains[link.chains[1]])
+	}
+}
+
+// linkAllPaths creates the clients, connections, and channels of every link.
+// If hooks are set for the intermediate phases, the handshakes of every path are completed one at a time,
+// calling the hooks in between.
+func (ic *Interchain) linkAllPaths(ctx context.Context, rep *testreporter.RelayerExecReporter, hooks BuildHooks) error {
+	if hooks.ClientsCreated == nil && hooks.ConnectionsCreated == nil {
+		if err := ic.linkPaths(ctx, rep, false); err != nil {
+			return err
+		}
+
+		// Links reusing a connection go last, as the connection may have been created by another link.
+		return ic.linkPaths(ctx, rep, true)
+	}
+
+	stepwise := func(link interchainLink) bool { return link.connection == nil && !link.ccv }
+
+	if err := ic.eachLink(stepwise, func(rp relayerPath, link interchainLink) error {
+		if err := ic.defaultLinkOptions(rp, &link); err != nil {
+			return err
+		}
+		if _, err := rp.Relayer.CreateClients(ctx, rep, rp.Path, link.createClientOpts); err != nil {
+			return fmt.Errorf("failed to create clients for %s: %w", ic.describePath(rp, link), err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := runHook(ctx, "clients created", hooks.ClientsCreated); err != nil {
+		return err
+	}
+
+	if err := ic.eachLink(stepwise, func(rp relayerPath, link interchainLink) error {
+		if _, err := rp.Relayer.CreateConnections(ctx, rep, rp.Path); err != nil {
+			return fmt.Errorf("failed to create connections for %s: %w", ic.describePath(rp, link), err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := runHook(ctx, "connections created", hooks.ConnectionsCreated); err != nil {
+		return err
+	}
+
+	if err := ic.eachLink(stepwise, func(rp relayerPath, link interchainLink) error {
+		if err := ic.defaultLinkOptions(rp, &link); err != nil {
+			return err
+		}
+		if _, err := rp.Relayer.CreateChannel(ctx, rep, rp.Path, link.createChannelOpts); err != nil {
+			return fmt.Errorf("failed to create channel for %s: %w", ic.describePath(rp, link), err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// CCV links open their channel over the clients created with the consumer chain.
+	if err := ic.eachLink(func(link interchainLink) bool { return link.ccv }, func(rp relayerPath, link interchainLink) error {
+		return ic.linkPath(ctx, rep, rp, link)
+	}); err != nil {
+		return err
+	}
+
+	return ic.linkPaths(ctx, rep, true)
+}
+
+// linkPaths concurrently links every path that either does or does not reuse an existing connection.
+func (ic *Interchain) linkPaths(ctx context.Context, rep *testreporter.RelayerExecReporter, reuseConnection bool) error {
+	return ic.eachLink(func(link interchainLink) bool {
+		return (link.connection != nil) == reuseConnection
+	}, func(rp relayerPath, link interchainLink) error {
+		return ic.linkPath(ctx, rep, rp, link)
+	})
+}
+
+// eachLink concurrently calls fn for every link matched by include, returning the first error.
+func (ic *Interchain) eachLink(include func(link interchainLink) bool, fn func(rp relayerPath, link interchainLink) error) error {
 	var eg errgroup.Group
 	for rp, link := range ic.links {
+		if !include(link) {
+			continue
+		}
 		rp := rp
 		link := link
-		c0 := link.chains[0]
-		c1 := link.chains[1]
 		eg.Go(func() error {
-			// If the user specifies a zero value CreateClientOptions struct then we fall back to the default
-			// client options.
-			if link.createClientOpts == (ibc.CreateClientOptions{}) {
-				link.createClientOpts = ibc.DefaultClientOpts()
-			}
+			return fn(rp, link)
+		})
+	}
+	return eg.Wait()
+}
 
-			// Check that the client creation options are valid and fully specified.
-			if err := link.createClientOpts.Validate(); err != nil {
-				return err
-			}
+// linkPath creates the clients, connections, and channel of a single link with one relayer command,
+// skipping the clients or connection the link reuses.
+func (ic *Interchain) linkPath(ctx context.Context, rep *testreporter.RelayerExecReporter, rp relayerPath, link interchainLink) error {
+	if link.ccv {
+		return ic.linkConsumerPath(ctx, rep, rp, link.chains[0], link.chains[1])
+	}
 
-			// If the user specifies a zero value CreateChannelOptions struct then we fall back to the default
-			// channel options for an ics20 fungible token transfer channel.
-			if link.createChannelOpts == (ibc.CreateChannelOptions{}) {
-				link.createChannelOpts = ibc.DefaultChannelOpts()
-			}
+	if err := ic.defaultLinkOptions(rp, &link); err != nil {
+		return err
+	}
 
-			// Check that the channel creation options are valid and fully specified.
-			if err := link.createChannelOpts.Validate(); err != nil {
-				return err
-			}
+	if link.connection != nil {
+		if err := rp.Relayer.LinkPathOverConnection(ctx, rep, rp.Path, *link.connection, link.createChannelOpts); err != nil {
+			return fmt.Errorf(
+				"failed to link %s over %s/%s: %w",
+				ic.describePath(rp, link), link.connection.SrcConnectionID, link.connection.DstConnectionID, err,
+			)
+		}
+		return nil
+	}
 
-			if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, link.createChannelOpts, link.createClientOpts); err != nil {
-				return fmt.Errorf(
-					"failed to link path %s on relayer %s between chains %s and %s: %w",
-					rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
-				)
-			}
-			return nil
-		})
+	if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, link.createChannelOpts, link.createClientOpts); err != nil {
+		return fmt.Errorf("failed to link %s: %w", ic.describePath(rp, link), err)
 	}
+	return nil
+}
 
-	return eg.Wait()
+// defaultLinkOptions fills in the client and channel options the link leaves unset, and validates them.
+func (ic *Interchain) defaultLinkOptions(rp relayerPath, link *interchainLink) error {
+	// If the user leaves the trusting period unset, e.g. with a zero value CreateClientOptions struct,
+	// then we fall back to the default trusting period of the relayer.
+	if link.createClientOpts.TrustingPeriod == "" {
+		link.createClientOpts.TrustingPeriod = ibc.DefaultClientOpts().TrustingPeriod
+	}
+
+	// Check that the client creation options are valid and fully specified.
+	if err := link.createClientOpts.Validate(); err != nil {
+		return err
+	}
+
+	// If the user specifies a zero value CreateChannelOptions struct then we fall back to the default
+	// channel options for an ics20 fungible token transfer channel.
+	if link.createChannelOpts == (ibc.CreateChannelOptions{}) {
+		link.createChannelOpts = ibc.DefaultChannelOpts()
+	}
+
+	// Check that the channel creation options are valid and fully specified.
+	if err := link.createChannelOpts.Validate(); err != nil {
+		return fmt.Errorf("invalid channel options for path %s: %w", rp.Path, err)
+	}
+	return nil
+}
+
+// describePath names the path, relayer, and chains of a link for error messages.
+func (ic *Interchain) describePath(rp relayerPath, link interchainLink) string {
+	return fmt.Sprintf("path %s on relayer %s between chains %s and %s", rp.Path, rp.Relayer, ic.chains[link.chains[0]], ic.chains[link.chains[1]])
+}
+
+// linkConsumerPath opens the CCV channel between a consumer and its provider,
+// over the client created by the consumer's genesis and the client the provider created when spawning the consumer.
+func (ic *Interchain) linkConsumerPath(ctx context.Context, rep *testreporter.RelayerExecReporter, rp relayerPath, consumer, provider ibc.Chain) error {
+	consumerChainID := consumer.Config().ChainID
+	clients, err := rp.Relayer.GetClients(ctx, rep, provider.Config().ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to get clients on provider chain %s: %w", ic.chains[provider], err)
+	}
+
+	var providerClientID string
+	for _, c := range clients {
+		if c.ClientState.ChainID == consumerChainID {
+			providerClientID = c.ClientID
+			break
+		}
+	}
+	if providerClientID == "" {
+		return fmt.Errorf("provider chain %s has no client for consumer chain %s", ic.chains[provider], ic.chains[consumer])
+	}
+
+	linker, ok := rp.Relayer.(ibc.ClientLinker)
+	if !ok {
+		return fmt.Errorf("relayer %s cannot link CCV path %s over existing clients", rp.Relayer, rp.Path)
+	}
+	if err := linker.LinkPathOverClients(ctx, rep, rp.Path, cosmos.ConsumerClientID, providerClientID, cosmos.CCVChannelOptions()); err != nil {
+		return fmt.Errorf(
+			"failed to link CCV path %s on relayer %s between consumer %s and provider %s: %w",
+			rp.Path, rp.Relayer, ic.chains[consumer], ic.chains[provider], err,
+		)
+	}
+	return nil
 }
 
 // WithLog sets the logger on the interchain object.
@@ -328,10 +771,61 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 	return ic
 }
 
+// relayerStopTimeout bounds how long Close waits for each running relayer to stop.
+const relayerStopTimeout = time.Minute
+
+// PauseLiveness keeps the liveness watchdog from failing the test until resume is called,
+// e.g. while a chain halts at its upgrade height and its nodes are restarted with StopAllNodes and StartAllNodes.
+// Once resumed, the chains have the full LivenessTimeout to advance again.
+// It is a no-op if the watchdog is off.
+func (ic *Interchain) PauseLiveness() (resume func()) {
+	ic.liveness.pause()
+	var once sync.Once
+	return func() {
+		once.Do(ic.liveness.resume)
+	}
+}
+
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
+//
+// Relayers that are still running are stopped first, reporting their output to the reporter passed to Build,
+// so a test does not need to call StopRelayer itself before the containers are removed.
+// Only relayers reporting their running paths, such as relayer.DockerRelayer, are stopped,
+// so relayers already stopped with StopRelayer are skipped.
+// Close returns every error encountered, and does nothing when called again.
 func (ic *Interchain) Close() error {
-	return ic.cs.Close()
+	if ic.closed {
+		return nil
+	}
+	ic.closed = true
+
+	// Stopped first, as the chains are about to go away.
+	ic.liveness.stop()
+
+	err := ic.stopRunningRelayers()
+	if ic.cs != nil {
+		multierr.AppendInto(&err, ic.cs.Close())
+	}
+	return err
+}
+
+// stopRunningRelayers stops every relayer that reports running paths.
+func (ic *Interchain) stopRunningRelayers() error {
+	var err error
+	for r, name := range ic.relayers {
+		rr, ok := r.(runningRelayer)
+		if !ok || len(rr.RunningPaths()) == 0 {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), relayerStopTimeout)
+		if stopErr := r.StopRelayer(ctx, ic.rep); stopErr != nil {
+			multierr.AppendInto(&err, fmt.Errorf("failed to stop relayer %s: %w", name, stopErr))
+		}
+		cancel()
+	}
+	return err
 }
 
 func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][]ibc.WalletAmount, error) {
@@ -346,15 +840,34 @@ func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][
 
 	// Add faucet for each chain first.
 	for c := range ic.chains {
+		faucetBalance := c.Config().FaucetBalance
+		if faucetBalance == 0 {
+			faucetBalance = DefaultFaucetBalance
+		}
+
 		// The values are nil at this point, so it is safe to directly assign the slice.
 		walletAmounts[c] = []ibc.WalletAmount{
 			{
 				Address: faucetAddresses[c],
 				Denom:   c.Config().Denom,
-				Amount:  100_000_000_000_000, // Faucet wallet gets 100T units of denom.
+				Amount:  faucetBalance,
 			},
 		}
 
+		faucetDenoms := c.Config().FaucetDenoms
+		denoms := make([]string, 0, len(faucetDenoms))
+		for denom := range faucetDenoms {
+			denoms = append(denoms, denom)
+		}
+		sort.Strings(denoms)
+		for _, denom := range denoms {
+			walletAmounts[c] = append(walletAmounts[c], ibc.WalletAmount{
+				Address: faucetAddresses[c],
+				Denom:   denom,
+				Amount:  faucetDenoms[denom],
+			})
+		}
+
 		if ic.AdditionalGenesisWallets != nil {
 			walletAmounts[c] = append(walletAmounts[c], ic.AdditionalGenesisWallets[c]...)
 		}
@@ -381,51 +894,81 @@ func (ic *Interchain) generateRelayerWallets(ctx context.Context) error {
 
 	relayerChains := ic.relayerChains()
 	ic.relayerWallets = make(map[relayerChain]ibc.Wallet, len(relayerChains))
+
+	// Group by chain, so that each chain's keyring is only used by one goroutine.
+	chainRelayers := make(map[ibc.Chain][]ibc.Relayer)
 	for r, chains := range relayerChains {
 		for _, c := range chains {
-			// Just an ephemeral unique name, only for the local use of the keyring.
-			accountName := ic.relayers[r] + "-" + ic.chains[c]
-			newWallet, err := c.BuildRelayerWallet(ctx, accountName)
-			if err != nil {
-				return err
-			}
-			ic.relayerWallets[relayerChain{R: r, C: c}] = newWallet
+			chainRelayers[c] = append(chainRelayers[c], r)
 		}
 	}
 
-	return nil
+	var mu sync.Mutex
+	eg, egCtx := errgroup.WithContext(ctx)
+	for c, relayers := range chainRelayers {
+		c := c
+		relayers := relayers
+		eg.Go(func() error {
+			for _, r := range relayers {
+				// Just an ephemeral unique name, only for the local use of the keyring.
+				accountName := ic.relayers[r] + "-" + ic.chains[c]
+				newWallet, err := c.BuildRelayerWallet(egCtx, accountName)
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				ic.relayerWallets[relayerChain{R: r, C: c}] = newWallet
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	return eg.Wait()
 }
 
 // configureRelayerKeys adds the chain configuration for each relayer
 // and adds the preconfigured key to the relayer for each relayer-chain.
 func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
-	// Possible optimization: each relayer could be configured concurrently.
-	// But we are only testing with a single relayer so far, so we don't need this yet.
-
+	// Each relayer has its own home directory and keys, so they are configured concurrently.
+	// The chains of a single relayer are still configured in turn, as they share its configuration.
+	eg, egCtx := errgroup.WithContext(ctx)
 	for r, chains := range ic.relayerChains() {
-		for _, c := range chains {
-			rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
-			if !r.UseDockerNetwork() {
-				rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
-			}
+		r := r
+		chains := chains
+		eg.Go(func() error {
+			return ic.configureRelayer(egCtx, rep, r, chains)
+		})
+	}
 
-			chainName := ic.chains[c]
-			if err := r.AddChainConfiguration(ctx,
-				rep,
-				c.Config(), chainName,
-				rpcAddr, grpcAddr,
-			); err != nil {
-				return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
+	return eg.Wait()
+}
 
-			if err := r.RestoreKey(ctx,
-				rep,
-				c.Config().ChainID, chainName,
-				c.Config().CoinType,
-				ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
-			); err != nil {
-				return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
+// configureRelayer adds each of the chains, and the relayer's wallet on it, to the relayer r.
+func (ic *Interchain) configureRelayer(ctx context.Context, rep *testreporter.RelayerExecReporter, r ibc.Relayer, chains []ibc.Chain) error {
+	for _, c := range chains {
+		rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+		if !r.UseDockerNetwork() {
+			rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+		}
+
+		chainName := ic.chains[c]
+		if err := r.AddChainConfiguration(ctx,
+			rep,
+			c.Config(), chainName,
+			rpcAddr, grpcAddr,
+		); err != nil {
+			return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
+		}
+
+		if err := r.RestoreKey(ctx,
+			rep,
+			c.Config().ChainID, chainName,
+			c.Config().CoinType,
+			ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
+		); err != nil {
+			return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
 		}
 	}
 
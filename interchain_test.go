@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	interchaintest "github.com/strangelove-ventures/interchaintest/v6"
 	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
@@ -169,6 +171,71 @@ func getRelayerWalletsTest(t *testing.T, relayerImpl ibc.RelayerImplementation)
 	_ = ic.Close()
 }
 
+func TestInterchain_MultipleRelayers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	rf := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t))
+	r1 := rf.Build(t, client, network)
+	r2 := rf.Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r1, "r1").
+		AddRelayer(r2, "r2").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r1,
+			Path:    "p1",
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r2,
+			Path:    "p2",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	w1, ok := r1.GetWallet(gaia0.Config().ChainID)
+	require.True(t, ok)
+	w2, ok := r2.GetWallet(gaia0.Config().ChainID)
+	require.True(t, ok)
+	require.NotEqual(t, w1.Address(), w2.Address())
+
+	require.NoError(t, r1.StartRelayer(ctx, eRep, "p1"))
+	require.NoError(t, r2.StartRelayer(ctx, eRep, "p2"))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia0, gaia1))
+	require.NoError(t, r1.StopRelayer(ctx, eRep))
+	require.NoError(t, r2.StopRelayer(ctx, eRep))
+}
+
 func TestInterchain_CreateUser(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
@@ -254,6 +321,117 @@ func TestCosmosChain_BroadcastTx_HermesRelayer(t *testing.T) {
 	broadcastTxCosmosChainTest(t, ibc.Hermes)
 }
 
+// TestCosmosChain_BroadcastTx_FileKeyring checks that keys created in a "file" keyring,
+// whose passphrase is prompted for, sign both the txs of the nodes and those of a Broadcaster.
+func TestCosmosChain_BroadcastTx_FileKeyring(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{KeyringBackend: "file"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The user is recovered into the nodes' keyring, and funded by a tx of the faucet key signed on a node.
+	user := interchaintest.GetAndFundTestUsers(t, ctx, "gaia-user-1", 10_000_000, gaia)[0].(*cosmos.CosmosWallet)
+
+	recipient, err := interchaintest.GetFaucetAddress(ctx, gaia)
+	require.NoError(t, err)
+	recipientAddr, err := types.AccAddressFromBech32(recipient)
+	require.NoError(t, err)
+	before, err := gaia.GetBalance(ctx, recipient, gaia.Config().Denom)
+	require.NoError(t, err)
+
+	b := cosmos.NewBroadcaster(t, gaia)
+	msg := banktypes.NewMsgSend(user.Address(), recipientAddr, types.NewCoins(types.NewInt64Coin(gaia.Config().Denom, 1_000)))
+	resp, err := cosmos.BroadcastTx(ctx, b, user, msg)
+	require.NoError(t, err)
+	assertTransactionIsValid(t, resp)
+
+	after, err := gaia.GetBalance(ctx, recipient, gaia.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, before+1_000, after)
+}
+
+func TestInterchain_SnapshotRestore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = 10_000_000
+	user := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), fundAmount, gaia)[0]
+	denom := gaia.Config().Denom
+
+	id, err := ic.Snapshot(ctx)
+	require.NoError(t, err)
+
+	// Spend some of the user's funds after the snapshot.
+	faucet, err := interchaintest.GetFaucetAddress(ctx, gaia)
+	require.NoError(t, err)
+	require.NoError(t, gaia.SendFunds(ctx, user.KeyName(), ibc.WalletAmount{Address: faucet, Denom: denom, Amount: 1_000}))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+	spent, err := gaia.GetBalance(ctx, user.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Less(t, spent, int64(fundAmount))
+	spentHeight, err := gaia.Height(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, ic.Restore(ctx, id))
+
+	// The chain continues from the snapshot, in which the user still holds all of its funds.
+	restoredHeight, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.Less(t, restoredHeight, spentHeight)
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+	restored, err := gaia.GetBalance(ctx, user.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(fundAmount), restored)
+}
+
 func broadcastTxCosmosChainTest(t *testing.T, relayerImpl ibc.RelayerImplementation) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
@@ -322,11 +500,53 @@ func broadcastTxCosmosChainTest(t *testing.T, relayerImpl ibc.RelayerImplementat
 			0,
 			"",
 		)
+		gasUsed, err := cosmos.SimulateTx(ctx, b, testUser.(*cosmos.CosmosWallet), msg)
+		require.NoError(t, err)
+		require.NotZero(t, gasUsed)
+
 		resp, err := cosmos.BroadcastTx(ctx, b, testUser.(*cosmos.CosmosWallet), msg)
 		require.NoError(t, err)
 		assertTransactionIsValid(t, resp)
 	})
 
+	t.Run("broadcast multiple messages", func(t *testing.T) {
+		user := testUser.(*cosmos.CosmosWallet)
+		recipient, err := interchaintest.GetFaucetAddress(ctx, gaia0)
+		require.NoError(t, err)
+		recipientAddr, err := types.AccAddressFromBech32(recipient)
+		require.NoError(t, err)
+
+		balance := func() int64 {
+			bal, err := gaia0.GetBalance(ctx, recipient, gaia0.Config().Denom)
+			require.NoError(t, err)
+			return bal
+		}
+		send := func(amount int64) types.Msg {
+			return banktypes.NewMsgSend(user.Address(), recipientAddr, types.NewCoins(types.NewInt64Coin(gaia0.Config().Denom, amount)))
+		}
+
+		before := balance()
+		resp, err := gaia0.(*cosmos.CosmosChain).BroadcastTx(ctx, user, send(1), send(2))
+		require.NoError(t, err)
+		assertTransactionIsValid(t, resp)
+		require.Len(t, resp.Logs, 2)
+		require.Equal(t, before+3, balance())
+
+		// The second message cannot be paid for, so neither send is applied.
+		resp, err = gaia0.(*cosmos.CosmosChain).BroadcastTx(ctx, user, send(1), send(1_000_000_000_000))
+		require.NoError(t, err)
+		require.NotZero(t, resp.Code)
+		require.Equal(t, before+3, balance())
+	})
+
+	t.Run("simulation failure", func(t *testing.T) {
+		tooMuch := types.Coin{Denom: gaia0.Config().Denom, Amount: types.NewInt(1_000_000_000_000)}
+		msg := banktypes.NewMsgSend(testUser.(*cosmos.CosmosWallet).Address(), testUser.(*cosmos.CosmosWallet).Address(), types.NewCoins(tooMuch))
+
+		_, err := gaia0.(*cosmos.CosmosChain).SimulateTx(ctx, testUser.(*cosmos.CosmosWallet), msg)
+		require.ErrorContains(t, err, "insufficient funds")
+	})
+
 	t.Run("transfer success", func(t *testing.T) {
 		require.NoError(t, testutil.WaitForBlocks(ctx, 5, gaia0, gaia1))
 
@@ -440,6 +660,98 @@ func TestInterchain_ConflictRejection(t *testing.T) {
 	})
 }
 
+func TestInterchain_AddStarLinks(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "hub", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "hub-0"}},
+		{Name: "gaia", ChainName: "s1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "spoke-1"}},
+		{Name: "gaia", ChainName: "s2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "spoke-2"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	hub, s1, s2 := chains[0], chains[1], chains[2]
+
+	require.Equal(t, "hub-0-spoke-1", interchaintest.StarPathName(hub, s1))
+
+	var r rly.CosmosRelayer
+	newInterchain := func() *interchaintest.Interchain {
+		return interchaintest.NewInterchain().
+			AddChain(hub).AddChain(s1).AddChain(s2).
+			AddRelayer(&r, "r")
+	}
+
+	ic := newInterchain().AddStarLinks(interchaintest.StarLinks{
+		Hub:     hub,
+		Spokes:  []ibc.Chain{s1, s2},
+		Relayer: &r,
+	})
+
+	// Every spoke's path was added.
+	for _, spoke := range []ibc.Chain{s1, s2} {
+		exp := fmt.Sprintf("relayer %q already has a path named %q", ibc.Relayer(&r), interchaintest.StarPathName(hub, spoke))
+		require.PanicsWithError(t, exp, func() {
+			ic.AddLink(interchaintest.InterchainLink{Chain1: hub, Chain2: spoke, Relayer: &r, Path: interchaintest.StarPathName(hub, spoke)})
+		})
+	}
+
+	// Overrides are validated like any other link.
+	require.Panics(t, func() {
+		newInterchain().AddStarLinks(interchaintest.StarLinks{
+			Hub:     hub,
+			Spokes:  []ibc.Chain{s1, s2},
+			Relayer: &r,
+			Overrides: map[ibc.Chain]interchaintest.InterchainLink{
+				s2: {CreateChannelOpts: ibc.CreateChannelOptions{SourcePortName: "transfer"}},
+			},
+		})
+	})
+}
+
+func TestInterchain_GetChain(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	ic := interchaintest.NewInterchain().AddChain(chains[0]).AddChain(chains[1])
+
+	require.Equal(t, chains[0], ic.GetChain("g1"))
+	require.Equal(t, chains[1], ic.GetChain("g2"))
+
+	_, err = ic.FindChain("g3")
+	require.EqualError(t, err, "no chain with name g3 was added to Interchain")
+	require.PanicsWithError(t, "no chain with name g3 was added to Interchain", func() {
+		_ = ic.GetChain("g3")
+	})
+}
+
+func TestInterchain_AddProviderConsumerLinkRequiresClientLinker(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "provider", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "provider-0"}},
+		{Name: "gaia", ChainName: "consumer", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "consumer-0"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	// A relayer implementing only ibc.Relayer cannot reuse the clients created with the consumer chain.
+	var r struct{ ibc.Relayer }
+	require.PanicsWithError(t, "relayer *struct { ibc.Relayer } cannot link a path over existing clients", func() {
+		interchaintest.NewInterchain().
+			AddChain(chains[0]).AddChain(chains[1]).
+			AddRelayer(&r, "r").
+			AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+				Provider: chains[0],
+				Consumer: chains[1],
+				Relayer:  &r,
+				Path:     "ccv",
+			})
+	})
+}
+
 func TestInterchain_AddNil(t *testing.T) {
 	require.PanicsWithError(t, "cannot add nil chain", func() {
 		_ = interchaintest.NewInterchain().AddChain(nil)
@@ -459,3 +771,126 @@ func assertTransactionIsValid(t *testing.T, resp sdk.TxResponse) {
 	require.NotEmpty(t, resp.TxHash)
 	require.NotEmpty(t, resp.Events)
 }
+
+func TestInterchain_PartitionNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	numVals, numFullNodes := 2, 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}, NumValidators: &numVals, NumFullNodes: &numFullNodes},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(gaia)
+	defer ic.Close()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia))
+
+	// Each validator holds half the voting power, so neither side can commit blocks alone.
+	groupA := []string{gaia.Validators[0].Name()}
+	groupB := []string{gaia.Validators[1].Name()}
+	require.ErrorContains(t, ic.PartitionNetwork(ctx, []string{"no-such-container"}, groupB), "no-such-container")
+	require.NoError(t, ic.PartitionNetwork(ctx, groupA, groupB))
+	require.Error(t, ic.PartitionNetwork(ctx, groupA, groupB), "only one partition may be active")
+
+	err = testutil.WaitForBlocksWithTimeout(ctx, 2, 20*time.Second, gaia)
+	require.ErrorContains(t, err, "stopped advancing")
+
+	require.NoError(t, ic.HealNetwork(ctx))
+	require.NoError(t, testutil.WaitForBlocksWithTimeout(ctx, 2, 2*time.Minute, gaia))
+}
+
+func TestInterchain_StopStartRelayers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	rf := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t))
+	r1 := rf.Build(t, client, network)
+	r2 := rf.Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r1, "r1").
+		AddRelayer(r2, "r2").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r1,
+			Path:    "p1",
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r2,
+			Path:    "p2",
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	require.NoError(t, r1.StartRelayer(ctx, eRep, "p1"))
+	require.NoError(t, r2.StartRelayer(ctx, eRep, "p2"))
+
+	type runningRelayer interface{ RunningPaths() []string }
+
+	height, err := gaia0.Height(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, ic.Stop(ctx, eRep))
+	require.Empty(t, r1.(runningRelayer).RunningPaths())
+	require.Empty(t, r2.(runningRelayer).RunningPaths())
+
+	require.NoError(t, ic.Start(ctx, eRep))
+	require.Equal(t, []string{"p1"}, r1.(runningRelayer).RunningPaths())
+	require.Equal(t, []string{"p2"}, r2.(runningRelayer).RunningPaths())
+
+	// The chains resume past their height at Stop.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia0, gaia1))
+	resumed, err := gaia0.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, resumed, height)
+}
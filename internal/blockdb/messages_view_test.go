@@ -93,7 +93,8 @@ func TestMessagesView(t *testing.T) {
 
 	t.Run("create clients", func(t *testing.T) {
 		// Creating the clients will cause transactions.
-		require.NoError(t, r.CreateClients(ctx, eRep, pathName, ibc.DefaultClientOpts()))
+		_, err := r.CreateClients(ctx, eRep, pathName, ibc.DefaultClientOpts())
+		require.NoError(t, err)
 
 		// MsgCreateClient should match the opposite chain IDs.
 		const qCreateClient = `SELECT
@@ -117,7 +118,8 @@ WHERE type = "/ibc.core.client.v1.MsgCreateClient" AND chain_id = ?;`
 		require.NoError(t, testutil.WaitForBlocks(ctx, 2, gaia0, gaia1))
 
 		// Next, create the connections.
-		require.NoError(t, r.CreateConnections(ctx, eRep, pathName))
+		_, err := r.CreateConnections(ctx, eRep, pathName)
+		require.NoError(t, err)
 
 		// Wait for another block before retrieving the connections and querying for them.
 		require.NoError(t, testutil.WaitForBlocks(ctx, 1, gaia0, gaia1))
@@ -180,12 +182,13 @@ WHERE type = "/ibc.core.connection.v1.MsgConnectionOpenConfirm" AND chain_id = ?
 	const gaia0Port, gaia1Port = "transfer", "transfer" // Would be nice if these could differ.
 	var gaia0ChannelID, gaia1ChannelID string
 	t.Run("create channel", func(t *testing.T) {
-		require.NoError(t, r.CreateChannel(ctx, eRep, pathName, ibc.CreateChannelOptions{
+		created, err := r.CreateChannel(ctx, eRep, pathName, ibc.CreateChannelOptions{
 			SourcePortName: gaia0Port,
 			DestPortName:   gaia1Port,
 			Order:          ibc.Unordered,
 			Version:        "ics20-1",
-		}))
+		})
+		require.NoError(t, err)
 
 		// Wait for another block before retrieving the channels and querying for them.
 		require.NoError(t, testutil.WaitForBlocks(ctx, 1, gaia0, gaia1))
@@ -194,8 +197,10 @@ WHERE type = "/ibc.core.connection.v1.MsgConnectionOpenConfirm" AND chain_id = ?
 		require.NoError(t, err)
 		require.Len(t, channels, 1)
 
-		gaia0ChannelID = channels[0].ChannelID
-		gaia1ChannelID = channels[0].Counterparty.ChannelID
+		gaia0ChannelID = created.ChannelID
+		gaia1ChannelID = created.CounterpartyChannelID
+		require.Equal(t, gaia0ChannelID, channels[0].ChannelID)
+		require.Equal(t, gaia1ChannelID, channels[0].Counterparty.ChannelID)
 
 		// OpenInit happens on first chain.
 		const qChannelOpenInit = `SELECT
@@ -49,7 +49,6 @@ func Migrate(db *sql.DB, gitSha string) error {
 		return fmt.Errorf("pragma journal_mode: %w", err)
 	}
 
-	// TODO(nix 05-27-2022): Appropriate indexes?
 	_, err = db.Exec(`PRAGMA foreign_keys = ON`)
 	if err != nil {
 		return fmt.Errorf("pragma foreign_keys: %w", err)
@@ -148,6 +147,19 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	// Index the foreign keys, which SQLite does not do automatically,
+	// so that looking up a chain's blocks by height and joining txs and events stays fast as the database grows.
+	for _, stmt := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_block_chain_height ON block(fk_chain_id, height)`,
+		`CREATE INDEX IF NOT EXISTS idx_tx_block ON tx(fk_block_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_tx ON tendermint_event(fk_tx_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_event ON tendermint_event_attr(fk_event_id)`,
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("create index: %w", err)
+		}
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
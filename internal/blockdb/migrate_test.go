@@ -42,4 +42,10 @@ func TestMigrate(t *testing.T) {
 
 	require.NoError(t, err)
 	require.Equal(t, "new-sha", gotSha)
+
+	row = db.QueryRow(`select count(*) from sqlite_master where type = 'index' and name like 'idx_%'`)
+	err = row.Scan(&count)
+
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
 }
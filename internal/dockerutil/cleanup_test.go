@@ -0,0 +1,35 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeptContainerInstructions(t *testing.T) {
+	got := keptContainerInstructions(types.Container{
+		ID:    "abc123",
+		Names: []string{"/gaia-1-val-0-TestFoo"},
+		Image: "ghcr.io/strangelove-ventures/heighliner/gaia:v7.0.3",
+		Labels: map[string]string{
+			ChainIDLabel: "gaia-1",
+			HomeDirLabel: "/var/cosmos-chain/gaia",
+
+			StartCmdLabel: "env GAIA_EXPERIMENTAL=1 gaiad start --home /var/cosmos-chain/gaia",
+		},
+		Ports: []types.Port{
+			{IP: "0.0.0.0", PrivatePort: 26657, PublicPort: 49153, Type: "tcp"},
+			{PrivatePort: 26656, Type: "tcp"},
+		},
+	})
+
+	require.Equal(t, `  container: gaia-1-val-0-TestFoo (image ghcr.io/strangelove-ventures/heighliner/gaia:v7.0.3)
+  chain id:  gaia-1
+  home dir:  /var/cosmos-chain/gaia
+  start cmd: env GAIA_EXPERIMENTAL=1 gaiad start --home /var/cosmos-chain/gaia
+  port 26657/tcp: 0.0.0.0:49153
+  shell:     docker exec -it gaia-1-val-0-TestFoo sh
+  logs:      docker logs -f gaia-1-val-0-TestFoo
+  remove:    docker rm -f gaia-1-val-0-TestFoo`, got)
+}
@@ -22,7 +22,7 @@ func TestFileRetriever(t *testing.T) {
 
 	ctx := context.Background()
 	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+		Labels: dockerutil.CleanupLabels(t.Name()),
 	})
 	require.NoError(t, err)
 
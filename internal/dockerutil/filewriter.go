@@ -5,6 +5,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -30,6 +36,106 @@ func NewFileWriter(log *zap.Logger, cli *client.Client, testName string) *FileWr
 
 // WriteFile writes the single file containing content, at relPath within the given volume.
 func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string, content []byte) error {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: relPath,
+
+		Size: int64(len(content)),
+		Mode: 0600,
+		// Not setting uname because the container will chown it anyway.
+
+		ModTime: time.Now(),
+
+		Format: tar.FormatPAX,
+	}); err != nil {
+		return fmt.Errorf("writing tar header: %w", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("writing content to tar: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+
+	return w.writeTar(ctx, volumeName, &buf)
+}
+
+// WriteHostFiles writes every file or directory on the host in files, keyed by its path relative to the volume,
+// into the given volume with a single container.
+// The mode of each file is kept, so that e.g. scripts and binaries stay executable.
+func (w *FileWriter) WriteHostFiles(ctx context.Context, volumeName string, files map[string]string) error {
+	relPaths := make([]string, 0, len(files))
+	for relPath := range files {
+		relPaths = append(relPaths, relPath)
+	}
+	sort.Strings(relPaths)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, relPath := range relPaths {
+		if err := tarHostPath(tw, relPath, files[relPath]); err != nil {
+			return fmt.Errorf("adding %s to tar: %w", files[relPath], err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+
+	return w.writeTar(ctx, volumeName, &buf)
+}
+
+// tarHostPath writes the file or directory tree at hostPath on the host to tw, at relPath,
+// keeping the mode of every entry.
+func tarHostPath(tw *tar.Writer, relPath, hostPath string) error {
+	return filepath.WalkDir(hostPath, func(p string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Symlinks are followed, so their target is written.
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(hostPath, p)
+		if err != nil {
+			return err
+		}
+
+		hdr := &tar.Header{
+			Name: path.Join(relPath, filepath.ToSlash(rel)),
+
+			Mode: int64(info.Mode().Perm()),
+			// Not setting uname because the container will chown it anyway.
+
+			ModTime: info.ModTime(),
+
+			Format: tar.FormatPAX,
+		}
+		if info.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+			return tw.WriteHeader(hdr)
+		}
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = info.Size()
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// writeTar extracts the tar archive into the given volume,
+// with every extracted path owned by the owner of the volume's root.
+func (w *FileWriter) writeTar(ctx context.Context, volumeName string, tarball io.Reader) error {
 	const mountPath = "/mnt/dockervolume"
 
 	if err := ensureBusybox(ctx, w.cli); err != nil {
@@ -56,7 +162,7 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			// Use root user to avoid permission issues when reading files from the volume.
 			User: GetRootUserString(),
 
-			Labels: map[string]string{CleanupLabel: w.testName},
+			Labels: CleanupLabels(w.testName),
 		},
 		&container.HostConfig{
 			Binds:      []string{volumeName + ":" + mountPath},
@@ -84,33 +190,11 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 	}()
 
-	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
-	if err := tw.WriteHeader(&tar.Header{
-		Name: relPath,
-
-		Size: int64(len(content)),
-		Mode: 0600,
-		// Not setting uname because the container will chown it anyway.
-
-		ModTime: time.Now(),
-
-		Format: tar.FormatPAX,
-	}); err != nil {
-		return fmt.Errorf("writing tar header: %w", err)
-	}
-	if _, err := tw.Write(content); err != nil {
-		return fmt.Errorf("writing content to tar: %w", err)
-	}
-	if err := tw.Close(); err != nil {
-		return fmt.Errorf("closing tar writer: %w", err)
-	}
-
 	if err := w.cli.CopyToContainer(
 		ctx,
 		cc.ID,
 		mountPath,
-		&buf,
+		tarball,
 		types.CopyToContainerOptions{},
 	); err != nil {
 		return fmt.Errorf("copying tar to container: %w", err)
@@ -134,7 +218,7 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 
 		if res.StatusCode != 0 {
-			return fmt.Errorf("chown on new file exited %d", res.StatusCode)
+			return fmt.Errorf("chown on new files exited %d", res.StatusCode)
 		}
 	}
 
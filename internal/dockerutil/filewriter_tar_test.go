@@ -0,0 +1,58 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTarHostPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "run.sh"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0644))
+	single := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(single, []byte("secret"), 0600))
+	// Set the modes regardless of the umask.
+	for p, mode := range map[string]os.FileMode{"bin": 0750, "bin/run.sh": 0755, "config.json": 0644} {
+		require.NoError(t, os.Chmod(filepath.Join(dir, p), mode))
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tarHostPath(tw, "files", dir))
+	require.NoError(t, tarHostPath(tw, "config/key", single))
+	require.NoError(t, tw.Close())
+
+	type entry struct {
+		mode    int64
+		dir     bool
+		content string
+	}
+	got := make(map[string]entry)
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		got[hdr.Name] = entry{mode: hdr.Mode, dir: hdr.Typeflag == tar.TypeDir, content: string(content)}
+	}
+
+	require.Equal(t, map[string]entry{
+		"files/":            {mode: 0755, dir: true},
+		"files/bin/":        {mode: 0750, dir: true},
+		"files/bin/run.sh":  {mode: 0755, content: "#!/bin/sh\n"},
+		"files/config.json": {mode: 0644, content: "{}"},
+		"config/key":        {mode: 0600, content: "secret"},
+	}, got)
+}
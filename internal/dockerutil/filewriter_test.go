@@ -2,6 +2,8 @@ package dockerutil_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -22,7 +24,7 @@ func TestFileWriter(t *testing.T) {
 
 	ctx := context.Background()
 	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+		Labels: dockerutil.CleanupLabels(t.Name()),
 	})
 	require.NoError(t, err)
 
@@ -65,4 +67,24 @@ func TestFileWriter(t *testing.T) {
 
 		require.Equal(t, string(res.Stdout), ":D")
 	})
+
+	t.Run("host files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\necho ran\n"), 0755))
+		require.NoError(t, os.Chmod(filepath.Join(dir, "run.sh"), 0755))
+
+		require.NoError(t, fw.WriteHostFiles(ctx, v.Name, map[string]string{"scripts": dir}))
+		res := img.Run(
+			ctx,
+			[]string{"sh", "-c", "/mnt/test/scripts/run.sh && stat -c '%a' /mnt/test/scripts/run.sh"},
+			dockerutil.ContainerOptions{
+				Binds: []string{v.Name + ":/mnt/test"},
+				User:  dockerutil.GetRootUserString(),
+			},
+		)
+		require.NoError(t, res.Err)
+
+		// The script kept its exec bit.
+		require.Equal(t, "ran\n755\n", string(res.Stdout))
+	})
 }
@@ -169,7 +169,7 @@ func (image *Image) createContainer(ctx context.Context, containerName, hostName
 			Hostname: hostName,
 			User:     opts.User,
 
-			Labels: map[string]string{CleanupLabel: image.testName},
+			Labels: CleanupLabels(image.testName),
 		},
 		&container.HostConfig{
 			Binds:           opts.Binds,
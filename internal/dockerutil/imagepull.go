@@ -0,0 +1,135 @@
+package dockerutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"go.uber.org/zap"
+)
+
+// hostPlatform is the platform of the images pulled for a DockerImage without a Platform,
+// i.e. Linux on the architecture of the host running the tests, e.g. "linux/arm64" on Apple Silicon.
+var hostPlatform = "linux/" + runtime.GOARCH
+
+// emulatedPlatform is pulled when an image has no variant for hostPlatform, as it is the one most images are built for.
+const emulatedPlatform = "linux/amd64"
+
+// PullImage makes the image available to the Docker daemon according to the image's pull policy.
+//
+// The variant of the image for its Platform, or hostPlatform if it has none, is requested.
+// If the image has no variant for hostPlatform, the linux/amd64 variant is pulled instead,
+// and a warning is logged as it will run under emulation, which is much slower.
+func PullImage(ctx context.Context, log *zap.Logger, cli *client.Client, image ibc.DockerImage) error {
+	ref := image.Ref()
+	platform := image.Platform
+	auto := platform == ""
+	if auto {
+		platform = hostPlatform
+	}
+
+	switch image.PullPolicy {
+	case "", ibc.PullAlways:
+		// Always pull.
+	case ibc.PullIfNotPresent, ibc.PullNever:
+		inspect, _, err := cli.ImageInspectWithRaw(ctx, ref)
+		if err == nil {
+			// A present image of another platform than the requested one is only used when the platform is auto-detected,
+			// so an image pulled under emulation is not pulled again every time.
+			if auto || imagePlatform(inspect) == platform {
+				warnIfEmulated(log, ref, inspect)
+				return nil
+			}
+			if image.PullPolicy == ibc.PullNever {
+				return fmt.Errorf("image %s is present locally for %s rather than %s, and its pull policy is %q",
+					ref, imagePlatform(inspect), platform, ibc.PullNever)
+			}
+		} else if image.PullPolicy == ibc.PullNever {
+			return fmt.Errorf("image %s is not present locally and its pull policy is %q: %w", ref, ibc.PullNever, err)
+		}
+	default:
+		return fmt.Errorf("image %s has unknown pull policy %q", ref, image.PullPolicy)
+	}
+
+	err := pullImage(ctx, cli, ref, platform)
+	if err != nil && auto && platform != emulatedPlatform && isNoMatchingManifest(err) {
+		log.Warn("Image has no variant for the host platform, pulling the amd64 variant to run under emulation",
+			zap.String("image", ref),
+			zap.String("host_platform", platform),
+		)
+		err = pullImage(ctx, cli, ref, emulatedPlatform)
+	}
+	if err != nil {
+		return fmt.Errorf("pull image %s for %s: %w", ref, platform, err)
+	}
+
+	if inspect, _, err := cli.ImageInspectWithRaw(ctx, ref); err == nil {
+		warnIfEmulated(log, ref, inspect)
+	}
+	return nil
+}
+
+// pullImage pulls the variant of ref for platform, returning any error reported while pulling.
+func pullImage(ctx context.Context, cli *client.Client, ref, platform string) error {
+	rc, err := cli.ImagePull(ctx, ref, types.ImagePullOptions{Platform: platform})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	return pullStreamError(rc)
+}
+
+// pullStreamError reads the JSON progress messages of an image pull to the end,
+// returning the error reported by the daemon, if any.
+// The daemon reports some errors, such as a missing variant, only in the progress stream.
+func pullStreamError(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Error       string `json:"error"`
+			ErrorDetail *struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read pull progress: %w", err)
+		}
+		if msg.ErrorDetail != nil && msg.ErrorDetail.Message != "" {
+			return errors.New(msg.ErrorDetail.Message)
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+	}
+}
+
+// isNoMatchingManifest reports whether a pull failed because the image has no variant for the requested platform.
+func isNoMatchingManifest(err error) bool {
+	return strings.Contains(err.Error(), "no matching manifest")
+}
+
+// imagePlatform returns the platform of a local image, e.g. "linux/amd64".
+func imagePlatform(inspect types.ImageInspect) string {
+	return inspect.Os + "/" + inspect.Architecture
+}
+
+// warnIfEmulated logs a warning if the local image does not match the host platform.
+func warnIfEmulated(log *zap.Logger, ref string, inspect types.ImageInspect) {
+	if p := imagePlatform(inspect); p != hostPlatform {
+		log.Warn("Image does not match the host platform and will run under emulation, which is much slower",
+			zap.String("image", ref),
+			zap.String("image_platform", p),
+			zap.String("host_platform", hostPlatform),
+		)
+	}
+}
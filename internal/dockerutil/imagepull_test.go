@@ -0,0 +1,35 @@
+package dockerutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullStreamError(t *testing.T) {
+	t.Parallel()
+
+	ok := `{"status":"Pulling from library/busybox","id":"latest"}
+{"status":"Digest: sha256:abc"}
+{"status":"Status: Downloaded newer image for busybox:latest"}
+`
+	require.NoError(t, pullStreamError(strings.NewReader(ok)))
+
+	noManifest := `{"status":"Pulling from ghcr.io/strangelove-ventures/heighliner/gaia","id":"v7.0.0"}
+{"errorDetail":{"message":"no matching manifest for linux/arm64/v8 in the manifest list entries"},"error":"no matching manifest for linux/arm64/v8 in the manifest list entries"}
+`
+	err := pullStreamError(strings.NewReader(noManifest))
+	require.EqualError(t, err, "no matching manifest for linux/arm64/v8 in the manifest list entries")
+	require.True(t, isNoMatchingManifest(err))
+
+	require.ErrorContains(t, pullStreamError(strings.NewReader(`{"status":`)), "read pull progress")
+}
+
+func TestImagePlatform(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "linux/arm64", imagePlatform(types.ImageInspect{Os: "linux", Architecture: "arm64"}))
+	require.True(t, strings.HasPrefix(hostPlatform, "linux/"))
+}
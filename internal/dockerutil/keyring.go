@@ -4,10 +4,12 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -18,13 +20,20 @@ import (
 
 // NewLocalKeyringFromDockerContainer copies the contents of the given container directory into a specified local directory.
 // This allows test hosts to sign transactions on behalf of test users.
-func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client, localDirectory, containerKeyringDir, containerId string) (keyring.Keyring, error) {
+// The keyring directory holds the keys of the given backend, "test" or "file",
+// and passphrase unlocks a "file" keyring.
+func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client, localDirectory, containerKeyringDir, containerId, backend, passphrase string) (keyring.Keyring, error) {
+	if backend != keyring.BackendTest && backend != keyring.BackendFile {
+		return nil, fmt.Errorf("keyring backend %s does not keep its keys in a directory that can be copied", backend)
+	}
+
 	reader, _, err := dc.CopyFromContainer(ctx, containerId, containerKeyringDir)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := os.Mkdir(filepath.Join(localDirectory, "keyring-test"), os.ModePerm); err != nil {
+	keyringDir := filepath.Join(localDirectory, "keyring-"+backend)
+	if err := os.Mkdir(keyringDir, os.ModePerm); err != nil {
 		return nil, err
 	}
 	tr := tar.NewReader(reader)
@@ -49,7 +58,7 @@ func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client,
 			continue
 		}
 
-		filePath := filepath.Join(localDirectory, "keyring-test", extractedFileName)
+		filePath := filepath.Join(keyringDir, extractedFileName)
 		if err := os.WriteFile(filePath, fileBuff.Bytes(), os.ModePerm); err != nil {
 			return nil, err
 		}
@@ -59,5 +68,6 @@ func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client,
 	cryptocodec.RegisterInterfaces(registry)
 	cdc := codec.NewProtoCodec(registry)
 
-	return keyring.New("", keyring.BackendTest, localDirectory, os.Stdin, cdc)
+	// The file keyring prompts for its passphrase once, when first unlocked.
+	return keyring.New("", backend, localDirectory, strings.NewReader(passphrase+"\n"), cdc)
 }
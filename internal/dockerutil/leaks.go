@@ -0,0 +1,86 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+)
+
+// LeakedResources lists the Docker resources of a test that remain once it should have been cleaned up.
+type LeakedResources struct {
+	Containers, Volumes, Networks []string
+}
+
+// Empty reports whether nothing leaked.
+func (r LeakedResources) Empty() bool {
+	return len(r.Containers) == 0 && len(r.Volumes) == 0 && len(r.Networks) == 0
+}
+
+// String describes the leaked resources, e.g. "containers: gaia-1-val-0; volumes: gaia-1-val-0".
+func (r LeakedResources) String() string {
+	var parts []string
+	for _, kind := range []struct {
+		name  string
+		names []string
+	}{
+		{"containers", r.Containers},
+		{"volumes", r.Volumes},
+		{"networks", r.Networks},
+	} {
+		if len(kind.names) > 0 {
+			parts = append(parts, kind.name+": "+strings.Join(kind.names, ", "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// FindLeakedResources lists the containers, volumes, and networks labeled for testName by the current process,
+// which the cleanup registered by DockerSetup is expected to have removed.
+//
+// If the test failed, the resources kept on purpose by KeepContainersOnFailure or KeepVolumesOnFailure are not listed.
+func FindLeakedResources(ctx context.Context, cli *client.Client, testName string, failed bool) (LeakedResources, error) {
+	var leaked LeakedResources
+	f := filters.NewArgs(cleanupFilters(testName)...)
+	keepContainers := failed && KeepContainersOnFailure
+	keepVolumes := keepContainers || (failed && KeepVolumesOnFailure)
+
+	if !keepContainers {
+		cs, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: f})
+		if err != nil {
+			return LeakedResources{}, fmt.Errorf("failed to list containers: %w", err)
+		}
+		for _, c := range cs {
+			name := c.ID
+			if len(c.Names) > 0 {
+				name = strings.TrimPrefix(c.Names[0], "/")
+			}
+			leaked.Containers = append(leaked.Containers, name)
+		}
+	}
+
+	if !keepVolumes {
+		vs, err := cli.VolumeList(ctx, f)
+		if err != nil {
+			return LeakedResources{}, fmt.Errorf("failed to list volumes: %w", err)
+		}
+		for _, v := range vs.Volumes {
+			leaked.Volumes = append(leaked.Volumes, v.Name)
+		}
+	}
+
+	if !keepContainers {
+		ns, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: f})
+		if err != nil {
+			return LeakedResources{}, fmt.Errorf("failed to list networks: %w", err)
+		}
+		for _, n := range ns {
+			leaked.Networks = append(leaked.Networks, n.Name)
+		}
+	}
+
+	return leaked, nil
+}
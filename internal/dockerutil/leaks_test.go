@@ -0,0 +1,53 @@
+package dockerutil
+
+import (
+	"context"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeakedResources_String(t *testing.T) {
+	require.True(t, LeakedResources{}.Empty())
+	require.Empty(t, LeakedResources{}.String())
+
+	r := LeakedResources{
+		Containers: []string{"gaia-1-val-0-TestFoo", "gaia-1-fn-0-TestFoo"},
+		Networks:   []string{"interchaintest-abcdefgh"},
+	}
+	require.False(t, r.Empty())
+	require.Equal(t, "containers: gaia-1-val-0-TestFoo, gaia-1-fn-0-TestFoo; networks: interchaintest-abcdefgh", r.String())
+}
+
+func TestFindLeakedResources(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := DockerSetup(t)
+	ctx := context.Background()
+	testName := t.Name() + "/leaky"
+
+	leaked, err := FindLeakedResources(ctx, cli, testName, false)
+	require.NoError(t, err)
+	require.True(t, leaked.Empty(), leaked.String())
+
+	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Labels: CleanupLabels(testName),
+	})
+	require.NoError(t, err)
+	defer func() { _ = cli.VolumeRemove(ctx, v.Name, true) }()
+
+	leaked, err = FindLeakedResources(ctx, cli, testName, false)
+	require.NoError(t, err)
+	require.Equal(t, LeakedResources{Volumes: []string{v.Name}}, leaked)
+
+	// A volume kept on purpose after a failure is not a leak.
+	origKeep := KeepVolumesOnFailure
+	defer func() { KeepVolumesOnFailure = origKeep }()
+	KeepVolumesOnFailure = true
+	leaked, err = FindLeakedResources(ctx, cli, testName, true)
+	require.NoError(t, err)
+	require.True(t, leaked.Empty(), leaked.String())
+}
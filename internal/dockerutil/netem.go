@@ -0,0 +1,126 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// netemRef is an image providing tc with netem support; busybox's tc applet lacks netem.
+// It is pinned so that every run configures traffic control with the same tc.
+const netemRef = "nicolaka/netshoot:v0.11"
+
+// ContainersWithLabel returns the IDs of the running containers created for testName by the current process
+// that carry the given label and value.
+func ContainersWithLabel(ctx context.Context, cli *client.Client, testName, label, value string) ([]string, error) {
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(
+			append(cleanupFilters(testName), filters.Arg("label", label+"="+value))...,
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing containers with label %s=%s: %w", label, value, err)
+	}
+
+	ids := make([]string, len(cs))
+	for i, c := range cs {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
+
+// SetNetem applies a netem queueing discipline with the given tc netem arguments,
+// e.g. "delay 100ms 10ms loss 1%", to every network interface of the container.
+// A nil netemArgs removes any previously applied discipline instead.
+//
+// The traffic control rules are configured from a short-lived sidecar container sharing the target's
+// network namespace, so the target image needs neither tc nor the NET_ADMIN capability.
+// The rules only shape egress traffic and last until the target container stops.
+func SetNetem(ctx context.Context, log *zap.Logger, cli *client.Client, testName, containerID string, netemArgs []string) error {
+	if err := ensureNetemImage(ctx, cli); err != nil {
+		return err
+	}
+
+	// Interfaces without a discipline make "tc qdisc del" fail, which is fine when clearing.
+	script := `for dev in $(ls /sys/class/net); do [ "$dev" = lo ] || tc qdisc del dev "$dev" root 2>/dev/null; done; true`
+	if netemArgs != nil {
+		script = `set -e; for dev in $(ls /sys/class/net); do [ "$dev" = lo ] || tc qdisc replace dev "$dev" root netem "$@"; done`
+	}
+
+	containerName := fmt.Sprintf("interchaintest-netem-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+	cc, err := cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: netemRef,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        append([]string{script, "_"}, netemArgs...), // "_" is the meaningless arg0 for sh -c.
+
+			Labels: CleanupLabels(testName),
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + containerID),
+			CapAdd:      []string{"NET_ADMIN"},
+		},
+		nil,
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating netem container: %w", err)
+	}
+
+	// The container is removed once waited on, rather than with AutoRemove, which races ContainerWait.
+	defer func() {
+		if err := cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			log.Warn("Failed to remove netem container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := cli.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("starting netem container: %w", err)
+	}
+
+	waitCh, errCh := cli.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		if res.Error != nil {
+			return fmt.Errorf("waiting for netem container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("applying netem %q to container %s exited %d", strings.Join(netemArgs, " "), containerID, res.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func ensureNetemImage(ctx context.Context, cli *client.Client) error {
+	if _, _, err := cli.ImageInspectWithRaw(ctx, netemRef); err == nil {
+		return nil
+	}
+
+	rc, err := cli.ImagePull(ctx, netemRef, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("pull image %s: %w", netemRef, err)
+	}
+	defer func() { _ = rc.Close() }()
+	if err := pullStreamError(rc); err != nil {
+		return fmt.Errorf("pull image %s: %w", netemRef, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// CreateTestNetwork creates a bridge network labeled for cleanup at the end of the test,
+// and returns its ID.
+func CreateTestNetwork(ctx context.Context, cli *client.Client, name, testName string) (string, error) {
+	res, err := cli.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+
+		Labels: CleanupLabels(testName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating network %s: %w", name, err)
+	}
+	return res.ID, nil
+}
+
+// MoveContainerNetwork disconnects the container from the network from and connects it to the network to.
+// The container's hostname is registered as an alias on the new network,
+// so peers addressing it by hostname can still resolve it there.
+func MoveContainerNetwork(ctx context.Context, cli *client.Client, containerID, from, to string) error {
+	c, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("inspecting container %s: %w", containerID, err)
+	}
+
+	if err := cli.NetworkDisconnect(ctx, from, containerID, true); err != nil {
+		return fmt.Errorf("disconnecting container %s from network %s: %w", containerID, from, err)
+	}
+
+	if err := cli.NetworkConnect(ctx, to, containerID, &network.EndpointSettings{
+		Aliases: []string{c.Config.Hostname},
+	}); err != nil {
+		return fmt.Errorf("connecting container %s to network %s: %w", containerID, to, err)
+	}
+	return nil
+}
+
+// ContainerOnNetwork reports whether the container, identified by name or ID, is connected to the network with ID networkID.
+func ContainerOnNetwork(ctx context.Context, cli *client.Client, containerID, networkID string) (bool, error) {
+	c, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return false, fmt.Errorf("inspecting container %s: %w", containerID, err)
+	}
+	if c.NetworkSettings == nil {
+		return false, nil
+	}
+	for _, ep := range c.NetworkSettings.Networks {
+		if ep.NetworkID == networkID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
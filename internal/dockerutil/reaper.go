@@ -0,0 +1,131 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+)
+
+// newRunID returns an identifier of the current process, in the form "<hostname>/<pid>/<start time>".
+// The start time distinguishes runs that happen to reuse a PID.
+func newRunID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s/%d/%d", hostname, os.Getpid(), time.Now().UnixNano())
+}
+
+// parseRunID returns the hostname and PID encoded in a run ID created by newRunID.
+func parseRunID(runID string) (hostname string, pid int, ok bool) {
+	parts := strings.Split(runID, "/")
+	if len(parts) != 3 {
+		return "", 0, false
+	}
+	pid, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[0], pid, true
+}
+
+// isOrphanedRun reports whether the process that created runID has exited.
+//
+// Only processes on the same host can be checked,
+// so resources created from other hosts sharing the Docker daemon are never considered orphaned.
+func isOrphanedRun(runID string) bool {
+	if runID == RunID {
+		return false
+	}
+	hostname, pid, ok := parseRunID(runID)
+	if !ok {
+		return false
+	}
+	if h, err := os.Hostname(); err != nil || h != hostname {
+		return false
+	}
+	return !processExists(pid)
+}
+
+// reapOrphanedResources removes containers, volumes, and networks labeled with a RunIDLabel
+// whose creating process is no longer running, e.g. because a test panicked or was killed
+// before its t.Cleanup functions ran.
+//
+// Resources of still-running processes, such as other test binaries invoked by the same `go test ./...`,
+// are left untouched.
+func reapOrphanedResources(ctx context.Context, t DockerSetupTestingT, cli *client.Client) {
+	runFilter := filters.NewArgs(filters.Arg("label", RunIDLabel))
+
+	orphaned := make(map[string]struct{})
+	addIfOrphaned := func(labels map[string]string) {
+		if runID := labels[RunIDLabel]; isOrphanedRun(runID) {
+			orphaned[runID] = struct{}{}
+		}
+	}
+
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: runFilter})
+	if err != nil {
+		t.Logf("Failed to list containers while reaping orphaned resources: %v", err)
+		return
+	}
+	for _, c := range cs {
+		addIfOrphaned(c.Labels)
+	}
+
+	vs, err := cli.VolumeList(ctx, runFilter)
+	if err != nil {
+		t.Logf("Failed to list volumes while reaping orphaned resources: %v", err)
+		return
+	}
+	for _, v := range vs.Volumes {
+		addIfOrphaned(v.Labels)
+	}
+
+	ns, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: runFilter})
+	if err != nil {
+		t.Logf("Failed to list networks while reaping orphaned resources: %v", err)
+		return
+	}
+	for _, n := range ns {
+		addIfOrphaned(n.Labels)
+	}
+
+	for runID := range orphaned {
+		reapRun(ctx, t, cli, runID)
+	}
+}
+
+// reapRun removes all containers, volumes, and networks labeled with the given run ID.
+func reapRun(ctx context.Context, t DockerSetupTestingT, cli *client.Client, runID string) {
+	runFilter := filters.NewArgs(filters.Arg("label", RunIDLabel+"="+runID))
+
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: runFilter})
+	if err != nil {
+		t.Logf("Failed to list containers of orphaned run %s: %v", runID, err)
+		return
+	}
+	for _, c := range cs {
+		if err := cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			t.Logf("Failed to remove container %s of orphaned run %s: %v", c.ID, runID, err)
+		}
+	}
+
+	if res, err := cli.VolumesPrune(ctx, runFilter); err != nil {
+		t.Logf("Failed to prune volumes of orphaned run %s: %v", runID, err)
+	} else if len(res.VolumesDeleted) > 0 {
+		t.Logf("Pruned %d volumes of orphaned run %s", len(res.VolumesDeleted), runID)
+	}
+
+	if res, err := cli.NetworksPrune(ctx, runFilter); err != nil {
+		t.Logf("Failed to prune networks of orphaned run %s: %v", runID, err)
+	} else if len(res.NetworksDeleted) > 0 {
+		t.Logf("Pruned networks of orphaned run %s: %v", runID, res.NetworksDeleted)
+	}
+}
@@ -0,0 +1,44 @@
+package dockerutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRunID(t *testing.T) {
+	hostname, pid, ok := parseRunID(RunID)
+	require.True(t, ok)
+	require.Equal(t, os.Getpid(), pid)
+
+	wantHostname, err := os.Hostname()
+	require.NoError(t, err)
+	require.Equal(t, wantHostname, hostname)
+
+	for _, bad := range []string{"", "host", "host/notapid/1", "a/b/c/d"} {
+		_, _, ok := parseRunID(bad)
+		require.False(t, ok, bad)
+	}
+}
+
+func TestIsOrphanedRun(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	require.False(t, isOrphanedRun(RunID), "current run must never be orphaned")
+	require.False(t, isOrphanedRun(fmt.Sprintf("%s/%d/1", hostname, os.Getpid())), "live process must not be orphaned")
+	require.False(t, isOrphanedRun("some-other-host/1/1"), "runs from other hosts must not be orphaned")
+	require.False(t, isOrphanedRun("malformed"))
+}
+
+func TestProcessExists(t *testing.T) {
+	require.True(t, processExists(os.Getpid()))
+
+	// A process that ran this test binary without running any test, and has exited.
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, cmd.Run())
+	require.False(t, processExists(cmd.Process.Pid), "exited process must not exist")
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package dockerutil
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processExists reports whether a process with the given PID is running on this host.
+func processExists(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	// EPERM means the process exists but belongs to another user.
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
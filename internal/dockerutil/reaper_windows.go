@@ -0,0 +1,27 @@
+//go:build windows
+
+package dockerutil
+
+import (
+	"errors"
+	"syscall"
+)
+
+// stillActive is the exit code GetExitCodeProcess reports for a process that has not exited.
+const stillActive = 259
+
+// processExists reports whether a process with the given PID is running on this host.
+func processExists(pid int) bool {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		// Processes of other users exist, but cannot be opened.
+		return errors.Is(err, syscall.ERROR_ACCESS_DENIED)
+	}
+	defer syscall.CloseHandle(h)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
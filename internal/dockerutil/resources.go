@@ -0,0 +1,31 @@
+package dockerutil
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+// ContainerResources returns the resources of a container limited to cpus, a possibly fractional number of CPUs,
+// and memory, a size such as "512m" or "2g".
+// A zero cpus or an empty memory leaves that resource unconstrained.
+func ContainerResources(cpus float64, memory string) (container.Resources, error) {
+	var r container.Resources
+	if cpus < 0 {
+		return r, fmt.Errorf("invalid CPU limit %v: must not be negative", cpus)
+	}
+	r.NanoCPUs = int64(cpus * 1e9)
+
+	if memory != "" {
+		b, err := units.RAMInBytes(memory)
+		if err != nil {
+			return r, fmt.Errorf("invalid memory limit %q: %w", memory, err)
+		}
+		if b <= 0 {
+			return r, fmt.Errorf("invalid memory limit %q: must be positive", memory)
+		}
+		r.Memory = b
+	}
+	return r, nil
+}
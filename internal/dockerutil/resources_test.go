@@ -0,0 +1,28 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerResources(t *testing.T) {
+	r, err := ContainerResources(0, "")
+	require.NoError(t, err)
+	require.Equal(t, container.Resources{}, r)
+
+	r, err = ContainerResources(1.5, "512m")
+	require.NoError(t, err)
+	require.Equal(t, int64(1_500_000_000), r.NanoCPUs)
+	require.Equal(t, int64(512*1024*1024), r.Memory)
+
+	_, err = ContainerResources(-1, "")
+	require.Error(t, err)
+
+	_, err = ContainerResources(0, "lots")
+	require.Error(t, err)
+
+	_, err = ContainerResources(0, "0")
+	require.Error(t, err)
+}
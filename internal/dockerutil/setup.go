@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
 )
@@ -45,8 +47,38 @@ const (
 
 	// NodeOwnerLabel indicates the logical node owning a particular object (probably a volume).
 	NodeOwnerLabel = LabelPrefix + "node-owner"
+
+	// RunIDLabel identifies the test process that created a particular object.
+	// DockerSetup uses it to find resources left behind by processes that have since exited.
+	RunIDLabel = LabelPrefix + "run-id"
+
+	// ChainIDLabel, HomeDirLabel, and StartCmdLabel describe the chain node running in a container,
+	// the latter being its command prefixed by the environment variables set for it.
+	// They are only used to print instructions for containers kept by KeepContainersOnFailure.
+	ChainIDLabel  = LabelPrefix + "chain-id"
+	HomeDirLabel  = LabelPrefix + "home-dir"
+	StartCmdLabel = LabelPrefix + "start-cmd"
 )
 
+// RunID is the value of RunIDLabel for all Docker resources created by the current process.
+var RunID = newRunID()
+
+// CleanupLabels returns the labels of a Docker resource created for the named test by the current process,
+// through which DockerSetup cleans it up, along with the labels of every map in extra.
+// Every container, volume, and network created by interchaintest is labeled with them.
+func CleanupLabels(testName string, extra ...map[string]string) map[string]string {
+	labels := map[string]string{
+		CleanupLabel: testName,
+		RunIDLabel:   RunID,
+	}
+	for _, e := range extra {
+		for k, v := range e {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
 // KeepVolumesOnFailure determines whether volumes associated with a test
 // using DockerSetup are retained or deleted following a test failure.
 //
@@ -57,35 +89,190 @@ const (
 // is interchaintest.KeepDockerVolumesOnFailure(bool).
 var KeepVolumesOnFailure = os.Getenv("IBCTEST_SKIP_FAILURE_CLEANUP") != ""
 
+// KeepContainersOnFailure determines whether the containers, volumes, and networks of a failed test
+// using DockerSetup are left running, so that they can be inspected after the test.
+// The docker commands to interact with each kept container are logged instead.
+// Successful tests are always cleaned up.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_KEEP_CONTAINERS_ON_FAILURE to a non-empty value.
+// The public API for setting this value is interchaintest.KeepDockerContainersOnFailure(bool).
+//
+// Kept resources are labeled with the RunID of the process that created them.
+// They are removed by the next DockerSetup call for the same test name in that process, e.g. with -count,
+// or by any DockerSetup once that process has exited.
+var KeepContainersOnFailure = os.Getenv("IBCTEST_KEEP_CONTAINERS_ON_FAILURE") != ""
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, DockerSetup panics because the test cannot continue.
 func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	t.Helper()
+	return DockerSetupWithOptions(t, DockerSetupOptions{})
+}
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+// DockerSetupOptions configure the network created by DockerSetupWithOptions.
+type DockerSetupOptions struct {
+	// Subnet is the network's IPv4 or IPv6 range in CIDR notation, e.g. "10.213.0.0/24".
+	// If empty, Docker allocates a range from its default address pools.
+	Subnet string
+
+	// Gateway is the network's gateway address, which must be within Subnet.
+	// If empty, Docker uses the first address of Subnet. Gateway requires Subnet.
+	Gateway string
+}
+
+// ipam returns the IPAM configuration of the network, or nil to let Docker allocate the subnet.
+func (o DockerSetupOptions) ipam() (*network.IPAM, error) {
+	if o.Subnet == "" {
+		if o.Gateway != "" {
+			return nil, fmt.Errorf("gateway %s requires a subnet", o.Gateway)
+		}
+		return nil, nil
+	}
+
+	_, subnet, err := net.ParseCIDR(o.Subnet)
 	if err != nil {
-		panic(fmt.Errorf("failed to create docker client: %v", err))
+		return nil, fmt.Errorf("invalid subnet: %w", err)
+	}
+	if o.Gateway != "" {
+		gw := net.ParseIP(o.Gateway)
+		if gw == nil {
+			return nil, fmt.Errorf("invalid gateway %q", o.Gateway)
+		}
+		if !subnet.Contains(gw) {
+			return nil, fmt.Errorf("gateway %s is not within subnet %s", o.Gateway, o.Subnet)
+		}
 	}
 
-	// Clean up docker resources at end of test.
-	t.Cleanup(dockerCleanup(t, cli))
+	return &network.IPAM{
+		Config: []network.IPAMConfig{{
+			Subnet:  o.Subnet,
+			Gateway: o.Gateway,
+		}},
+	}, nil
+}
 
-	// Also eagerly clean up any leftover resources from a previous test run,
-	// e.g. if the test was interrupted.
-	dockerCleanup(t, cli)()
+// ipv6 reports whether the network's subnet is an IPv6 range,
+// which Docker only allocates on networks with IPv6 enabled.
+func (o DockerSetupOptions) ipv6() bool {
+	ip, _, err := net.ParseCIDR(o.Subnet)
+	return err == nil && ip.To4() == nil
+}
+
+// DockerSetupWithOptions is like DockerSetup, but creates the network according to opts,
+// e.g. with a subnet that does not overlap the routes of the host, such as those of a VPN.
+// The zero value of opts behaves exactly like DockerSetup.
+//
+// DockerSetupWithOptions panics if opts are invalid or the network cannot be created,
+// which can happen if the subnet overlaps another Docker network.
+func DockerSetupWithOptions(t DockerSetupTestingT, opts DockerSetupOptions) (*client.Client, string) {
+	t.Helper()
+
+	ipam, err := opts.ipam()
+	if err != nil {
+		panic(fmt.Errorf("invalid docker setup options: %v", err))
+	}
+
+	cli := newCleanedUpClient(t)
 
 	name := fmt.Sprintf("interchaintest-%s", RandLowerCaseLetterString(8))
-	network, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
+	res, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
 		CheckDuplicate: true,
+		IPAM:           ipam,
+		EnableIPv6:     opts.ipv6(),
 
-		Labels: map[string]string{CleanupLabel: t.Name()},
+		Labels: CleanupLabels(t.Name()),
 	})
 	if err != nil {
 		panic(fmt.Errorf("failed to create docker network: %v", err))
 	}
 
-	return cli, network.ID
+	return cli, res.ID
+}
+
+// DockerSetupWithNetwork is like DockerSetup, but reuses the existing Docker network with the given name or ID,
+// so that many tests can share one network instead of each creating their own.
+// If network is empty, a new network is created exactly as in DockerSetup.
+//
+// An existing network is never removed during cleanup, as it is not owned by t.
+// DockerSetupWithNetwork panics if the network does not exist.
+func DockerSetupWithNetwork(t DockerSetupTestingT, network string) (*client.Client, string) {
+	t.Helper()
+
+	if network == "" {
+		return DockerSetup(t)
+	}
+
+	cli := newCleanedUpClient(t)
+
+	res, err := cli.NetworkInspect(context.TODO(), network, types.NetworkInspectOptions{})
+	if err != nil {
+		panic(fmt.Errorf("failed to inspect docker network %s: %v", network, err))
+	}
+
+	return cli, res.ID
+}
+
+// newCleanedUpClient returns a new Docker client after removing leftover resources,
+// and registers the cleanup of t's resources once t completes.
+func newCleanedUpClient(t DockerSetupTestingT) *client.Client {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		panic(fmt.Errorf("failed to create docker client: %v", err))
+	}
+
+	// Clean up docker resources at end of test.
+	t.Cleanup(dockerCleanup(t, cli))
+
+	// Also eagerly clean up any leftover resources from a previous run of the test in this process,
+	// e.g. with -count.
+	dockerCleanup(t, cli)()
+
+	// And remove resources of any other test whose process crashed before its cleanup could run.
+	reapOrphanedResources(context.TODO(), t, cli)
+
+	return cli
+}
+
+// keptContainerInstructions describes how to interact with a container kept by KeepContainersOnFailure.
+func keptContainerInstructions(c types.Container) string {
+	name := c.ID
+	if len(c.Names) > 0 {
+		name = strings.TrimPrefix(c.Names[0], "/")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  container: %s (image %s)\n", name, c.Image)
+	if chainID := c.Labels[ChainIDLabel]; chainID != "" {
+		fmt.Fprintf(&b, "  chain id:  %s\n", chainID)
+	}
+	if homeDir := c.Labels[HomeDirLabel]; homeDir != "" {
+		fmt.Fprintf(&b, "  home dir:  %s\n", homeDir)
+	}
+	if startCmd := c.Labels[StartCmdLabel]; startCmd != "" {
+		fmt.Fprintf(&b, "  start cmd: %s\n", startCmd)
+	}
+	for _, p := range c.Ports {
+		if p.PublicPort == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  port %d/%s: %s:%d\n", p.PrivatePort, p.Type, p.IP, p.PublicPort)
+	}
+	fmt.Fprintf(&b, "  shell:     docker exec -it %s sh\n", name)
+	fmt.Fprintf(&b, "  logs:      docker logs -f %s\n", name)
+	fmt.Fprintf(&b, "  remove:    docker rm -f %s", name)
+	return b.String()
+}
+
+// cleanupFilters returns the label filters matching the Docker resources of testName created by the current process.
+// Resources of a test with the same name in another process, e.g. a different package run by the same `go test ./...`,
+// are not matched; those are only removed by reapOrphanedResources once their process exits.
+func cleanupFilters(testName string) []filters.KeyValuePair {
+	return []filters.KeyValuePair{
+		filters.Arg("label", CleanupLabel+"="+testName),
+		filters.Arg("label", RunIDLabel+"="+RunID),
+	}
 }
 
 // dockerCleanup will clean up Docker containers, networks, and the other various config files generated in testing
@@ -97,7 +284,7 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 		cs, err := cli.ContainerList(ctx, types.ContainerListOptions{
 			All: true,
 			Filters: filters.NewArgs(
-				filters.Arg("label", CleanupLabel+"="+t.Name()),
+				cleanupFilters(t.Name())...,
 			),
 		})
 		if err != nil {
@@ -105,6 +292,13 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 			return
 		}
 
+		if KeepContainersOnFailure && t.Failed() {
+			for _, c := range cs {
+				t.Logf("Keeping container after test failure:\n%s", keptContainerInstructions(c))
+			}
+			return
+		}
+
 		for _, c := range cs {
 			stopTimeout := 10 * time.Second
 			deadline := time.Now().Add(stopTimeout)
@@ -167,7 +361,7 @@ func pruneVolumesWithRetry(ctx context.Context, t DockerSetupTestingT, cli *clie
 	var msg string
 	err := retry.Do(
 		func() error {
-			res, err := cli.VolumesPrune(ctx, filters.NewArgs(filters.Arg("label", CleanupLabel+"="+t.Name())))
+			res, err := cli.VolumesPrune(ctx, filters.NewArgs(cleanupFilters(t.Name())...))
 			if err != nil {
 				if errdefs.IsConflict(err) {
 					// Prune is already in progress; try again.
@@ -204,7 +398,7 @@ func pruneNetworksWithRetry(ctx context.Context, t DockerSetupTestingT, cli *cli
 	var deleted []string
 	err := retry.Do(
 		func() error {
-			res, err := cli.NetworksPrune(ctx, filters.NewArgs(filters.Arg("label", CleanupLabel+"="+t.Name())))
+			res, err := cli.NetworksPrune(ctx, filters.NewArgs(cleanupFilters(t.Name())...))
 			if err != nil {
 				if errdefs.IsConflict(err) {
 					// Prune is already in progress; try again.
@@ -0,0 +1,60 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerSetupOptions_IPAM(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		ipam, err := DockerSetupOptions{}.ipam()
+		require.NoError(t, err)
+		require.Nil(t, ipam)
+	})
+
+	t.Run("subnet and gateway", func(t *testing.T) {
+		ipam, err := DockerSetupOptions{Subnet: "10.213.0.0/24", Gateway: "10.213.0.1"}.ipam()
+		require.NoError(t, err)
+		require.Equal(t, []network.IPAMConfig{{Subnet: "10.213.0.0/24", Gateway: "10.213.0.1"}}, ipam.Config)
+	})
+
+	t.Run("subnet only", func(t *testing.T) {
+		ipam, err := DockerSetupOptions{Subnet: "fd00:213::/64"}.ipam()
+		require.NoError(t, err)
+		require.Equal(t, []network.IPAMConfig{{Subnet: "fd00:213::/64"}}, ipam.Config)
+	})
+
+	for _, tt := range []struct {
+		name string
+		opts DockerSetupOptions
+	}{
+		{"gateway without subnet", DockerSetupOptions{Gateway: "10.213.0.1"}},
+		{"invalid subnet", DockerSetupOptions{Subnet: "10.213.0.0"}},
+		{"invalid gateway", DockerSetupOptions{Subnet: "10.213.0.0/24", Gateway: "gateway"}},
+		{"gateway outside subnet", DockerSetupOptions{Subnet: "10.213.0.0/24", Gateway: "10.214.0.1"}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.opts.ipam()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDockerSetupOptions_IPv6(t *testing.T) {
+	require.False(t, DockerSetupOptions{}.ipv6())
+	require.False(t, DockerSetupOptions{Subnet: "10.213.0.0/24"}.ipv6())
+	require.True(t, DockerSetupOptions{Subnet: "fd00:213::/64"}.ipv6())
+}
+
+func TestCleanupLabels(t *testing.T) {
+	require.Equal(t, map[string]string{CleanupLabel: "TestFoo", RunIDLabel: RunID}, CleanupLabels("TestFoo"))
+
+	require.Equal(t, map[string]string{
+		CleanupLabel:   "TestFoo",
+		RunIDLabel:     RunID,
+		NodeOwnerLabel: "gaia-1-val-0",
+	}, CleanupLabels("TestFoo", map[string]string{NodeOwnerLabel: "gaia-1-val-0"}))
+}
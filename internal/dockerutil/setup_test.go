@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
@@ -52,7 +53,7 @@ func TestDockerSetup_KeepVolumes(t *testing.T) {
 				cli, _ := dockerutil.DockerSetup(mt)
 
 				v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
-					Labels: map[string]string{dockerutil.CleanupLabel: mt.Name()},
+					Labels: dockerutil.CleanupLabels(mt.Name()),
 				})
 				require.NoError(t, err)
 
@@ -78,3 +79,48 @@ func TestDockerSetup_KeepVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerSetupWithNetwork_KeepsSharedNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, _ := dockerutil.DockerSetup(t)
+
+	ctx := context.Background()
+
+	// The shared network is created outside of any test, so without the labels DockerSetup cleans up by.
+	res, err := cli.NetworkCreate(ctx, "interchaintest-shared-"+dockerutil.RandLowerCaseLetterString(8), types.NetworkCreate{CheckDuplicate: true})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := cli.NetworkRemove(ctx, res.ID); err != nil {
+			t.Logf("failed to remove network %s: %v", res.ID, err)
+		}
+	})
+
+	// A distinct name, so the simulated test's cleanup cannot match resources of t by its label.
+	mt := mocktesting.NewT(t.Name() + "-sharer")
+	var sharedID string
+	mt.Simulate(func() {
+		_, sharedID = dockerutil.DockerSetupWithNetwork(mt, res.ID)
+	})
+	require.Equal(t, res.ID, sharedID)
+
+	// The simulated test's cleanup has run, and must not have removed a network it did not create.
+	_, err = cli.NetworkInspect(ctx, res.ID, types.NetworkInspectOptions{})
+	require.NoError(t, err)
+}
+
+func TestDockerSetupWithOptions_IPv6(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	cli, networkID := dockerutil.DockerSetupWithOptions(t, dockerutil.DockerSetupOptions{Subnet: "fd00:213::/64"})
+
+	res, err := cli.NetworkInspect(context.Background(), networkID, types.NetworkInspectOptions{})
+	require.NoError(t, err)
+	require.True(t, res.EnableIPv6)
+	require.Len(t, res.IPAM.Config, 1)
+	require.Equal(t, "fd00:213::/64", res.IPAM.Config[0].Subnet)
+}
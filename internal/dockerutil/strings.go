@@ -2,6 +2,7 @@ package dockerutil
 
 import (
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"net"
 	"os"
@@ -98,3 +99,17 @@ var validContainerCharsRE = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
 func SanitizeContainerName(name string) string {
 	return validContainerCharsRE.ReplaceAllLiteralString(name, "_")
 }
+
+// runNameSuffix is a short hash of RunID, safe to use in container names.
+var runNameSuffix = func() string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(RunID))
+	return fmt.Sprintf("%08x", h.Sum32())
+}()
+
+// ContainerNameSuffix returns the suffix for names of containers belonging to testName.
+// Besides the sanitized test name, it includes a short hash of RunID,
+// so tests with the same name in concurrent processes do not create containers with the same name.
+func ContainerNameSuffix(testName string) string {
+	return SanitizeContainerName(testName) + "-" + runNameSuffix
+}
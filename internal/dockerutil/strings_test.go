@@ -86,3 +86,10 @@ func TestSanitizeContainerName(t *testing.T) {
 		require.Equal(t, tt.Want, SanitizeContainerName(tt.Name), tt)
 	}
 }
+
+func TestContainerNameSuffix(t *testing.T) {
+	got := ContainerNameSuffix("TestFoo/bar")
+	require.Equal(t, "TestFoo_bar-"+runNameSuffix, got)
+	require.Len(t, runNameSuffix, 8)
+	require.Equal(t, got, SanitizeContainerName(got))
+}
@@ -0,0 +1,176 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// VolumeCopyOptions contain the configuration for the CopyVolume function.
+type VolumeCopyOptions struct {
+	Log *zap.Logger
+
+	Client *client.Client
+
+	// SrcVolumeName is the volume whose contents are copied.
+	SrcVolumeName string
+	// DstVolumeName is the volume to be overwritten with the contents of SrcVolumeName.
+	// Any existing content in DstVolumeName is removed first.
+	DstVolumeName string
+
+	TestName string
+}
+
+// CopyVolume replaces the contents of one volume with the contents of another,
+// preserving file ownership and modes.
+//
+// Neither volume should be mounted by a running container while the copy is in progress.
+func CopyVolume(ctx context.Context, opts VolumeCopyOptions) error {
+	containerName := fmt.Sprintf("interchaintest-volumecopy-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	if err := ensureBusybox(ctx, opts.Client); err != nil {
+		return err
+	}
+
+	const (
+		srcPath = "/mnt/src"
+		dstPath = "/mnt/dst"
+	)
+	cc, err := opts.Client.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef, // Using busybox image which has find and cp.
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd: []string{
+				`find "$2" -mindepth 1 -delete && cp -a "$1"/. "$2"`,
+				"_", // Meaningless arg0 for sh -c with positional args.
+				srcPath,
+				dstPath,
+			},
+
+			// Root user so we can preserve ownership of every file.
+			User: GetRootUserString(),
+
+			Labels: CleanupLabels(opts.TestName),
+		},
+		&container.HostConfig{
+			Binds: []string{
+				opts.SrcVolumeName + ":" + srcPath + ":ro",
+				opts.DstVolumeName + ":" + dstPath,
+			},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	autoRemoved := false
+	defer func() {
+		if autoRemoved {
+			// No need to attempt removing the container if we successfully started and waited for it to complete.
+			return
+		}
+
+		if err := opts.Client.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			opts.Log.Warn("Failed to remove volume-copy container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := opts.Client.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("starting volume-copy container: %w", err)
+	}
+
+	waitCh, errCh := opts.Client.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		autoRemoved = true
+
+		if res.Error != nil {
+			return fmt.Errorf("waiting for volume-copy container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("copying volume %s to %s exited %d", opts.SrcVolumeName, opts.DstVolumeName, res.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// VolumeSnapshotOptions contain the configuration for the SnapshotVolume and RestoreVolume functions.
+type VolumeSnapshotOptions struct {
+	Log *zap.Logger
+
+	Client *client.Client
+
+	// VolumeName is the volume being checkpointed or restored.
+	VolumeName string
+	// SnapshotName distinguishes multiple snapshots of the same volume.
+	SnapshotName string
+
+	TestName string
+}
+
+// SnapshotVolumeName returns the name of the volume holding the named snapshot of volumeName.
+func SnapshotVolumeName(volumeName, snapshotName string) string {
+	return volumeName + "-snapshot-" + snapshotName
+}
+
+// SnapshotVolume copies the contents of opts.VolumeName into a new volume named
+// according to SnapshotVolumeName.
+// The snapshot volume is labeled with the test name so it is removed during test cleanup.
+func SnapshotVolume(ctx context.Context, opts VolumeSnapshotOptions) error {
+	v, err := opts.Client.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Name: SnapshotVolumeName(opts.VolumeName, opts.SnapshotName),
+
+		Labels: CleanupLabels(opts.TestName),
+	})
+	if err != nil {
+		return fmt.Errorf("creating snapshot volume: %w", err)
+	}
+
+	return CopyVolume(ctx, VolumeCopyOptions{
+		Log:           opts.Log,
+		Client:        opts.Client,
+		SrcVolumeName: opts.VolumeName,
+		DstVolumeName: v.Name,
+		TestName:      opts.TestName,
+	})
+}
+
+// RestoreVolume overwrites the contents of opts.VolumeName
+// with a snapshot previously created by SnapshotVolume.
+func RestoreVolume(ctx context.Context, opts VolumeSnapshotOptions) error {
+	snapshotVolume := SnapshotVolumeName(opts.VolumeName, opts.SnapshotName)
+
+	// Binding a missing volume would silently create an empty one,
+	// so confirm the snapshot exists before copying.
+	if _, err := opts.Client.VolumeInspect(ctx, snapshotVolume); err != nil {
+		return fmt.Errorf("inspecting snapshot volume %s: %w", snapshotVolume, err)
+	}
+
+	return CopyVolume(ctx, VolumeCopyOptions{
+		Log:           opts.Log,
+		Client:        opts.Client,
+		SrcVolumeName: snapshotVolume,
+		DstVolumeName: opts.VolumeName,
+		TestName:      opts.TestName,
+	})
+}
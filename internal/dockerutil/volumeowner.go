@@ -3,6 +3,7 @@ package dockerutil
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -55,7 +56,7 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 			// Root user so we have permissions to set ownership and mode.
 			User: GetRootUserString(),
 
-			Labels: map[string]string{CleanupLabel: opts.TestName},
+			Labels: CleanupLabels(opts.TestName),
 		},
 		&container.HostConfig{
 			Binds:      []string{opts.VolumeName + ":" + mountPath},
@@ -107,3 +108,43 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 
 	return nil
 }
+
+// CheckVolumeWritable returns an error if containers of imageRef, running as the image's default user,
+// cannot write to a volume set up by SetVolumeOwner for uidGid.
+func CheckVolumeWritable(ctx context.Context, cli *client.Client, imageRef, uidGid string) error {
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, imageRef)
+	if err != nil {
+		return fmt.Errorf("inspecting image %s: %w", imageRef, err)
+	}
+	var imageUser string
+	if inspect.Config != nil {
+		imageUser = inspect.Config.User
+	}
+	return checkVolumeWritable(imageUser, uidGid)
+}
+
+// checkVolumeWritable returns an error if imageUser, in the form of a Dockerfile USER,
+// cannot write to a volume owned by uidGid with mode 0700.
+// Named users cannot be resolved without running the image, so they are assumed to match.
+func checkVolumeWritable(imageUser, uidGid string) error {
+	if uidGid == "" {
+		uidGid = GetRootUserString()
+	}
+	uid, _, _ := strings.Cut(imageUser, ":")
+	switch uid {
+	case "", "0", "root":
+		return nil
+	}
+	if strings.Trim(uid, "0123456789") != "" {
+		return nil
+	}
+
+	owner, _, _ := strings.Cut(uidGid, ":")
+	if uid == owner {
+		return nil
+	}
+	return fmt.Errorf(
+		"image runs as user %s but its home directory volume is owned by %s, so it cannot write to it; "+
+			"set the DockerImage UidGid to the image's user", imageUser, uidGid,
+	)
+}
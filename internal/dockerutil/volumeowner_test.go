@@ -0,0 +1,29 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVolumeWritable(t *testing.T) {
+	for _, tt := range []struct {
+		imageUser, uidGid string
+		ok                bool
+	}{
+		{imageUser: "", uidGid: "", ok: true},
+		{imageUser: "root", uidGid: "1025:1025", ok: true},
+		{imageUser: "1025:1025", uidGid: "1025:1025", ok: true},
+		{imageUser: "1025", uidGid: "1025:1025", ok: true},
+		{imageUser: "heighliner", uidGid: "", ok: true},
+		{imageUser: "1025:1025", uidGid: "", ok: false},
+		{imageUser: "1000:1000", uidGid: "1025:1025", ok: false},
+	} {
+		err := checkVolumeWritable(tt.imageUser, tt.uidGid)
+		if tt.ok {
+			require.NoError(t, err, "image user %q, volume owner %q", tt.imageUser, tt.uidGid)
+		} else {
+			require.ErrorContains(t, err, "cannot write", "image user %q, volume owner %q", tt.imageUser, tt.uidGid)
+		}
+	}
+}
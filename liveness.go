@@ -0,0 +1,165 @@
+package interchaintest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"go.uber.org/zap"
+)
+
+// LivenessFailer is what the liveness watchdog fails the test through when a chain halts,
+// and registers the watchdog's stop with, so it stops with the test at the latest.
+// Typically this is t itself.
+type LivenessFailer interface {
+	Errorf(format string, args ...any)
+	Cleanup(func())
+}
+
+// minLivenessPollInterval and maxLivenessPollInterval bound how often the liveness watchdog queries the height of each chain.
+const (
+	minLivenessPollInterval = time.Millisecond
+	maxLivenessPollInterval = time.Second
+)
+
+// livenessWatchdog fails the test if the height of any chain does not advance within timeout.
+// It is started by Build when InterchainBuildOptions.LivenessTimeout is set and stopped by Close,
+// when Build fails or when the test ends.
+type livenessWatchdog struct {
+	log     *zap.Logger
+	fail    LivenessFailer
+	timeout time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// paused counts the operations halting the chains on purpose, e.g. Stop, during which stalls are not failures.
+	paused int
+	// resumedAt is when the watchdog was last resumed, before which no chain is considered stalled.
+	resumedAt time.Time
+}
+
+// startLivenessWatchdog starts watching the height of every chain, keyed by the name used in failures.
+func startLivenessWatchdog(log *zap.Logger, chains map[ibc.Chain]string, timeout time.Duration, fail LivenessFailer) *livenessWatchdog {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &livenessWatchdog{
+		log:       log,
+		fail:      fail,
+		timeout:   timeout,
+		cancel:    cancel,
+		resumedAt: time.Now(),
+	}
+
+	interval := timeout / 4
+	if interval > maxLivenessPollInterval {
+		interval = maxLivenessPollInterval
+	} else if interval < minLivenessPollInterval {
+		interval = minLivenessPollInterval
+	}
+	for c, name := range chains {
+		c, name := c, name
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.watch(ctx, c, name, interval)
+		}()
+	}
+	return w
+}
+
+// watch polls the height of c until ctx is done, failing the test once per stall.
+func (w *livenessWatchdog) watch(ctx context.Context, c ibc.Chain, name string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		height   uint64
+		since    = time.Now()
+		reported bool
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// A halted chain may not answer at all, so each query is bounded by the poll interval.
+		hctx, cancel := context.WithTimeout(ctx, interval)
+		h, err := c.Height(hctx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+		if err == nil && h != height {
+			// The height also changes backwards, e.g. after Restore, which is progress as well.
+			height, since, reported = h, now, false
+			continue
+		}
+
+		w.mu.Lock()
+		if w.paused > 0 || since.Before(w.resumedAt) {
+			// Time spent paused does not count towards a stall.
+			if w.paused > 0 {
+				since = now
+			} else {
+				since = w.resumedAt
+			}
+			w.mu.Unlock()
+			continue
+		}
+		w.mu.Unlock()
+
+		if reported || now.Sub(since) < w.timeout {
+			continue
+		}
+		reported = true
+		if err != nil {
+			w.log.Error("Chain halted", zap.String("chain", name), zap.Uint64("height", height), zap.Error(err))
+			w.fail.Errorf("liveness watchdog: chain %s halted: height %d has not advanced in %s (last height query failed: %v)",
+				name, height, w.timeout, err)
+		} else {
+			w.log.Error("Chain halted", zap.String("chain", name), zap.Uint64("height", height))
+			w.fail.Errorf("liveness watchdog: chain %s halted: height %d has not advanced in %s",
+				name, height, w.timeout)
+		}
+	}
+}
+
+// pause stops stalls from failing the test until the matching call to resume.
+// Both are no-ops on a nil watchdog, i.e. when it is off.
+func (w *livenessWatchdog) pause() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.paused++
+}
+
+// resume undoes a call to pause. The chains then have the full timeout to advance again.
+func (w *livenessWatchdog) resume() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.paused > 0 {
+		w.paused--
+	}
+	w.resumedAt = time.Now()
+}
+
+// stop stops the watchdog and waits for it to return, so the test is not failed after stop returns.
+// It may be called more than once.
+func (w *livenessWatchdog) stop() {
+	if w == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
@@ -0,0 +1,151 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// heightChain is a chain whose height advances on every query until it is halted.
+type heightChain struct {
+	ibc.Chain // Unimplemented methods panic.
+
+	height uint64
+	halted int32
+}
+
+func (c *heightChain) Height(ctx context.Context) (uint64, error) {
+	if atomic.LoadInt32(&c.halted) == 1 {
+		return atomic.LoadUint64(&c.height), nil
+	}
+	return atomic.AddUint64(&c.height, 1), nil
+}
+
+func (c *heightChain) halt() {
+	atomic.StoreInt32(&c.halted, 1)
+}
+
+// recordingFailer records the failures of the liveness watchdog.
+type recordingFailer struct {
+	mu       sync.Mutex
+	failures []string
+}
+
+func (f *recordingFailer) Errorf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+// Cleanup does nothing, as the watchdogs of these tests are stopped by the tests themselves.
+func (f *recordingFailer) Cleanup(func()) {}
+
+func (f *recordingFailer) Failures() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.failures...)
+}
+
+func TestLivenessWatchdog(t *testing.T) {
+	const timeout = 200 * time.Millisecond
+
+	t.Run("halted chain fails once", func(t *testing.T) {
+		live, halted := new(heightChain), new(heightChain)
+		halted.halt()
+		fail := new(recordingFailer)
+
+		w := startLivenessWatchdog(zap.NewNop(), map[ibc.Chain]string{live: "live-1", halted: "halted-1"}, timeout, fail)
+		require.Eventually(t, func() bool { return len(fail.Failures()) > 0 }, 5*time.Second, 10*time.Millisecond)
+		time.Sleep(2 * timeout)
+		w.stop()
+
+		failures := fail.Failures()
+		require.Len(t, failures, 1)
+		require.Contains(t, failures[0], "chain halted-1 halted: height 0 has not advanced in 200ms")
+	})
+
+	t.Run("tiny timeout", func(t *testing.T) {
+		c := new(heightChain)
+		fail := new(recordingFailer)
+
+		// A quarter of the timeout rounds to no interval at all, which the poll interval is clamped up from.
+		w := startLivenessWatchdog(zap.NewNop(), map[ibc.Chain]string{c: "a-1"}, time.Nanosecond, fail)
+		require.Eventually(t, func() bool { return atomic.LoadUint64(&c.height) > 1 }, 5*time.Second, time.Millisecond)
+		w.stop()
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		c := new(heightChain)
+		fail := new(recordingFailer)
+
+		w := startLivenessWatchdog(zap.NewNop(), map[ibc.Chain]string{c: "a-1"}, timeout, fail)
+		defer w.stop()
+
+		w.pause()
+		c.halt()
+		time.Sleep(3 * timeout)
+		require.Empty(t, fail.Failures())
+
+		// Once resumed, the chain has the full timeout again before it is considered halted.
+		w.resume()
+		require.Empty(t, fail.Failures())
+		require.Eventually(t, func() bool { return len(fail.Failures()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("PauseLiveness", func(t *testing.T) {
+		c := new(heightChain)
+		fail := new(recordingFailer)
+
+		w := startLivenessWatchdog(zap.NewNop(), map[ibc.Chain]string{c: "a-1"}, timeout, fail)
+		defer w.stop()
+		ic := &Interchain{liveness: w}
+
+		resume := ic.PauseLiveness()
+		c.halt()
+		time.Sleep(3 * timeout)
+		require.Empty(t, fail.Failures())
+
+		// Resuming more than once, e.g. also in a deferred call, undoes a single pause.
+		resume()
+		resume()
+		require.Eventually(t, func() bool { return len(fail.Failures()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		c := new(heightChain)
+		c.halt()
+		fail := new(recordingFailer)
+
+		w := startLivenessWatchdog(zap.NewNop(), map[ibc.Chain]string{c: "a-1"}, timeout, fail)
+		w.stop()
+		time.Sleep(2 * timeout)
+		require.Empty(t, fail.Failures())
+	})
+
+	t.Run("off", func(t *testing.T) {
+		var w *livenessWatchdog
+		w.pause()
+		w.resume()
+		w.stop()
+		_ = (&Interchain{}).PauseLiveness()
+	})
+}
+
+func TestInterchain_BuildLivenessOptions(t *testing.T) {
+	ctx := context.Background()
+
+	ic := NewInterchain()
+	err := ic.Build(ctx, nil, InterchainBuildOptions{LivenessTimeout: time.Minute})
+	require.ErrorContains(t, err, "without a LivenessFailer")
+
+	ic = NewInterchain()
+	err = ic.Build(ctx, nil, InterchainBuildOptions{LivenessTimeout: -time.Minute, LivenessFailer: t})
+	require.ErrorContains(t, err, "must not be negative")
+}
@@ -0,0 +1,139 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// NetworkConditions describes degraded network behavior injected into a chain's node containers
+// with tc netem, to reproduce production-like relayer timing.
+//
+// Conditions apply to all traffic leaving the nodes, so they affect both traffic between the nodes
+// and the responses to relayer queries and transactions.
+type NetworkConditions struct {
+	// Latency added to every outgoing packet.
+	Latency time.Duration
+	// Jitter varies Latency randomly by up to this amount. Requires a non-zero Latency.
+	Jitter time.Duration
+	// PacketLoss is the percentage, from 0 to 100, of outgoing packets dropped.
+	PacketLoss float64
+}
+
+// Validate returns an error if the conditions cannot be applied.
+func (nc NetworkConditions) Validate() error {
+	if nc.Latency < 0 || nc.Jitter < 0 {
+		return fmt.Errorf("latency and jitter must not be negative")
+	}
+	if nc.Jitter > 0 && nc.Latency == 0 {
+		return fmt.Errorf("jitter requires a non-zero latency")
+	}
+	if nc.PacketLoss < 0 || nc.PacketLoss > 100 {
+		return fmt.Errorf("packet loss must be between 0 and 100 percent, got %v", nc.PacketLoss)
+	}
+	return nil
+}
+
+// netemArgs returns the tc netem arguments for the conditions.
+func (nc NetworkConditions) netemArgs() []string {
+	// Without any argument, netem installs a no-op discipline.
+	args := []string{}
+	if nc.Latency > 0 {
+		args = append(args, "delay", netemTime(nc.Latency))
+		if nc.Jitter > 0 {
+			args = append(args, netemTime(nc.Jitter))
+		}
+	}
+	if nc.PacketLoss > 0 {
+		args = append(args, "loss", strconv.FormatFloat(nc.PacketLoss, 'f', -1, 64)+"%")
+	}
+	return args
+}
+
+// netemTime formats d in microseconds, the finest unit tc accepts.
+func netemTime(d time.Duration) string {
+	return strconv.FormatInt(d.Microseconds(), 10) + "us"
+}
+
+// SetNetworkConditions applies the conditions to every running node container of the chain,
+// replacing any conditions previously applied.
+//
+// Only chains labeling their containers with a chain ID, currently cosmos chains, are affected.
+// The conditions are lost when a node container restarts, and disappear with the containers on cleanup.
+// SetNetworkConditions must be called after Build.
+func (ic *Interchain) SetNetworkConditions(ctx context.Context, chain ibc.Chain, nc NetworkConditions) error {
+	if err := nc.Validate(); err != nil {
+		return fmt.Errorf("invalid network conditions: %w", err)
+	}
+	return ic.setNetem(ctx, chain, nc.netemArgs())
+}
+
+// ClearNetworkConditions removes any conditions applied to the chain's node containers.
+func (ic *Interchain) ClearNetworkConditions(ctx context.Context, chain ibc.Chain) error {
+	return ic.setNetem(ctx, chain, nil)
+}
+
+func (ic *Interchain) setNetem(ctx context.Context, chain ibc.Chain, netemArgs []string) error {
+	if !ic.built {
+		return fmt.Errorf("network conditions changed before Build")
+	}
+
+	chainID := chain.Config().ChainID
+	ids, err := dockerutil.ContainersWithLabel(ctx, ic.dockerClient, ic.testName, dockerutil.ChainIDLabel, chainID)
+	if err != nil {
+		return err
+	}
+
+	var eg errgroup.Group
+	for _, id := range ids {
+		id := id
+		eg.Go(func() error {
+			return dockerutil.SetNetem(ctx, ic.log, ic.dockerClient, ic.testName, id, netemArgs)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("setting network conditions for chain %s: %w", chainID, err)
+	}
+	return nil
+}
+
+// buildNetworkConditions resolves the conditions for each chain from the build options and links.
+// Link conditions take precedence over the global conditions,
+// and a chain in several links must be given the same conditions by each of them.
+func (ic *Interchain) buildNetworkConditions(global *NetworkConditions) (map[ibc.Chain]NetworkConditions, error) {
+	out := make(map[ibc.Chain]NetworkConditions)
+	if global != nil {
+		for c := range ic.chains {
+			out[c] = *global
+		}
+	}
+
+	fromLink := make(map[ibc.Chain]string)
+	for rp, link := range ic.links {
+		if link.networkConditions == nil {
+			continue
+		}
+		for _, c := range link.chains {
+			if path, ok := fromLink[c]; ok && out[c] != *link.networkConditions {
+				return nil, fmt.Errorf(
+					"chain %s has conflicting network conditions in paths %s and %s",
+					ic.chains[c], path, rp.Path,
+				)
+			}
+			out[c] = *link.networkConditions
+			fromLink[c] = rp.Path
+		}
+	}
+
+	for c, nc := range out {
+		if err := nc.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid network conditions for chain %s: %w", ic.chains[c], err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package interchaintest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkConditions_NetemArgs(t *testing.T) {
+	nc := NetworkConditions{Latency: 100 * time.Millisecond, Jitter: 1500 * time.Microsecond, PacketLoss: 0.5}
+	require.NoError(t, nc.Validate())
+	require.Equal(t, []string{"delay", "100000us", "1500us", "loss", "0.5%"}, nc.netemArgs())
+
+	require.Equal(t, []string{"loss", "10%"}, NetworkConditions{PacketLoss: 10}.netemArgs())
+	require.Empty(t, NetworkConditions{}.netemArgs())
+
+	require.ErrorContains(t, NetworkConditions{Jitter: time.Millisecond}.Validate(), "non-zero latency")
+	require.ErrorContains(t, NetworkConditions{PacketLoss: 101}.Validate(), "between 0 and 100")
+	require.Error(t, NetworkConditions{Latency: -time.Second}.Validate())
+}
+
+func TestInterchain_BuildNetworkConditions(t *testing.T) {
+	a := &readyChain{name: "a"}
+	b := &readyChain{name: "b"}
+	c := &readyChain{name: "c"}
+
+	global := NetworkConditions{Latency: time.Millisecond}
+	slow := NetworkConditions{Latency: time.Second}
+
+	ic := NewInterchain()
+	ic.chains = map[ibc.Chain]string{a: "a", b: "b", c: "c"}
+	ic.links = map[relayerPath]interchainLink{
+		{Path: "ab"}: {chains: [2]ibc.Chain{a, b}, networkConditions: &slow},
+		{Path: "bc"}: {chains: [2]ibc.Chain{b, c}},
+	}
+
+	got, err := ic.buildNetworkConditions(&global)
+	require.NoError(t, err)
+	require.Equal(t, map[ibc.Chain]NetworkConditions{a: slow, b: slow, c: global}, got)
+
+	got, err = ic.buildNetworkConditions(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[ibc.Chain]NetworkConditions{a: slow, b: slow}, got)
+
+	ic.links[relayerPath{Path: "bc"}] = interchainLink{chains: [2]ibc.Chain{b, c}, networkConditions: &global}
+	_, err = ic.buildNetworkConditions(nil)
+	require.ErrorContains(t, err, "conflicting network conditions")
+}
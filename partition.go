@@ -0,0 +1,104 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"go.uber.org/multierr"
+)
+
+// networkPartition records the containers moved off the interchain's network by PartitionNetwork.
+type networkPartition struct {
+	networkID  string
+	containers []string
+}
+
+// PartitionNetwork cuts network connectivity between two groups of containers,
+// identified by container name or ID, e.g. (*cosmos.ChainNode).Name().
+//
+// The containers in groupB are moved to a separate Docker network, isolating them from groupA.
+// Containers in neither group, such as relayers and one-off command containers,
+// stay on the original network and can only reach groupA.
+// Validators on either side of the partition stop producing blocks together
+// once neither side holds more than two thirds of the voting power.
+//
+// Every container of both groups must be connected to the interchain's network.
+// Only one partition may be active at a time; call HealNetwork to restore connectivity.
+// PartitionNetwork must be called after Build.
+func (ic *Interchain) PartitionNetwork(ctx context.Context, groupA, groupB []string) error {
+	if !ic.built {
+		return fmt.Errorf("PartitionNetwork called before Build")
+	}
+	if ic.partition != nil {
+		return fmt.Errorf("network is already partitioned")
+	}
+	if len(groupA) == 0 || len(groupB) == 0 {
+		return fmt.Errorf("both partition groups must be non-empty")
+	}
+
+	inA := make(map[string]bool, len(groupA))
+	for _, c := range groupA {
+		inA[c] = true
+	}
+	for _, c := range groupB {
+		if inA[c] {
+			return fmt.Errorf("container %s is in both partition groups", c)
+		}
+	}
+
+	// A misspelled container in either group would otherwise silently stay connected to the other side.
+	for _, group := range [][]string{groupA, groupB} {
+		for _, c := range group {
+			ok, err := dockerutil.ContainerOnNetwork(ctx, ic.dockerClient, c, ic.networkID)
+			if err != nil {
+				return fmt.Errorf("partitioning network: %w", err)
+			}
+			if !ok {
+				return fmt.Errorf("container %s is not on the interchain network", c)
+			}
+		}
+	}
+
+	name := fmt.Sprintf("interchaintest-partition-%s", dockerutil.RandLowerCaseLetterString(8))
+	networkID, err := dockerutil.CreateTestNetwork(ctx, ic.dockerClient, name, ic.testName)
+	if err != nil {
+		return err
+	}
+
+	p := &networkPartition{networkID: networkID}
+	ic.partition = p
+	for _, c := range groupB {
+		if err := dockerutil.MoveContainerNetwork(ctx, ic.dockerClient, c, ic.networkID, networkID); err != nil {
+			return fmt.Errorf("partitioning network: %w", err)
+		}
+		p.containers = append(p.containers, c)
+	}
+
+	return nil
+}
+
+// HealNetwork reverses PartitionNetwork, moving every partitioned container back to the original network
+// and removing the partition network.
+// Nodes reconnect to their persistent peers on their own, so block production resumes shortly after.
+func (ic *Interchain) HealNetwork(ctx context.Context) error {
+	p := ic.partition
+	if p == nil {
+		return fmt.Errorf("network is not partitioned")
+	}
+
+	var err error
+	for _, c := range p.containers {
+		err = multierr.Append(err, dockerutil.MoveContainerNetwork(ctx, ic.dockerClient, c, p.networkID, ic.networkID))
+	}
+	if err != nil {
+		return fmt.Errorf("healing network: %w", err)
+	}
+
+	if err := ic.dockerClient.NetworkRemove(ctx, p.networkID); err != nil {
+		return fmt.Errorf("removing partition network: %w", err)
+	}
+
+	ic.partition = nil
+	return nil
+}
@@ -4,20 +4,22 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"path"
 	"strings"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -41,12 +43,27 @@ type DockerRelayer struct {
 	customImage *ibc.DockerImage
 	pullImage   bool
 
+	// imageVersion, if set, replaces the version of the default or custom image.
+	imageVersion string
+
 	// The ID of the container created by StartRelayer.
 	containerID string
+	// The paths passed to StartRelayer, reused by RestartRelayer.
+	pathNames []string
+	// Whether the container created by StartRelayer has not been stopped yet.
+	running bool
 
 	// wallets contains a mapping of chainID to relayer wallet
 	wallets map[string]ibc.Wallet
 
+	// srcChainIDs maps the paths created by GeneratePath to their source chain IDs,
+	// so that CreateChannel can find the channel it opened if the relayer output does not identify it.
+	srcChainIDs map[string]string
+
+	// instanceID distinguishes multiple relayers of the same kind within one test,
+	// so that their containers and reported execs do not collide.
+	instanceID string
+
 	homeDir string
 }
 
@@ -68,6 +85,10 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		testName: testName,
 
 		wallets: map[string]ibc.Wallet{},
+
+		srcChainIDs: map[string]string{},
+
+		instanceID: dockerutil.RandLowerCaseLetterString(5),
 	}
 
 	r.homeDir = defaultRlyHomeDirectory
@@ -76,6 +97,8 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		switch o := opt.(type) {
 		case RelayerOptionDockerImage:
 			r.customImage = &o.DockerImage
+		case RelayerOptionImageVersion:
+			r.imageVersion = o.Version
 		case RelayerOptionImagePull:
 			r.pullImage = o.Pull
 		case RelayerOptionHomeDir:
@@ -91,7 +114,7 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 	v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
 		// Have to leave Driver unspecified for Docker Desktop compatibility.
 
-		Labels: map[string]string{dockerutil.CleanupLabel: testName},
+		Labels: dockerutil.CleanupLabels(testName),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume: %w", err)
@@ -142,6 +165,100 @@ func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath str
 	return nil
 }
 
+// DumpConfig returns the relayer's configuration file, as generated by Build and the path commands,
+// so that tests can check how chains and paths were wired.
+// The values of any mnemonic, private key or secret settings are redacted.
+func (r *DockerRelayer) DumpConfig(ctx context.Context) ([]byte, error) {
+	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
+	config, err := fr.SingleFileContent(ctx, r.volumeName, r.c.ConfigPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s config: %w", r.c.Name(), err)
+	}
+	return redactConfig(r.c.ConfigPath(), config)
+}
+
+// redactedConfigKeys are the substrings of YAML or TOML setting names whose values are redacted by DumpConfig.
+var redactedConfigKeys = []string{"mnemonic", "private", "priv_key", "secret", "password"}
+
+// isRedactedConfigKey reports whether the value of the setting named key is redacted by DumpConfig.
+func isRedactedConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range redactedConfigKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactConfig replaces the values of the secret settings in the config at configPath with "<redacted>".
+// The config is parsed, as TOML if configPath has a .toml extension and as YAML otherwise,
+// so that multiline and nested values are redacted in full.
+func redactConfig(configPath string, config []byte) ([]byte, error) {
+	if path.Ext(configPath) == ".toml" {
+		var doc map[string]any
+		if err := toml.Unmarshal(config, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		redactTOML(doc)
+
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", configPath, err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(config, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	redactYAML(&doc)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", configPath, err)
+	}
+	return out, nil
+}
+
+// redactYAML redacts the secret settings in the YAML node n and the nodes nested under it.
+func redactYAML(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode {
+		// Mapping nodes hold their keys and values alternately.
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if isRedactedConfigKey(n.Content[i].Value) {
+				n.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "<redacted>"}
+			}
+		}
+	}
+	for _, c := range n.Content {
+		redactYAML(c)
+	}
+}
+
+// redactTOML redacts the secret settings in the decoded TOML value v and the tables nested under it.
+func redactTOML(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, value := range t {
+			if isRedactedConfigKey(key) {
+				t[key] = "<redacted>"
+				continue
+			}
+			redactTOML(value)
+		}
+	case []map[string]any:
+		for _, table := range t {
+			redactTOML(table)
+		}
+	case []any:
+		for _, value := range t {
+			redactTOML(value)
+		}
+	}
+}
+
 // AddWallet adds a stores a wallet for the given chain ID.
 func (r *DockerRelayer) AddWallet(chainID string, wallet ibc.Wallet) {
 	r.wallets[chainID] = wallet
@@ -201,22 +318,93 @@ func (r *DockerRelayer) GetWallet(chainID string) (ibc.Wallet, bool) {
 	return wallet, ok
 }
 
-func (r *DockerRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+// CreateChannel opens a channel on the path and returns its IDs, parsed from the relayer output.
+// If they cannot be parsed from the output of a path created by GeneratePath,
+// the channel is identified as the new one on the source port instead.
+func (r *DockerRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) (ibc.CreatedChannel, error) {
+	if err := opts.Validate(); err != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("invalid channel options: %w", err)
+	}
+
+	srcChainID, knownPath := r.srcChainIDs[pathName]
+	var before []ibc.ChannelOutput
+	if knownPath {
+		var err error
+		if before, err = r.GetChannels(ctx, rep, srcChainID); err != nil {
+			return ibc.CreatedChannel{}, fmt.Errorf("failed to get channels on source chain: %w", err)
+		}
+	}
+
 	cmd := r.c.CreateChannel(pathName, opts, r.HomeDir())
 	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+	if res.Err != nil {
+		return ibc.CreatedChannel{}, res.Err
+	}
+
+	channel, err := r.c.ParseCreateChannelOutput(string(res.Stdout), string(res.Stderr))
+	if err == nil {
+		return channel, nil
+	}
+	if !knownPath {
+		return ibc.CreatedChannel{}, fmt.Errorf("channel created on path %s, but its IDs could not be determined: %w", pathName, err)
+	}
+
+	created, newErr := r.NewChannel(ctx, rep, srcChainID, opts.SourcePortName, before)
+	if newErr != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("channel created on path %s, but its IDs could not be determined: %v; %w", pathName, err, newErr)
+	}
+	return created, nil
+}
+
+// NewChannel returns the IDs of the only channel on portID of chainID that is absent from before,
+// the channels of chainID before the channel was created.
+// It identifies a newly opened channel when the relayer output does not.
+func (r *DockerRelayer) NewChannel(ctx context.Context, rep ibc.RelayerExecReporter, chainID, portID string, before []ibc.ChannelOutput) (ibc.CreatedChannel, error) {
+	after, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+	channel, err := ibc.NewChannelOnPort(before, after, portID)
+	if err != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("on %s: %w", chainID, err)
+	}
+	return ibc.CreatedChannel{ChannelID: channel.ChannelID, CounterpartyChannelID: channel.Counterparty.ChannelID}, nil
 }
 
-func (r *DockerRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
+// CreateClients creates clients on both ends of the path and returns their IDs, parsed from the relayer output.
+// The IDs are empty if the relayer output does not identify them.
+func (r *DockerRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) (ibc.CreatedClients, error) {
 	cmd := r.c.CreateClients(pathName, opts, r.HomeDir())
 	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+	if res.Err != nil {
+		return ibc.CreatedClients{}, res.Err
+	}
+
+	clients, err := r.c.ParseCreateClientsOutput(string(res.Stdout), string(res.Stderr))
+	if err != nil {
+		// The clients were created, so only callers using their IDs need to look them up.
+		r.log.Warn("Failed to parse created client IDs from relayer output", zap.String("path_name", pathName), zap.Error(err))
+		return ibc.CreatedClients{}, nil
+	}
+	return clients, nil
 }
 
-func (r *DockerRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+// CreateConnections opens a connection on the path and returns its IDs, parsed from the relayer output.
+// The IDs are empty if the relayer output does not identify them.
+func (r *DockerRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (ibc.CreatedConnection, error) {
 	cmd := r.c.CreateConnections(pathName, r.HomeDir())
 	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+	if res.Err != nil {
+		return ibc.CreatedConnection{}, res.Err
+	}
+
+	conn, err := r.c.ParseCreateConnectionsOutput(string(res.Stdout), string(res.Stderr))
+	if err != nil {
+		// The connection was opened, so only callers using its IDs need to look them up.
+		r.log.Warn("Failed to parse created connection IDs from relayer output", zap.String("path_name", pathName), zap.Error(err))
+		return ibc.CreatedConnection{}, nil
+	}
+	return conn, nil
 }
 
 func (r *DockerRelayer) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
@@ -231,13 +419,26 @@ func (r *DockerRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecRep
 	return res.Err
 }
 
+// RelayPacket always fails, as relaying a single packet depends on the relayer implementation.
+func (r *DockerRelayer) RelayPacket(ctx context.Context, rep ibc.RelayerExecReporter, src ibc.ChannelRef, sequence uint64) error {
+	return fmt.Errorf("%s: %w", r.c.Name(), ibc.ErrSingleSequenceUnsupported)
+}
+
 func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
 	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.HomeDir())
 	res := r.Exec(ctx, rep, cmd, nil)
-	return res.Err
+	if res.Err != nil {
+		return res.Err
+	}
+	r.srcChainIDs[pathName] = srcChainID
+	return nil
 }
 
 func (r *DockerRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, filter ibc.ChannelFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
 	cmd := r.c.UpdatePath(pathName, r.HomeDir(), filter)
 	res := r.Exec(ctx, rep, cmd, nil)
 	return res.Err
@@ -284,6 +485,39 @@ func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporte
 	return res.Err
 }
 
+func (r *DockerRelayer) LinkPathOverConnection(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, conn ibc.PathConnection, channelOpts ibc.CreateChannelOptions) error {
+	if err := conn.Validate(); err != nil {
+		return err
+	}
+
+	cmd := r.c.UsePathConnection(pathName, r.HomeDir(), conn)
+	if res := r.Exec(ctx, rep, cmd, nil); res.Err != nil {
+		return res.Err
+	}
+
+	_, err := r.CreateChannel(ctx, rep, pathName, channelOpts)
+	return err
+}
+
+// LinkPathOverClients implements ibc.ClientLinker.
+func (r *DockerRelayer) LinkPathOverClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcClientID, dstClientID string, channelOpts ibc.CreateChannelOptions) error {
+	if srcClientID == "" || dstClientID == "" {
+		return fmt.Errorf("client IDs must be set on both ends of path %s", pathName)
+	}
+
+	cmd := r.c.UsePathClients(pathName, r.HomeDir(), srcClientID, dstClientID)
+	if res := r.Exec(ctx, rep, cmd, nil); res.Err != nil {
+		return res.Err
+	}
+
+	if _, err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+
+	_, err := r.CreateChannel(ctx, rep, pathName, channelOpts)
+	return err
+}
+
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
 	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.containerImage().Repository, r.containerImage().Version)
 	opts := dockerutil.ContainerOptions{
@@ -328,7 +562,7 @@ func (r *DockerRelayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecRepor
 
 	addrBytes := r.c.ParseRestoreKeyOutput(string(res.Stdout), string(res.Stderr))
 
-	r.wallets[chainID] = r.c.CreateWallet("", addrBytes, mnemonic)
+	r.wallets[chainID] = r.c.CreateWallet(keyName, addrBytes, mnemonic)
 
 	return nil
 }
@@ -340,13 +574,41 @@ func (r *DockerRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecRe
 }
 
 func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
-	return r.createNodeContainer(ctx, pathNames...)
+	r.pathNames = pathNames
+	if err := r.createNodeContainer(ctx, pathNames...); err != nil {
+		return err
+	}
+	r.running = true
+	return nil
+}
+
+// RunningPaths returns the paths the relayer was started on, or nil if it is not running.
+func (r *DockerRelayer) RunningPaths() []string {
+	if !r.running {
+		return nil
+	}
+	return r.pathNames
+}
+
+// RestartRelayer stops the running relayer container and starts a new one on the same paths.
+// Chains, paths, and keys live in the relayer's home volume, so they survive the restart.
+func (r *DockerRelayer) RestartRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if r.containerID == "" {
+		return fmt.Errorf("relayer %s was never started", r.Name())
+	}
+
+	if err := r.StopRelayer(ctx, rep); err != nil {
+		return fmt.Errorf("failed to stop relayer %s: %w", r.Name(), err)
+	}
+
+	return r.StartRelayer(ctx, rep, r.pathNames...)
 }
 
 func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
 	if err := r.stopContainer(ctx); err != nil {
 		return err
 	}
+	r.running = false
 
 	stdoutBuf := new(bytes.Buffer)
 	stderrBuf := new(bytes.Buffer)
@@ -409,15 +671,72 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 	})
 }
 
-func (r *DockerRelayer) containerImage() ibc.DockerImage {
-	if r.customImage != nil {
-		return *r.customImage
+// Snapshot copies the relayer's home volume into a snapshot volume identified by name.
+// The relayer must be stopped, so that its state is not modified during the copy.
+func (r *DockerRelayer) Snapshot(ctx context.Context, name string) error {
+	if err := r.ensureStopped(ctx); err != nil {
+		return err
+	}
+
+	return dockerutil.SnapshotVolume(ctx, dockerutil.VolumeSnapshotOptions{
+		Log:          r.log,
+		Client:       r.client,
+		VolumeName:   r.volumeName,
+		SnapshotName: name,
+		TestName:     r.testName,
+	})
+}
+
+// Restore replaces the relayer's home volume with the snapshot
+// previously created by Snapshot with the same name.
+// The relayer must be stopped.
+func (r *DockerRelayer) Restore(ctx context.Context, name string) error {
+	if err := r.ensureStopped(ctx); err != nil {
+		return err
+	}
+
+	return dockerutil.RestoreVolume(ctx, dockerutil.VolumeSnapshotOptions{
+		Log:          r.log,
+		Client:       r.client,
+		VolumeName:   r.volumeName,
+		SnapshotName: name,
+		TestName:     r.testName,
+	})
+}
+
+// ensureStopped returns an error if the container created by StartRelayer is still running.
+func (r *DockerRelayer) ensureStopped(ctx context.Context) error {
+	if r.containerID == "" {
+		return nil
+	}
+
+	c, err := r.client.ContainerInspect(ctx, r.containerID)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			// StopRelayer removes the container.
+			return nil
+		}
+		return fmt.Errorf("inspecting relayer container: %w", err)
 	}
-	return ibc.DockerImage{
+	if c.State.Running {
+		return fmt.Errorf("relayer container %s is still running; call StopRelayer first", c.Name)
+	}
+	return nil
+}
+
+func (r *DockerRelayer) containerImage() ibc.DockerImage {
+	image := ibc.DockerImage{
 		Repository: r.c.DefaultContainerImage(),
 		Version:    r.c.DefaultContainerVersion(),
 		UidGid:     r.c.DockerUser(),
 	}
+	if r.customImage != nil {
+		image = *r.customImage
+	}
+	if r.imageVersion != "" {
+		image.Version = r.imageVersion
+	}
+	return image
 }
 
 func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerImage) error {
@@ -425,20 +744,13 @@ func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerI
 		return nil
 	}
 
-	rc, err := r.client.ImagePull(context.TODO(), containerImage.Ref(), types.ImagePullOptions{})
-	if err != nil {
-		return err
-	}
-
-	_, _ = io.Copy(io.Discard, rc)
-	_ = rc.Close()
-	return nil
+	return dockerutil.PullImage(context.TODO(), r.log, r.client, containerImage)
 }
 
 func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames ...string) error {
 	containerImage := r.containerImage()
 	joinedPaths := strings.Join(pathNames, ".")
-	containerName := fmt.Sprintf("%s-%s", r.c.Name(), joinedPaths)
+	containerName := fmt.Sprintf("%s-%s-%s", r.c.Name(), r.instanceID, joinedPaths)
 	cmd := r.c.StartRelayer(r.HomeDir(), pathNames...)
 	r.log.Info(
 		"Running command",
@@ -456,7 +768,7 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames ...st
 			Hostname: r.HostName(joinedPaths),
 			User:     r.c.DockerUser(),
 
-			Labels: map[string]string{dockerutil.CleanupLabel: r.testName},
+			Labels: dockerutil.CleanupLabels(r.testName),
 		},
 		&container.HostConfig{
 			Binds:      r.Bind(),
@@ -484,7 +796,7 @@ func (r *DockerRelayer) stopContainer(ctx context.Context) error {
 }
 
 func (r *DockerRelayer) Name() string {
-	return r.c.Name() + "-" + dockerutil.SanitizeContainerName(r.testName)
+	return r.c.Name() + "-" + r.instanceID + "-" + dockerutil.ContainerNameSuffix(r.testName)
 }
 
 // Bind returns the home folder bind point for running the node.
@@ -498,7 +810,7 @@ func (r *DockerRelayer) HomeDir() string {
 }
 
 func (r *DockerRelayer) HostName(pathName string) string {
-	return dockerutil.CondenseHostName(fmt.Sprintf("%s-%s", r.c.Name(), pathName))
+	return dockerutil.CondenseHostName(fmt.Sprintf("%s-%s-%s", r.c.Name(), r.instanceID, pathName))
 }
 
 func (r *DockerRelayer) UseDockerNetwork() bool {
@@ -519,6 +831,9 @@ type RelayerCommander interface {
 	// ConfigContent generates the content of the config file that will be passed to AddChainConfiguration.
 	ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error)
 
+	// ConfigPath is the path of the relayer's configuration file, relative to its home directory.
+	ConfigPath() string
+
 	// ParseAddKeyOutput processes the output of AddKey
 	// to produce the wallet that was created.
 	ParseAddKeyOutput(stdout, stderr string) (ibc.Wallet, error)
@@ -538,6 +853,15 @@ type RelayerCommander interface {
 	// to produce the client output values.
 	ParseGetClientsOutput(stdout, stderr string) (ibc.ClientOutputs, error)
 
+	// ParseCreateClientsOutput extracts the IDs of the clients created by CreateClients.
+	ParseCreateClientsOutput(stdout, stderr string) (ibc.CreatedClients, error)
+
+	// ParseCreateConnectionsOutput extracts the IDs of the connection opened by CreateConnections.
+	ParseCreateConnectionsOutput(stdout, stderr string) (ibc.CreatedConnection, error)
+
+	// ParseCreateChannelOutput extracts the IDs of the channel opened by CreateChannel.
+	ParseCreateChannelOutput(stdout, stderr string) (ibc.CreatedChannel, error)
+
 	// Init is the command to run on the first call to AddChainConfiguration.
 	// If the returned command is nil or empty, nothing will be executed.
 	Init(homeDir string) []string
@@ -553,6 +877,8 @@ type RelayerCommander interface {
 	FlushPackets(pathName, channelID, homeDir string) []string
 	GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string
 	UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string
+	UsePathConnection(pathName, homeDir string, conn ibc.PathConnection) []string
+	UsePathClients(pathName, homeDir, srcClientID, dstClientID string) []string
 	GetChannels(chainID, homeDir string) []string
 	GetConnections(chainID, homeDir string) []string
 	GetClients(chainID, homeDir string) []string
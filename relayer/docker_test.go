@@ -0,0 +1,55 @@
+package relayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactConfig(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		config := `global:
+  api-listen-addr: :5183
+chains:
+  gaia:
+    type: cosmos
+    value:
+      key: default
+      mnemonic: |
+        abandon abandon abandon abandon abandon abandon
+        abandon abandon abandon abandon abandon about
+      Private-Key: "0xabc"
+      rpc-addr: http://gaia:26657
+`
+		got, err := redactConfig("config/config.yaml", []byte(config))
+		require.NoError(t, err)
+		require.NotContains(t, string(got), "abandon")
+		require.NotContains(t, string(got), "0xabc")
+		require.Contains(t, string(got), "mnemonic: <redacted>")
+		require.Contains(t, string(got), "Private-Key: <redacted>")
+		require.Contains(t, string(got), "rpc-addr: http://gaia:26657")
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		config := `[global]
+log_level = "info"
+
+[[chains]]
+id = "gaia-1"
+key_name = "default"
+secret = """
+multiline
+secret"""
+`
+		got, err := redactConfig(".hermes/config.toml", []byte(config))
+		require.NoError(t, err)
+		require.NotContains(t, string(got), "multiline")
+		require.Contains(t, string(got), `secret = "<redacted>"`)
+		require.Contains(t, string(got), `id = "gaia-1"`)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := redactConfig("config/config.yaml", []byte("chains: [unterminated"))
+		require.Error(t, err)
+	})
+}
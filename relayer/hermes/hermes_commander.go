@@ -31,6 +31,10 @@ func (c commander) DefaultContainerVersion() string {
 	return DefaultContainerVersion
 }
 
+func (c commander) ConfigPath() string {
+	return hermesConfigPath
+}
+
 func (c commander) DockerUser() string {
 	return hermesDefaultUidGid
 }
@@ -116,6 +120,18 @@ func (c commander) ParseGetClientsOutput(stdout, stderr string) (ibc.ClientOutpu
 	return clientOutputs, nil
 }
 
+func (c commander) ParseCreateClientsOutput(stdout, stderr string) (ibc.CreatedClients, error) {
+	panic("create clients implemented in hermes relayer not the commander")
+}
+
+func (c commander) ParseCreateConnectionsOutput(stdout, stderr string) (ibc.CreatedConnection, error) {
+	panic("create connections implemented in hermes relayer not the commander")
+}
+
+func (c commander) ParseCreateChannelOutput(stdout, stderr string) (ibc.CreatedChannel, error) {
+	return getChannelIDsFromStdout([]byte(stdout))
+}
+
 func (c commander) Init(homeDir string) []string {
 	return nil
 }
@@ -142,14 +158,21 @@ func (c commander) CreateWallet(keyName, address, mnemonic string) ibc.Wallet {
 	return NewWallet(keyName, address, mnemonic)
 }
 
-func (c commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
-	// TODO: figure out how to implement this.
-	panic("implement me")
-}
-
 // the following methods do not have a single command that cleanly maps to a single hermes command without
 // additional logic wrapping them. They have been implemented one layer up in the hermes relayer.
 
+func (c commander) UsePathConnection(pathName, homeDir string, conn ibc.PathConnection) []string {
+	panic("use path connection implemented in hermes relayer not the commander")
+}
+
+func (c commander) UsePathClients(pathName, homeDir, srcClientID, dstClientID string) []string {
+	panic("use path clients implemented in hermes relayer not the commander")
+}
+
+func (c commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
+	panic("update path implemented in hermes relayer not the commander")
+}
+
 func (c commander) UpdateClients(pathName, homeDir string) []string {
 	panic("update clients implemented in hermes relayer not the commander")
 }
@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-)
 
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
 
 // NewConfig returns a hermes Config with an entry for each of the provided ChainConfigs.
 // The defaults were adapted from the sample config file found here: https://github.com/informalsystems/hermes/blob/master/config.toml
@@ -22,7 +23,7 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 		chains = append(chains, Chain{
 			ID:            chainCfg.ChainID,
 			RPCAddr:       hermesCfg.rpcAddr,
-			GrpcAddr:      fmt.Sprintf("http://%s", hermesCfg.grpcAddr),
+			GrpcAddr:      fmt.Sprintf("%s://%s", chainCfg.TLS.RPCScheme(), hermesCfg.grpcAddr),
 			WebsocketAddr: strings.ReplaceAll(fmt.Sprintf("%s/websocket", hermesCfg.rpcAddr), "http", "ws"),
 			RPCTimeout:    "10s",
 			AccountPrefix: chainCfg.Bech32Prefix,
@@ -47,7 +48,8 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 				Numerator:   "1",
 				Denominator: "3",
 			},
-			MemoPrefix: "hermes",
+			MemoPrefix:   "hermes",
+			PacketFilter: hermesCfg.packetFilter,
 		},
 		)
 	}
@@ -172,4 +174,42 @@ type Chain struct {
 	TrustingPeriod string         `toml:"trusting_period"`
 	TrustThreshold TrustThreshold `toml:"trust_threshold"`
 	MemoPrefix     string         `toml:"memo_prefix,omitempty"`
+	PacketFilter   *PacketFilter  `toml:"packet_filter,omitempty"`
+}
+
+// PacketFilter restricts the channels a chain's packets are relayed on.
+type PacketFilter struct {
+	// Policy is either "allow" or "deny".
+	Policy string `toml:"policy"`
+	// List holds [port, channel] pairs, either of which may be a "*" wildcard.
+	List [][]string `toml:"list"`
+}
+
+// newPacketFilters converts an ibc.ChannelFilter on the source chain of a path into the equivalent hermes packet filters
+// of both chains of the path. The source chain's filter matches the listed channels on any port.
+// The destination chain's filter matches their counterparties, as found in srcChannels, the channels of the source chain,
+// since hermes filters the packets sent from each chain by that chain's own channels.
+func newPacketFilters(filter ibc.ChannelFilter, srcChannels []ibc.ChannelOutput) (src, dst *PacketFilter, err error) {
+	policy := "allow"
+	if filter.Rule == ibc.ChannelFilterDenylist {
+		policy = "deny"
+	}
+	srcList := make([][]string, len(filter.ChannelList))
+	dstList := make([][]string, len(filter.ChannelList))
+	for i, ch := range filter.ChannelList {
+		srcList[i] = []string{"*", ch}
+
+		found := false
+		for _, c := range srcChannels {
+			if c.ChannelID == ch {
+				dstList[i] = []string{c.Counterparty.PortID, c.Counterparty.ChannelID}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("channel %s not found", ch)
+		}
+	}
+	return &PacketFilter{Policy: policy, List: srcList}, &PacketFilter{Policy: policy, List: dstList}, nil
 }
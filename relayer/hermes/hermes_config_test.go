@@ -0,0 +1,57 @@
+package hermes
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPacketFilters(t *testing.T) {
+	srcChannels := []ibc.ChannelOutput{
+		{ChannelID: "channel-0", PortID: "transfer", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"}},
+		{ChannelID: "channel-1", PortID: "icacontroller-a", Counterparty: ibc.ChannelCounterparty{PortID: "icahost", ChannelID: "channel-4"}},
+	}
+
+	src, dst, err := newPacketFilters(ibc.ChannelFilter{Rule: ibc.ChannelFilterAllowlist, ChannelList: []string{"channel-0", "channel-1"}}, srcChannels)
+	require.NoError(t, err)
+	require.Equal(t, &PacketFilter{Policy: "allow", List: [][]string{{"*", "channel-0"}, {"*", "channel-1"}}}, src)
+	require.Equal(t, &PacketFilter{Policy: "allow", List: [][]string{{"transfer", "channel-3"}, {"icahost", "channel-4"}}}, dst)
+
+	src, dst, err = newPacketFilters(ibc.ChannelFilter{Rule: ibc.ChannelFilterDenylist, ChannelList: []string{"channel-1"}}, srcChannels)
+	require.NoError(t, err)
+	require.Equal(t, &PacketFilter{Policy: "deny", List: [][]string{{"*", "channel-1"}}}, src)
+	require.Equal(t, &PacketFilter{Policy: "deny", List: [][]string{{"icahost", "channel-4"}}}, dst)
+
+	_, _, err = newPacketFilters(ibc.ChannelFilter{Rule: ibc.ChannelFilterAllowlist, ChannelList: []string{"channel-9"}}, srcChannels)
+	require.EqualError(t, err, "channel channel-9 not found")
+}
+
+func TestConfig_PacketFilters(t *testing.T) {
+	chainConfig := func(chainID string) ChainConfig {
+		return ChainConfig{cfg: ibc.ChainConfig{ChainID: chainID, Denom: "uatom", GasPrices: "0.01uatom", Bech32Prefix: "cosmos"}}
+	}
+	r := &Relayer{chainConfigs: []ChainConfig{chainConfig("a-1"), chainConfig("b-1"), chainConfig("c-1")}}
+
+	src, dst, err := newPacketFilters(ibc.ChannelFilter{Rule: ibc.ChannelFilterAllowlist, ChannelList: []string{"channel-0"}}, []ibc.ChannelOutput{
+		{ChannelID: "channel-0", PortID: "transfer", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-7"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.setPacketFilter("a-1", src))
+	require.NoError(t, r.setPacketFilter("b-1", dst))
+	require.EqualError(t, r.setPacketFilter("d-1", dst), "chain d-1 has not been configured")
+
+	bz, err := toml.Marshal(NewConfig(r.chainConfigs...))
+	require.NoError(t, err)
+
+	// The config file written by UpdatePath filters the packets sent from both chains of the path.
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 3)
+	require.Equal(t, "a-1", cfg.Chains[0].ID)
+	require.Equal(t, &PacketFilter{Policy: "allow", List: [][]string{{"*", "channel-0"}}}, cfg.Chains[0].PacketFilter)
+	require.Equal(t, "b-1", cfg.Chains[1].ID)
+	require.Equal(t, &PacketFilter{Policy: "allow", List: [][]string{{"transfer", "channel-7"}}}, cfg.Chains[1].PacketFilter)
+	require.Nil(t, cfg.Chains[2].PacketFilter)
+}
@@ -26,7 +26,8 @@ const (
 )
 
 var (
-	_ ibc.Relayer = &Relayer{}
+	_ ibc.Relayer    = &Relayer{}
+	_ ibc.PathGetter = &Relayer{}
 	// parseRestoreKeyOutputPattern extracts the address from the hermes output.
 	// SUCCESS Restored key 'g2-2' (cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e) on chain g2-2
 	parseRestoreKeyOutputPattern = regexp.MustCompile(`\((.*)\)`)
@@ -43,6 +44,7 @@ type Relayer struct {
 type ChainConfig struct {
 	cfg                        ibc.ChainConfig
 	keyName, rpcAddr, grpcAddr string
+	packetFilter               *PacketFilter
 }
 
 // pathConfiguration represents the concept of a "path" which is implemented at the interchain test level rather
@@ -61,6 +63,13 @@ type pathChainConfig struct {
 
 // NewHermesRelayer returns a new hermes relayer.
 func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *Relayer {
+	for _, opt := range options {
+		switch opt.(type) {
+		case relayer.RelayerOptionMemo, relayer.RelayerOptionChainGas:
+			panic(fmt.Errorf("relayer option %T is not supported by the hermes relayer", opt))
+		}
+	}
+
 	c := commander{log: log}
 	options = append(options, relayer.HomeDir(hermesHome))
 	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
@@ -96,49 +105,111 @@ func (r *Relayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pat
 		return fmt.Errorf("path %s not found", pathName)
 	}
 
-	if err := r.CreateClients(ctx, rep, pathName, clientOpts); err != nil {
+	if _, err := r.CreateClients(ctx, rep, pathName, clientOpts); err != nil {
 		return err
 	}
 
-	if err := r.CreateConnections(ctx, rep, pathName); err != nil {
+	if _, err := r.CreateConnections(ctx, rep, pathName); err != nil {
 		return err
 	}
 
-	if err := r.CreateChannel(ctx, rep, pathName, channelOpts); err != nil {
+	if _, err := r.CreateChannel(ctx, rep, pathName, channelOpts); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+// LinkPathOverConnection records the existing clients and connections on the path and then establishes a channel
+// over them, skipping the client and connection creation performed by LinkPath.
+func (r *Relayer) LinkPathOverConnection(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, conn ibc.PathConnection, channelOpts ibc.CreateChannelOptions) error {
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	if err := conn.Validate(); err != nil {
+		return err
+	}
+
+	pathConfig.chainA.clientID = conn.SrcClientID
+	pathConfig.chainA.connectionID = conn.SrcConnectionID
+	pathConfig.chainB.clientID = conn.DstClientID
+	pathConfig.chainB.connectionID = conn.DstConnectionID
+
+	_, err := r.CreateChannel(ctx, rep, pathName, channelOpts)
+	return err
+}
+
+// LinkPathOverClients records the existing clients on the path and then establishes a connection and a channel
+// over them, skipping the client creation performed by LinkPath.
+func (r *Relayer) LinkPathOverClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcClientID, dstClientID string, channelOpts ibc.CreateChannelOptions) error {
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	if srcClientID == "" || dstClientID == "" {
+		return fmt.Errorf("client IDs must be set on both ends of path %s", pathName)
+	}
+
+	pathConfig.chainA.clientID = srcClientID
+	pathConfig.chainB.clientID = dstClientID
+
+	if _, err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+
+	_, err := r.CreateChannel(ctx, rep, pathName, channelOpts)
+	return err
+}
+
+func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) (ibc.CreatedChannel, error) {
+	if err := opts.Validate(); err != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("invalid channel options: %w", err)
+	}
+
 	pathConfig := r.paths[pathName]
+	before, err := r.GetChannels(ctx, rep, pathConfig.chainA.chainID)
+	if err != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("failed to get channels on source chain: %w", err)
+	}
+
 	cmd := []string{hermes, "--json", "create", "channel", "--a-chain", pathConfig.chainA.chainID, "--a-port", opts.SourcePortName, "--b-port", opts.DestPortName, "--a-connection", pathConfig.chainA.connectionID}
 	res := r.Exec(ctx, rep, cmd, nil)
 	if res.Err != nil {
-		return res.Err
+		return ibc.CreatedChannel{}, res.Err
 	}
 	pathConfig.chainA.portID = opts.SourcePortName
 	pathConfig.chainB.portID = opts.DestPortName
-	return nil
+
+	channel, err := getChannelIDsFromStdout(res.Stdout)
+	if err == nil {
+		return channel, nil
+	}
+	created, newErr := r.NewChannel(ctx, rep, pathConfig.chainA.chainID, opts.SourcePortName, before)
+	if newErr != nil {
+		return ibc.CreatedChannel{}, fmt.Errorf("channel created on path %s, but its IDs could not be determined: %v; %w", pathName, err, newErr)
+	}
+	return created, nil
 }
 
-func (r *Relayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+func (r *Relayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (ibc.CreatedConnection, error) {
 	pathConfig := r.paths[pathName]
 	cmd := []string{hermes, "--json", "create", "connection", "--a-chain", pathConfig.chainA.chainID, "--a-client", pathConfig.chainA.clientID, "--b-client", pathConfig.chainB.clientID}
 
 	res := r.Exec(ctx, rep, cmd, nil)
 	if res.Err != nil {
-		return res.Err
+		return ibc.CreatedConnection{}, res.Err
 	}
 
 	chainAConnectionID, chainBConnectionID, err := getConnectionIDsFromStdout(res.Stdout)
 	if err != nil {
-		return err
+		return ibc.CreatedConnection{}, err
 	}
 	pathConfig.chainA.connectionID = chainAConnectionID
 	pathConfig.chainB.connectionID = chainBConnectionID
-	return res.Err
+	return ibc.CreatedConnection{ConnectionID: chainAConnectionID, CounterpartyConnectionID: chainBConnectionID}, nil
 }
 
 func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
@@ -158,33 +229,50 @@ func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter
 // CreateClients creates clients on both chains.
 // Note: in the go relayer this can be done with a single command using the path reference,
 // however in Hermes this needs to be done as two separate commands.
-func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
+func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) (ibc.CreatedClients, error) {
 	pathConfig := r.paths[pathName]
 	chainACreateClientCmd := []string{hermes, "--json", "create", "client", "--host-chain", pathConfig.chainA.chainID, "--reference-chain", pathConfig.chainB.chainID}
+	chainACreateClientCmd = append(chainACreateClientCmd, createClientFlags(opts)...)
 	res := r.Exec(ctx, rep, chainACreateClientCmd, nil)
 	if res.Err != nil {
-		return res.Err
+		return ibc.CreatedClients{}, res.Err
 	}
 
 	chainAClientId, err := getClientIdFromStdout(res.Stdout)
 	if err != nil {
-		return err
+		return ibc.CreatedClients{}, err
 	}
 	pathConfig.chainA.clientID = chainAClientId
 
 	chainBCreateClientCmd := []string{hermes, "--json", "create", "client", "--host-chain", pathConfig.chainB.chainID, "--reference-chain", pathConfig.chainA.chainID}
+	chainBCreateClientCmd = append(chainBCreateClientCmd, createClientFlags(opts)...)
 	res = r.Exec(ctx, rep, chainBCreateClientCmd, nil)
 	if res.Err != nil {
-		return res.Err
+		return ibc.CreatedClients{}, res.Err
 	}
 
 	chainBClientId, err := getClientIdFromStdout(res.Stdout)
 	if err != nil {
-		return err
+		return ibc.CreatedClients{}, err
 	}
 	pathConfig.chainB.clientID = chainBClientId
 
-	return res.Err
+	return ibc.CreatedClients{ClientID: chainAClientId, CounterpartyClientID: chainBClientId}, nil
+}
+
+// createClientFlags returns the hermes create client flags for any options that override the relayer's defaults.
+func createClientFlags(opts ibc.CreateClientOptions) []string {
+	var flags []string
+	if opts.TrustingPeriod != "" && opts.TrustingPeriod != "0" {
+		flags = append(flags, "--trusting-period", opts.TrustingPeriod)
+	}
+	if opts.TrustLevel != "" {
+		flags = append(flags, "--trust-threshold", opts.TrustLevel)
+	}
+	if opts.MaxClockDrift != "" {
+		flags = append(flags, "--clock-drift", opts.MaxClockDrift)
+	}
+	return flags
 }
 
 // RestoreKey restores a key from a mnemonic. In hermes, you must provide a file containing the mnemonic. We need
@@ -209,7 +297,7 @@ func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, c
 	}
 
 	addrBytes := parseRestoreKeyOutput(string(res.Stdout))
-	r.AddWallet(chainID, NewWallet(chainID, addrBytes, mnemonic))
+	r.AddWallet(chainID, NewWallet(keyName, addrBytes, mnemonic))
 	return nil
 }
 
@@ -224,6 +312,58 @@ func (r *Relayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter,
 	return res.Err
 }
 
+// UpdatePath applies the channel filter, on the path's source chain, to the packet filters of both chains of the path
+// and rewrites the config file. The destination chain filters the counterparties of the listed channels,
+// so the channels must already exist.
+// As hermes filters per chain rather than per path, the filters apply to every path from either chain.
+// The filters take effect the next time the relayer is started.
+func (r *Relayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, filter ibc.ChannelFilter) error {
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	channels, err := r.GetChannels(ctx, rep, pathConfig.chainA.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get channels of chain %s: %w", pathConfig.chainA.chainID, err)
+	}
+	srcFilter, dstFilter, err := newPacketFilters(filter, channels)
+	if err != nil {
+		return fmt.Errorf("invalid channel filter on chain %s of path %s: %w", pathConfig.chainA.chainID, pathName, err)
+	}
+	if err := r.setPacketFilter(pathConfig.chainA.chainID, srcFilter); err != nil {
+		return err
+	}
+	if err := r.setPacketFilter(pathConfig.chainB.chainID, dstFilter); err != nil {
+		return err
+	}
+
+	bz, err := toml.Marshal(NewConfig(r.chainConfigs...))
+	if err != nil {
+		return fmt.Errorf("failed to generate config content: %w", err)
+	}
+	if err := r.WriteFileToHomeDir(ctx, hermesConfigPath, bz); err != nil {
+		return fmt.Errorf("failed to write hermes config: %w", err)
+	}
+
+	return r.validateConfig(ctx, rep)
+}
+
+// setPacketFilter sets the packet filter of the configured chain with the given ID.
+func (r *Relayer) setPacketFilter(chainID string, filter *PacketFilter) error {
+	for i := range r.chainConfigs {
+		if r.chainConfigs[i].cfg.ChainID == chainID {
+			r.chainConfigs[i].packetFilter = filter
+			return nil
+		}
+	}
+	return fmt.Errorf("chain %s has not been configured", chainID)
+}
+
 // GeneratePath establishes an in memory path representation. The concept does not exist in hermes, so it is handled
 // at the interchain test level.
 func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
@@ -241,6 +381,24 @@ func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter,
 	return nil
 }
 
+// GetPath returns the chains, clients and connections recorded for the in memory path.
+func (r *Relayer) GetPath(_ context.Context, _ ibc.RelayerExecReporter, pathName string) (ibc.PathOutput, error) {
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return ibc.PathOutput{}, fmt.Errorf("path %s not found", pathName)
+	}
+	return ibc.PathOutput{
+		SrcChainID: pathConfig.chainA.chainID,
+		DstChainID: pathConfig.chainB.chainID,
+		PathConnection: ibc.PathConnection{
+			SrcClientID:     pathConfig.chainA.clientID,
+			SrcConnectionID: pathConfig.chainA.connectionID,
+			DstClientID:     pathConfig.chainB.clientID,
+			DstConnectionID: pathConfig.chainB.connectionID,
+		},
+	}, nil
+}
+
 // configContent returns the contents of the hermes config file as a byte array. Note: as hermes expects a single file
 // rather than multiple config files, we need to maintain a list of chain configs each time they are added to write the
 // full correct file update calling Relayer.AddChainConfiguration.
@@ -300,6 +458,22 @@ func getConnectionIDsFromStdout(stdout []byte) (string, string, error) {
 	return connectionResponse.Result.ASide.ConnectionID, connectionResponse.Result.BSide.ConnectionID, nil
 }
 
+// getChannelIDsFromStdout extracts the channelIDs on both ends from the stdout.
+func getChannelIDsFromStdout(stdout []byte) (ibc.CreatedChannel, error) {
+	var channelResponse ChannelCreationResponse
+	if err := json.Unmarshal(extractJsonResult(stdout), &channelResponse); err != nil {
+		return ibc.CreatedChannel{}, err
+	}
+	channel := ibc.CreatedChannel{
+		ChannelID:             channelResponse.Result.ASide.ChannelID,
+		CounterpartyChannelID: channelResponse.Result.BSide.ChannelID,
+	}
+	if channel.ChannelID == "" || channel.CounterpartyChannelID == "" {
+		return ibc.CreatedChannel{}, fmt.Errorf("no channel IDs in hermes output")
+	}
+	return channel, nil
+}
+
 // parseRestoreKeyOutput extracts the address from the hermes output.
 func parseRestoreKeyOutput(stdout string) string {
 	fullMatchIdx, addressGroupIdx := 0, 1
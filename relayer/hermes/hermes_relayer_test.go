@@ -0,0 +1,33 @@
+package hermes
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateClientFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts ibc.CreateClientOptions
+		want []string
+	}{
+		{name: "defaults", opts: ibc.CreateClientOptions{}, want: nil},
+		{name: "default trusting period", opts: ibc.CreateClientOptions{TrustingPeriod: "0"}, want: nil},
+		{
+			name: "all overrides",
+			opts: ibc.CreateClientOptions{TrustingPeriod: "24h", TrustLevel: "2/3", MaxClockDrift: "30s"},
+			want: []string{"--trusting-period", "24h", "--trust-threshold", "2/3", "--clock-drift", "30s"},
+		},
+		{
+			name: "trust level only",
+			opts: ibc.CreateClientOptions{TrustLevel: "1/2"},
+			want: []string{"--trust-threshold", "1/2"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, createClientFlags(tt.opts))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package relayer
 
 import (
+	"context"
+
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 )
 
@@ -36,6 +38,18 @@ func CustomDockerImage(repository string, version string, uidGid string) Relayer
 	}
 }
 
+type RelayerOptionImageVersion struct {
+	Version string
+}
+
+// ImageVersion pins the version of the relayer docker image, e.g. a release candidate,
+// while keeping the default repository and user, or those given with CustomDockerImage.
+func ImageVersion(version string) RelayerOption {
+	return RelayerOptionImageVersion{Version: version}
+}
+
+func (opt RelayerOptionImageVersion) relayerOption() {}
+
 func HomeDir(homeDir string) RelayerOption {
 	return RelayerOptionHomeDir{HomeDir: homeDir}
 }
@@ -66,3 +80,110 @@ func StartupFlags(flags ...string) RelayerOption {
 }
 
 func (opt RelayerOptionExtraStartFlags) relayerOption() {}
+
+// Event processors supported by the Go relayer's --processor start flag.
+const (
+	// ProcessorEvents relays by processing the events of every new block.
+	ProcessorEvents = "events"
+	// ProcessorLegacy relays by periodically polling for pending packets.
+	ProcessorLegacy = "legacy"
+)
+
+type RelayerOptionProcessor struct {
+	Processor string
+}
+
+// Processor selects how the relayer finds packets to relay, e.g. ProcessorEvents.
+// Currently used for the Go relayer only.
+func Processor(processor string) RelayerOption {
+	return RelayerOptionProcessor{Processor: processor}
+}
+
+func (opt RelayerOptionProcessor) relayerOption() {}
+
+type RelayerOptionBlockHistory struct {
+	Blocks uint64
+}
+
+// BlockHistory sets how many blocks the relayer looks back through when it starts,
+// so that packets sent shortly before the relayer started are still relayed.
+// Currently used for the Go relayer only.
+func BlockHistory(blocks uint64) RelayerOption {
+	return RelayerOptionBlockHistory{Blocks: blocks}
+}
+
+func (opt RelayerOptionBlockHistory) relayerOption() {}
+
+type RelayerOptionMemo struct {
+	Memo string
+}
+
+// Memo sets the memo of the transactions sent by the relayer while it is running, i.e. once StartRelayer was called,
+// e.g. to assert that relayed packets were submitted by a particular relayer.
+// Transactions of the one-off commands, such as those creating clients, connections and channels
+// or flushing packets, carry no memo.
+// Currently used for the Go relayer only.
+func Memo(memo string) RelayerOption {
+	return RelayerOptionMemo{Memo: memo}
+}
+
+func (opt RelayerOptionMemo) relayerOption() {}
+
+type RelayerOptionChainGas struct {
+	ChainID   string
+	MaxGas    uint64
+	GasPrices string
+}
+
+// ChainGas caps the gas of each transaction the relayer sends on the chain with the given ID at maxGas,
+// and pays gasPrices instead of the chain's configured GasPrices.
+// A zero maxGas or empty gasPrices leaves the respective default in place.
+// Currently used for the Go relayer only.
+func ChainGas(chainID string, maxGas uint64, gasPrices string) RelayerOption {
+	return RelayerOptionChainGas{ChainID: chainID, MaxGas: maxGas, GasPrices: gasPrices}
+}
+
+func (opt RelayerOptionChainGas) relayerOption() {}
+
+// FaultClock is the chain whose blocks measure the delays of Faults, such as an ibc.Chain.
+type FaultClock interface {
+	Height(ctx context.Context) (uint64, error)
+}
+
+// Faults describes the relaying failures injected by FaultInjection.
+type Faults struct {
+	// DropEveryNth withholds every Nth packet seen pending, counted across all channels of the started paths,
+	// for DropBlocks blocks, after which it is relayed as usual: as a timeout if it expired in the meantime.
+	// Since a channel's pending packets are relayed all at once, every packet pending on the channel of a
+	// withheld one is withheld, and may time out, along with it. To fail exactly one packet, send it while
+	// no other packet is pending on its channel.
+	// Zero disables dropping.
+	DropEveryNth int
+
+	// DropBlocks is how many blocks of Clock a dropped packet is withheld for.
+	// To have dropped packets time out, it must exceed their timeout.
+	DropBlocks uint64
+
+	// DelayAckBlocks withholds every acknowledgement for at least this many blocks of Clock
+	// after the relayer first sees it pending, along with those pending on its channel since.
+	DelayAckBlocks uint64
+
+	// Clock measures DropBlocks and DelayAckBlocks, e.g. the source chain of the packets.
+	Clock FaultClock
+}
+
+type RelayerOptionFaultInjection struct {
+	Faults Faults
+}
+
+// FaultInjection makes the relayer deliberately drop packets and delay acknowledgements, as described by faults,
+// so that tests can assert how IBC apps handle timeouts and late acknowledgements.
+// For testing only: instead of running the relayer's own start command, StartRelayer then polls for pending
+// packets and acknowledgements from the test process, which is slower than the relayer itself.
+// Faults apply to a channel's pending packets as a whole, not to single packets, see Faults.DropEveryNth.
+// Currently used for the Go relayer only.
+func FaultInjection(faults Faults) RelayerOption {
+	return RelayerOptionFaultInjection{Faults: faults}
+}
+
+func (opt RelayerOptionFaultInjection) relayerOption() {}
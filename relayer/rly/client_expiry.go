@@ -0,0 +1,95 @@
+package rly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+var _ ibc.ClientExpiryQuerier = (*CosmosRelayer)(nil)
+
+// ClientExpiries returns the expiry of the client on the source chain of the path, followed by that on the destination chain.
+// The clients are those configured on the path, as reported by rly paths show,
+// and their last update is the block time of the tracked chain at the client's latest height.
+func (r *CosmosRelayer) ClientExpiries(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) ([]ibc.ClientExpiry, error) {
+	p, err := r.GetPath(ctx, rep, pathName)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := r.clientExpiry(ctx, rep, p.SrcChainID, p.SrcClientID, p.DstChainID)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := r.clientExpiry(ctx, rep, p.DstChainID, p.DstClientID, p.SrcChainID)
+	if err != nil {
+		return nil, err
+	}
+	return []ibc.ClientExpiry{src, dst}, nil
+}
+
+// clientExpiry returns the expiry of the client clientID on chainID, which tracks trackedChainID.
+func (r *CosmosRelayer) clientExpiry(ctx context.Context, rep ibc.RelayerExecReporter, chainID, clientID, trackedChainID string) (ibc.ClientExpiry, error) {
+	if clientID == "" {
+		return ibc.ClientExpiry{}, fmt.Errorf("no client on %s tracking %s is configured on the path", chainID, trackedChainID)
+	}
+	clients, err := r.GetClients(ctx, rep, chainID)
+	if err != nil {
+		return ibc.ClientExpiry{}, fmt.Errorf("failed to get clients on %s: %w", chainID, err)
+	}
+	client, err := findClient(clients, chainID, clientID)
+	if err != nil {
+		return ibc.ClientExpiry{}, err
+	}
+
+	height := client.ClientState.LatestHeight.RevisionHeight
+	res := r.Exec(ctx, rep, []string{"rly", "q", "header", trackedChainID, strconv.FormatUint(height, 10), "--home", r.HomeDir()}, nil)
+	if res.Err != nil {
+		return ibc.ClientExpiry{}, fmt.Errorf("failed to query header of %s at height %d: %w", trackedChainID, height, res.Err)
+	}
+	lastUpdate, err := parseHeaderTime(res.Stdout)
+	if err != nil {
+		return ibc.ClientExpiry{}, fmt.Errorf("failed to parse header of %s at height %d: %w", trackedChainID, height, err)
+	}
+
+	return ibc.NewClientExpiry(chainID, *client, lastUpdate)
+}
+
+// findClient returns the client with clientID among the clients on chainID.
+func findClient(clients ibc.ClientOutputs, chainID, clientID string) (*ibc.ClientOutput, error) {
+	for _, c := range clients {
+		if c.ClientID == clientID {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find client %s on %s", clientID, chainID)
+}
+
+// parseHeaderTime returns the block time from the output of rly q header,
+// which is either a light client header, with the block header under signed_header, or a block header itself.
+func parseHeaderTime(out []byte) (time.Time, error) {
+	type blockHeader struct {
+		Time time.Time `json:"time"`
+	}
+	var h struct {
+		blockHeader
+		Header       blockHeader `json:"header"`
+		SignedHeader struct {
+			Header blockHeader `json:"header"`
+		} `json:"signed_header"`
+	}
+	if err := json.Unmarshal(out, &h); err != nil {
+		return time.Time{}, err
+	}
+	for _, t := range []time.Time{h.SignedHeader.Header.Time, h.Header.Time, h.Time} {
+		if !t.IsZero() {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("header has no time")
+}
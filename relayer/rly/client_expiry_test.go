@@ -0,0 +1,41 @@
+package rly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeaderTime(t *testing.T) {
+	want := time.Date(2022, 10, 1, 12, 0, 0, 500, time.UTC)
+
+	for _, out := range []string{
+		`{"signed_header":{"header":{"chain_id":"gaia-2","height":"42","time":"2022-10-01T12:00:00.0000005Z"}},"validator_set":{}}`,
+		`{"header":{"chain_id":"gaia-2","height":"42","time":"2022-10-01T12:00:00.0000005Z"}}`,
+		`{"chain_id":"gaia-2","height":"42","time":"2022-10-01T12:00:00.0000005Z"}`,
+	} {
+		got, err := parseHeaderTime([]byte(out))
+		require.NoError(t, err, out)
+		require.True(t, want.Equal(got), out)
+	}
+
+	_, err := parseHeaderTime([]byte(`{"height":"42"}`))
+	require.EqualError(t, err, "header has no time")
+}
+
+func TestFindClient(t *testing.T) {
+	// Several clients on the chain may track the same chain, e.g. one per path.
+	clients := ibc.ClientOutputs{
+		{ClientID: "07-tendermint-0", ClientState: ibc.ClientState{ChainID: "gaia-2"}},
+		{ClientID: "07-tendermint-1", ClientState: ibc.ClientState{ChainID: "gaia-2"}},
+	}
+
+	c, err := findClient(clients, "gaia-1", "07-tendermint-1")
+	require.NoError(t, err)
+	require.Equal(t, clients[1], c)
+
+	_, err = findClient(clients, "gaia-1", "07-tendermint-2")
+	require.EqualError(t, err, "unable to find client 07-tendermint-2 on gaia-1")
+}
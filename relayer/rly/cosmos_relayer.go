@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/docker/docker/client"
@@ -22,14 +25,37 @@ const (
 type CosmosRelayer struct {
 	// Embedded DockerRelayer so commands just work.
 	*relayer.DockerRelayer
+
+	// paths maps the name of each path created by GeneratePath to its source and destination chain IDs,
+	// so that RelayPacket can find the path of a channel.
+	paths map[string]pathChains
+
+	log *zap.Logger
+
+	// faults are injected into relaying by StartRelayer if set, see relayer.FaultInjection.
+	faults *relayer.Faults
+	// faultyMu guards faulty, which is the relaying loop started with faults, or nil if it is not running.
+	faultyMu sync.Mutex
+	faulty   *faultyRelaying
+}
+
+type pathChains struct {
+	srcChainID, dstChainID string
 }
 
 func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *CosmosRelayer {
-	c := commander{log: log}
+	c := commander{log: log, extraStartFlags: extraStartFlags(options)}
+	var faults *relayer.Faults
 	for _, opt := range options {
 		switch o := opt.(type) {
-		case relayer.RelayerOptionExtraStartFlags:
-			c.extraStartFlags = o.Flags
+		case relayer.RelayerOptionFaultInjection:
+			f := o.Faults
+			faults = &f
+		case relayer.RelayerOptionChainGas:
+			if c.chainGas == nil {
+				c.chainGas = make(map[string]relayer.RelayerOptionChainGas)
+			}
+			c.chainGas[o.ChainID] = o
 		}
 	}
 	dr, err := relayer.NewDockerRelayer(context.TODO(), log, testName, cli, networkID, c, options...)
@@ -39,11 +65,82 @@ func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 
 	r := &CosmosRelayer{
 		DockerRelayer: dr,
+		paths:         map[string]pathChains{},
+		log:           log,
+		faults:        faults,
 	}
 
 	return r
 }
 
+// CreateClients creates clients on both ends of the path.
+// The Go relayer always uses a trust level of 1/3, so a custom TrustLevel is rejected rather than ignored.
+func (r *CosmosRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) (ibc.CreatedClients, error) {
+	if err := checkTrustLevel(opts); err != nil {
+		return ibc.CreatedClients{}, err
+	}
+	clients, err := r.DockerRelayer.CreateClients(ctx, rep, pathName, opts)
+	if err != nil || clients != (ibc.CreatedClients{}) {
+		return clients, err
+	}
+
+	// rly records the new clients in the path, so their IDs are read from there if its output did not report them.
+	p, err := r.GetPath(ctx, rep, pathName)
+	if err != nil {
+		return ibc.CreatedClients{}, fmt.Errorf("clients created on path %s, but their IDs could not be determined: %w", pathName, err)
+	}
+	return ibc.CreatedClients{ClientID: p.SrcClientID, CounterpartyClientID: p.DstClientID}, nil
+}
+
+// CreateConnections opens a connection on the path and returns its IDs.
+func (r *CosmosRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (ibc.CreatedConnection, error) {
+	conn, err := r.DockerRelayer.CreateConnections(ctx, rep, pathName)
+	if err != nil || conn != (ibc.CreatedConnection{}) {
+		return conn, err
+	}
+
+	// Like the clients, the new connection is recorded in the path.
+	p, err := r.GetPath(ctx, rep, pathName)
+	if err != nil {
+		return ibc.CreatedConnection{}, fmt.Errorf("connection created on path %s, but its IDs could not be determined: %w", pathName, err)
+	}
+	return ibc.CreatedConnection{ConnectionID: p.SrcConnectionID, CounterpartyConnectionID: p.DstConnectionID}, nil
+}
+
+// LinkPath creates clients, a connection and a channel on the path.
+// See CreateClients for the restriction on client options.
+func (r *CosmosRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	if err := checkTrustLevel(clientOpts); err != nil {
+		return err
+	}
+	return r.DockerRelayer.LinkPath(ctx, rep, pathName, channelOpts, clientOpts)
+}
+
+func checkTrustLevel(opts ibc.CreateClientOptions) error {
+	if opts.TrustLevel != "" && opts.TrustLevel != "1/3" {
+		return fmt.Errorf("rly does not support a client trust level of %s", opts.TrustLevel)
+	}
+	return nil
+}
+
+// extraStartFlags returns the flags appended to rly start for the given options, in the order they were given.
+func extraStartFlags(options []relayer.RelayerOption) []string {
+	var flags []string
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			flags = append(flags, o.Flags...)
+		case relayer.RelayerOptionProcessor:
+			flags = append(flags, "--processor", o.Processor)
+		case relayer.RelayerOptionBlockHistory:
+			flags = append(flags, "--block-history", strconv.FormatUint(o.Blocks, 10))
+		case relayer.RelayerOptionMemo:
+			flags = append(flags, "--memo", o.Memo)
+		}
+	}
+	return flags
+}
+
 type CosmosRelayerChainConfigValue struct {
 	AccountPrefix  string  `json:"account-prefix"`
 	ChainID        string  `json:"chain-id"`
@@ -53,6 +150,7 @@ type CosmosRelayerChainConfigValue struct {
 	GasPrices      string  `json:"gas-prices"`
 	Key            string  `json:"key"`
 	KeyringBackend string  `json:"keyring-backend"`
+	MaxGasAmount   uint64  `json:"max-gas-amount,omitempty"`
 	OutputFormat   string  `json:"output-format"`
 	RPCAddr        string  `json:"rpc-addr"`
 	SignMode       string  `json:"sign-mode"`
@@ -106,12 +204,19 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 type commander struct {
 	log             *zap.Logger
 	extraStartFlags []string
+
+	// chainGas holds the gas overrides of relayer.ChainGas, by chain ID.
+	chainGas map[string]relayer.RelayerOptionChainGas
 }
 
 func (commander) Name() string {
 	return "rly"
 }
 
+func (commander) ConfigPath() string {
+	return "config/config.yaml"
+}
+
 func (commander) DockerUser() string {
 	return RlyDefaultUidGid // docker run -it --rm --entrypoint echo ghcr.io/cosmos/relayer "$(id -u):$(id -g)"
 }
@@ -143,10 +248,19 @@ func (commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, h
 }
 
 func (commander) CreateClients(pathName string, opts ibc.CreateClientOptions, homeDir string) []string {
-	return []string{
+	cmd := []string{
 		"rly", "tx", "clients", pathName, "--client-tp", opts.TrustingPeriod,
 		"--home", homeDir,
 	}
+	return append(cmd, clockDriftFlags(opts)...)
+}
+
+// clockDriftFlags returns the flags overriding the client's max clock drift, if set.
+func clockDriftFlags(opts ibc.CreateClientOptions) []string {
+	if opts.MaxClockDrift == "" {
+		return nil
+	}
+	return []string{"--max-clock-drift", opts.MaxClockDrift}
 }
 
 // passing a value of 0 for customeClientTrustingPeriod will use default
@@ -194,6 +308,26 @@ func (commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter)
 	}
 }
 
+func (commander) UsePathConnection(pathName, homeDir string, conn ibc.PathConnection) []string {
+	return []string{
+		"rly", "paths", "update", pathName,
+		"--home", homeDir,
+		"--src-client-id", conn.SrcClientID,
+		"--src-connection-id", conn.SrcConnectionID,
+		"--dst-client-id", conn.DstClientID,
+		"--dst-connection-id", conn.DstConnectionID,
+	}
+}
+
+func (commander) UsePathClients(pathName, homeDir, srcClientID, dstClientID string) []string {
+	return []string{
+		"rly", "paths", "update", pathName,
+		"--home", homeDir,
+		"--src-client-id", srcClientID,
+		"--dst-client-id", dstClientID,
+	}
+}
+
 func (commander) GetChannels(chainID, homeDir string) []string {
 	return []string{
 		"rly", "q", "channels", chainID,
@@ -216,7 +350,7 @@ func (commander) GetClients(chainID, homeDir string) []string {
 }
 
 func (commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChannelOptions, clientOpt ibc.CreateClientOptions) []string {
-	return []string{
+	cmd := []string{
 		"rly", "tx", "link", pathName,
 		"--src-port", channelOpts.SourcePortName,
 		"--dst-port", channelOpts.DestPortName,
@@ -226,6 +360,7 @@ func (commander) LinkPath(pathName, homeDir string, channelOpts ibc.CreateChanne
 
 		"--home", homeDir,
 	}
+	return append(cmd, clockDriftFlags(clientOpt)...)
 }
 
 func (commander) RestoreKey(chainID, keyName, coinType, mnemonic, homeDir string) []string {
@@ -252,8 +387,14 @@ func (commander) UpdateClients(pathName, homeDir string) []string {
 	}
 }
 
-func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
+func (c commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
 	cosmosRelayerChainConfig := ChainConfigToCosmosRelayerChainConfig(cfg, keyName, rpcAddr, grpcAddr)
+	if gas, ok := c.chainGas[cfg.ChainID]; ok {
+		cosmosRelayerChainConfig.Value.MaxGasAmount = gas.MaxGas
+		if gas.GasPrices != "" {
+			cosmosRelayerChainConfig.Value.GasPrices = gas.GasPrices
+		}
+	}
 	jsonBytes, err := json.Marshal(cosmosRelayerChainConfig)
 	if err != nil {
 		return nil, err
@@ -299,6 +440,69 @@ func (c commander) ParseGetChannelsOutput(stdout, stderr string) ([]ibc.ChannelO
 	return channels, nil
 }
 
+// channelOpenAckFields match the channel IDs logged by the relayer for the MsgChannelOpenAck it sends to the source chain,
+// in either the console, logfmt, or JSON log format, e.g. channel_id=channel-0 counterparty_channel_id=channel-1.
+var (
+	channelIDField             = regexp.MustCompile(`(?:^|[^_\w])"?channel_id"?\s*[=:]\s*"?(channel-\d+)`)
+	counterpartyChannelIDField = regexp.MustCompile(`"?counterparty_channel_id"?\s*[=:]\s*"?(channel-\d+)`)
+)
+
+// ParseCreateChannelOutput extracts the channel IDs from the log of the channel handshake,
+// taken from the last line with both a channel and a counterparty channel ID.
+func (commander) ParseCreateChannelOutput(stdout, stderr string) (ibc.CreatedChannel, error) {
+	lines := strings.Split(stderr+"\n"+stdout, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		src := channelIDField.FindStringSubmatch(lines[i])
+		dst := counterpartyChannelIDField.FindStringSubmatch(lines[i])
+		if src != nil && dst != nil {
+			return ibc.CreatedChannel{ChannelID: src[1], CounterpartyChannelID: dst[1]}, nil
+		}
+	}
+	return ibc.CreatedChannel{}, fmt.Errorf("no channel IDs in rly output")
+}
+
+// clientsCreatedFields match the client IDs logged by the relayer once it has created the clients of a path,
+// e.g. src_client_id=07-tendermint-0 dst_client_id=07-tendermint-1.
+var (
+	srcClientIDField = regexp.MustCompile(`"?src_client_id"?\s*[=:]\s*"?([\w-]+-\d+)`)
+	dstClientIDField = regexp.MustCompile(`"?dst_client_id"?\s*[=:]\s*"?([\w-]+-\d+)`)
+)
+
+// ParseCreateClientsOutput extracts the client IDs from the log of the client creation,
+// taken from the last line with both a source and a destination client ID.
+func (commander) ParseCreateClientsOutput(stdout, stderr string) (ibc.CreatedClients, error) {
+	lines := strings.Split(stderr+"\n"+stdout, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		src := srcClientIDField.FindStringSubmatch(lines[i])
+		dst := dstClientIDField.FindStringSubmatch(lines[i])
+		if src != nil && dst != nil {
+			return ibc.CreatedClients{ClientID: src[1], CounterpartyClientID: dst[1]}, nil
+		}
+	}
+	return ibc.CreatedClients{}, fmt.Errorf("no client IDs in rly output")
+}
+
+// connectionOpenAckFields match the connection IDs logged by the relayer for the MsgConnectionOpenAck it sends to the source chain,
+// like channelOpenAckFields do for channels.
+var (
+	connectionIDField             = regexp.MustCompile(`(?:^|[^_\w])"?connection_id"?\s*[=:]\s*"?(connection-\d+)`)
+	counterpartyConnectionIDField = regexp.MustCompile(`"?counterparty_connection_id"?\s*[=:]\s*"?(connection-\d+)`)
+)
+
+// ParseCreateConnectionsOutput extracts the connection IDs from the log of the connection handshake,
+// taken from the last line with both a connection and a counterparty connection ID.
+func (commander) ParseCreateConnectionsOutput(stdout, stderr string) (ibc.CreatedConnection, error) {
+	lines := strings.Split(stderr+"\n"+stdout, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		src := connectionIDField.FindStringSubmatch(lines[i])
+		dst := counterpartyConnectionIDField.FindStringSubmatch(lines[i])
+		if src != nil && dst != nil {
+			return ibc.CreatedConnection{ConnectionID: src[1], CounterpartyConnectionID: dst[1]}, nil
+		}
+	}
+	return ibc.CreatedConnection{}, fmt.Errorf("no connection IDs in rly output")
+}
+
 func (c commander) ParseGetConnectionsOutput(stdout, stderr string) (ibc.ConnectionOutputs, error) {
 	var connections ibc.ConnectionOutputs
 	for _, connection := range strings.Split(stdout, "\n") {
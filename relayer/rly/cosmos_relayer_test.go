@@ -0,0 +1,189 @@
+package rly
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateChannelOutput(t *testing.T) {
+	want := ibc.CreatedChannel{ChannelID: "channel-2", CounterpartyChannelID: "channel-7"}
+
+	for name, stderr := range map[string]string{
+		"console": `2023-01-01T00:00:00.000000Z	info	Successful transaction	{"provider_type": "cosmos", "chain_id": "gaia-1", "msg_type": "/ibc.core.channel.v1.MsgChannelOpenTry", "port_id": "transfer", "counterparty_channel_id": "channel-2"}
+2023-01-01T00:00:01.000000Z	info	Successful transaction	{"provider_type": "cosmos", "chain_id": "gaia-0", "msg_type": "/ibc.core.channel.v1.MsgChannelOpenAck", "port_id": "transfer", "channel_id": "channel-2", "counterparty_channel_id": "channel-7"}
+2023-01-01T00:00:02.000000Z	info	Successful transaction	{"provider_type": "cosmos", "chain_id": "gaia-1", "msg_type": "/ibc.core.channel.v1.MsgChannelOpenConfirm", "port_id": "transfer", "channel_id": "channel-7"}`,
+		"logfmt": `ts=2023-01-01T00:00:00Z lvl=info msg="Successful transaction" chain_id=gaia-0 msg_type=/ibc.core.channel.v1.MsgChannelOpenAck port_id=transfer channel_id=channel-2 counterparty_channel_id=channel-7`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := commander{}.ParseCreateChannelOutput("", stderr)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+
+	_, err := commander{}.ParseCreateChannelOutput("", "info	Starting event processor for channel handshake")
+	require.Error(t, err)
+}
+
+func TestParseCreateClientsOutput(t *testing.T) {
+	want := ibc.CreatedClients{ClientID: "07-tendermint-0", CounterpartyClientID: "07-tendermint-3"}
+
+	for name, stderr := range map[string]string{
+		"console": `2023-01-01T00:00:00.000000Z	info	Client Created	{"src_chain_id": "gaia-0", "src_client_id": "07-tendermint-0", "dst_chain_id": "gaia-1"}
+2023-01-01T00:00:01.000000Z	info	Clients created	{"src_client_id": "07-tendermint-0", "src_chain_id": "gaia-0", "dst_client_id": "07-tendermint-3", "dst_chain_id": "gaia-1"}`,
+		"logfmt": `ts=2023-01-01T00:00:01Z lvl=info msg="Clients created" src_client_id=07-tendermint-0 src_chain_id=gaia-0 dst_client_id=07-tendermint-3 dst_chain_id=gaia-1`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := commander{}.ParseCreateClientsOutput("", stderr)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+
+	_, err := commander{}.ParseCreateClientsOutput("", "info	Client Created	{\"src_client_id\": \"07-tendermint-0\"}")
+	require.Error(t, err)
+}
+
+func TestParseCreateConnectionsOutput(t *testing.T) {
+	want := ibc.CreatedConnection{ConnectionID: "connection-1", CounterpartyConnectionID: "connection-4"}
+
+	for name, stderr := range map[string]string{
+		"console": `2023-01-01T00:00:00.000000Z	info	Successful transaction	{"provider_type": "cosmos", "chain_id": "gaia-1", "msg_type": "/ibc.core.connection.v1.MsgConnectionOpenTry", "client_id": "07-tendermint-3", "counterparty_connection_id": "connection-1"}
+2023-01-01T00:00:01.000000Z	info	Successful transaction	{"provider_type": "cosmos", "chain_id": "gaia-0", "msg_type": "/ibc.core.connection.v1.MsgConnectionOpenAck", "connection_id": "connection-1", "counterparty_connection_id": "connection-4"}
+2023-01-01T00:00:02.000000Z	info	Successful transaction	{"provider_type": "cosmos", "chain_id": "gaia-1", "msg_type": "/ibc.core.connection.v1.MsgConnectionOpenConfirm", "connection_id": "connection-4"}`,
+		"logfmt": `ts=2023-01-01T00:00:01Z lvl=info msg="Successful transaction" chain_id=gaia-0 msg_type=/ibc.core.connection.v1.MsgConnectionOpenAck connection_id=connection-1 counterparty_connection_id=connection-4`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := commander{}.ParseCreateConnectionsOutput("", stderr)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+
+	_, err := commander{}.ParseCreateConnectionsOutput("", "info	Starting event processor for connection handshake")
+	require.Error(t, err)
+}
+
+func TestCommanderCreateClients(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts ibc.CreateClientOptions
+		want []string
+	}{
+		{
+			name: "defaults",
+			opts: ibc.CreateClientOptions{TrustingPeriod: "0"},
+			want: []string{"rly", "tx", "clients", "a-b", "--client-tp", "0", "--home", "/home/relayer"},
+		},
+		{
+			name: "max clock drift",
+			opts: ibc.CreateClientOptions{TrustingPeriod: "24h", MaxClockDrift: "30s"},
+			want: []string{"rly", "tx", "clients", "a-b", "--client-tp", "24h", "--home", "/home/relayer", "--max-clock-drift", "30s"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, commander{}.CreateClients("a-b", tt.opts, "/home/relayer"))
+		})
+	}
+}
+
+func TestCommanderLinkPath(t *testing.T) {
+	channelOpts := ibc.DefaultChannelOpts()
+	base := []string{
+		"rly", "tx", "link", "a-b",
+		"--src-port", "transfer",
+		"--dst-port", "transfer",
+		"--order", "unordered",
+		"--version", "ics20-1",
+	}
+
+	for _, tt := range []struct {
+		name string
+		opts ibc.CreateClientOptions
+		want []string
+	}{
+		{
+			name: "defaults",
+			opts: ibc.CreateClientOptions{TrustingPeriod: "0"},
+			want: append(append([]string{}, base...), "--client-tp", "0", "--home", "/home/relayer"),
+		},
+		{
+			name: "max clock drift",
+			opts: ibc.CreateClientOptions{TrustingPeriod: "24h", MaxClockDrift: "30s"},
+			want: append(append([]string{}, base...), "--client-tp", "24h", "--home", "/home/relayer", "--max-clock-drift", "30s"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, commander{}.LinkPath("a-b", "/home/relayer", channelOpts, tt.opts))
+		})
+	}
+}
+
+func TestCheckTrustLevel(t *testing.T) {
+	require.NoError(t, checkTrustLevel(ibc.CreateClientOptions{}))
+	require.NoError(t, checkTrustLevel(ibc.CreateClientOptions{TrustLevel: "1/3"}))
+	require.EqualError(t, checkTrustLevel(ibc.CreateClientOptions{TrustLevel: "2/3"}), "rly does not support a client trust level of 2/3")
+}
+
+func TestCommanderUsePathConnection(t *testing.T) {
+	conn := ibc.PathConnection{
+		SrcClientID:     "07-tendermint-0",
+		SrcConnectionID: "connection-0",
+		DstClientID:     "07-tendermint-3",
+		DstConnectionID: "connection-4",
+	}
+
+	require.Equal(t, []string{
+		"rly", "paths", "update", "a-b",
+		"--home", "/home/relayer",
+		"--src-client-id", "07-tendermint-0",
+		"--src-connection-id", "connection-0",
+		"--dst-client-id", "07-tendermint-3",
+		"--dst-connection-id", "connection-4",
+	}, commander{}.UsePathConnection("a-b", "/home/relayer", conn))
+}
+
+func TestCommanderStartRelayer(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []relayer.RelayerOption
+		want    []string
+	}{
+		{
+			name: "defaults",
+			want: []string{"rly", "start", "--debug", "--home", "/home/relayer", "a-b"},
+		},
+		{
+			name:    "processor",
+			options: []relayer.RelayerOption{relayer.Processor(relayer.ProcessorEvents)},
+			want:    []string{"rly", "start", "--debug", "--home", "/home/relayer", "--processor", "events", "a-b"},
+		},
+		{
+			name:    "block history",
+			options: []relayer.RelayerOption{relayer.BlockHistory(100)},
+			want:    []string{"rly", "start", "--debug", "--home", "/home/relayer", "--block-history", "100", "a-b"},
+		},
+		{
+			name: "combined with extra flags",
+			options: []relayer.RelayerOption{
+				relayer.StartupFlags("--time-threshold", "1m"),
+				relayer.Processor(relayer.ProcessorLegacy),
+				relayer.BlockHistory(20),
+				relayer.ImagePull(false),
+			},
+			want: []string{
+				"rly", "start", "--debug", "--home", "/home/relayer",
+				"--time-threshold", "1m", "--processor", "legacy", "--block-history", "20",
+				"a-b",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := commander{extraStartFlags: extraStartFlags(tt.options)}
+			require.Equal(t, tt.want, c.StartRelayer("/home/relayer", "a-b"))
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package rly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"go.uber.org/zap"
+)
+
+// faultPollInterval is how often the fault-injecting relayer looks for pending packets and acknowledgements.
+const faultPollInterval = time.Second
+
+// faultyRelaying is the relaying loop run by StartRelayer in place of rly start when faults are injected.
+type faultyRelaying struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	paths  []string
+}
+
+// StartRelayer starts relaying on the paths, with the faults of relayer.FaultInjection if it was given.
+func (r *CosmosRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if r.faults == nil {
+		return r.DockerRelayer.StartRelayer(ctx, rep, pathNames...)
+	}
+	if r.faults.Clock == nil {
+		return errors.New("fault injection requires a clock chain")
+	}
+	for _, p := range pathNames {
+		if _, ok := r.paths[p]; !ok {
+			return fmt.Errorf("path %s was not generated by this relayer", p)
+		}
+	}
+
+	r.faultyMu.Lock()
+	defer r.faultyMu.Unlock()
+	if r.faulty != nil {
+		return fmt.Errorf("relayer %s is already running", r.Name())
+	}
+
+	// The loop outlives ctx, which may only cover the call to StartRelayer, until StopRelayer.
+	loopCtx, cancel := context.WithCancel(context.Background())
+	f := &faultyRelaying{cancel: cancel, done: make(chan struct{}), paths: pathNames}
+	r.faulty = f
+
+	inj := newFaultInjector(*r.faults)
+	go func() {
+		defer close(f.done)
+		for {
+			r.relayWithFaults(loopCtx, rep, inj, pathNames)
+			select {
+			case <-loopCtx.Done():
+				return
+			case <-time.After(faultPollInterval):
+			}
+		}
+	}()
+	return nil
+}
+
+// StopRelayer stops the relayer started by StartRelayer.
+func (r *CosmosRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if r.faults == nil {
+		return r.DockerRelayer.StopRelayer(ctx, rep)
+	}
+	r.faultyMu.Lock()
+	f := r.faulty
+	r.faultyMu.Unlock()
+	if f == nil {
+		return fmt.Errorf("relayer %s is not running", r.Name())
+	}
+
+	f.cancel()
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	r.faultyMu.Lock()
+	if r.faulty == f {
+		r.faulty = nil
+	}
+	r.faultyMu.Unlock()
+	return nil
+}
+
+// RestartRelayer stops the relayer and starts it again on the same paths.
+// Withheld packets and acknowledgements are forgotten, so they are relayed as soon as the relayer restarts.
+func (r *CosmosRelayer) RestartRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if r.faults == nil {
+		return r.DockerRelayer.RestartRelayer(ctx, rep)
+	}
+	r.faultyMu.Lock()
+	f := r.faulty
+	r.faultyMu.Unlock()
+	if f == nil {
+		return fmt.Errorf("relayer %s is not running", r.Name())
+	}
+	paths := f.paths
+	if err := r.StopRelayer(ctx, rep); err != nil {
+		return err
+	}
+	return r.StartRelayer(ctx, rep, paths...)
+}
+
+// RunningPaths returns the paths the relayer was started on, or nil if it is not running.
+func (r *CosmosRelayer) RunningPaths() []string {
+	if r.faults == nil {
+		return r.DockerRelayer.RunningPaths()
+	}
+	r.faultyMu.Lock()
+	defer r.faultyMu.Unlock()
+	if r.faulty == nil {
+		return nil
+	}
+	return r.faulty.paths
+}
+
+// relayWithFaults relays the pending packets and acknowledgements of every channel of the paths,
+// unless inj withholds them. Failures are logged, to be retried in the next round.
+func (r *CosmosRelayer) relayWithFaults(ctx context.Context, rep ibc.RelayerExecReporter, inj *faultInjector, pathNames []string) {
+	height, err := inj.faults.Clock.Height(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Warn("Fault injection: failed to get clock height", zap.Error(err))
+		}
+		return
+	}
+
+	for _, pathName := range pathNames {
+		channels, err := r.GetChannels(ctx, rep, r.paths[pathName].srcChainID)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.log.Warn("Fault injection: failed to get channels", zap.String("path", pathName), zap.Error(err))
+			}
+			continue
+		}
+		for _, ch := range channels {
+			if !ch.Open() {
+				continue
+			}
+			for _, ack := range []bool{false, true} {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := r.relayChannelWithFaults(ctx, rep, inj, height, pathName, ch.ChannelID, ack); err != nil {
+					r.log.Debug("Fault injection: failed to relay channel",
+						zap.String("path", pathName), zap.String("channel", ch.ChannelID), zap.Bool("acks", ack), zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// relayChannelWithFaults flushes the pending packets, or acknowledgements if ack is set, of channelID
+// once inj no longer withholds any of them.
+func (r *CosmosRelayer) relayChannelWithFaults(ctx context.Context, rep ibc.RelayerExecReporter, inj *faultInjector, height uint64, pathName, channelID string, ack bool) error {
+	unrelayed, err := r.unrelayed(ctx, rep, pathName, channelID, ack)
+	if err != nil {
+		return err
+	}
+
+	ch := faultChannel{path: pathName, channel: channelID, ack: ack}
+	if !inj.due(height, ch, unrelayed) || len(unrelayed.Src)+len(unrelayed.Dst) == 0 {
+		return nil
+	}
+	if ack {
+		return r.FlushAcknowledgements(ctx, rep, pathName, channelID)
+	}
+	return r.FlushPackets(ctx, rep, pathName, channelID)
+}
+
+// faultChannel identifies the packets, or acknowledgements if ack is set, pending on a channel of a path.
+type faultChannel struct {
+	path, channel string
+	ack           bool
+}
+
+// faultKey identifies a pending packet or acknowledgement, sent from the source chain of the path unless dst is set.
+type faultKey struct {
+	faultChannel
+	dst      bool
+	sequence uint64
+}
+
+// faultInjector decides when pending packets and acknowledgements are relayed.
+type faultInjector struct {
+	faults relayer.Faults
+
+	mu sync.Mutex
+	// packets counts the packets seen pending, to drop every Nth.
+	packets int
+	// releaseAt is the clock height at which each pending packet or acknowledgement may be relayed.
+	releaseAt map[faultKey]uint64
+}
+
+func newFaultInjector(faults relayer.Faults) *faultInjector {
+	return &faultInjector{faults: faults, releaseAt: map[faultKey]uint64{}}
+}
+
+// due records the sequences pending on ch at the clock height,
+// and reports whether all of them may be relayed, so that the channel can be flushed.
+func (f *faultInjector) due(height uint64, ch faultChannel, pending unrelayedSequences) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := make(map[faultKey]bool, len(pending.Src)+len(pending.Dst))
+	due := true
+	check := func(dst bool, seqs []uint64) {
+		for _, seq := range seqs {
+			k := faultKey{faultChannel: ch, dst: dst, sequence: seq}
+			seen[k] = true
+			at, ok := f.releaseAt[k]
+			if !ok {
+				at = height + f.withhold(ch.ack)
+				f.releaseAt[k] = at
+			}
+			if height < at {
+				due = false
+			}
+		}
+	}
+	check(false, pending.Src)
+	check(true, pending.Dst)
+
+	// Forget what is no longer pending, i.e. has been relayed.
+	for k := range f.releaseAt {
+		if k.faultChannel == ch && !seen[k] {
+			delete(f.releaseAt, k)
+		}
+	}
+	return due
+}
+
+// withhold returns for how many blocks a newly seen packet, or acknowledgement if ack is set, is withheld.
+func (f *faultInjector) withhold(ack bool) uint64 {
+	if ack {
+		return f.faults.DelayAckBlocks
+	}
+	f.packets++
+	if f.faults.DropEveryNth > 0 && f.packets%f.faults.DropEveryNth == 0 {
+		return f.faults.DropBlocks
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package rly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultInjector_DropEveryNth(t *testing.T) {
+	inj := newFaultInjector(relayer.Faults{DropEveryNth: 2, DropBlocks: 10})
+	ch := faultChannel{path: "p", channel: "channel-0"}
+
+	// The first packet is relayed right away.
+	require.True(t, inj.due(100, ch, unrelayedSequences{Src: []uint64{1}}))
+
+	// The second is dropped, holding back the third pending along with it.
+	require.False(t, inj.due(101, ch, unrelayedSequences{Src: []uint64{2}}))
+	require.False(t, inj.due(102, ch, unrelayedSequences{Src: []uint64{2, 3}}))
+	require.False(t, inj.due(110, ch, unrelayedSequences{Src: []uint64{2, 3}}))
+	require.True(t, inj.due(111, ch, unrelayedSequences{Src: []uint64{2, 3}}))
+
+	// Relayed packets are forgotten.
+	require.True(t, inj.due(112, ch, unrelayedSequences{}))
+	require.Empty(t, inj.releaseAt)
+
+	// Packets are counted across channels and directions.
+	other := faultChannel{path: "p", channel: "channel-1"}
+	require.False(t, inj.due(113, other, unrelayedSequences{Src: []uint64{1}, Dst: []uint64{1}}))
+}
+
+func TestFaultInjector_DelayAckBlocks(t *testing.T) {
+	inj := newFaultInjector(relayer.Faults{DelayAckBlocks: 3})
+	packets := faultChannel{path: "p", channel: "channel-0"}
+	acks := faultChannel{path: "p", channel: "channel-0", ack: true}
+
+	require.True(t, inj.due(10, packets, unrelayedSequences{Src: []uint64{1}}))
+
+	require.False(t, inj.due(11, acks, unrelayedSequences{Src: []uint64{1}}))
+	require.False(t, inj.due(13, acks, unrelayedSequences{Src: []uint64{1}}))
+	require.True(t, inj.due(14, acks, unrelayedSequences{Src: []uint64{1}}))
+}
+
+func TestStopRelayer_Faulty(t *testing.T) {
+	r := &CosmosRelayer{faults: &relayer.Faults{}}
+	done := make(chan struct{})
+	r.faulty = &faultyRelaying{cancel: func() { close(done) }, done: done, paths: []string{"p"}}
+
+	// RunningPaths may be polled while the loop is being stopped.
+	polled := make(chan struct{})
+	go func() {
+		defer close(polled)
+		for i := 0; i < 100; i++ {
+			_ = r.RunningPaths()
+		}
+	}()
+
+	require.NoError(t, r.StopRelayer(context.Background(), nil))
+	<-polled
+	require.Empty(t, r.RunningPaths())
+}
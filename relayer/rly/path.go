@@ -0,0 +1,57 @@
+package rly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+var _ ibc.PathGetter = (*CosmosRelayer)(nil)
+
+// GetPath returns the chains, clients and connections configured for the path, using rly paths show.
+func (r *CosmosRelayer) GetPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (ibc.PathOutput, error) {
+	res := r.Exec(ctx, rep, []string{"rly", "paths", "show", pathName, "--json", "--home", r.HomeDir()}, nil)
+	if res.Err != nil {
+		return ibc.PathOutput{}, fmt.Errorf("failed to show path %s: %w", pathName, res.Err)
+	}
+	p, err := parsePathsShowOutput(res.Stdout)
+	if err != nil {
+		return ibc.PathOutput{}, fmt.Errorf("failed to parse path %s: %w", pathName, err)
+	}
+	return p, nil
+}
+
+// parsePathsShowOutput parses the output of rly paths show --json,
+// which nests the path under "chains" alongside its status.
+func parsePathsShowOutput(out []byte) (ibc.PathOutput, error) {
+	type pathEnd struct {
+		ChainID      string `json:"chain-id"`
+		ClientID     string `json:"client-id"`
+		ConnectionID string `json:"connection-id"`
+	}
+	var p struct {
+		Chains struct {
+			Src pathEnd `json:"src"`
+			Dst pathEnd `json:"dst"`
+		} `json:"chains"`
+	}
+	if err := json.Unmarshal(out, &p); err != nil {
+		return ibc.PathOutput{}, err
+	}
+	src, dst := p.Chains.Src, p.Chains.Dst
+	if src.ChainID == "" || dst.ChainID == "" {
+		return ibc.PathOutput{}, fmt.Errorf("path has no source or destination chain: %s", out)
+	}
+	return ibc.PathOutput{
+		SrcChainID: src.ChainID,
+		DstChainID: dst.ChainID,
+		PathConnection: ibc.PathConnection{
+			SrcClientID:     src.ClientID,
+			SrcConnectionID: src.ConnectionID,
+			DstClientID:     dst.ClientID,
+			DstConnectionID: dst.ConnectionID,
+		},
+	}, nil
+}
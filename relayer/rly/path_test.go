@@ -0,0 +1,32 @@
+package rly
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePathsShowOutput(t *testing.T) {
+	out := []byte(`{"chains":{"src":{"chain-id":"gaia-1","client-id":"07-tendermint-0","connection-id":"connection-0"},"dst":{"chain-id":"osmosis-1","client-id":"07-tendermint-3","connection-id":"connection-2"},"src-channel-filter":{"rule":"","channel-list":[]}},"status":{"chains":true,"clients":true,"connection":true}}`)
+	p, err := parsePathsShowOutput(out)
+	require.NoError(t, err)
+	require.Equal(t, ibc.PathOutput{
+		SrcChainID: "gaia-1",
+		DstChainID: "osmosis-1",
+		PathConnection: ibc.PathConnection{
+			SrcClientID:     "07-tendermint-0",
+			SrcConnectionID: "connection-0",
+			DstClientID:     "07-tendermint-3",
+			DstConnectionID: "connection-2",
+		},
+	}, p)
+
+	// A new path has no clients or connections yet.
+	p, err = parsePathsShowOutput([]byte(`{"chains":{"src":{"chain-id":"gaia-1"},"dst":{"chain-id":"osmosis-1"}},"status":{}}`))
+	require.NoError(t, err)
+	require.Equal(t, ibc.PathOutput{SrcChainID: "gaia-1", DstChainID: "osmosis-1"}, p)
+
+	_, err = parsePathsShowOutput([]byte(`{"status":{}}`))
+	require.ErrorContains(t, err, "no source or destination chain")
+}
@@ -0,0 +1,159 @@
+package rly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// unrelayedSequences is the output of rly q unrelayed-packets and rly q unrelayed-acknowledgements,
+// with the pending sequences of packets sent from each chain of the path.
+type unrelayedSequences struct {
+	Src []uint64 `json:"src"`
+	Dst []uint64 `json:"dst"`
+}
+
+// GeneratePath creates a new path between the chains, recording it for RelayPacket.
+func (r *CosmosRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	if err := r.DockerRelayer.GeneratePath(ctx, rep, srcChainID, dstChainID, pathName); err != nil {
+		return err
+	}
+	r.paths[pathName] = pathChains{srcChainID: srcChainID, dstChainID: dstChainID}
+	return nil
+}
+
+// RelayPacket flushes the packets and then the acknowledgements of the src channel,
+// on the path generated by GeneratePath over the connection of the src channel.
+//
+// The Go relayer can only flush a channel, relaying the pending packets and acknowledgements in both directions,
+// so RelayPacket fails with ibc.ErrSingleSequenceUnsupported, before relaying anything,
+// unless sequence is the only packet and no acknowledgement is pending on either end of the channel.
+func (r *CosmosRelayer) RelayPacket(ctx context.Context, rep ibc.RelayerExecReporter, src ibc.ChannelRef, sequence uint64) error {
+	channels, err := r.GetChannels(ctx, rep, src.ChainID)
+	if err != nil {
+		return err
+	}
+	var channel *ibc.ChannelOutput
+	for _, c := range channels {
+		if c.PortID == src.PortID && c.ChannelID == src.ChannelID {
+			c := c
+			channel = &c
+		}
+	}
+	if channel == nil {
+		return fmt.Errorf("channel %s/%s not found on %s", src.PortID, src.ChannelID, src.ChainID)
+	}
+
+	paths := make(map[string]ibc.PathOutput)
+	for name, p := range r.paths {
+		if p.srcChainID != src.ChainID && p.dstChainID != src.ChainID {
+			continue
+		}
+		if paths[name], err = r.GetPath(ctx, rep, name); err != nil {
+			return err
+		}
+	}
+	pathName, onSrc, err := channelPath(*channel, src.ChainID, paths)
+	if err != nil {
+		return err
+	}
+
+	// rly identifies the channel by its end on the source chain of the path.
+	channelID := src.ChannelID
+	if !onSrc {
+		channelID = channel.Counterparty.ChannelID
+	}
+
+	packets, err := r.unrelayed(ctx, rep, pathName, channelID, false)
+	if err != nil {
+		return err
+	}
+	sent, received := packets.Src, packets.Dst
+	if !onSrc {
+		sent, received = received, sent
+	}
+	if err := onlyPending(sent, sequence); err != nil {
+		return fmt.Errorf("packet %d on %s/%s of %s: %w", sequence, src.PortID, src.ChannelID, src.ChainID, err)
+	}
+	if len(received) > 0 {
+		return fmt.Errorf("packet %d on %s/%s of %s: %w: packets %v are also pending to it", sequence, src.PortID, src.ChannelID, src.ChainID, ibc.ErrSingleSequenceUnsupported, received)
+	}
+
+	acks, err := r.unrelayed(ctx, rep, pathName, channelID, true)
+	if err != nil {
+		return err
+	}
+	if len(acks.Src)+len(acks.Dst) > 0 {
+		return fmt.Errorf("packet %d on %s/%s of %s: %w: acknowledgements of packets %v and %v are also pending", sequence, src.PortID, src.ChannelID, src.ChainID, ibc.ErrSingleSequenceUnsupported, acks.Src, acks.Dst)
+	}
+
+	if err := r.FlushPackets(ctx, rep, pathName, channelID); err != nil {
+		return err
+	}
+	return r.FlushAcknowledgements(ctx, rep, pathName, channelID)
+}
+
+// unrelayed returns the sequences of the packets, or acknowledgements if ack is set, pending on channelID of the path.
+func (r *CosmosRelayer) unrelayed(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string, ack bool) (unrelayedSequences, error) {
+	query := "unrelayed-packets"
+	if ack {
+		query = "unrelayed-acknowledgements"
+	}
+	res := r.Exec(ctx, rep, []string{"rly", "q", query, pathName, channelID, "--home", r.HomeDir()}, nil)
+	if res.Err != nil {
+		return unrelayedSequences{}, res.Err
+	}
+	var unrelayed unrelayedSequences
+	if err := json.Unmarshal(res.Stdout, &unrelayed); err != nil {
+		return unrelayedSequences{}, fmt.Errorf("failed to parse %s: %w", query, err)
+	}
+	return unrelayed, nil
+}
+
+// channelPath returns the only one of paths over the connection of channel, which is on chainID,
+// and whether chainID is the source chain of that path.
+func channelPath(channel ibc.ChannelOutput, chainID string, paths map[string]ibc.PathOutput) (pathName string, onSrc bool, _ error) {
+	if len(channel.ConnectionHops) == 0 {
+		return "", false, fmt.Errorf("channel %s/%s on %s has no connection", channel.PortID, channel.ChannelID, chainID)
+	}
+	connectionID := channel.ConnectionHops[0]
+
+	var matched []string
+	for name, p := range paths {
+		if id, err := p.ConnectionID(chainID); err == nil && id == connectionID {
+			matched = append(matched, name)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return "", false, fmt.Errorf("no path over connection %s of channel %s/%s on %s", connectionID, channel.PortID, channel.ChannelID, chainID)
+	case 1:
+		return matched[0], paths[matched[0]].SrcChainID == chainID, nil
+	default:
+		sort.Strings(matched)
+		return "", false, fmt.Errorf("connection %s of channel %s/%s on %s is on several paths %v", connectionID, channel.PortID, channel.ChannelID, chainID, matched)
+	}
+}
+
+// onlyPending returns an error unless sequence is the only pending sequence.
+func onlyPending(pending []uint64, sequence uint64) error {
+	found := false
+	var others []uint64
+	for _, seq := range pending {
+		if seq == sequence {
+			found = true
+		} else {
+			others = append(others, seq)
+		}
+	}
+	if !found {
+		return fmt.Errorf("not pending")
+	}
+	if len(others) > 0 {
+		return fmt.Errorf("%w: packets %v are also pending", ibc.ErrSingleSequenceUnsupported, others)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rly
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnlyPending(t *testing.T) {
+	require.NoError(t, onlyPending([]uint64{3}, 3))
+
+	require.EqualError(t, onlyPending(nil, 3), "not pending")
+	require.EqualError(t, onlyPending([]uint64{1, 2}, 3), "not pending")
+
+	err := onlyPending([]uint64{2, 3, 4}, 3)
+	require.True(t, errors.Is(err, ibc.ErrSingleSequenceUnsupported))
+	require.Contains(t, err.Error(), "[2 4]")
+}
+
+func TestChannelPath(t *testing.T) {
+	paths := map[string]ibc.PathOutput{
+		"ab":        {SrcChainID: "a", DstChainID: "b", PathConnection: ibc.PathConnection{SrcConnectionID: "connection-0", DstConnectionID: "connection-0"}},
+		"ab-second": {SrcChainID: "a", DstChainID: "b", PathConnection: ibc.PathConnection{SrcConnectionID: "connection-1", DstConnectionID: "connection-1"}},
+		"cb":        {SrcChainID: "c", DstChainID: "b", PathConnection: ibc.PathConnection{SrcConnectionID: "connection-0", DstConnectionID: "connection-2"}},
+	}
+	channel := func(connectionID string) ibc.ChannelOutput {
+		return ibc.ChannelOutput{PortID: "transfer", ChannelID: "channel-0", ConnectionHops: []string{connectionID}}
+	}
+
+	// Paths between the same chains are told apart by their connection.
+	path, onSrc, err := channelPath(channel("connection-1"), "a", paths)
+	require.NoError(t, err)
+	require.Equal(t, "ab-second", path)
+	require.True(t, onSrc)
+
+	path, onSrc, err = channelPath(channel("connection-0"), "b", paths)
+	require.NoError(t, err)
+	require.Equal(t, "ab", path)
+	require.False(t, onSrc)
+
+	path, onSrc, err = channelPath(channel("connection-2"), "b", paths)
+	require.NoError(t, err)
+	require.Equal(t, "cb", path)
+	require.False(t, onSrc)
+
+	_, _, err = channelPath(channel("connection-5"), "a", paths)
+	require.EqualError(t, err, "no path over connection connection-5 of channel transfer/channel-0 on a")
+
+	paths["ab-again"] = paths["ab"]
+	_, _, err = channelPath(channel("connection-0"), "a", paths)
+	require.ErrorContains(t, err, "is on several paths [ab ab-again]")
+	require.False(t, errors.Is(err, ibc.ErrSingleSequenceUnsupported))
+
+	_, _, err = channelPath(ibc.ChannelOutput{PortID: "transfer", ChannelID: "channel-0"}, "a", paths)
+	require.ErrorContains(t, err, "has no connection")
+}
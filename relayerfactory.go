@@ -79,26 +79,33 @@ func (f builtinRelayerFactory) Name() string {
 		// This is using the string "rly" instead of rly.ContainerImage
 		// so that the slashes in the image repository don't add ambiguity
 		// to subtest paths, when the factory name is used in calls to t.Run.
-		for _, opt := range f.options {
-			switch o := opt.(type) {
-			case relayer.RelayerOptionDockerImage:
-				return "rly@" + o.DockerImage.Version
-			}
-		}
-		return "rly@" + rly.DefaultContainerVersion
+		return "rly@" + f.imageVersion(rly.DefaultContainerVersion)
 	case ibc.Hermes:
-		for _, opt := range f.options {
-			switch o := opt.(type) {
-			case relayer.RelayerOptionDockerImage:
-				return "hermes@" + o.DockerImage.Version
-			}
-		}
-		return "hermes@" + hermes.DefaultContainerVersion
+		return "hermes@" + f.imageVersion(hermes.DefaultContainerVersion)
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
 }
 
+// imageVersion returns the version of the relayer image configured by the factory's options,
+// or defaultVersion if it is not overridden.
+func (f builtinRelayerFactory) imageVersion(defaultVersion string) string {
+	version := defaultVersion
+	var pinned string
+	for _, opt := range f.options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionDockerImage:
+			version = o.DockerImage.Version
+		case relayer.RelayerOptionImageVersion:
+			pinned = o.Version
+		}
+	}
+	if pinned != "" {
+		return pinned
+	}
+	return version
+}
+
 func (f builtinRelayerFactory) Labels() []label.Relayer {
 	switch f.impl {
 	case ibc.CosmosRly:
@@ -0,0 +1,27 @@
+package interchaintest
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer"
+	"github.com/strangelove-ventures/interchaintest/v6/relayer/rly"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuiltinRelayerFactory_Name(t *testing.T) {
+	log := zap.NewNop()
+
+	f := NewBuiltinRelayerFactory(ibc.CosmosRly, log)
+	require.Equal(t, "rly@"+rly.DefaultContainerVersion, f.Name())
+
+	f = NewBuiltinRelayerFactory(ibc.CosmosRly, log, relayer.CustomDockerImage("my/relayer", "v9.9.9", "100:1000"))
+	require.Equal(t, "rly@v9.9.9", f.Name())
+
+	f = NewBuiltinRelayerFactory(ibc.CosmosRly, log,
+		relayer.ImageVersion("v2.4.0-rc1"),
+		relayer.CustomDockerImage("my/relayer", "v9.9.9", "100:1000"),
+	)
+	require.Equal(t, "rly@v2.4.0-rc1", f.Name(), "the pinned version applies to the custom image")
+}
@@ -0,0 +1,137 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// Restartable is implemented by chains whose nodes can be stopped
+// and started again from the same Docker volumes, such as cosmos chains.
+type Restartable interface {
+	StopAllNodes(ctx context.Context) error
+	StartAllNodes(ctx context.Context) error
+}
+
+// runningRelayer is implemented by relayers that report the paths they are relaying, such as relayer.DockerRelayer,
+// so that Stop can resume them in Start.
+type runningRelayer interface {
+	RunningPaths() []string
+}
+
+// Stop halts every chain and running relayer of the Interchain, keeping their data volumes,
+// so that Start can bring them back up with their state intact. Unlike Close, Stop destroys nothing.
+//
+// Every chain must be Restartable. Relayers are only stopped if they report their running paths,
+// and are started again on the same paths by Start, including those stopped before a failure of Stop.
+// Network conditions are lost with the node containers, so reapply them after Start if needed,
+// and a partitioned network must be healed before calling Stop.
+func (ic *Interchain) Stop(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if !ic.built {
+		return fmt.Errorf("Stop called before Build")
+	}
+	if ic.partition != nil {
+		return fmt.Errorf("network is partitioned; call HealNetwork before Stop")
+	}
+
+	chains, err := ic.restartables()
+	if err != nil {
+		return err
+	}
+
+	// Relayers are stopped first, so they do not observe chains going down.
+	// Each is recorded before it is stopped, so that Start resumes it even if Stop fails partway,
+	// and relayers recorded by an earlier, failed Stop are kept.
+	if ic.stoppedRelayers == nil {
+		ic.stoppedRelayers = make(map[ibc.Relayer][]string)
+	}
+	for r, name := range ic.relayers {
+		rr, ok := r.(runningRelayer)
+		if !ok {
+			continue
+		}
+		paths := rr.RunningPaths()
+		if len(paths) == 0 {
+			continue
+		}
+		ic.stoppedRelayers[r] = paths
+		if err := r.StopRelayer(ctx, rep); err != nil {
+			return fmt.Errorf("failed to stop relayer %s: %w", name, err)
+		}
+	}
+
+	// Left paused, should stopping the chains fail, as some of them may be halted.
+	ic.liveness.pause()
+	return ic.forEachRestartable(chains, func(c Restartable) error {
+		return c.StopAllNodes(ctx)
+	})
+}
+
+// Start resumes the chains and relayers halted by Stop.
+// Chains continue from their last height and must produce a block before the relayers are started again
+// on the paths they were relaying.
+func (ic *Interchain) Start(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if !ic.built {
+		return fmt.Errorf("Start called before Build")
+	}
+
+	chains, err := ic.restartables()
+	if err != nil {
+		return err
+	}
+
+	if err := ic.forEachRestartable(chains, func(c Restartable) error {
+		return c.StartAllNodes(ctx)
+	}); err != nil {
+		return err
+	}
+
+	heighters := make([]testutil.ChainHeighter, 0, len(chains))
+	for c := range chains {
+		heighters = append(heighters, c)
+	}
+	if err := testutil.WaitForBlocks(ctx, 1, heighters...); err != nil {
+		return fmt.Errorf("chains did not resume producing blocks: %w", err)
+	}
+	ic.liveness.resume()
+
+	for r, paths := range ic.stoppedRelayers {
+		if err := r.StartRelayer(ctx, rep, paths...); err != nil {
+			return fmt.Errorf("failed to start relayer %s: %w", ic.relayers[r], err)
+		}
+		delete(ic.stoppedRelayers, r)
+	}
+	return nil
+}
+
+// restartables returns the chains of the Interchain as Restartables,
+// or an error if any of them cannot be restarted.
+func (ic *Interchain) restartables() (map[ibc.Chain]Restartable, error) {
+	chains := make(map[ibc.Chain]Restartable, len(ic.chains))
+	for c, id := range ic.chains {
+		r, ok := c.(Restartable)
+		if !ok {
+			return nil, fmt.Errorf("chain %s (%T) does not support restarts", id, c)
+		}
+		chains[c] = r
+	}
+	return chains, nil
+}
+
+// forEachRestartable calls fn concurrently for each chain.
+func (ic *Interchain) forEachRestartable(chains map[ibc.Chain]Restartable, fn func(Restartable) error) error {
+	var eg errgroup.Group
+	for c, r := range chains {
+		c, r := c, r
+		eg.Go(func() error {
+			if err := fn(r); err != nil {
+				return fmt.Errorf("chain %s: %w", ic.chains[c], err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
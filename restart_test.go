@@ -0,0 +1,146 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type restartChain struct {
+	ibc.Chain // Unimplemented methods panic.
+
+	running bool
+	height  uint64
+}
+
+func (c *restartChain) StopAllNodes(ctx context.Context) error {
+	c.running = false
+	return nil
+}
+
+func (c *restartChain) StartAllNodes(ctx context.Context) error {
+	c.running = true
+	return nil
+}
+
+func (c *restartChain) Height(ctx context.Context) (uint64, error) {
+	if c.running {
+		c.height++
+	}
+	return c.height, nil
+}
+
+type restartRelayer struct {
+	ibc.Relayer // Unimplemented methods panic.
+
+	paths []string
+}
+
+func (r *restartRelayer) RunningPaths() []string {
+	return r.paths
+}
+
+func (r *restartRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	r.paths = nil
+	return nil
+}
+
+func (r *restartRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	r.paths = pathNames
+	return nil
+}
+
+func TestInterchain_StopStart(t *testing.T) {
+	ctx := context.Background()
+
+	c := &restartChain{running: true, height: 10}
+	r := &restartRelayer{paths: []string{"a-b"}}
+	idle := &restartRelayer{}
+
+	ic := NewInterchain()
+	ic.chains = map[ibc.Chain]string{c: "a"}
+	ic.relayers = map[ibc.Relayer]string{r: "r", idle: "idle"}
+
+	require.ErrorContains(t, ic.Stop(ctx, nil), "before Build")
+	ic.built = true
+
+	require.NoError(t, ic.Stop(ctx, nil))
+	require.False(t, c.running)
+	require.Empty(t, r.paths)
+
+	require.NoError(t, ic.Start(ctx, nil))
+	require.True(t, c.running)
+	require.Greater(t, c.height, uint64(10))
+	require.Equal(t, []string{"a-b"}, r.paths)
+	require.Empty(t, idle.paths, "relayers not running at Stop must not be started")
+}
+
+func TestInterchain_StopUnsupportedChain(t *testing.T) {
+	ic := NewInterchain()
+	ic.chains = map[ibc.Chain]string{&readyChain{name: "a"}: "a"}
+	ic.built = true
+
+	require.ErrorContains(t, ic.Stop(context.Background(), nil), "does not support restarts")
+}
+
+// failingStopRelayer is a running relayer that fails to stop.
+type failingStopRelayer struct {
+	restartRelayer
+}
+
+func (r *failingStopRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	return errors.New("container gone")
+}
+
+func TestInterchain_StopFailsPartway(t *testing.T) {
+	ctx := context.Background()
+
+	c := &restartChain{running: true, height: 10}
+	failing := &failingStopRelayer{restartRelayer{paths: []string{"c-d"}}}
+
+	ic := NewInterchain()
+	ic.chains = map[ibc.Chain]string{c: "a"}
+	ic.relayers = map[ibc.Relayer]string{failing: "failing"}
+
+	// Several relayers, so that some are likely stopped before the failing one.
+	var relayers []*restartRelayer
+	for i := 0; i < 8; i++ {
+		r := &restartRelayer{paths: []string{"a-b"}}
+		relayers = append(relayers, r)
+		ic.relayers[r] = fmt.Sprintf("r%d", i)
+	}
+	ic.built = true
+
+	require.EqualError(t, ic.Stop(ctx, nil), "failed to stop relayer failing: container gone")
+	for _, r := range relayers {
+		if len(r.paths) == 0 {
+			require.Contains(t, ic.stoppedRelayers, r, "stopped relayer not recorded")
+		}
+	}
+
+	require.NoError(t, ic.Start(ctx, nil))
+	for _, r := range relayers {
+		require.Equal(t, []string{"a-b"}, r.paths)
+	}
+	require.Equal(t, []string{"c-d"}, failing.paths)
+	require.Empty(t, ic.stoppedRelayers)
+}
+
+func TestInterchain_CloseStopsRunningRelayers(t *testing.T) {
+	r := &restartRelayer{paths: []string{"a-b"}}
+	stopped := &restartRelayer{}
+	failing := &failingStopRelayer{restartRelayer{paths: []string{"c-d"}}}
+
+	ic := NewInterchain()
+	ic.relayers = map[ibc.Relayer]string{r: "r", stopped: "stopped", failing: "failing"}
+
+	err := ic.Close()
+	require.EqualError(t, err, "failed to stop relayer failing: container gone")
+	require.Empty(t, r.paths)
+
+	require.NoError(t, ic.Close(), "Close must be idempotent")
+}
@@ -0,0 +1,125 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// SnapshotID identifies a checkpoint created by (*Interchain).Snapshot.
+type SnapshotID string
+
+// Snapshotter is implemented by chains and relayers
+// whose state can be checkpointed and restored through their Docker volumes.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, name string) error
+	Restore(ctx context.Context, name string) error
+}
+
+// Snapshot checkpoints the data volumes of every chain and relayer in the Interchain,
+// so that a later call to Restore can return them all to the current state.
+//
+// All relayers must be stopped before calling Snapshot.
+// With no relayer running, no packets or client updates are in flight between chains,
+// so each chain may be checkpointed independently while staying consistent with the relayer state.
+// Chains are briefly stopped during the snapshot and are running again when Snapshot returns.
+func (ic *Interchain) Snapshot(ctx context.Context) (SnapshotID, error) {
+	if !ic.built {
+		return "", fmt.Errorf("Snapshot called before Build")
+	}
+
+	chains, relayers, err := ic.snapshotters()
+	if err != nil {
+		return "", err
+	}
+
+	id := SnapshotID(fmt.Sprintf("%d-%s", time.Now().Unix(), dockerutil.RandLowerCaseLetterString(6)))
+
+	// Relayers are snapshotted first, as they return an error if still running;
+	// that way no chain is stopped unnecessarily.
+	for r, s := range relayers {
+		if err := s.Snapshot(ctx, string(id)); err != nil {
+			return "", fmt.Errorf("failed to snapshot relayer %s: %w", ic.relayers[r], err)
+		}
+	}
+
+	ic.liveness.pause()
+	defer ic.liveness.resume()
+	if err := ic.forEachSnapshotter(chains, func(s Snapshotter) error {
+		return s.Snapshot(ctx, string(id))
+	}); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Restore returns every chain and relayer in the Interchain to the state
+// captured by the Snapshot call that returned id.
+//
+// All relayers must be stopped before calling Restore, and may be started again once Restore returns.
+func (ic *Interchain) Restore(ctx context.Context, id SnapshotID) error {
+	if !ic.built {
+		return fmt.Errorf("Restore called before Build")
+	}
+
+	chains, relayers, err := ic.snapshotters()
+	if err != nil {
+		return err
+	}
+
+	for r, s := range relayers {
+		if err := s.Restore(ctx, string(id)); err != nil {
+			return fmt.Errorf("failed to restore relayer %s: %w", ic.relayers[r], err)
+		}
+	}
+
+	ic.liveness.pause()
+	defer ic.liveness.resume()
+	return ic.forEachSnapshotter(chains, func(s Snapshotter) error {
+		return s.Restore(ctx, string(id))
+	})
+}
+
+// snapshotters returns the chains and relayers of the Interchain as Snapshotters,
+// or an error if any of them does not support snapshots.
+func (ic *Interchain) snapshotters() (map[ibc.Chain]Snapshotter, map[ibc.Relayer]Snapshotter, error) {
+	chains := make(map[ibc.Chain]Snapshotter, len(ic.chains))
+	for c, id := range ic.chains {
+		s, ok := c.(Snapshotter)
+		if !ok {
+			return nil, nil, fmt.Errorf("chain %s (%T) does not support snapshots", id, c)
+		}
+		chains[c] = s
+	}
+
+	relayers := make(map[ibc.Relayer]Snapshotter, len(ic.relayers))
+	for r, name := range ic.relayers {
+		s, ok := r.(Snapshotter)
+		if !ok {
+			return nil, nil, fmt.Errorf("relayer %s (%T) does not support snapshots", name, r)
+		}
+		relayers[r] = s
+	}
+
+	return chains, relayers, nil
+}
+
+// forEachSnapshotter calls fn concurrently for each chain snapshotter.
+func (ic *Interchain) forEachSnapshotter(chains map[ibc.Chain]Snapshotter, fn func(Snapshotter) error) error {
+	var eg errgroup.Group
+	for c, s := range chains {
+		c, s := c, s
+		eg.Go(func() error {
+			if err := fn(s); err != nil {
+				return fmt.Errorf("chain %s: %w", ic.chains[c], err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
@@ -18,8 +19,21 @@ const (
 	testPathName = "test-path"
 
 	FaucetAccountKeyName = "faucet"
+
+	// DefaultFaucetBalance is the faucet's genesis balance when ChainConfig.FaucetBalance is unset.
+	DefaultFaucetBalance = int64(100_000_000_000_000)
 )
 
+// GetFaucetAddress returns the bech32 address of the faucet account on chain,
+// which funds the users created by GetAndFundTestUsers.
+func GetFaucetAddress(ctx context.Context, chain ibc.Chain) (string, error) {
+	addr, err := chain.GetAddress(ctx, FaucetAccountKeyName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get faucet address on chain %s: %w", chain.Config().ChainID, err)
+	}
+	return types.Bech32ifyAddressBytes(chain.Config().Bech32Prefix, addr)
+}
+
 // KeepDockerVolumesOnFailure sets whether volumes associated with a particular test
 // are retained or deleted following a test failure.
 //
@@ -30,14 +44,78 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+// KeepDockerContainersOnFailure sets whether the containers, volumes, and networks of a failed test
+// are left running so they can be inspected, in which case the commands to reach each container are logged.
+// Passing tests are still cleaned up.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable IBCTEST_KEEP_CONTAINERS_ON_FAILURE to a non-empty value.
+func KeepDockerContainersOnFailure(b bool) {
+	dockerutil.KeepContainersOnFailure = b
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
-func DockerSetup(t *testing.T) (*client.Client, string) {
+func DockerSetup(t testing.TB) (*client.Client, string) {
 	t.Helper()
 	return dockerutil.DockerSetup(t)
 }
 
+// DockerSetupOptions configure the Docker network created by DockerSetupWithOptions.
+type DockerSetupOptions = dockerutil.DockerSetupOptions
+
+// DockerSetupWithOptions is like DockerSetup, but creates the network according to opts,
+// e.g. with a Subnet and Gateway that avoid conflicts with the routes of the host, such as those of a VPN.
+// If Subnet is empty, Docker allocates one as in DockerSetup.
+//
+// DockerSetupWithOptions panics if opts are invalid or any part of the setup fails.
+func DockerSetupWithOptions(t testing.TB, opts DockerSetupOptions) (*client.Client, string) {
+	t.Helper()
+	return dockerutil.DockerSetupWithOptions(t, opts)
+}
+
+// DockerSetupWithNetwork is like DockerSetup, but reuses the existing Docker network with the given name or ID
+// instead of creating a new one, which helps environments that run into Docker's network limits.
+// An empty network behaves exactly like DockerSetup.
+// A reused network is not removed when t completes.
+//
+// If any part of the setup fails, t.Fatal is called.
+func DockerSetupWithNetwork(t testing.TB, network string) (*client.Client, string) {
+	t.Helper()
+	return dockerutil.DockerSetupWithNetwork(t, network)
+}
+
+// AssertNoLeakedResources fails t if any Docker container, volume, or network created for t by the current process
+// remains once t and all its cleanup functions have run, e.g. because a helper bypassed the labeling of DockerSetup
+// or a cleanup regressed. Resources kept on purpose after a failure, see KeepDockerContainersOnFailure, are not reported.
+//
+// Cleanup functions run in reverse order of registration, so call AssertNoLeakedResources first,
+// before DockerSetup and anything else registering cleanup, for the check to run after all of them:
+//
+//	interchaintest.AssertNoLeakedResources(t)
+//	client, network := interchaintest.DockerSetup(t)
+func AssertNoLeakedResources(t testing.TB) {
+	t.Helper()
+	t.Cleanup(func() {
+		cli, err := client.NewClientWithOpts(client.FromEnv)
+		if err != nil {
+			t.Errorf("Failed to create docker client to check for leaked resources: %v", err)
+			return
+		}
+		defer cli.Close()
+
+		leaked, err := dockerutil.FindLeakedResources(context.Background(), cli, t.Name(), t.Failed())
+		if err != nil {
+			t.Errorf("Failed to check for leaked docker resources: %v", err)
+			return
+		}
+		if !leaked.Empty() {
+			t.Errorf("Docker resources of %s leaked after cleanup: %s", t.Name(), leaked)
+		}
+	})
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
@@ -2,9 +2,11 @@ package interchaintest
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"testing"
 
+	"github.com/cosmos/go-bip39"
 	"github.com/strangelove-ventures/interchaintest/v6/ibc"
 	"github.com/strangelove-ventures/interchaintest/v6/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v6/testutil"
@@ -20,6 +22,17 @@ func GetAndFundTestUserWithMnemonic(
 	keyNamePrefix, mnemonic string,
 	amount int64,
 	chain ibc.Chain,
+) (ibc.Wallet, error) {
+	return getAndFundTestUser(ctx, keyNamePrefix, mnemonic, chain.Config().Denom, amount, chain)
+}
+
+// getAndFundTestUser restores a user using the given mnemonic, or a new key if it is empty,
+// and funds it from the faucet with amount of denom.
+func getAndFundTestUser(
+	ctx context.Context,
+	keyNamePrefix, mnemonic, denom string,
+	amount int64,
+	chain ibc.Chain,
 ) (ibc.Wallet, error) {
 	chainCfg := chain.Config()
 	keyName := fmt.Sprintf("%s-%s-%s", keyNamePrefix, chainCfg.ChainID, dockerutil.RandLowerCaseLetterString(3))
@@ -31,30 +44,137 @@ func GetAndFundTestUserWithMnemonic(
 	err = chain.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
 		Address: user.FormattedAddress(),
 		Amount:  amount,
-		Denom:   chainCfg.Denom,
+		Denom:   denom,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get funds from faucet: %w", err)
+		return nil, faucetError(ctx, chain, denom, amount, err)
 	}
 	return user, nil
 }
 
+// faucetError wraps a failure to fund a user, first reporting whether the faucet ran dry,
+// which is otherwise only visible as an obscure insufficient-funds error.
+func faucetError(ctx context.Context, chain ibc.Chain, denom string, amount int64, sendErr error) error {
+	chainCfg := chain.Config()
+	faucetAddr, err := GetFaucetAddress(ctx, chain)
+	if err != nil {
+		return fmt.Errorf("failed to get funds from faucet: %w", sendErr)
+	}
+
+	bal, err := chain.GetBalance(ctx, faucetAddr, denom)
+	if err == nil && bal < amount {
+		field := "ChainConfig.FaucetBalance"
+		if denom != chainCfg.Denom {
+			field = "ChainConfig.FaucetDenoms"
+		}
+		return fmt.Errorf(
+			"faucet %s on chain %s has %d%s left, not enough to fund %d%s (increase %s): %w",
+			faucetAddr, chainCfg.ChainID, bal, denom, amount, denom, field, sendErr,
+		)
+	}
+	return fmt.Errorf("failed to get funds from faucet: %w", sendErr)
+}
+
 // GetAndFundTestUsers generates and funds chain users with the native chain denom.
 // The caller should wait for some blocks to complete before the funds will be accessible.
 func GetAndFundTestUsers(
-	t *testing.T,
+	t testing.TB,
+	ctx context.Context,
+	keyNamePrefix string,
+	amount int64,
+	chains ...ibc.Chain,
+) []ibc.Wallet {
+	return GetAndFundTestUsersWithMnemonic(t, ctx, keyNamePrefix, "", amount, chains...)
+}
+
+// GetAndFundTestUsersWithMnemonic restores a user from the same mnemonic on every chain
+// and funds each with the chain's native denom.
+// Each returned wallet's address uses its own chain's bech32 prefix,
+// so the same mnemonic produces a different formatted address per chain.
+// If mnemonic is empty, a new random key is generated for each chain.
+// The caller should wait for some blocks to complete before the funds will be accessible.
+func GetAndFundTestUsersWithMnemonic(
+	t testing.TB,
+	ctx context.Context,
+	keyNamePrefix, mnemonic string,
+	amount int64,
+	chains ...ibc.Chain,
+) []ibc.Wallet {
+	users, err := fundTestUsers(ctx, keyNamePrefix, mnemonic, "", amount, chains...)
+	require.NoError(t, err)
+	return users
+}
+
+// GetAndFundTestUsersWithDenom is like GetAndFundTestUsers, but funds each user with amount of denom,
+// e.g. a fee token, which must be held by every chain's faucet, see ChainConfig.FaucetDenoms.
+// The caller should wait for some blocks to complete before the funds will be accessible.
+func GetAndFundTestUsersWithDenom(
+	t testing.TB,
+	ctx context.Context,
+	keyNamePrefix, denom string,
+	amount int64,
+	chains ...ibc.Chain,
+) []ibc.Wallet {
+	users, err := fundTestUsers(ctx, keyNamePrefix, "", denom, amount, chains...)
+	require.NoError(t, err)
+	return users
+}
+
+// GetAndFundDeterministicTestUsers is like GetAndFundTestUsers, but restores each user from DeterministicMnemonic
+// of the test's name, keyNamePrefix and index, so that every run of the test funds the same addresses,
+// e.g. for reproducible logs or comparisons against golden files.
+// Each call within a test must use a different keyNamePrefix or index, as a key cannot be restored twice on a chain.
+// The caller should wait for some blocks to complete before the funds will be accessible.
+func GetAndFundDeterministicTestUsers(
+	t testing.TB,
 	ctx context.Context,
 	keyNamePrefix string,
+	index int,
 	amount int64,
 	chains ...ibc.Chain,
 ) []ibc.Wallet {
+	mnemonic, err := DeterministicMnemonic(t.Name()+"/"+keyNamePrefix, index)
+	require.NoError(t, err)
+	return GetAndFundTestUsersWithMnemonic(t, ctx, keyNamePrefix, mnemonic, amount, chains...)
+}
+
+// DeterministicMnemonic returns the mnemonic derived from seed and index, always the same for the same arguments.
+// The derived keys are only as secret as seed, so they must not hold anything of value.
+func DeterministicMnemonic(seed string, index int) (string, error) {
+	entropy := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", seed, index)))
+	return bip39.NewMnemonic(entropy[:])
+}
+
+// FundTestUsers is like GetAndFundTestUsers, but returns an error instead of failing the test,
+// so that it can be used by shared setup code or benchmarks without a *testing.T.
+func FundTestUsers(
+	ctx context.Context,
+	keyNamePrefix string,
+	amount int64,
+	chains ...ibc.Chain,
+) ([]ibc.Wallet, error) {
+	return fundTestUsers(ctx, keyNamePrefix, "", "", amount, chains...)
+}
+
+// fundTestUsers concurrently creates and funds a user on each chain, as GetAndFundTestUserWithMnemonic does.
+// If denom is empty, each user is funded with its chain's native denom.
+func fundTestUsers(
+	ctx context.Context,
+	keyNamePrefix, mnemonic, denom string,
+	amount int64,
+	chains ...ibc.Chain,
+) ([]ibc.Wallet, error) {
 	users := make([]ibc.Wallet, len(chains))
 	var eg errgroup.Group
 	for i, chain := range chains {
 		i := i
 		chain := chain
 		eg.Go(func() error {
-			user, err := GetAndFundTestUserWithMnemonic(ctx, keyNamePrefix, "", amount, chain)
+			d := denom
+			if d == "" {
+				d = chain.Config().Denom
+			}
+			user, err := getAndFundTestUser(ctx, keyNamePrefix, mnemonic, d, amount, chain)
 			if err != nil {
 				return err
 			}
@@ -62,12 +182,72 @@ func GetAndFundTestUsers(
 			return nil
 		})
 	}
-	require.NoError(t, eg.Wait())
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// relayerFundingBlocks is how many blocks FundRelayerWallet waits for the funds to arrive.
+const relayerFundingBlocks = 10
 
-	// TODO(nix 05-17-2022): Map with generics once using go 1.18
-	chainHeights := make([]testutil.ChainHeighter, len(chains))
-	for i := range chains {
-		chainHeights[i] = chains[i]
+// FundRelayerWallet tops up the wallet of the relayer on chain, as returned by r.GetWallet,
+// so that it holds at least amount of the chain's native denom,
+// and waits for the funds to arrive.
+// Relayer wallets are funded at genesis, but a long-running test, e.g. of ICS-29 fees, may drain them.
+func FundRelayerWallet(ctx context.Context, r ibc.Relayer, amount int64, chain ibc.Chain) error {
+	chainCfg := chain.Config()
+	wallet, ok := r.GetWallet(chainCfg.ChainID)
+	if !ok {
+		return fmt.Errorf("relayer has no wallet on chain %s", chainCfg.ChainID)
 	}
-	return users
+	addr := wallet.FormattedAddress()
+
+	bal, err := chain.GetBalance(ctx, addr, chainCfg.Denom)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of relayer wallet %s: %w", addr, err)
+	}
+	if bal >= amount {
+		return nil
+	}
+
+	topUp := amount - bal
+	if err := chain.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
+		Address: addr,
+		Amount:  topUp,
+		Denom:   chainCfg.Denom,
+	}); err != nil {
+		return faucetError(ctx, chain, chainCfg.Denom, topUp, err)
+	}
+
+	h, err := chain.Height(ctx)
+	if err != nil {
+		return err
+	}
+	// The relayer may be paying fees meanwhile, so the balance is not compared to amount exactly.
+	poll := func(ctx context.Context, _ uint64) (any, error) {
+		got, err := chain.GetBalance(ctx, addr, chainCfg.Denom)
+		if err != nil {
+			return nil, err
+		}
+		if got <= bal {
+			return nil, fmt.Errorf("relayer wallet %s balance %d%s has not been topped up", addr, got, chainCfg.Denom)
+		}
+		return nil, nil
+	}
+	poller := testutil.BlockPoller[any]{CurrentHeight: chain.Height, PollFunc: poll}
+	_, err = poller.DoPoll(ctx, h, h+relayerFundingBlocks)
+	return err
+}
+
+// FundRelayerWallets calls FundRelayerWallet for the relayer's wallet on each chain concurrently.
+func FundRelayerWallets(t *testing.T, ctx context.Context, r ibc.Relayer, amount int64, chains ...ibc.Chain) {
+	var eg errgroup.Group
+	for _, chain := range chains {
+		chain := chain
+		eg.Go(func() error {
+			return FundRelayerWallet(ctx, r, amount, chain)
+		})
+	}
+	require.NoError(t, eg.Wait())
 }
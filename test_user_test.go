@@ -0,0 +1,78 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type walletChain struct {
+	ibc.Chain // Unimplemented methods panic.
+
+	chainID string
+	err     error
+
+	mu    sync.Mutex
+	funds []ibc.WalletAmount
+}
+
+func (c *walletChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: c.chainID, Denom: "ustake", Bech32Prefix: "cosmos"}
+}
+
+func (c *walletChain) BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return cosmos.NewWallet(keyName, []byte("addr"), mnemonic, c.Config()), nil
+}
+
+func (c *walletChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.funds = append(c.funds, amount)
+	return nil
+}
+
+func TestFundTestUsers(t *testing.T) {
+	ctx := context.Background()
+
+	users, err := FundTestUsers(ctx, "user", 100, &walletChain{chainID: "a"}, &walletChain{chainID: "b"})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	_, err = FundTestUsers(ctx, "user", 100, &walletChain{chainID: "a"}, &walletChain{chainID: "b", err: errors.New("boom")})
+	require.ErrorContains(t, err, "boom")
+}
+
+func TestGetAndFundTestUsersWithDenom(t *testing.T) {
+	a, b := &walletChain{chainID: "a"}, &walletChain{chainID: "b"}
+
+	users := GetAndFundTestUsersWithDenom(t, context.Background(), "user", "ufee", 100, a, b)
+	require.Len(t, users, 2)
+
+	for i, c := range []*walletChain{a, b} {
+		require.Equal(t, []ibc.WalletAmount{{Address: users[i].FormattedAddress(), Denom: "ufee", Amount: 100}}, c.funds)
+	}
+}
+
+func TestGetAndFundDeterministicTestUsers(t *testing.T) {
+	ctx := context.Background()
+
+	first := GetAndFundDeterministicTestUsers(t, ctx, "user", 0, 100, &walletChain{chainID: "a"})
+	again := GetAndFundDeterministicTestUsers(t, ctx, "user", 0, 100, &walletChain{chainID: "a"})
+	other := GetAndFundDeterministicTestUsers(t, ctx, "user", 1, 100, &walletChain{chainID: "a"})
+
+	require.NotEmpty(t, first[0].Mnemonic())
+	require.Equal(t, first[0].Mnemonic(), again[0].Mnemonic())
+	require.NotEqual(t, first[0].Mnemonic(), other[0].Mnemonic())
+
+	mnemonic, err := DeterministicMnemonic(t.Name()+"/user", 0)
+	require.NoError(t, err)
+	require.Equal(t, mnemonic, first[0].Mnemonic())
+}
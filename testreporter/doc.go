@@ -19,6 +19,10 @@
 //	  os.Exit(code)
 //	}
 //
+// NewReporter writes a stream of JSON messages as they are tracked.
+// If a single JSON document grouping all details by test is preferred, e.g. for ingestion into a CI dashboard,
+// use NewSummaryFileReporter in place of NewReporter.
+//
 // Next, every test that needs to be tracked must call TrackTest.
 // If you omit the call to TrackTest, then the test's start and end time,
 // and skip/fail status, will not be reported.
@@ -144,6 +144,22 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// LinkMessage is tracked by interchaintest.Interchain.Build for every path it linked,
+// through the RelayerExecReporter passed to Build.
+type LinkMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	Path, Relayer string
+
+	// Names of the two chains linked by the path.
+	Chains [2]string
+}
+
+func (m LinkMessage) typ() string {
+	return "Link"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -210,6 +226,10 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "Link":
+		x := LinkMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
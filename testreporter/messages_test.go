@@ -45,6 +45,15 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.LinkMessage{
+				Name:    "foo",
+				When:    time.Now(),
+				Path:    "gaia-osmosis",
+				Relayer: "r",
+				Chains:  [2]string{"gaia", "osmosis"},
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/strangelove-ventures/interchaintest/v6/label"
@@ -29,20 +30,32 @@ type Reporter struct {
 	in chan Message
 
 	writerDone chan error
+
+	// If set, messages are accumulated into a single summary written on Close,
+	// instead of being streamed to w.
+	summary *summaryBuilder
 }
 
 func NewReporter(w io.WriteCloser) *Reporter {
-	r := &Reporter{
+	r := newReporter(w)
+	r.start()
+	return r
+}
+
+// newReporter returns a Reporter that has not yet begun writing messages.
+func newReporter(w io.WriteCloser) *Reporter {
+	return &Reporter{
 		w: w,
 
 		in:         make(chan Message, 256), // Arbitrary size that seems unlikely to be filled.
 		writerDone: make(chan error, 1),
 	}
+}
 
+// start begins the write goroutine and tracks the beginning of the suite.
+func (r *Reporter) start() {
 	go r.write()
 	r.in <- BeginSuiteMessage{StartedAt: time.Now()}
-
-	return r
 }
 
 // write runs in its own goroutine to continually output reporting messages.
@@ -53,11 +66,25 @@ func (r *Reporter) write() {
 	enc.SetEscapeHTML(false)
 
 	for m := range r.in {
+		if r.summary != nil {
+			r.summary.add(m)
+			continue
+		}
+
 		if err := enc.Encode(JSONMessage(m)); err != nil {
 			panic(fmt.Errorf("reporter failed to encode message; tests cannot continue: %w", err))
 		}
 	}
 
+	if r.summary != nil {
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r.summary.s); err != nil {
+			_ = r.w.Close()
+			r.writerDone <- fmt.Errorf("reporter failed to encode summary: %w", err)
+			return
+		}
+	}
+
 	r.writerDone <- r.w.Close()
 }
 
@@ -151,7 +178,8 @@ func (r *Reporter) TrackSkip(t T, format string, args ...any) {
 }
 
 // RelayerExecReporter returns a RelayerExecReporter associated with t.
-func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
+// Only the name of t is used, so t may also be a *testing.B.
+func (r *Reporter) RelayerExecReporter(t interface{ Name() string }) *RelayerExecReporter {
 	return &RelayerExecReporter{r: r, testName: t.Name()}
 }
 
@@ -160,6 +188,9 @@ func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 type RelayerExecReporter struct {
 	r        *Reporter
 	testName string
+
+	mu    sync.Mutex
+	execs []RelayerExecMessage
 }
 
 // TrackRelayerExec tracks the execution of an individual relayer command.
@@ -175,7 +206,7 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	if err != nil {
 		errMsg = err.Error()
 	}
-	r.r.in <- RelayerExecMessage{
+	msg := RelayerExecMessage{
 		Name:          r.testName,
 		StartedAt:     startedAt,
 		FinishedAt:    finishedAt,
@@ -186,6 +217,38 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 		ExitCode:      exitCode,
 		Error:         errMsg,
 	}
+
+	r.mu.Lock()
+	r.execs = append(r.execs, msg)
+	r.mu.Unlock()
+
+	r.r.in <- msg
+}
+
+// TrackLink tracks a path linked by the relayer between two chains.
+// Unlike TrackRelayerExec, it may be called on a nil RelayerExecReporter, which tracks nothing.
+func (r *RelayerExecReporter) TrackLink(path, relayer, chain1, chain2 string) {
+	if r == nil {
+		return
+	}
+	r.r.in <- LinkMessage{
+		Name:    r.testName,
+		When:    time.Now(),
+		Path:    path,
+		Relayer: relayer,
+		Chains:  [2]string{chain1, chain2},
+	}
+}
+
+// RelayerExecs returns every relayer execution tracked through r so far, in the order they were tracked.
+// This allows a test to inspect relayer output, e.g. to debug why a packet was not relayed.
+func (r *RelayerExecReporter) RelayerExecs() []RelayerExecMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]RelayerExecMessage, len(r.execs))
+	copy(out, r.execs)
+	return out
 }
 
 // TestifyT returns a TestifyReporter which will track logged errors in test.
@@ -231,7 +231,8 @@ func TestReporter_RelayerExec(t *testing.T) {
 
 	execStartedAt := time.Now()
 	execFinishedAt := execStartedAt.Add(time.Second)
-	r.RelayerExecReporter(mt).TrackRelayerExec(
+	eRep := r.RelayerExecReporter(mt)
+	eRep.TrackRelayerExec(
 		"my_container",
 		[]string{"rly", "fake_command"},
 		"stdout", "stderr",
@@ -247,7 +248,7 @@ func TestReporter_RelayerExec(t *testing.T) {
 	msgs := ReporterMessages(t, buf)
 	require.Len(t, msgs, 5)
 
-	diff := cmp.Diff(testreporter.RelayerExecMessage{
+	want := testreporter.RelayerExecMessage{
 		Name:          "my_test",
 		StartedAt:     execStartedAt,
 		FinishedAt:    execFinishedAt,
@@ -257,8 +258,14 @@ func TestReporter_RelayerExec(t *testing.T) {
 		Stderr:        "stderr",
 		ExitCode:      1,
 		Error:         "",
-	}, msgs[2].(testreporter.RelayerExecMessage))
+	}
+	diff := cmp.Diff(want, msgs[2].(testreporter.RelayerExecMessage))
 	require.Empty(t, diff)
+
+	// The same execution is retrievable from the RelayerExecReporter after the test.
+	execs := eRep.RelayerExecs()
+	require.Len(t, execs, 1)
+	require.Empty(t, cmp.Diff(want, execs[0]))
 }
 
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
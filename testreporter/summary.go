@@ -0,0 +1,129 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Summary is the single JSON document written by a Reporter
+// created with NewSummaryReporter or NewSummaryFileReporter.
+//
+// Unlike the stream of messages written by a Reporter from NewReporter,
+// a Summary groups everything tracked for a test under that test,
+// which is simpler for CI systems to ingest.
+type Summary struct {
+	StartedAt, FinishedAt time.Time
+
+	// Tests are ordered by the time they began.
+	Tests []TestSummary
+}
+
+// TestSummary is the summary of a single tracked test.
+type TestSummary struct {
+	Name string
+
+	// Labels include the relayers and chains tracked by TrackParameters.
+	Labels LabelSet
+
+	StartedAt, FinishedAt time.Time
+
+	// Time spent waiting for a parallel test to resume, as tracked by TrackParallel.
+	PausedFor time.Duration `json:",omitempty"`
+
+	Failed, Skipped bool
+
+	SkipReason string `json:",omitempty"`
+
+	// Errors from a TestifyT associated with the test.
+	Errors []string `json:",omitempty"`
+
+	// Paths linked by interchaintest.Interchain.Build, ordered by path name.
+	Links []LinkMessage `json:",omitempty"`
+
+	RelayerExecs []RelayerExecMessage `json:",omitempty"`
+}
+
+// NewSummaryReporter returns a Reporter that writes a single Summary to w
+// when the Reporter is closed.
+func NewSummaryReporter(w io.WriteCloser) *Reporter {
+	r := newReporter(w)
+	r.summary = &summaryBuilder{testIdx: make(map[string]int)}
+	r.start()
+	return r
+}
+
+// NewSummaryFileReporter creates the file at path,
+// and returns a Reporter that writes a Summary to that file when closed.
+func NewSummaryFileReporter(path string) (*Reporter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report file: %w", err)
+	}
+	return NewSummaryReporter(f), nil
+}
+
+// summaryBuilder accumulates messages into a Summary.
+// It is only accessed from the Reporter's write goroutine, so it needs no synchronization.
+type summaryBuilder struct {
+	s Summary
+
+	// Index into s.Tests, keyed by test name.
+	testIdx map[string]int
+
+	// Time each paused test was paused, keyed by test name.
+	pausedAt map[string]time.Time
+}
+
+// test returns the summary for the named test, creating it if it was not yet tracked.
+func (b *summaryBuilder) test(name string) *TestSummary {
+	i, ok := b.testIdx[name]
+	if !ok {
+		i = len(b.s.Tests)
+		b.s.Tests = append(b.s.Tests, TestSummary{Name: name})
+		b.testIdx[name] = i
+	}
+	return &b.s.Tests[i]
+}
+
+func (b *summaryBuilder) add(m Message) {
+	switch m := m.(type) {
+	case BeginSuiteMessage:
+		b.s.StartedAt = m.StartedAt
+	case FinishSuiteMessage:
+		b.s.FinishedAt = m.FinishedAt
+	case BeginTestMessage:
+		ts := b.test(m.Name)
+		ts.StartedAt = m.StartedAt
+		ts.Labels.Relayer = append(ts.Labels.Relayer, m.Labels.Relayer...)
+		ts.Labels.Chain = append(ts.Labels.Chain, m.Labels.Chain...)
+		ts.Labels.Test = append(ts.Labels.Test, m.Labels.Test...)
+	case FinishTestMessage:
+		ts := b.test(m.Name)
+		ts.FinishedAt = m.FinishedAt
+		ts.Failed = m.Failed
+		ts.Skipped = m.Skipped
+	case PauseTestMessage:
+		if b.pausedAt == nil {
+			b.pausedAt = make(map[string]time.Time)
+		}
+		b.pausedAt[m.Name] = m.When
+	case ContinueTestMessage:
+		if pausedAt, ok := b.pausedAt[m.Name]; ok {
+			b.test(m.Name).PausedFor += m.When.Sub(pausedAt)
+			delete(b.pausedAt, m.Name)
+		}
+	case TestErrorMessage:
+		ts := b.test(m.Name)
+		ts.Errors = append(ts.Errors, m.Message)
+	case TestSkipMessage:
+		b.test(m.Name).SkipReason = m.Message
+	case RelayerExecMessage:
+		ts := b.test(m.Name)
+		ts.RelayerExecs = append(ts.RelayerExecs, m)
+	case LinkMessage:
+		ts := b.test(m.Name)
+		ts.Links = append(ts.Links, m)
+	}
+}
@@ -0,0 +1,73 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v6/label"
+	"github.com/strangelove-ventures/interchaintest/v6/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryReporter(t *testing.T) {
+	t.Parallel()
+
+	beforeStart := time.Now()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewSummaryReporter(nopCloser{Writer: buf})
+
+	mt1 := mocktesting.NewT("test_1")
+	r.TrackParameters(mt1, []label.Relayer{label.Rly}, []label.Chain{"gaia"})
+	r.TestifyT(mt1).Errorf("oops %d", 1)
+	r.RelayerExecReporter(mt1).TrackRelayerExec(
+		"my_container",
+		[]string{"rly", "fake_command"},
+		"stdout", "stderr",
+		0,
+		beforeStart, beforeStart.Add(time.Second),
+		nil,
+	)
+	r.RelayerExecReporter(mt1).TrackLink("gaia-osmosis", "r", "gaia", "osmosis")
+
+	mt2 := mocktesting.NewT("test_2")
+	r.TrackTest(mt2)
+
+	mt2.RunCleanups()
+	mt1.RunCleanups()
+
+	require.NoError(t, r.Close())
+	afterClose := time.Now()
+
+	var s testreporter.Summary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &s))
+
+	requireTimeInRange(t, s.StartedAt, beforeStart, afterClose)
+	requireTimeInRange(t, s.FinishedAt, s.StartedAt, afterClose)
+
+	require.Len(t, s.Tests, 2)
+
+	ts := s.Tests[0]
+	require.Equal(t, "test_1", ts.Name)
+	require.Equal(t, []label.Relayer{label.Rly}, ts.Labels.Relayer)
+	require.Equal(t, []label.Chain{"gaia"}, ts.Labels.Chain)
+	require.True(t, ts.Failed)
+	require.Equal(t, []string{"oops 1"}, ts.Errors)
+	require.Len(t, ts.RelayerExecs, 1)
+	require.Equal(t, []string{"rly", "fake_command"}, ts.RelayerExecs[0].Command)
+	require.Len(t, ts.Links, 1)
+	require.Equal(t, "gaia-osmosis", ts.Links[0].Path)
+	require.Equal(t, "r", ts.Links[0].Relayer)
+	require.Equal(t, [2]string{"gaia", "osmosis"}, ts.Links[0].Chains)
+	requireTimeInRange(t, ts.FinishedAt, ts.StartedAt, afterClose)
+
+	ts = s.Tests[1]
+	require.Equal(t, "test_2", ts.Name)
+	require.False(t, ts.Failed)
+	require.Empty(t, ts.Errors)
+	require.Empty(t, ts.RelayerExecs)
+	require.Empty(t, ts.Links)
+}
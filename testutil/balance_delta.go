@@ -0,0 +1,66 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// balanceDeltaBlocks is how many blocks the balance delta assertions poll for the expected balance,
+// which leaves time for a relayer to deliver a transfer.
+const balanceDeltaBlocks = 10
+
+// ChainFeeBalancer is a chain that can report account balances and the fees charged for gas.
+type ChainFeeBalancer interface {
+	ChainBalancer
+	Config() ibc.ChainConfig
+	GetGasFeesInNativeDenom(gasPaid int64) int64
+}
+
+// AssertBalanceDelta records the denom balance of address, runs fn, e.g. a transfer,
+// then polls until the balance changed by exactly expectedDelta, which is negative for a decrease.
+// Returns the error of fn, or an error if the balance does not reach the expected amount within a few blocks.
+//
+// Gas fees are not accounted for, so this fits a recipient, or a sender paying fees in another denom;
+// see AssertSenderBalanceDelta.
+func AssertBalanceDelta(ctx context.Context, chain ChainBalancer, address, denom string, expectedDelta int64, fn func() error) error {
+	before, err := chain.GetBalance(ctx, address, denom)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return pollForBalanceDelta(ctx, chain, address, denom, before, expectedDelta)
+}
+
+// AssertSenderBalanceDelta is like AssertBalanceDelta for the account paying for the tx returned by fn.
+// If denom is the chain's native denom, the fees for the gas spent by the tx are deducted
+// in addition to expectedDelta, e.g. -amount for the sender of a transfer.
+func AssertSenderBalanceDelta(ctx context.Context, chain ChainFeeBalancer, address, denom string, expectedDelta int64, fn func() (ibc.Tx, error)) error {
+	before, err := chain.GetBalance(ctx, address, denom)
+	if err != nil {
+		return err
+	}
+
+	tx, err := fn()
+	if err != nil {
+		return err
+	}
+	if denom == chain.Config().Denom {
+		expectedDelta -= chain.GetGasFeesInNativeDenom(tx.GasSpent)
+	}
+
+	return pollForBalanceDelta(ctx, chain, address, denom, before, expectedDelta)
+}
+
+func pollForBalanceDelta(ctx context.Context, chain ChainBalancer, address, denom string, before, delta int64) error {
+	want := ibc.WalletAmount{Address: address, Denom: denom, Amount: before + delta}
+	if err := PollForBalance(ctx, chain, balanceDeltaBlocks, want); err != nil {
+		return fmt.Errorf("balance did not change by %d%s from %d%s: %w", delta, denom, before, denom, err)
+	}
+	return nil
+}
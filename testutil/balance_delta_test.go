@@ -0,0 +1,72 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFeeBalancer struct {
+	mockBalancer
+
+	Denom string
+}
+
+func (m *mockFeeBalancer) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{Denom: m.Denom}
+}
+
+func (m *mockFeeBalancer) GetGasFeesInNativeDenom(gasPaid int64) int64 {
+	return gasPaid / 10
+}
+
+func TestAssertBalanceDelta(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{100, 100, 100, 150}}
+		var called bool
+		require.NoError(t, AssertBalanceDelta(ctx, &chain, "cosmos1abc", "uatom", 50, func() error {
+			called = true
+			return nil
+		}))
+		require.True(t, called)
+	})
+
+	t.Run("wrong delta", func(t *testing.T) {
+		chain := mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{100, 90}}
+		err := AssertBalanceDelta(ctx, &chain, "cosmos1abc", "uatom", -5, func() error { return nil })
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did not change by -5uatom from 100uatom")
+	})
+
+	t.Run("fn error", func(t *testing.T) {
+		chain := mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{100}}
+		err := AssertBalanceDelta(ctx, &chain, "cosmos1abc", "uatom", 0, func() error { return errors.New("boom") })
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestAssertSenderBalanceDelta(t *testing.T) {
+	ctx := context.Background()
+	transfer := func() (ibc.Tx, error) { return ibc.Tx{GasSpent: 200}, nil }
+
+	t.Run("native denom", func(t *testing.T) {
+		chain := mockFeeBalancer{
+			mockBalancer: mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{100, 100, 30}},
+			Denom:        "uatom",
+		}
+		require.NoError(t, AssertSenderBalanceDelta(ctx, &chain, "cosmos1abc", "uatom", -50, transfer))
+	})
+
+	t.Run("other denom", func(t *testing.T) {
+		chain := mockFeeBalancer{
+			mockBalancer: mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{100, 50}},
+			Denom:        "uatom",
+		}
+		require.NoError(t, AssertSenderBalanceDelta(ctx, &chain, "cosmos1abc", "ibc/ABC", -50, transfer))
+	})
+}
@@ -0,0 +1,65 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+const (
+	// channelOpenTimeout bounds how long WaitForChannelOpen waits for a handshake to complete.
+	channelOpenTimeout = 2 * time.Minute
+	// channelOpenPollInterval is the delay between queries of WaitForChannelOpen.
+	// Querying channels takes a couple of seconds with the Go relayer, so polling more often gains nothing.
+	channelOpenPollInterval = time.Second
+)
+
+// WaitForChannelOpen polls the channels on chainID through r until one on portID is OPEN, and returns it,
+// e.g. after a handshake completed by a running relayer, rather than waiting for an arbitrary number of blocks.
+// If several channels on portID are open, the most recently created one is returned.
+// It gives up after channelOpenTimeout, or as soon as ctx is done.
+func WaitForChannelOpen(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, chainID, portID string) (ibc.ChannelOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, channelOpenTimeout)
+	defer cancel()
+
+	q := ibc.ChannelQuery{PortID: portID, OpenOnly: true}
+	for {
+		channels, err := r.GetChannels(ctx, rep, chainID)
+		if err == nil {
+			if ch, ok := newestChannel(ibc.FilterChannels(channels, q)); ok {
+				return ch, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return ibc.ChannelOutput{}, fmt.Errorf("no open channel on %s port %s: %w (last query error: %v)", chainID, portID, ctx.Err(), err)
+			}
+			return ibc.ChannelOutput{}, fmt.Errorf("no open channel on %s port %s: %w", chainID, portID, ctx.Err())
+		case <-time.After(channelOpenPollInterval):
+		}
+	}
+}
+
+// newestChannel returns the channel with the highest sequence in its identifier, e.g. channel-2 over channel-1.
+func newestChannel(channels []ibc.ChannelOutput) (ibc.ChannelOutput, bool) {
+	var (
+		newest ibc.ChannelOutput
+		seq    uint64
+		found  bool
+	)
+	for _, ch := range channels {
+		s, err := chantypes.ParseChannelSequence(ch.ChannelID)
+		if err != nil {
+			continue
+		}
+		if !found || s > seq {
+			newest, seq, found = ch, s, true
+		}
+	}
+	return newest, found
+}
@@ -0,0 +1,56 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type handshakeRelayer struct {
+	ibc.Relayer // Unimplemented methods panic.
+
+	// channels are reported by successive calls to GetChannels; the last one is repeated.
+	channels [][]ibc.ChannelOutput
+	calls    int
+}
+
+func (r *handshakeRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	i := r.calls
+	if i >= len(r.channels) {
+		i = len(r.channels) - 1
+	}
+	r.calls++
+	if r.channels[i] == nil {
+		return nil, errors.New("query failed")
+	}
+	return r.channels[i], nil
+}
+
+func TestWaitForChannelOpen(t *testing.T) {
+	transfer := func(id, state string) ibc.ChannelOutput {
+		return ibc.ChannelOutput{ChannelID: id, PortID: "transfer", State: state}
+	}
+	r := &handshakeRelayer{channels: [][]ibc.ChannelOutput{
+		nil,
+		{transfer("channel-0", "STATE_INIT"), {ChannelID: "channel-1", PortID: "icahost", State: "STATE_OPEN"}},
+		{transfer("channel-0", "STATE_OPEN")},
+	}}
+
+	// Failed queries and channels that are not open yet, or on other ports, are skipped.
+	ch, err := WaitForChannelOpen(context.Background(), r, nil, "gaia-0", "transfer")
+	require.NoError(t, err)
+	require.Equal(t, "channel-0", ch.ChannelID)
+	require.Equal(t, 3, r.calls)
+
+	ch, ok := newestChannel([]ibc.ChannelOutput{transfer("channel-10", "Open"), transfer("channel-9", "Open")})
+	require.True(t, ok)
+	require.Equal(t, "channel-10", ch.ChannelID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitForChannelOpen(ctx, &handshakeRelayer{channels: [][]ibc.ChannelOutput{{transfer("channel-0", "STATE_INIT")}}}, nil, "gaia-0", "transfer")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,52 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// WaitForClientExpiry blocks until a client at either end of pathName has expired, per the relayer's ibc.ClientExpiryQuerier.
+// Clients with a short trusting period, see ibc.CreateClientOptions, can so be expired on purpose,
+// e.g. to assert that transfers fail over an expired client and succeed again once it is recovered.
+//
+// The relayer must not be relaying the path, as it would keep updating the clients.
+// The expiries are queried again once the earliest one is due, in case a client was updated meanwhile.
+// The host chain compares against its own block time, so wait for a block on it before asserting on the expired client.
+func WaitForClientExpiry(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, pathName string) error {
+	q, ok := r.(ibc.ClientExpiryQuerier)
+	if !ok {
+		return fmt.Errorf("relayer %T cannot query client expiries", r)
+	}
+
+	for {
+		expiries, err := q.ClientExpiries(ctx, rep, pathName)
+		if err != nil {
+			return fmt.Errorf("failed to query client expiries of path %s: %w", pathName, err)
+		}
+		if len(expiries) == 0 {
+			return fmt.Errorf("no clients on path %s", pathName)
+		}
+
+		next := expiries[0]
+		for _, e := range expiries[1:] {
+			if e.ExpiresAt().Before(next.ExpiresAt()) {
+				next = e
+			}
+		}
+		wait := time.Until(next.ExpiresAt())
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("client %s on %s did not expire before %s: %w", next.ClientID, next.ChainID, next.ExpiresAt(), ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// expiringRelayer reports the client expiries of a path, updating the source client once.
+type expiringRelayer struct {
+	ibc.Relayer // Unimplemented methods panic.
+
+	queries int
+	src     ibc.ClientExpiry
+	dst     ibc.ClientExpiry
+	updated time.Time
+}
+
+func (r *expiringRelayer) ClientExpiries(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) ([]ibc.ClientExpiry, error) {
+	r.queries++
+	if r.queries == 2 {
+		r.src.LastUpdate = r.updated
+	}
+	return []ibc.ClientExpiry{r.src, r.dst}, nil
+}
+
+func TestWaitForClientExpiry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("earliest", func(t *testing.T) {
+		now := time.Now()
+		r := &expiringRelayer{
+			src: ibc.ClientExpiry{ClientID: "src", TrustingPeriod: time.Hour, LastUpdate: now},
+			dst: ibc.ClientExpiry{ClientID: "dst", TrustingPeriod: 100 * time.Millisecond, LastUpdate: now},
+			// Updating the source client does not delay the expiry of the other one.
+			updated: now.Add(time.Hour),
+		}
+		require.NoError(t, WaitForClientExpiry(ctx, r, nil, "p"))
+		require.False(t, time.Now().Before(now.Add(100*time.Millisecond)))
+		require.Equal(t, 2, r.queries)
+	})
+
+	t.Run("updated meanwhile", func(t *testing.T) {
+		now := time.Now()
+		r := &expiringRelayer{
+			src:     ibc.ClientExpiry{ClientID: "src", ChainID: "a", TrustingPeriod: 50 * time.Millisecond, LastUpdate: now},
+			dst:     ibc.ClientExpiry{ClientID: "dst", ChainID: "b", TrustingPeriod: time.Hour, LastUpdate: now},
+			updated: now.Add(time.Hour),
+		}
+		ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		err := WaitForClientExpiry(ctx, r, nil, "p")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "client dst on b did not expire")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		err := WaitForClientExpiry(ctx, struct{ ibc.Relayer }{}, nil, "p")
+		require.ErrorContains(t, err, "cannot query client expiries")
+	})
+}
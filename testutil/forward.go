@@ -0,0 +1,78 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// SendForwardedTransfer sends amount from src over channelID to amount.Address on the first intermediate chain,
+// with a memo that makes its packet-forward-middleware route the tokens through each of hops in turn.
+// See ibc.ForwardMemo; options.Memo must be empty.
+func SendForwardedTransfer(
+	ctx context.Context,
+	src ibc.Chain,
+	keyName, channelID string,
+	amount ibc.WalletAmount,
+	options ibc.TransferOptions,
+	hops ...ibc.ForwardHop,
+) (ibc.Tx, error) {
+	if options.Memo != "" {
+		return ibc.Tx{}, errors.New("transfer memo is set by SendForwardedTransfer")
+	}
+	memo, err := ibc.ForwardMemo(hops...)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	options.Memo = memo
+	return src.SendIBCTransfer(ctx, channelID, keyName, amount, options)
+}
+
+// RelayerHop is a channel of a multi-hop transfer along with the relayer serving it.
+type RelayerHop struct {
+	Relayer  ibc.Relayer
+	PathName string
+	// ChannelID is the channel on the chain sending this hop.
+	ChannelID string
+}
+
+// FlushHops relays a transfer forwarded over hops, given in the order the tokens travel.
+//
+// Packets are flushed hop by hop, as each intermediate chain only forwards a packet once it is received.
+// Acknowledgements are then flushed in reverse, as the middleware only acknowledges the incoming packet
+// once the packet it forwarded has been acknowledged.
+func FlushHops(ctx context.Context, rep ibc.RelayerExecReporter, hops ...RelayerHop) error {
+	for i, hop := range hops {
+		if err := hop.Relayer.FlushPackets(ctx, rep, hop.PathName, hop.ChannelID); err != nil {
+			return fmt.Errorf("failed to flush packets of hop %d on path %s: %w", i, hop.PathName, err)
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := hops[i]
+		if err := hop.Relayer.FlushAcknowledgements(ctx, rep, hop.PathName, hop.ChannelID); err != nil {
+			return fmt.Errorf("failed to flush acknowledgements of hop %d on path %s: %w", i, hop.PathName, err)
+		}
+	}
+	return nil
+}
+
+// PollForForwardedBalance polls the final chain of a multi-hop transfer for up to deltaBlocks blocks
+// until receiver holds exactly amount of the voucher for baseDenom with the multi-hop denom trace.
+// The path lists the receiving port and channel of each hop in order; see ibc.GetMultiHopTransferDenom.
+func PollForForwardedBalance(
+	ctx context.Context,
+	dst ChainBalancer,
+	deltaBlocks uint64,
+	receiver string,
+	amount int64,
+	baseDenom string,
+	path ...ibc.ChannelCounterparty,
+) error {
+	return PollForBalance(ctx, dst, deltaBlocks, ibc.WalletAmount{
+		Address: receiver,
+		Denom:   ibc.GetMultiHopTransferDenom(baseDenom, path...),
+		Amount:  amount,
+	})
+}
@@ -0,0 +1,37 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type flushRecorder struct {
+	ibc.Relayer
+
+	calls *[]string
+}
+
+func (r flushRecorder) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	*r.calls = append(*r.calls, "packets "+pathName)
+	return nil
+}
+
+func (r flushRecorder) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	*r.calls = append(*r.calls, "acks "+pathName)
+	return nil
+}
+
+func TestFlushHops(t *testing.T) {
+	var calls []string
+	r := flushRecorder{calls: &calls}
+
+	err := FlushHops(context.Background(), nil,
+		RelayerHop{Relayer: r, PathName: "a-b", ChannelID: "channel-0"},
+		RelayerHop{Relayer: r, PathName: "b-c", ChannelID: "channel-1"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"packets a-b", "packets b-c", "acks b-c", "acks a-b"}, calls)
+}
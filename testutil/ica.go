@@ -0,0 +1,164 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	icatypes "github.com/cosmos/ibc-go/v6/modules/apps/27-interchain-accounts/types"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"google.golang.org/grpc"
+)
+
+// icaChannelOpenTimeout bounds how long SetupInterchainAccount waits for the host end of the account's channel to open.
+const icaChannelOpenTimeout = 2 * time.Minute
+
+// InterchainAccountController is a chain that registers interchain accounts and submits their txs
+// through the intertx module, such as cosmos.CosmosChain.
+type InterchainAccountController interface {
+	ibc.Chain
+
+	// RegisterInterchainAccount registers the account owned by keyName over connectionID,
+	// and returns its host chain address once the controller end of its channel is open.
+	RegisterInterchainAccount(ctx context.Context, keyName, connectionID string) (string, error)
+
+	// SendICATx submits a JSON-encoded sdk.Msg to be executed by the interchain account owned by keyName.
+	SendICATx(ctx context.Context, keyName, connectionID, msg string) error
+}
+
+// SetupInterchainAccount registers the interchain account of ownerKey on controllerChain over connectionID,
+// waits for both ends of the account's channel to be open, and returns the account's address on hostChain.
+// The account then exists on the host, so it can be funded, e.g. with hostChain.SendFunds, before sending from it with SendICATx.
+//
+// A relayer must be relaying the path of connectionID for the channel handshake to complete.
+// Both chains must serve the IBC channel gRPC queries.
+func SetupInterchainAccount(ctx context.Context, controllerChain InterchainAccountController, hostChain ibc.Chain, connectionID, ownerKey string) (string, error) {
+	owner, err := controllerChain.GetAddress(ctx, ownerKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get address of key %s: %w", ownerKey, err)
+	}
+	ownerAddr, err := types.Bech32ifyAddressBytes(controllerChain.Config().Bech32Prefix, owner)
+	if err != nil {
+		return "", err
+	}
+
+	icaAddr, err := controllerChain.RegisterInterchainAccount(ctx, ownerKey, connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	// The controller learns the address on the handshake's ack, so the host end may still be confirming.
+	portID, err := icatypes.NewControllerPortID(ownerAddr)
+	if err != nil {
+		return "", err
+	}
+	ch, err := icaControllerChannel(ctx, controllerChain, connectionID, portID)
+	if err != nil {
+		return "", err
+	}
+	if err := waitForHostChannelOpen(ctx, hostChain, ch.Counterparty.ChannelId); err != nil {
+		return "", err
+	}
+	return icaAddr, nil
+}
+
+// SendICATx submits a MsgSend of amount, from the interchain account of ownerKey at icaAddress to amount.Address,
+// on the host chain of connectionID. It returns once the tx is committed on controllerChain;
+// the send is executed by the host once the relayer relays the packet, so poll the host balances to observe it.
+func SendICATx(ctx context.Context, controllerChain InterchainAccountController, ownerKey, connectionID, icaAddress string, amount ibc.WalletAmount) error {
+	msg, err := icaBankSendMsg(icaAddress, amount)
+	if err != nil {
+		return err
+	}
+	if err := controllerChain.SendICATx(ctx, ownerKey, connectionID, msg); err != nil {
+		return fmt.Errorf("failed to send %d%s from interchain account %s: %w", amount.Amount, amount.Denom, icaAddress, err)
+	}
+	return nil
+}
+
+// icaBankSendMsg returns the JSON-encoded MsgSend of amount from the interchain account at from.
+func icaBankSendMsg(from string, amount ibc.WalletAmount) (string, error) {
+	msg, err := json.Marshal(map[string]any{
+		"@type":        "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": from,
+		"to_address":   amount.Address,
+		"amount": []map[string]any{
+			{
+				"denom":  amount.Denom,
+				"amount": strconv.FormatInt(amount.Amount, 10),
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(msg), nil
+}
+
+// icaControllerChannel returns the open channel on portID over connectionID on the controller chain.
+func icaControllerChannel(ctx context.Context, controller ibc.Chain, connectionID, portID string) (*chantypes.IdentifiedChannel, error) {
+	conn, err := grpc.Dial(controller.GetHostGRPCAddress(), grpc.WithTransportCredentials(controller.Config().TLS.GRPCCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{Connection: connectionID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channels of %s on %s: %w", connectionID, controller.Config().ChainID, err)
+	}
+	ch, ok := openChannelOnPort(res.Channels, portID)
+	if !ok {
+		return nil, fmt.Errorf("no open channel on %s port %s over %s", controller.Config().ChainID, portID, connectionID)
+	}
+	return ch, nil
+}
+
+// openChannelOnPort returns the most recently created open channel on portID.
+// A reopened interchain account channel, e.g. after the previous one closed, uses the same port.
+func openChannelOnPort(channels []*chantypes.IdentifiedChannel, portID string) (*chantypes.IdentifiedChannel, bool) {
+	var (
+		newest *chantypes.IdentifiedChannel
+		seq    uint64
+	)
+	for _, ch := range channels {
+		if ch.PortId != portID || ch.State != chantypes.OPEN {
+			continue
+		}
+		s, err := chantypes.ParseChannelSequence(ch.ChannelId)
+		if err != nil {
+			continue
+		}
+		if newest == nil || s > seq {
+			newest, seq = ch, s
+		}
+	}
+	return newest, newest != nil
+}
+
+// waitForHostChannelOpen polls the host end of an interchain account channel, once per block, until it is open.
+func waitForHostChannelOpen(ctx context.Context, host ibc.Chain, channelID string) error {
+	ctx, cancel := context.WithTimeout(ctx, icaChannelOpenTimeout)
+	defer cancel()
+
+	conn, err := grpc.Dial(host.GetHostGRPCAddress(), grpc.WithTransportCredentials(host.Config().TLS.GRPCCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	qc := chantypes.NewQueryClient(conn)
+
+	for {
+		res, err := qc.Channel(ctx, &chantypes.QueryChannelRequest{PortId: icatypes.HostPortID, ChannelId: channelID})
+		if err == nil && res.Channel != nil && res.Channel.State == chantypes.OPEN {
+			return nil
+		}
+		if err := WaitForBlocks(ctx, 1, host); err != nil {
+			return fmt.Errorf("channel %s/%s on %s not open: %w", icatypes.HostPortID, channelID, host.Config().ChainID, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package testutil
+
+import (
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICABankSendMsg(t *testing.T) {
+	msg, err := icaBankSendMsg("cosmos1ica", ibc.WalletAmount{Address: "cosmos1dst", Denom: "uatom", Amount: 1234})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"@type": "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": "cosmos1ica",
+		"to_address": "cosmos1dst",
+		"amount": [{"denom": "uatom", "amount": "1234"}]
+	}`, msg)
+}
+
+func TestOpenChannelOnPort(t *testing.T) {
+	const port = "icacontroller-cosmos1owner"
+	channels := []*chantypes.IdentifiedChannel{
+		{PortId: port, ChannelId: "channel-1", State: chantypes.CLOSED},
+		{PortId: "transfer", ChannelId: "channel-0", State: chantypes.OPEN},
+		{PortId: port, ChannelId: "channel-3", State: chantypes.OPEN},
+		{PortId: port, ChannelId: "channel-4", State: chantypes.INIT},
+		{PortId: port, ChannelId: "channel-2", State: chantypes.OPEN},
+	}
+
+	ch, ok := openChannelOnPort(channels, port)
+	require.True(t, ok)
+	require.Equal(t, "channel-3", ch.ChannelId)
+
+	_, ok = openChannelOnPort(channels, "icacontroller-cosmos1other")
+	require.False(t, ok)
+}
@@ -0,0 +1,63 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AcknowledgedSequences returns the sequences of packets sent over the given source port and channel
+// whose acknowledgements were committed on chain from startHeight through endHeight,
+// in the order they were committed.
+//
+// As with PollForAck, it is safe to call this function before the chain reaches endHeight;
+// it waits for each height to exist before querying it.
+//
+// On an ordered channel, the result can be checked with ValidateOrderedSequences
+// to assert that no packet was acknowledged ahead of an earlier one,
+// e.g. that a stuck packet N prevents packet N+1 from being acknowledged.
+func AcknowledgedSequences(ctx context.Context, chain ChainAcker, startHeight, endHeight uint64, portID, channelID string) ([]uint64, error) {
+	if endHeight < startHeight {
+		panic("endHeight must be greater than or equal to startHeight")
+	}
+
+	var seqs []uint64
+	for h := startHeight; h <= endHeight; {
+		cur, err := chain.Height(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if h > cur {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+			continue
+		}
+
+		acks, err := chain.Acknowledgements(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get acknowledgements at height %d: %w", h, err)
+		}
+		for _, ack := range acks {
+			if ack.Packet.SourcePort == portID && ack.Packet.SourceChannel == channelID {
+				seqs = append(seqs, ack.Packet.Sequence)
+			}
+		}
+		h++
+	}
+	return seqs, nil
+}
+
+// ValidateOrderedSequences returns an error unless seqs is a contiguous, strictly increasing run of sequences,
+// as is required for packets relayed over an ordered channel.
+// An empty slice is valid.
+func ValidateOrderedSequences(seqs []uint64) error {
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] != seqs[i-1]+1 {
+			return fmt.Errorf("sequence %d at index %d does not follow sequence %d", seqs[i], i, seqs[i-1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledgedSequences(t *testing.T) {
+	ctx := context.Background()
+
+	chain := mockChain{CurrentHeight: 1, FoundAcks: []ibc.PacketAcknowledgement{
+		{Packet: ibc.Packet{Sequence: 1, SourcePort: "transfer", SourceChannel: "channel-0"}},
+		{Packet: ibc.Packet{Sequence: 7, SourcePort: "transfer", SourceChannel: "channel-1"}},
+		{Packet: ibc.Packet{Sequence: 2, SourcePort: "transfer", SourceChannel: "channel-0"}},
+	}}
+
+	seqs, err := AcknowledgedSequences(ctx, &chain, 1, 2, "transfer", "channel-0")
+	require.NoError(t, err)
+
+	// The mock returns the same acks at every height.
+	require.Equal(t, []uint64{1, 2, 1, 2}, seqs)
+	require.Equal(t, []uint64{1, 2}, chain.GotHeights)
+}
+
+func TestValidateOrderedSequences(t *testing.T) {
+	require.NoError(t, ValidateOrderedSequences(nil))
+	require.NoError(t, ValidateOrderedSequences([]uint64{4}))
+	require.NoError(t, ValidateOrderedSequences([]uint64{4, 5, 6}))
+
+	require.Error(t, ValidateOrderedSequences([]uint64{4, 6}))
+	require.Error(t, ValidateOrderedSequences([]uint64{5, 4}))
+	require.Error(t, ValidateOrderedSequences([]uint64{4, 4}))
+}
@@ -0,0 +1,137 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	chantypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"google.golang.org/grpc"
+)
+
+// PendingPackets lists the packets sent from one end of a channel that are still in flight.
+type PendingPackets struct {
+	// Unreceived are the sequences of packets the counterparty has not received yet.
+	Unreceived []uint64
+	// Unacknowledged are the sequences of packets received by the counterparty
+	// whose acknowledgement has not been relayed back yet.
+	Unacknowledged []uint64
+}
+
+// Empty reports whether no packet is in flight.
+func (p PendingPackets) Empty() bool {
+	return len(p.Unreceived) == 0 && len(p.Unacknowledged) == 0
+}
+
+// UnrelayedPackets lists the packets in flight in each direction of a channel.
+type UnrelayedPackets struct {
+	// Src holds the packets sent from the source chain, and Dst the packets sent from its counterparty.
+	Src, Dst PendingPackets
+}
+
+// AllPacketsRelayed reports whether every packet sent in either direction of the channel on src
+// has been received and acknowledged, based on the packet commitments remaining on both chains.
+// When it returns false, the returned UnrelayedPackets lists the stuck sequences.
+//
+// The relayer is used to look up the counterparty channel on dst.
+// Both chains must serve the IBC channel gRPC queries.
+func AllPacketsRelayed(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, src, dst ibc.Chain, channelID string) (bool, UnrelayedPackets, error) {
+	var out UnrelayedPackets
+
+	channels, err := r.GetChannels(ctx, rep, src.Config().ChainID)
+	if err != nil {
+		return false, out, fmt.Errorf("failed to get channels on %s: %w", src.Config().ChainID, err)
+	}
+	var ch *ibc.ChannelOutput
+	for i := range channels {
+		if channels[i].ChannelID == channelID {
+			ch = &channels[i]
+			break
+		}
+	}
+	if ch == nil {
+		return false, out, fmt.Errorf("channel %s not found on %s", channelID, src.Config().ChainID)
+	}
+
+	out.Src, err = pendingPackets(ctx, src, dst, ch.PortID, ch.ChannelID, ch.Counterparty.PortID, ch.Counterparty.ChannelID)
+	if err != nil {
+		return false, out, err
+	}
+	out.Dst, err = pendingPackets(ctx, dst, src, ch.Counterparty.PortID, ch.Counterparty.ChannelID, ch.PortID, ch.ChannelID)
+	if err != nil {
+		return false, out, err
+	}
+
+	return out.Src.Empty() && out.Dst.Empty(), out, nil
+}
+
+// pendingPackets finds the packets sent from the sender's end of a channel that still have a commitment,
+// and splits them by whether the receiver has received them.
+func pendingPackets(ctx context.Context, sender, receiver ibc.Chain, portID, channelID, counterpartyPortID, counterpartyChannelID string) (PendingPackets, error) {
+	var out PendingPackets
+
+	senderConn, err := grpc.Dial(sender.GetHostGRPCAddress(), grpc.WithTransportCredentials(sender.Config().TLS.GRPCCredentials()))
+	if err != nil {
+		return out, err
+	}
+	defer senderConn.Close()
+
+	var committed []uint64
+	qc := chantypes.NewQueryClient(senderConn)
+	page := &query.PageRequest{}
+	for {
+		res, err := qc.PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: page,
+		})
+		if err != nil {
+			return out, fmt.Errorf("failed to query packet commitments on %s %s/%s: %w", sender.Config().ChainID, portID, channelID, err)
+		}
+		for _, c := range res.Commitments {
+			committed = append(committed, c.Sequence)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		page = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+	if len(committed) == 0 {
+		return out, nil
+	}
+
+	receiverConn, err := grpc.Dial(receiver.GetHostGRPCAddress(), grpc.WithTransportCredentials(receiver.Config().TLS.GRPCCredentials()))
+	if err != nil {
+		return out, err
+	}
+	defer receiverConn.Close()
+
+	res, err := chantypes.NewQueryClient(receiverConn).UnreceivedPackets(ctx, &chantypes.QueryUnreceivedPacketsRequest{
+		PortId:                    counterpartyPortID,
+		ChannelId:                 counterpartyChannelID,
+		PacketCommitmentSequences: committed,
+	})
+	if err != nil {
+		return out, fmt.Errorf("failed to query unreceived packets on %s %s/%s: %w", receiver.Config().ChainID, counterpartyPortID, counterpartyChannelID, err)
+	}
+
+	out.Unreceived, out.Unacknowledged = splitUnreceived(committed, res.Sequences)
+	return out, nil
+}
+
+// splitUnreceived partitions the committed sequences into those in unreceived and the rest.
+func splitUnreceived(committed, unreceived []uint64) (notReceived, notAcked []uint64) {
+	isUnreceived := make(map[uint64]bool, len(unreceived))
+	for _, s := range unreceived {
+		isUnreceived[s] = true
+	}
+	for _, s := range committed {
+		if isUnreceived[s] {
+			notReceived = append(notReceived, s)
+		} else {
+			notAcked = append(notAcked, s)
+		}
+	}
+	return notReceived, notAcked
+}
@@ -0,0 +1,20 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitUnreceived(t *testing.T) {
+	notReceived, notAcked := splitUnreceived([]uint64{1, 2, 3, 4}, []uint64{2, 4})
+	require.Equal(t, []uint64{2, 4}, notReceived)
+	require.Equal(t, []uint64{1, 3}, notAcked)
+
+	notReceived, notAcked = splitUnreceived([]uint64{5}, nil)
+	require.Empty(t, notReceived)
+	require.Equal(t, []uint64{5}, notAcked)
+
+	require.True(t, PendingPackets{}.Empty())
+	require.False(t, PendingPackets{Unacknowledged: []uint64{1}}.Empty())
+}
@@ -0,0 +1,64 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// ChainBalancer is a chain that can report account balances.
+type ChainBalancer interface {
+	ChainHeighter
+	GetBalance(ctx context.Context, address string, denom string) (int64, error)
+}
+
+// PollForBalance polls the chain for up to deltaBlocks blocks until the account balance.Address
+// holds exactly balance.Amount of balance.Denom.
+// Returns an error if the balance never matches or problems getting height or balances.
+//
+// Combined with a short ibc.IBCTimeout on a transfer that is not relayed in time,
+// this can be used to assert that the sender was refunded once the timeout is relayed.
+func PollForBalance(ctx context.Context, chain ChainBalancer, deltaBlocks uint64, balance ibc.WalletAmount) error {
+	h, err := chain.Height(ctx)
+	if err != nil {
+		return err
+	}
+
+	poll := func(ctx context.Context, _ uint64) (any, error) {
+		bal, err := chain.GetBalance(ctx, balance.Address, balance.Denom)
+		if err != nil {
+			return nil, err
+		}
+		if bal != balance.Amount {
+			return nil, fmt.Errorf("balance of %s (%d%s) does not match expected %d%s", balance.Address, bal, balance.Denom, balance.Amount, balance.Denom)
+		}
+		return nil, nil
+	}
+
+	poller := BlockPoller[any]{CurrentHeight: chain.Height, PollFunc: poll}
+	_, err = poller.DoPoll(ctx, h, h+deltaBlocks)
+	return err
+}
+
+// WaitForHeight blocks until the chain reaches at least the given height,
+// for instance to let an absolute ibc.IBCTimeout height elapse on a destination chain.
+// The height is polled every stallPollInterval.
+func WaitForHeight(ctx context.Context, chain ChainHeighter, height uint64) error {
+	for {
+		cur, err := chain.Height(ctx)
+		if err != nil {
+			return err
+		}
+		if cur >= height {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(stallPollInterval):
+		}
+	}
+}
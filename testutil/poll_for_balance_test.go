@@ -0,0 +1,77 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBalancer struct {
+	mockChain
+
+	Balances   []int64
+	BalanceErr error
+}
+
+func (m *mockBalancer) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if m.BalanceErr != nil {
+		return 0, m.BalanceErr
+	}
+	bal := m.Balances[0]
+	if len(m.Balances) > 1 {
+		m.Balances = m.Balances[1:]
+	}
+	return bal, nil
+}
+
+func TestPollForBalance(t *testing.T) {
+	ctx := context.Background()
+	want := ibc.WalletAmount{Address: "cosmos1abc", Denom: "uatom", Amount: 100}
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{90, 90, 100}}
+		require.NoError(t, PollForBalance(ctx, &chain, 5, want))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		chain := mockBalancer{mockChain: mockChain{CurrentHeight: 1}, Balances: []int64{90}}
+		err := PollForBalance(ctx, &chain, 3, want)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "90uatom")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		chain := mockBalancer{mockChain: mockChain{HeightErr: errors.New("height boom")}}
+		require.EqualError(t, PollForBalance(ctx, &chain, 3, want), "height boom")
+
+		chain = mockBalancer{mockChain: mockChain{CurrentHeight: 1}, BalanceErr: errors.New("balance boom")}
+		require.EqualError(t, PollForBalance(ctx, &chain, 3, want), "balance boom")
+	})
+}
+
+func TestWaitForHeight(t *testing.T) {
+	ctx := context.Background()
+
+	chain := mockChain{CurrentHeight: 1}
+	require.NoError(t, WaitForHeight(ctx, &chain, 5))
+	require.Equal(t, 6, chain.CurrentHeight)
+
+	chain = mockChain{HeightErr: errors.New("boom")}
+	require.EqualError(t, WaitForHeight(ctx, &chain, 5), "boom")
+}
+
+func TestWaitForHeight_PollsAtInterval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*stallPollInterval/2)
+	defer cancel()
+
+	chain := mockChain{CurrentHeight: 1}
+	require.ErrorIs(t, WaitForHeight(ctx, &chain, 1000), context.DeadlineExceeded)
+	// Queried once right away and once after the first interval, rather than spinning until the deadline.
+	require.Equal(t, 2, chain.HeightCallCount)
+}
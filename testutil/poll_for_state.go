@@ -86,6 +86,99 @@ func PollForAck(ctx context.Context, chain ChainAcker, startHeight, maxHeight ui
 	return found, nil
 }
 
+// ackWaitBlocks is how many blocks WaitForAck searches for an acknowledgement, both before and after it is called.
+const ackWaitBlocks = 20
+
+// WaitForAck waits until the packet with the given sequence, sent from chain over channelID,
+// is acknowledged, and returns its acknowledgement.
+//
+// The relayer is first asked to flush the packets and acknowledgements of the channel on pathName,
+// so the packet is relayed whether or not the relayer is running.
+// Acknowledgements written up to ackWaitBlocks blocks before the call are found,
+// and polling gives up ackWaitBlocks blocks after it.
+func WaitForAck(
+	ctx context.Context,
+	chain ChainAcker,
+	r ibc.Relayer,
+	rep ibc.RelayerExecReporter,
+	pathName, channelID string,
+	sequence uint64,
+) (ibc.PacketAcknowledgement, error) {
+	var zero ibc.PacketAcknowledgement
+
+	height, err := chain.Height(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	if err := r.FlushPackets(ctx, rep, pathName, channelID); err != nil {
+		return zero, fmt.Errorf("failed to flush packets on path %s: %w", pathName, err)
+	}
+	if err := r.FlushAcknowledgements(ctx, rep, pathName, channelID); err != nil {
+		return zero, fmt.Errorf("failed to flush acknowledgements on path %s: %w", pathName, err)
+	}
+
+	startHeight := uint64(1)
+	if height > ackWaitBlocks {
+		startHeight = height - ackWaitBlocks
+	}
+
+	poll := func(ctx context.Context, height uint64) (ibc.PacketAcknowledgement, error) {
+		acks, err := chain.Acknowledgements(ctx, height)
+		if err != nil {
+			return zero, err
+		}
+		for _, ack := range acks {
+			if ack.Packet.SourceChannel == channelID && ack.Packet.Sequence == sequence {
+				return ack, nil
+			}
+		}
+		return zero, ErrNotFound
+	}
+
+	poller := BlockPoller[ibc.PacketAcknowledgement]{CurrentHeight: chain.Height, PollFunc: poll}
+	found, err := poller.DoPoll(ctx, startHeight, height+ackWaitBlocks)
+	if err != nil {
+		return zero, fmt.Errorf("acknowledgement of packet %d on %s: %w", sequence, channelID, err)
+	}
+	return found, nil
+}
+
+// ChainReceiver is a chain that can get the packets it received at a specified height.
+type ChainReceiver interface {
+	ChainHeighter
+	ReceivedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error)
+}
+
+// PollForReceivedPacket attempts to find the packet received by chain with the same source port, channel and sequence
+// as the packet argument, e.g. the packet of a transfer sent to chain, and returns it as received.
+// Otherwise, works identically to PollForAck.
+func PollForReceivedPacket(ctx context.Context, chain ChainReceiver, startHeight, maxHeight uint64, packet ibc.Packet) (ibc.Packet, error) {
+	pollError := &packetPollError{targetPacket: packet}
+	var zero ibc.Packet
+	poll := func(ctx context.Context, height uint64) (ibc.Packet, error) {
+		packets, err := chain.ReceivedPackets(ctx, height)
+		if err != nil {
+			return zero, err
+		}
+		for _, p := range packets {
+			pollError.PushSearched(p)
+			if p.SourcePort == packet.SourcePort && p.SourceChannel == packet.SourceChannel && p.Sequence == packet.Sequence {
+				return p, nil
+			}
+		}
+		return zero, ErrNotFound
+	}
+
+	poller := BlockPoller[ibc.Packet]{CurrentHeight: chain.Height, PollFunc: poll}
+	found, err := poller.DoPoll(ctx, startHeight, maxHeight)
+	if err != nil {
+		pollError.SetErr(err)
+		return zero, pollError
+	}
+	return found, nil
+}
+
 // ChainTimeouter is a chain that can get its timeouts at a specified height
 type ChainTimeouter interface {
 	ChainHeighter
@@ -22,6 +22,9 @@ type mockChain struct {
 
 	FoundTimeouts []ibc.PacketTimeout
 	TimeoutErr    error
+
+	FoundReceived []ibc.Packet
+	ReceivedErr   error
 }
 
 func (m *mockChain) Height(ctx context.Context) (uint64, error) {
@@ -49,6 +52,14 @@ func (m *mockChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTi
 	return m.FoundTimeouts, m.TimeoutErr
 }
 
+func (m *mockChain) ReceivedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotHeights = append(m.GotHeights, height)
+	return m.FoundReceived, m.ReceivedErr
+}
+
 func TestPollForAck(t *testing.T) {
 	ctx := context.Background()
 
@@ -160,3 +171,62 @@ func TestPollForTimeout(t *testing.T) {
 		})
 	})
 }
+
+func TestPollForReceivedPacket(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, FoundReceived: []ibc.Packet{
+			{Sequence: 44, SourcePort: "transfer", SourceChannel: "channel-0"},
+			{Sequence: 33, SourcePort: "transfer", SourceChannel: "channel-1"},
+			{Sequence: 33, SourcePort: "transfer", SourceChannel: "channel-0", Data: []byte("received")},
+		}}
+		got, err := PollForReceivedPacket(ctx, &chain, 3, 5, ibc.Packet{Sequence: 33, SourcePort: "transfer", SourceChannel: "channel-0"})
+
+		require.NoError(t, err)
+		require.Equal(t, "received", string(got.Data))
+		require.Equal(t, []uint64{3}, chain.GotHeights)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1}
+		_, err := PollForReceivedPacket(ctx, &chain, 1, 3, ibc.Packet{})
+
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Equal(t, []uint64{1, 2, 3}, chain.GotHeights)
+	})
+
+	t.Run("find received packets error", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, ReceivedErr: errors.New("recv go boom")}
+		_, err := PollForReceivedPacket(ctx, &chain, 1, 2, ibc.Packet{})
+
+		require.ErrorContains(t, err, "recv go boom")
+	})
+}
+
+func TestWaitForAck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var calls []string
+		chain := mockChain{CurrentHeight: 30, FoundAcks: []ibc.PacketAcknowledgement{
+			{Packet: ibc.Packet{Sequence: 7, SourceChannel: "channel-1"}},
+			{Packet: ibc.Packet{Sequence: 7, SourceChannel: "channel-0"}, Acknowledgement: []byte("ok")},
+		}}
+
+		ack, err := WaitForAck(ctx, &chain, flushRecorder{calls: &calls}, nil, "a-b", "channel-0", 7)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ok"), ack.Acknowledgement)
+		require.Equal(t, []string{"packets a-b", "acks a-b"}, calls)
+		require.Equal(t, []uint64{10}, chain.GotHeights, "acks from before the call must be searched")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var calls []string
+		chain := mockChain{CurrentHeight: 1}
+
+		_, err := WaitForAck(ctx, &chain, flushRecorder{calls: &calls}, nil, "a-b", "channel-0", 7)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Len(t, chain.GotHeights, 21)
+	})
+}
@@ -0,0 +1,167 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+)
+
+// FloodTransfers sends n transfers of amount from the wallet with keyName over channelID, one after another,
+// and returns the packets they sent.
+// Run it while the relayer is stopped to build up a backlog for MeasureThroughput.
+func FloodTransfers(ctx context.Context, chain ibc.Chain, channelID, keyName string, amount ibc.WalletAmount, n int) ([]ibc.Packet, error) {
+	packets := make([]ibc.Packet, 0, n)
+	for i := 0; i < n; i++ {
+		tx, err := chain.SendIBCTransfer(ctx, channelID, keyName, amount, ibc.TransferOptions{})
+		if err != nil {
+			return packets, fmt.Errorf("failed to send transfer %d of %d: %w", i+1, n, err)
+		}
+		packets = append(packets, tx.Packet)
+	}
+	return packets, nil
+}
+
+// ThroughputResult describes how fast a relayer acknowledged a backlog of packets.
+type ThroughputResult struct {
+	// Packets is the number of packets acknowledged.
+	Packets int
+
+	// Duration is the time from starting the relayer until the last packet was acknowledged.
+	Duration time.Duration
+
+	// PacketsPerSecond is Packets divided by Duration.
+	PacketsPerSecond float64
+
+	// Latencies of the packets from starting the relayer until the block with their acknowledgement was seen,
+	// as the 50th, 90th and 99th percentiles and maximum.
+	P50, P90, P99, Max time.Duration
+}
+
+// MetricReporter reports custom benchmark metrics, such as *testing.B.
+type MetricReporter interface {
+	ReportMetric(n float64, unit string)
+}
+
+// ReportMetrics reports the throughput and latency percentiles as metrics of a benchmark,
+// e.g. through res.ReportMetrics(b) in a func BenchmarkXxx(b *testing.B).
+func (res ThroughputResult) ReportMetrics(b MetricReporter) {
+	b.ReportMetric(res.PacketsPerSecond, "packets/s")
+	b.ReportMetric(float64(res.P50.Milliseconds()), "p50-ms")
+	b.ReportMetric(float64(res.P90.Milliseconds()), "p90-ms")
+	b.ReportMetric(float64(res.P99.Milliseconds()), "p99-ms")
+	b.ReportMetric(float64(res.Max.Milliseconds()), "max-ms")
+}
+
+// throughputPollInterval is how often MeasureThroughput checks for new blocks on the sending chain.
+const throughputPollInterval = 100 * time.Millisecond
+
+// MeasureThroughput starts the relayer on pathName and measures how long it takes to acknowledge packets,
+// which must have been sent from chain, e.g. by FloodTransfers, and not yet relayed.
+// The relayer is stopped again before MeasureThroughput returns.
+//
+// Acknowledgements are searched in every block of chain from the height when the relayer is started,
+// so the latency of a packet includes the block time of chain.
+// MeasureThroughput waits until all packets are acknowledged or ctx is done,
+// so ctx should have a deadline.
+func MeasureThroughput(
+	ctx context.Context,
+	chain ChainAcker,
+	r ibc.Relayer,
+	rep ibc.RelayerExecReporter,
+	pathName string,
+	packets []ibc.Packet,
+) (ThroughputResult, error) {
+	if len(packets) == 0 {
+		return ThroughputResult{}, errors.New("no packets to measure")
+	}
+
+	pending := make(map[packetKey]struct{}, len(packets))
+	for _, p := range packets {
+		pending[packetKey{p.SourcePort, p.SourceChannel, p.Sequence}] = struct{}{}
+	}
+
+	height, err := chain.Height(ctx)
+	if err != nil {
+		return ThroughputResult{}, err
+	}
+
+	start := time.Now()
+	if err := r.StartRelayer(ctx, rep, pathName); err != nil {
+		return ThroughputResult{}, fmt.Errorf("failed to start relayer on path %s: %w", pathName, err)
+	}
+	defer func() {
+		// Use a fresh context, as ctx may be done.
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		_ = r.StopRelayer(stopCtx, rep)
+	}()
+
+	latencies := make([]time.Duration, 0, len(packets))
+	for next := height; len(pending) > 0; {
+		cur, err := chain.Height(ctx)
+		if err != nil {
+			return ThroughputResult{}, err
+		}
+		if next > cur {
+			select {
+			case <-ctx.Done():
+				return ThroughputResult{}, fmt.Errorf("%d of %d packets not acknowledged: %w", len(pending), len(packets), ctx.Err())
+			case <-time.After(throughputPollInterval):
+			}
+			continue
+		}
+
+		acks, err := chain.Acknowledgements(ctx, next)
+		if err != nil {
+			return ThroughputResult{}, fmt.Errorf("failed to get acknowledgements at height %d: %w", next, err)
+		}
+		seen := time.Since(start)
+		for _, ack := range acks {
+			key := packetKey{ack.Packet.SourcePort, ack.Packet.SourceChannel, ack.Packet.Sequence}
+			if _, ok := pending[key]; ok {
+				delete(pending, key)
+				latencies = append(latencies, seen)
+			}
+		}
+		next++
+	}
+
+	return newThroughputResult(latencies), nil
+}
+
+// packetKey identifies a packet by its source end and sequence.
+type packetKey struct {
+	port, channel string
+	sequence      uint64
+}
+
+// newThroughputResult summarizes the latencies of acknowledged packets, all measured from the same start.
+func newThroughputResult(latencies []time.Duration) ThroughputResult {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	percentile := func(p float64) time.Duration {
+		i := int(math.Ceil(p*float64(len(latencies)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return latencies[i]
+	}
+
+	res := ThroughputResult{
+		Packets:  len(latencies),
+		Duration: latencies[len(latencies)-1],
+		P50:      percentile(0.50),
+		P90:      percentile(0.90),
+		P99:      percentile(0.99),
+		Max:      latencies[len(latencies)-1],
+	}
+	if res.Duration > 0 {
+		res.PacketsPerSecond = float64(res.Packets) / res.Duration.Seconds()
+	}
+	return res
+}
@@ -0,0 +1,122 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// ackingChain produces a block on every call to Height, with the acknowledgements at each height in acks.
+type ackingChain struct {
+	height uint64
+	acks   map[uint64][]ibc.PacketAcknowledgement
+}
+
+func (c *ackingChain) Height(ctx context.Context) (uint64, error) {
+	c.height++
+	return c.height, nil
+}
+
+func (c *ackingChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
+	return c.acks[height], nil
+}
+
+type startStopRelayer struct {
+	ibc.Relayer // Unimplemented methods panic.
+
+	started, stopped bool
+}
+
+func (r *startStopRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	r.started = true
+	return nil
+}
+
+func (r *startStopRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	r.stopped = true
+	return nil
+}
+
+type metricRecorder map[string]float64
+
+func (m metricRecorder) ReportMetric(n float64, unit string) {
+	m[unit] = n
+}
+
+func TestMeasureThroughput(t *testing.T) {
+	ctx := context.Background()
+
+	packet := func(seq uint64) ibc.Packet {
+		return ibc.Packet{SourcePort: "transfer", SourceChannel: "channel-0", Sequence: seq}
+	}
+	chain := &ackingChain{acks: map[uint64][]ibc.PacketAcknowledgement{
+		3: {{Packet: packet(1)}, {Packet: ibc.Packet{SourcePort: "transfer", SourceChannel: "channel-1", Sequence: 2}}},
+		5: {{Packet: packet(2)}, {Packet: packet(3)}},
+	}}
+	r := new(startStopRelayer)
+
+	res, err := MeasureThroughput(ctx, chain, r, nil, "path", []ibc.Packet{packet(1), packet(2), packet(3)})
+	require.NoError(t, err)
+	require.True(t, r.started)
+	require.True(t, r.stopped)
+
+	require.Equal(t, 3, res.Packets)
+	require.Equal(t, res.Max, res.Duration)
+	require.LessOrEqual(t, res.P50, res.P90)
+	require.Positive(t, res.PacketsPerSecond)
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		chain := &ackingChain{}
+		_, err := MeasureThroughput(ctx, &fixedHeightChain{chain}, new(startStopRelayer), nil, "path", []ibc.Packet{packet(1)})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "1 of 1 packets not acknowledged")
+	})
+
+	t.Run("no packets", func(t *testing.T) {
+		_, err := MeasureThroughput(ctx, chain, new(startStopRelayer), nil, "path", nil)
+		require.Error(t, err)
+	})
+}
+
+// fixedHeightChain never produces a new block.
+type fixedHeightChain struct {
+	*ackingChain
+}
+
+func (c *fixedHeightChain) Height(ctx context.Context) (uint64, error) {
+	return c.height, nil
+}
+
+func TestThroughputResult(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*10*time.Millisecond)
+	}
+
+	res := newThroughputResult(latencies)
+	require.Equal(t, ThroughputResult{
+		Packets:          100,
+		Duration:         time.Second,
+		PacketsPerSecond: 100,
+		P50:              500 * time.Millisecond,
+		P90:              900 * time.Millisecond,
+		P99:              990 * time.Millisecond,
+		Max:              time.Second,
+	}, res)
+
+	metrics := make(metricRecorder)
+	res.ReportMetrics(metrics)
+	require.Equal(t, metricRecorder{
+		"packets/s": 100,
+		"p50-ms":    500,
+		"p90-ms":    900,
+		"p99-ms":    990,
+		"max-ms":    1000,
+	}, metrics)
+}
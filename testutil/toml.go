@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"reflect"
 
 	"github.com/BurntSushi/toml"
 	"github.com/docker/docker/client"
@@ -15,22 +14,35 @@ import (
 // Toml is used for holding the decoded state of a toml config file.
 type Toml map[string]any
 
+// AsToml returns v as a Toml if it is a Toml or a plain map[string]any,
+// such as a table decoded from YAML or JSON.
+func AsToml(v any) (Toml, bool) {
+	switch t := v.(type) {
+	case Toml:
+		return t, true
+	case map[string]any:
+		return Toml(t), true
+	default:
+		return nil, false
+	}
+}
+
 // recursiveModifyToml will apply toml modifications at the current depth,
 // then recurse for new depths.
 func recursiveModifyToml(c map[string]any, modifications Toml) error {
 	for key, value := range modifications {
-		if reflect.ValueOf(value).Kind() == reflect.Map {
+		if nested, ok := AsToml(value); ok {
 			cV, ok := c[key]
 			if !ok {
 				// Did not find section in existing config, populating fresh.
 				cV = make(Toml)
 			}
 			// Retrieve existing config to apply overrides to.
-			cVM, ok := cV.(map[string]any)
+			cVM, ok := AsToml(cV)
 			if !ok {
 				return fmt.Errorf("failed to convert section to (map[string]any), found (%T)", cV)
 			}
-			if err := recursiveModifyToml(cVM, value.(Toml)); err != nil {
+			if err := recursiveModifyToml(cVM, nested); err != nil {
 				return err
 			}
 			c[key] = cVM
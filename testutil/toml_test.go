@@ -0,0 +1,34 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecursiveModifyToml(t *testing.T) {
+	c := map[string]any{
+		"pruning": "default",
+		"api": map[string]any{
+			"enable":  false,
+			"address": "tcp://0.0.0.0:1317",
+		},
+	}
+
+	err := recursiveModifyToml(c, Toml{
+		"pruning": "nothing",
+		// A plain map, as decoded from YAML, is merged like a Toml.
+		"api": map[string]any{"enable": true},
+		"grpc": Toml{
+			"nested": Toml{"enable": true},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "nothing", c["pruning"])
+	require.Equal(t, Toml{"enable": true, "address": "tcp://0.0.0.0:1317"}, c["api"])
+	require.Equal(t, Toml{"nested": Toml{"enable": true}}, c["grpc"])
+
+	err = recursiveModifyToml(c, Toml{"pruning": Toml{"keep-recent": "100"}})
+	require.ErrorContains(t, err, "failed to convert section")
+}
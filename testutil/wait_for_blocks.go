@@ -3,7 +3,12 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"math"
+	"sync"
+	"time"
 
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"go.uber.org/multierr"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -29,6 +34,88 @@ func WaitForBlocks(ctx context.Context, delta int, chains ...ChainHeighter) erro
 	return eg.Wait()
 }
 
+// WaitForBlocksWithTimeout is like WaitForBlocks,
+// but fails instead of blocking if any chain produces no new block within stallTimeout,
+// e.g. because it halted at an upgrade height.
+// It also returns as soon as ctx is done.
+// In both cases the error identifies the chain and the height at which it stopped advancing.
+func WaitForBlocksWithTimeout(ctx context.Context, delta int, stallTimeout time.Duration, chains ...ChainHeighter) error {
+	if len(chains) == 0 {
+		panic("missing chains")
+	}
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := range chains {
+		i := i
+		chain := chains[i]
+		eg.Go(func() error {
+			h := &height{Chain: chain}
+			if err := h.waitForDeltaWithStall(egCtx, delta, stallTimeout); err != nil {
+				return fmt.Errorf("chain %s: %w", chainName(i, chain), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// BlockWait is the number of blocks WaitForBlocksEach waits for on one chain, and how long that may take.
+type BlockWait struct {
+	Chain  ChainHeighter
+	Blocks int
+	// Timeout bounds the wait for this chain alone. If zero, only the context passed to WaitForBlocksEach does.
+	Timeout time.Duration
+}
+
+// WaitForBlocksEach waits for every chain to advance by its own number of blocks, concurrently and within its own timeout,
+// so that chains producing blocks at different rates can be waited on together without a slow chain's wait
+// bounding that of a fast one, e.g. 2 blocks of a 100ms chain and 2 blocks of a 6s chain.
+//
+// A chain that times out does not stop the others: WaitForBlocksEach returns once every wait is over,
+// with the errors of all the chains that did not advance in time, each identifying the chain and the height it reached.
+func WaitForBlocksEach(ctx context.Context, waits ...BlockWait) error {
+	if len(waits) == 0 {
+		panic("missing chains")
+	}
+
+	var (
+		mu   sync.Mutex
+		errs error
+		wg   sync.WaitGroup
+	)
+	for i, w := range waits {
+		i, w := i, w
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			wctx := ctx
+			if w.Timeout > 0 {
+				var cancel context.CancelFunc
+				wctx, cancel = context.WithTimeout(ctx, w.Timeout)
+				defer cancel()
+			}
+
+			h := &height{Chain: w.Chain}
+			if err := h.waitForDeltaWithStall(wctx, w.Blocks, noStallTimeout); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				multierr.AppendInto(&errs, fmt.Errorf("chain %s: waiting for %d blocks: %w", chainName(i, w.Chain), w.Blocks, err))
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// chainName returns the chain ID of chain if it exposes its config,
+// otherwise its position in the arguments.
+func chainName(i int, chain ChainHeighter) string {
+	if c, ok := chain.(interface{ Config() ibc.ChainConfig }); ok {
+		return c.Config().ChainID
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
 // nodesInSync returns an error if the nodes are not in sync with the chain.
 func nodesInSync(ctx context.Context, chain ChainHeighter, nodes []ChainHeighter) error {
 	var chainHeight uint64
@@ -99,6 +186,41 @@ func (h *height) WaitForDelta(ctx context.Context, delta int) error {
 	return nil
 }
 
+// stallPollInterval is the delay between height queries in waitForDeltaWithStall.
+const stallPollInterval = 100 * time.Millisecond
+
+// noStallTimeout is passed to waitForDeltaWithStall to wait for as long as its context allows.
+const noStallTimeout = time.Duration(math.MaxInt64)
+
+func (h *height) waitForDeltaWithStall(ctx context.Context, delta int, stallTimeout time.Duration) error {
+	lastProgress := time.Now()
+	for h.delta() < delta {
+		cur, err := h.Chain.Height(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("stopped waiting at height %d: %w", h.current, ctx.Err())
+			}
+			return err
+		}
+
+		if cur > h.current {
+			h.update(cur)
+			lastProgress = time.Now()
+			continue
+		}
+		if time.Since(lastProgress) >= stallTimeout {
+			return fmt.Errorf("stopped advancing at height %d: no new block in %s", h.current, stallTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting at height %d: %w", h.current, ctx.Err())
+		case <-time.After(stallPollInterval):
+		}
+	}
+	return nil
+}
+
 func (h *height) delta() int {
 	if h.starting == 0 {
 		return 0
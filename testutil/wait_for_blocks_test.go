@@ -132,3 +132,80 @@ func TestWaitForInSync(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestWaitForBlocksWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := mockChainHeighter{CurHeight: 10}
+		err := WaitForBlocksWithTimeout(context.Background(), 5, time.Second, &chain)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("halted chain", func(t *testing.T) {
+		halted := mockChainHeighterFixed{CurHeight: 42}
+		err := WaitForBlocksWithTimeout(context.Background(), 1, 300*time.Millisecond, &mockChainHeighter{}, &halted)
+
+		require.EqualError(t, err, "chain #1: stopped advancing at height 42: no new block in 300ms")
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		halted := mockChainHeighterFixed{CurHeight: 7}
+		err := WaitForBlocksWithTimeout(ctx, 1, time.Minute, &halted)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "chain #0: stopped waiting at height 7")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errMock := mockChainHeighter{Err: errors.New("boom")}
+		err := WaitForBlocksWithTimeout(context.Background(), 1, time.Second, &errMock)
+
+		require.EqualError(t, err, "chain #0: boom")
+	})
+}
+
+func TestWaitForBlocksEach(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		fast, slow := mockChainHeighter{CurHeight: 10}, mockChainHeighter{CurHeight: 3}
+		err := WaitForBlocksEach(context.Background(),
+			BlockWait{Chain: &fast, Blocks: 20, Timeout: time.Second},
+			BlockWait{Chain: &slow, Blocks: 2},
+		)
+
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, atomic.LoadInt64(&fast.CurHeight), int64(31))
+		require.GreaterOrEqual(t, atomic.LoadInt64(&slow.CurHeight), int64(6))
+	})
+
+	t.Run("independent timeouts", func(t *testing.T) {
+		halted, live := mockChainHeighterFixed{CurHeight: 42}, mockChainHeighter{}
+		start := time.Now()
+		err := WaitForBlocksEach(context.Background(),
+			BlockWait{Chain: &halted, Blocks: 1, Timeout: 200 * time.Millisecond},
+			BlockWait{Chain: &live, Blocks: 5, Timeout: time.Minute},
+		)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "chain #0: waiting for 1 blocks: stopped waiting at height 42")
+		require.NotContains(t, err.Error(), "chain #1")
+		require.Less(t, time.Since(start), 10*time.Second)
+	})
+
+	t.Run("every failure reported", func(t *testing.T) {
+		a, b := mockChainHeighterFixed{CurHeight: 1}, mockChainHeighterFixed{CurHeight: 2}
+		err := WaitForBlocksEach(context.Background(),
+			BlockWait{Chain: &a, Blocks: 1, Timeout: 100 * time.Millisecond},
+			BlockWait{Chain: &b, Blocks: 1, Timeout: 300 * time.Millisecond},
+		)
+
+		require.ErrorContains(t, err, "chain #0: waiting for 1 blocks: stopped waiting at height 1")
+		require.ErrorContains(t, err, "chain #1: waiting for 1 blocks: stopped waiting at height 2")
+	})
+}
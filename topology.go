@@ -0,0 +1,167 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"go.uber.org/multierr"
+)
+
+// topologyCounts is the number of clients, connections and channels on a chain to one counterparty chain.
+type topologyCounts struct {
+	clients, connections, channels int
+}
+
+// VerifyTopology checks that the IBC clients, connections and channels on every linked chain match the declared links,
+// e.g. to catch a relayer that silently failed to create part of a path during Build.
+//
+// Between each pair of linked chains, each chain must have one client tracking the other and one connection over it
+// per link, except for links reusing a Connection, and one channel per link.
+// The consumer of an Interchain Security link opens a transfer channel to its provider of its own accord,
+// so extra channels are allowed between a provider and its consumer.
+// Anything to chains outside the Interchain is ignored.
+//
+// VerifyTopology must be called after Build without SkipPathCreation,
+// and before the test creates any other clients, connections or channels.
+func (ic *Interchain) VerifyTopology(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if !ic.built {
+		return fmt.Errorf("VerifyTopology called before Build")
+	}
+
+	expected := make(map[ibc.Chain]map[string]*topologyCounts)
+	extraChannels := make(map[ibc.Chain]map[string]bool)
+	relayers := make(map[ibc.Chain]ibc.Relayer)
+	for rp, link := range ic.links {
+		for i, c := range link.chains {
+			counterparty := ic.chains[link.chains[1-i]]
+			if expected[c] == nil {
+				expected[c] = make(map[string]*topologyCounts)
+				extraChannels[c] = make(map[string]bool)
+			}
+			e := expected[c][counterparty]
+			if e == nil {
+				e = new(topologyCounts)
+				expected[c][counterparty] = e
+			}
+
+			if link.connection == nil {
+				e.clients++
+				e.connections++
+			}
+			e.channels++
+			if link.ccv {
+				extraChannels[c][counterparty] = true
+			}
+			// Any relayer configured for the chain can query it.
+			relayers[c] = rp.Relayer
+		}
+	}
+
+	chains := make([]ibc.Chain, 0, len(expected))
+	for c := range expected {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool { return ic.chains[chains[i]] < ic.chains[chains[j]] })
+
+	var err error
+	for _, c := range chains {
+		actual, qErr := ic.topologyCounts(ctx, rep, relayers[c], ic.chains[c])
+		if qErr != nil {
+			multierr.AppendInto(&err, qErr)
+			continue
+		}
+
+		counterparties := make([]string, 0, len(actual)+len(expected[c]))
+		for id := range expected[c] {
+			counterparties = append(counterparties, id)
+		}
+		for id := range actual {
+			if _, ok := expected[c][id]; !ok {
+				counterparties = append(counterparties, id)
+			}
+		}
+		sort.Strings(counterparties)
+
+		for _, id := range counterparties {
+			var want, got topologyCounts
+			if e := expected[c][id]; e != nil {
+				want = *e
+			}
+			if a := actual[id]; a != nil {
+				got = *a
+			}
+
+			if got.clients != want.clients {
+				multierr.AppendInto(&err, fmt.Errorf("chain %s has %d clients of chain %s, expected %d", ic.chains[c], got.clients, id, want.clients))
+			}
+			if got.connections != want.connections {
+				multierr.AppendInto(&err, fmt.Errorf("chain %s has %d connections to chain %s, expected %d", ic.chains[c], got.connections, id, want.connections))
+			}
+			if got.channels != want.channels && !(extraChannels[c][id] && got.channels > want.channels) {
+				multierr.AppendInto(&err, fmt.Errorf("chain %s has %d channels to chain %s, expected %d", ic.chains[c], got.channels, id, want.channels))
+			}
+		}
+	}
+	return err
+}
+
+// topologyCounts queries the clients, connections and channels on chainID through r,
+// and counts them by the ID of the counterparty chain, which must be part of the Interchain.
+func (ic *Interchain) topologyCounts(ctx context.Context, rep ibc.RelayerExecReporter, r ibc.Relayer, chainID string) (map[string]*topologyCounts, error) {
+	clients, err := r.GetClients(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clients on chain %s: %w", chainID, err)
+	}
+	connections, err := r.GetConnections(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections on chain %s: %w", chainID, err)
+	}
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on chain %s: %w", chainID, err)
+	}
+
+	known := make(map[string]bool, len(ic.chains))
+	for _, id := range ic.chains {
+		known[id] = true
+	}
+
+	counts := make(map[string]*topologyCounts)
+	count := func(counterparty string) *topologyCounts {
+		if !known[counterparty] {
+			return nil
+		}
+		if counts[counterparty] == nil {
+			counts[counterparty] = new(topologyCounts)
+		}
+		return counts[counterparty]
+	}
+
+	clientChains := make(map[string]string, len(clients))
+	for _, client := range clients {
+		clientChains[client.ClientID] = client.ClientState.ChainID
+		if n := count(client.ClientState.ChainID); n != nil {
+			n.clients++
+		}
+	}
+
+	connectionChains := make(map[string]string, len(connections))
+	for _, conn := range connections {
+		connectionChains[conn.ID] = clientChains[conn.ClientID]
+		if n := count(clientChains[conn.ClientID]); n != nil {
+			n.connections++
+		}
+	}
+
+	for _, ch := range channels {
+		if len(ch.ConnectionHops) == 0 {
+			continue
+		}
+		if n := count(connectionChains[ch.ConnectionHops[0]]); n != nil {
+			n.channels++
+		}
+	}
+	return counts, nil
+}
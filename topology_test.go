@@ -0,0 +1,96 @@
+package interchaintest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v6/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+type topologyRelayer struct {
+	ibc.Relayer // Unimplemented methods panic.
+
+	clients     map[string]ibc.ClientOutputs
+	connections map[string]ibc.ConnectionOutputs
+	channels    map[string][]ibc.ChannelOutput
+}
+
+func (r *topologyRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ClientOutputs, error) {
+	return r.clients[chainID], nil
+}
+
+func (r *topologyRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
+	return r.connections[chainID], nil
+}
+
+func (r *topologyRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	return r.channels[chainID], nil
+}
+
+// linkedTopology returns a relayer reporting one client, connection and channel between chains a and b,
+// as created by a single link.
+func linkedTopology() *topologyRelayer {
+	end := func(counterparty string) (ibc.ClientOutputs, ibc.ConnectionOutputs, []ibc.ChannelOutput) {
+		return ibc.ClientOutputs{{ClientID: "07-tendermint-0", ClientState: ibc.ClientState{ChainID: counterparty}}},
+			ibc.ConnectionOutputs{{ID: "connection-0", ClientID: "07-tendermint-0"}},
+			[]ibc.ChannelOutput{{ChannelID: "channel-0", PortID: "transfer", ConnectionHops: []string{"connection-0"}}}
+	}
+	r := &topologyRelayer{
+		clients:     make(map[string]ibc.ClientOutputs),
+		connections: make(map[string]ibc.ConnectionOutputs),
+		channels:    make(map[string][]ibc.ChannelOutput),
+	}
+	for _, ids := range [][2]string{{"a", "b"}, {"b", "a"}} {
+		r.clients[ids[0]], r.connections[ids[0]], r.channels[ids[0]] = end(ids[1])
+	}
+	return r
+}
+
+func TestInterchain_VerifyTopology(t *testing.T) {
+	ctx := context.Background()
+	a, b := &readyChain{name: "a"}, &readyChain{name: "b"}
+
+	newInterchain := func(r ibc.Relayer, link interchainLink) *Interchain {
+		ic := NewInterchain()
+		ic.chains = map[ibc.Chain]string{a: "a", b: "b"}
+		ic.relayers = map[ibc.Relayer]string{r: "r"}
+		link.chains = [2]ibc.Chain{a, b}
+		ic.links = map[relayerPath]interchainLink{{Relayer: r, Path: "a-b"}: link}
+		ic.built = true
+		return ic
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		require.NoError(t, newInterchain(linkedTopology(), interchainLink{}).VerifyTopology(ctx, nil))
+	})
+
+	t.Run("before build", func(t *testing.T) {
+		ic := newInterchain(linkedTopology(), interchainLink{})
+		ic.built = false
+		require.ErrorContains(t, ic.VerifyTopology(ctx, nil), "before Build")
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		r := linkedTopology()
+		r.channels["b"] = nil
+		err := newInterchain(r, interchainLink{}).VerifyTopology(ctx, nil)
+		require.EqualError(t, err, "chain b has 0 channels to chain a, expected 1")
+	})
+
+	t.Run("extra client", func(t *testing.T) {
+		r := linkedTopology()
+		r.clients["a"] = append(r.clients["a"], &ibc.ClientOutput{ClientID: "07-tendermint-1", ClientState: ibc.ClientState{ChainID: "b"}})
+		// Clients of chains outside the interchain are ignored.
+		r.clients["a"] = append(r.clients["a"], &ibc.ClientOutput{ClientID: "07-tendermint-2", ClientState: ibc.ClientState{ChainID: "other"}})
+		err := newInterchain(r, interchainLink{}).VerifyTopology(ctx, nil)
+		require.EqualError(t, err, "chain a has 2 clients of chain b, expected 1")
+	})
+
+	t.Run("consumer transfer channel", func(t *testing.T) {
+		r := linkedTopology()
+		r.channels["a"] = append(r.channels["a"], ibc.ChannelOutput{ChannelID: "channel-1", PortID: "transfer", ConnectionHops: []string{"connection-0"}})
+		require.Error(t, newInterchain(r, interchainLink{}).VerifyTopology(ctx, nil))
+		require.NoError(t, newInterchain(r, interchainLink{ccv: true}).VerifyTopology(ctx, nil))
+	})
+}